@@ -22,6 +22,7 @@ const (
 
 var (
 	ErrUrlsIsEmpty = errors.New("urls is empty")
+	ErrNacked      = errors.New("message is nacked by broker")
 )
 
 type (
@@ -64,8 +65,9 @@ type (
 	Channel struct {
 		ch *amqp.Channel
 
-		conn   *Connection
-		logger zerolog.Logger
+		conn     *Connection
+		logger   zerolog.Logger
+		confirms bool
 
 		// Reconnect
 		reconnectLock sync.RWMutex
@@ -78,8 +80,18 @@ type (
 		// State
 		closed atomic.Bool
 	}
+
+	ChannelOption func(*Channel)
 )
 
+// WithConfirms puts the channel in confirm mode, so Publish waits until the broker confirms the message.
+// Messages, which are not confirmed before the channel is closed, are nacked
+func WithConfirms() ChannelOption {
+	return func(ch *Channel) {
+		ch.confirms = true
+	}
+}
+
 // Dial wrap amqp.Dial, dial and get a reconnect connection
 func Dial(ctx context.Context, cfg Config) (*Connection, error) {
 	// Connect to RabbitMQ
@@ -263,25 +275,11 @@ func (c *Connection) GetConnection() *amqp.Connection {
 }
 
 // Channel wrap amqp.Connection.Channel, get a auto reconnect channel
-func (c *Connection) Channel(ctx context.Context) (*Channel, error) {
-	// Open a channel
-	origCh, err := c.GetConnection().Channel()
-	if err != nil {
-		return nil, fmt.Errorf("failed to open a channel: %w", err)
-	}
-
-	// set prefetch
-	if err := origCh.Qos(c.prefetch, 0, false); err != nil {
-		_ = origCh.Close()
-		return nil, fmt.Errorf("failed to set prefetch: %w", err)
-	}
-
+func (c *Connection) Channel(ctx context.Context, opts ...ChannelOption) (*Channel, error) {
 	// Create context for watcher
 	ctx, cancel := context.WithCancel(ctx)
 
 	ch := &Channel{
-		ch: origCh,
-
 		conn:   c,
 		logger: log.With().Str("component", "amqp-channel").Logger(),
 
@@ -294,6 +292,19 @@ func (c *Connection) Channel(ctx context.Context) (*Channel, error) {
 		closed: atomic.Bool{},
 	}
 
+	for _, opt := range opts {
+		opt(ch)
+	}
+
+	// Open a channel
+	origCh, err := ch.open()
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	ch.ch = origCh
+
 	go ch.runWatcher(ctx)
 
 	return ch, nil
@@ -356,19 +367,12 @@ func (ch *Channel) runWatcher(ctx context.Context) {
 				}
 
 				// open a new channel
-				origCh, err := ch.conn.GetConnection().Channel()
+				origCh, err := ch.open()
 				if err != nil {
 					ch.logger.Error().Err(err).Msg("failed to reconnect")
 					continue
 				}
 
-				// set prefetch
-				if err := origCh.Qos(ch.conn.prefetch, 0, false); err != nil {
-					_ = origCh.Close()
-					ch.logger.Error().Err(err).Msg("failed to set prefetch")
-					continue
-				}
-
 				// set new amqp.Channel
 				ch.ch = origCh
 				break
@@ -382,6 +386,30 @@ func (ch *Channel) runWatcher(ctx context.Context) {
 	}
 }
 
+// open opens a channel, sets prefetch and puts it in confirm mode if it is enabled
+func (ch *Channel) open() (*amqp.Channel, error) {
+	origCh, err := ch.conn.GetConnection().Channel()
+	if err != nil {
+		return nil, fmt.Errorf("failed to open a channel: %w", err)
+	}
+
+	// set prefetch
+	if err := origCh.Qos(ch.conn.prefetch, 0, false); err != nil {
+		_ = origCh.Close()
+		return nil, fmt.Errorf("failed to set prefetch: %w", err)
+	}
+
+	// set confirm mode
+	if ch.confirms {
+		if err := origCh.Confirm(false); err != nil {
+			_ = origCh.Close()
+			return nil, fmt.Errorf("failed to set confirm mode: %w", err)
+		}
+	}
+
+	return origCh, nil
+}
+
 // GetChannel get amqp.Channel
 func (ch *Channel) GetChannel() *amqp.Channel {
 	ch.reconnectLock.RLock()
@@ -417,31 +445,114 @@ func (ch *Channel) Close() error {
 	return nil
 }
 
-// Publish wrap amqp.Channel.Publish
+// Publish wrap amqp.Channel.Publish, the channel in confirm mode waits until the broker confirms the message
 func (ch *Channel) Publish(ctx context.Context, exchange, key string, mandatory, immediate bool, msg amqp.Publishing) error {
-	if err := ch.GetChannel().PublishWithContext(ctx, exchange, key, mandatory, immediate, msg); err != nil {
+	if !ch.confirms {
+		if err := ch.GetChannel().PublishWithContext(ctx, exchange, key, mandatory, immediate, msg); err != nil {
+			return fmt.Errorf("failed to publish a message: %w", err)
+		}
+
+		return nil
+	}
+
+	confirm, err := ch.GetChannel().PublishWithDeferredConfirmWithContext(ctx, exchange, key, mandatory, immediate, msg)
+	if err != nil {
 		return fmt.Errorf("failed to publish a message: %w", err)
 	}
 
+	acked, err := confirm.WaitContext(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to wait for confirmation: %w", err)
+	}
+
+	if !acked {
+		return ErrNacked
+	}
+
 	return nil
 }
 
+// QueueDeclare wrap amqp.Channel.QueueDeclare and amqp.Channel.QueueBind, declare a queue and bind it to the exchange
+func (ch *Channel) QueueDeclare(
+	name, exchange, key string, durable, autoDelete, exclusive, noWait bool, args amqp.Table,
+) error {
+	origCh := ch.GetChannel()
+
+	if _, err := origCh.QueueDeclare(name, durable, autoDelete, exclusive, noWait, args); err != nil {
+		return fmt.Errorf("failed to declare queue: %w", err)
+	}
+
+	if err := origCh.QueueBind(name, key, exchange, noWait, nil); err != nil {
+		return fmt.Errorf("failed to bind queue: %w", err)
+	}
+
+	return nil
+}
+
+// QueueInspect wrap amqp.Channel.QueueDeclarePassive, get the number of ready messages and consumers of the queue.
+// The channel is closed by the server if the queue does not exist
+func (ch *Channel) QueueInspect(name string) (amqp.Queue, error) {
+	queue, err := ch.GetChannel().QueueDeclarePassive(name, true, false, false, false, nil)
+	if err != nil {
+		return amqp.Queue{}, fmt.Errorf("failed to inspect queue: %w", err)
+	}
+
+	return queue, nil
+}
+
 // Consume wrap amqp.Channel.Consume, the returned delivery will end only when channel closed by developer
 func (ch *Channel) Consume(
 	ctx context.Context, queue, consumer string, autoAck, exclusive, noLocal, noWait bool, args amqp.Table,
 ) <-chan amqp.Delivery {
+	declare := func(*amqp.Channel) (string, error) {
+		return queue, nil
+	}
+
+	deliveries := make(chan amqp.Delivery)
+	go ch.runConsumer(ctx, deliveries, declare, consumer, autoAck, exclusive, noLocal, noWait, args)
+	return deliveries
+}
+
+// ConsumeExchange declares an exclusive server-named queue bound to the exchange and consumes it,
+// so every consumer gets its own copy of the messages of a fanout exchange. The queue is declared
+// again after reconnect, the returned delivery will end only when channel closed by developer
+func (ch *Channel) ConsumeExchange(
+	ctx context.Context, exchange, consumer string, autoAck, noLocal, noWait bool, args amqp.Table,
+) <-chan amqp.Delivery {
+	declare := func(origCh *amqp.Channel) (string, error) {
+		queue, err := origCh.QueueDeclare("", false, true, true, false, nil)
+		if err != nil {
+			return "", fmt.Errorf("failed to declare queue: %w", err)
+		}
+
+		if err := origCh.QueueBind(queue.Name, "", exchange, false, nil); err != nil {
+			return "", fmt.Errorf("failed to bind queue: %w", err)
+		}
+
+		return queue.Name, nil
+	}
+
 	deliveries := make(chan amqp.Delivery)
-	go ch.runConsumer(ctx, deliveries, queue, consumer, autoAck, exclusive, noLocal, noWait, args)
+	go ch.runConsumer(ctx, deliveries, declare, consumer, autoAck, true, noLocal, noWait, args)
 	return deliveries
 }
 
 // runConsumer run channel consumer
 func (ch *Channel) runConsumer(
-	ctx context.Context, deliveries chan<- amqp.Delivery, queue, consumer string, autoAck, exclusive, noLocal,
-	noWait bool, args amqp.Table,
+	ctx context.Context, deliveries chan<- amqp.Delivery, declare func(*amqp.Channel) (string, error),
+	consumer string, autoAck, exclusive, noLocal, noWait bool, args amqp.Table,
 ) {
 	for {
-		d, err := ch.GetChannel().ConsumeWithContext(ctx, queue, consumer, autoAck, exclusive, noLocal, noWait, args)
+		origCh := ch.GetChannel()
+
+		queue, err := declare(origCh)
+		if err != nil {
+			ch.logger.Error().Err(err).Msg("failed to declare queue")
+			time.Sleep(timeout)
+			continue
+		}
+
+		d, err := origCh.ConsumeWithContext(ctx, queue, consumer, autoAck, exclusive, noLocal, noWait, args)
 		if err != nil {
 			ch.logger.Error().Err(err).Msg("failed to consume")
 			time.Sleep(timeout)
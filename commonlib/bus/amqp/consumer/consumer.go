@@ -2,8 +2,11 @@ package consumer
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"runtime/debug"
 	"sync"
+	"time"
 
 	"github.com/goccy/go-json"
 	amqp "github.com/rabbitmq/amqp091-go"
@@ -11,20 +14,49 @@ import (
 	"github.com/rs/zerolog/log"
 
 	commonamqp "github.com/ptrvsrg/crack-hash/commonlib/bus/amqp"
+	"github.com/ptrvsrg/crack-hash/commonlib/bus/amqp/deadletter"
 )
 
+// ErrPermanent marks errors of handlers, which are not fixed by redelivery of the message
+var ErrPermanent = errors.New("permanent error")
+
 type (
+	// Handler handles the message, the delivery is settled by the consumer by the returned error
 	Handler[T any] func(ctx context.Context, data T, delivery amqp.Delivery) error
 
 	Config struct {
 		Unmarshal func(data []byte, v any) error
 		Queue     string
+		// Exchange is consumed with an exclusive server-named queue instead of the Queue if set
+		Exchange  string
 		Consumer  string
 		AutoAck   bool
 		Exclusive bool
 		NoLocal   bool
 		NoWait    bool
 		Args      map[string]any
+
+		// MaxRetries is the number of redeliveries of a failed message. The failed message is rejected without
+		// requeue, so the queue must dead-letter it to a delay queue, which returns it to the queue after its TTL.
+		// Redeliveries are counted by the x-death header
+		MaxRetries int
+		// DeadLetterExchange gets messages, which can not be unmarshalled, failed with a permanent error or
+		// failed after max retries. Such messages are dropped if it is empty
+		DeadLetterExchange   string
+		DeadLetterRoutingKey string
+		// Requeue requeues failed messages at once instead of retries and dead-lettering, it is used
+		// by consumers of dead letters
+		Requeue bool
+
+		// MaxInFlight is the number of messages handled at once, the consumer stops getting messages until
+		// a handler finishes. Only the prefetch of the channel limits handlers if it is 0
+		MaxInFlight int
+		// Timeout is the timeout of the handler of a message, the timed out message is failed. Handlers
+		// have no timeout if it is 0
+		Timeout time.Duration
+		// DrainTimeout is the time to wait for handlers in flight after the consumer is stopped, then
+		// they are cancelled and their messages are requeued
+		DrainTimeout time.Duration
 	}
 
 	Consumer interface {
@@ -36,12 +68,22 @@ type (
 		handler   Handler[T]
 		config    Config
 		unmarshal func(data []byte, v any) error
+		consume   func(ctx context.Context) <-chan amqp.Delivery
+		publish   publishFunc
 		logger    zerolog.Logger
 		wg        sync.WaitGroup
-		errChan   chan error
+		inFlight  chan struct{}
 	}
+
+	// publishFunc publishes dead letters, it is the publish of the channel
+	publishFunc func(ctx context.Context, exchange, key string, mandatory, immediate bool, msg amqp.Publishing) error
 )
 
+// Permanent marks the error as permanent, the message is dead-lettered without retries
+func Permanent(err error) error {
+	return fmt.Errorf("%w: %w", ErrPermanent, err)
+}
+
 func New[T any](ch *commonamqp.Channel, handler Handler[T], cfg Config) Consumer {
 	if handler == nil {
 		handler = func(context.Context, T, amqp.Delivery) error { return nil }
@@ -56,18 +98,37 @@ func New[T any](ch *commonamqp.Channel, handler Handler[T], cfg Config) Consumer
 		handler:   handler,
 		config:    cfg,
 		unmarshal: cfg.Unmarshal,
-		errChan:   make(chan error, 1),
+		publish:   ch.Publish,
 		logger: log.With().
 			Str("component", "amqp-consumer").
 			Type("type", *new(T)).
 			Str("queue", cfg.Queue).
+			Str("exchange", cfg.Exchange).
 			Logger(),
 	}
 
+	c.consume = c.connect
+
+	if cfg.MaxInFlight > 0 {
+		c.inFlight = make(chan struct{}, cfg.MaxInFlight)
+	}
+
 	return c
 }
 
 func (c *consumer[T]) connect(ctx context.Context) <-chan amqp.Delivery {
+	if c.config.Exchange != "" {
+		return c.ch.ConsumeExchange(
+			ctx,
+			c.config.Exchange,
+			c.config.Consumer,
+			c.config.AutoAck,
+			c.config.NoLocal,
+			c.config.NoWait,
+			c.config.Args,
+		)
+	}
+
 	return c.ch.Consume(
 		ctx,
 		c.config.Queue,
@@ -80,8 +141,14 @@ func (c *consumer[T]) connect(ctx context.Context) <-chan amqp.Delivery {
 	)
 }
 
+// Subscribe consumes messages until the context is done, then it waits for handlers in flight
 func (c *consumer[T]) Subscribe(ctx context.Context) {
-	msgCh := c.connect(ctx)
+	// Handlers are not cancelled with the consumer, they are drained before the channel is closed
+	handlerCtx, cancelHandlers := context.WithCancel(context.WithoutCancel(ctx))
+	defer cancelHandlers()
+	defer c.drain(cancelHandlers)
+
+	msgCh := c.consume(ctx)
 	c.logger.Info().Msg("consumer connected")
 
 	for {
@@ -97,30 +164,173 @@ func (c *consumer[T]) Subscribe(ctx context.Context) {
 				}
 
 				c.logger.Info().Msg("consumer closed, try to reconnect")
-				msgCh = c.connect(ctx)
+				msgCh = c.consume(ctx)
 				continue
 			}
 
 			c.logger.Info().Bytes("body", d.Body).Msg("got new event")
 
-			data := *new(T)
-			if err := c.unmarshal(d.Body, &data); err != nil {
-				c.logger.Error().Err(err).Msg("failed to unmarshal event")
-				continue
+			if !c.acquire(ctx) {
+				c.logger.Info().Msg("consumer stopped, requeue message")
+				c.reject(d, true)
+				return
 			}
 
-			// catch panic
+			c.wg.Add(1)
 			go func() {
-				defer func() {
-					if r := recover(); r != nil {
-						c.logger.Error().Msgf("catch panic: %v\n%s", r, string(debug.Stack()))
-					}
-				}()
-
-				if err := c.handler(ctx, data, d); err != nil {
-					c.logger.Error().Err(err).Msg("failed to consume event")
-				}
+				defer c.wg.Done()
+				defer c.release()
+
+				c.handle(handlerCtx, d)
 			}()
 		}
 	}
 }
+
+// acquire waits for a free slot of handlers in flight, it returns false if the consumer is stopped
+func (c *consumer[T]) acquire(ctx context.Context) bool {
+	if c.inFlight == nil {
+		return true
+	}
+
+	select {
+	case c.inFlight <- struct{}{}:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+func (c *consumer[T]) release() {
+	if c.inFlight != nil {
+		<-c.inFlight
+	}
+}
+
+// drain waits for handlers in flight, they are cancelled after the drain timeout
+func (c *consumer[T]) drain(cancel context.CancelFunc) {
+	done := make(chan struct{})
+	go func() {
+		c.wg.Wait()
+		close(done)
+	}()
+
+	timer := time.NewTimer(c.config.DrainTimeout)
+	defer timer.Stop()
+
+	select {
+	case <-done:
+		c.logger.Info().Msg("consumer drained")
+	case <-timer.C:
+		c.logger.Warn().Msg("drain timeout, cancel handlers")
+		cancel()
+		<-done
+	}
+}
+
+func (c *consumer[T]) handle(ctx context.Context, d amqp.Delivery) {
+	data := *new(T)
+	if err := c.unmarshal(d.Body, &data); err != nil {
+		c.logger.Error().Err(err).Msg("failed to unmarshal event")
+		c.settle(ctx, d, Permanent(fmt.Errorf("failed to unmarshal event: %w", err)))
+		return
+	}
+
+	handlerCtx := ctx
+	if c.config.Timeout > 0 {
+		var cancel context.CancelFunc
+		handlerCtx, cancel = context.WithTimeout(ctx, c.config.Timeout)
+		defer cancel()
+	}
+
+	// The message is settled with the context of the consumer, so the timed out message is retried
+	c.settle(ctx, d, c.call(handlerCtx, data, d))
+}
+
+// call calls the handler, the panic of the handler is returned as an error
+func (c *consumer[T]) call(ctx context.Context, data T, d amqp.Delivery) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			c.logger.Error().Msgf("catch panic: %v\n%s", r, string(debug.Stack()))
+			err = fmt.Errorf("catch panic: %v", r)
+		}
+	}()
+
+	return c.handler(ctx, data, d)
+}
+
+// settle acks the handled message. The failed message is requeued if handlers are cancelled by the stop
+// of the consumer, is redelivered through the delay queue until max retries and is dead-lettered after them
+func (c *consumer[T]) settle(ctx context.Context, d amqp.Delivery, err error) {
+	if c.config.AutoAck {
+		if err != nil {
+			c.logger.Error().Err(err).Msg("failed to consume event")
+		}
+		return
+	}
+
+	if err == nil {
+		if err := d.Ack(false); err != nil {
+			c.logger.Error().Err(err).Msg("failed to ack message")
+		}
+		return
+	}
+
+	retries := c.retries(d)
+	logger := c.logger.With().Err(err).Int("retries", retries).Logger()
+
+	switch {
+	case c.config.Requeue || ctx.Err() != nil:
+		logger.Error().Msg("failed to consume event, requeue message")
+		c.reject(d, true)
+
+	case !errors.Is(err, ErrPermanent) && retries < c.config.MaxRetries:
+		logger.Warn().Msg("failed to consume event, retry message")
+		c.reject(d, false)
+
+	case c.config.DeadLetterExchange == "":
+		logger.Error().Msg("failed to consume event, drop message")
+		// The message is acked, because the rejected message is dead-lettered to the delay queue by the queue
+		if err := d.Ack(false); err != nil {
+			c.logger.Error().Err(err).Msg("failed to ack message")
+		}
+
+	default:
+		logger.Error().Msg("failed to consume event, dead-letter message")
+
+		msg := deadletter.Build(d, c.config.Queue, c.config.Exchange, err, retries)
+		if err := c.publish(
+			ctx, c.config.DeadLetterExchange, c.config.DeadLetterRoutingKey, false, false, msg,
+		); err != nil {
+			c.logger.Error().Err(err).Msg("failed to publish dead letter, requeue message")
+			c.reject(d, true)
+			return
+		}
+
+		if err := d.Ack(false); err != nil {
+			c.logger.Error().Err(err).Msg("failed to ack message")
+		}
+	}
+}
+
+func (c *consumer[T]) reject(d amqp.Delivery, requeue bool) {
+	if err := d.Reject(requeue); err != nil {
+		c.logger.Error().Err(err).Msg("failed to reject message")
+	}
+}
+
+// retries returns the number of times the message was rejected by the queue of the consumer
+func (c *consumer[T]) retries(d amqp.Delivery) int {
+	deaths, _ := d.Headers["x-death"].([]any)
+	for _, death := range deaths {
+		table, ok := death.(amqp.Table)
+		if !ok || table["queue"] != c.config.Queue || table["reason"] != "rejected" {
+			continue
+		}
+
+		count, _ := table["count"].(int64)
+		return int(count)
+	}
+
+	return 0
+}
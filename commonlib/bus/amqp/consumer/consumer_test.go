@@ -0,0 +1,496 @@
+package consumer
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ptrvsrg/crack-hash/commonlib/bus/amqp/deadletter"
+)
+
+const testQueue = "queue.test"
+
+type (
+	testEvent struct {
+		ID string `json:"id"`
+	}
+
+	// outcome is the settlement of a fake delivery
+	outcome string
+
+	fakeAcknowledger struct {
+		mu      sync.Mutex
+		outcome outcome
+	}
+
+	fakePublisher struct {
+		err       error
+		exchange  string
+		published []amqp.Publishing
+	}
+)
+
+const (
+	outcomeNone    outcome = ""
+	outcomeAck     outcome = "ack"
+	outcomeRequeue outcome = "requeue"
+	outcomeReject  outcome = "reject"
+)
+
+func (a *fakeAcknowledger) Ack(_ uint64, _ bool) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.outcome = outcomeAck
+	return nil
+}
+
+func (a *fakeAcknowledger) Nack(_ uint64, _, requeue bool) error {
+	return a.Reject(0, requeue)
+}
+
+func (a *fakeAcknowledger) Reject(_ uint64, requeue bool) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.outcome = outcomeReject
+	if requeue {
+		a.outcome = outcomeRequeue
+	}
+	return nil
+}
+
+func (a *fakeAcknowledger) result() outcome {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	return a.outcome
+}
+
+func (p *fakePublisher) Publish(
+	_ context.Context, exchange, _ string, _, _ bool, msg amqp.Publishing,
+) error {
+	if p.err != nil {
+		return p.err
+	}
+
+	p.exchange = exchange
+	p.published = append(p.published, msg)
+	return nil
+}
+
+func newTestConsumer(cfg Config, handler Handler[testEvent], pub *fakePublisher) *consumer[testEvent] {
+	cfg.Queue = testQueue
+	c := New[testEvent](nil, handler, cfg).(*consumer[testEvent])
+	c.publish = pub.Publish
+	c.logger = zerolog.Nop()
+
+	return c
+}
+
+func newDelivery(body string, headers amqp.Table) (amqp.Delivery, *fakeAcknowledger) {
+	ack := &fakeAcknowledger{}
+
+	return amqp.Delivery{Acknowledger: ack, DeliveryTag: 1, Headers: headers, Body: []byte(body)}, ack
+}
+
+// deaths builds the x-death header of the message rejected by the queue the number of times
+func deaths(queue, reason string, count int64) amqp.Table {
+	return amqp.Table{
+		"x-death": []any{
+			amqp.Table{"queue": "queue.test.retry", "reason": "expired", "count": count},
+			amqp.Table{"queue": queue, "reason": reason, "count": count},
+		},
+	}
+}
+
+func TestRetries(t *testing.T) {
+	tests := []struct {
+		name    string
+		headers amqp.Table
+		want    int
+	}{
+		{name: "No header", headers: nil, want: 0},
+		{name: "Rejected by queue", headers: deaths(testQueue, "rejected", 2), want: 2},
+		{name: "Rejected by another queue", headers: deaths("queue.other", "rejected", 2), want: 0},
+		{name: "Expired in queue", headers: deaths(testQueue, "expired", 2), want: 0},
+		{name: "Invalid header", headers: amqp.Table{"x-death": []any{"rejected"}}, want: 0},
+	}
+
+	for _, tc := range tests {
+		t.Run(
+			tc.name, func(t *testing.T) {
+				// Arrange
+				c := newTestConsumer(Config{}, nil, &fakePublisher{})
+				d, _ := newDelivery("{}", tc.headers)
+
+				// Act
+				retries := c.retries(d)
+
+				// Assert
+				assert.Equal(t, tc.want, retries)
+			},
+		)
+	}
+}
+
+func TestSettle(t *testing.T) {
+	errHandler := errors.New("handler error")
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	tests := []struct {
+		name       string
+		cfg        Config
+		ctx        context.Context
+		headers    amqp.Table
+		err        error
+		publishErr error
+		want       outcome
+		deadLetter bool
+	}{
+		{
+			name: "Success",
+			cfg:  Config{MaxRetries: 3},
+			err:  nil,
+			want: outcomeAck,
+		},
+		{
+			name: "Auto ack",
+			cfg:  Config{AutoAck: true, MaxRetries: 3},
+			err:  errHandler,
+			want: outcomeNone,
+		},
+		{
+			name: "Requeue",
+			cfg:  Config{Requeue: true, MaxRetries: 3},
+			err:  errHandler,
+			want: outcomeRequeue,
+		},
+		{
+			name: "Consumer stopped",
+			cfg:  Config{MaxRetries: 3},
+			ctx:  cancelled,
+			err:  errHandler,
+			want: outcomeRequeue,
+		},
+		{
+			name:    "Retry",
+			cfg:     Config{MaxRetries: 3},
+			headers: deaths(testQueue, "rejected", 2),
+			err:     errHandler,
+			want:    outcomeReject,
+		},
+		{
+			name:    "Drop after max retries",
+			cfg:     Config{MaxRetries: 3},
+			headers: deaths(testQueue, "rejected", 3),
+			err:     errHandler,
+			want:    outcomeAck,
+		},
+		{
+			name:       "Dead-letter after max retries",
+			cfg:        Config{MaxRetries: 3, DeadLetterExchange: "exchange.dead-letter"},
+			headers:    deaths(testQueue, "rejected", 3),
+			err:        errHandler,
+			want:       outcomeAck,
+			deadLetter: true,
+		},
+		{
+			name:       "Dead-letter permanent error",
+			cfg:        Config{MaxRetries: 3, DeadLetterExchange: "exchange.dead-letter"},
+			err:        Permanent(errHandler),
+			want:       outcomeAck,
+			deadLetter: true,
+		},
+		{
+			name: "Drop permanent error",
+			cfg:  Config{MaxRetries: 3},
+			err:  Permanent(errHandler),
+			want: outcomeAck,
+		},
+		{
+			name:       "Dead-letter failed",
+			cfg:        Config{MaxRetries: 0, DeadLetterExchange: "exchange.dead-letter"},
+			err:        errHandler,
+			publishErr: errors.New("publish error"),
+			want:       outcomeRequeue,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(
+			tc.name, func(t *testing.T) {
+				// Arrange
+				pub := &fakePublisher{err: tc.publishErr}
+				c := newTestConsumer(tc.cfg, nil, pub)
+				d, ack := newDelivery(`{"id":"1"}`, tc.headers)
+
+				ctx := tc.ctx
+				if ctx == nil {
+					ctx = context.Background()
+				}
+
+				// Act
+				c.settle(ctx, d, tc.err)
+
+				// Assert
+				assert.Equal(t, tc.want, ack.result())
+				if !tc.deadLetter {
+					assert.Empty(t, pub.published)
+					return
+				}
+
+				require.Len(t, pub.published, 1)
+				assert.Equal(t, tc.cfg.DeadLetterExchange, pub.exchange)
+				assert.Equal(t, testQueue, pub.published[0].Headers[deadletter.HeaderQueue])
+				assert.Equal(t, tc.err.Error(), pub.published[0].Headers[deadletter.HeaderError])
+				assert.Equal(t, d.Body, pub.published[0].Body)
+			},
+		)
+	}
+}
+
+func TestHandle(t *testing.T) {
+	t.Run(
+		"Unmarshal error", func(t *testing.T) {
+			tests := []struct {
+				name       string
+				cfg        Config
+				deadLetter bool
+			}{
+				{name: "Drop", cfg: Config{MaxRetries: 3}},
+				{
+					name:       "Dead-letter",
+					cfg:        Config{MaxRetries: 3, DeadLetterExchange: "exchange.dead-letter"},
+					deadLetter: true,
+				},
+			}
+
+			for _, tc := range tests {
+				t.Run(
+					tc.name, func(t *testing.T) {
+						// Arrange
+						called := false
+						handler := func(context.Context, testEvent, amqp.Delivery) error {
+							called = true
+							return nil
+						}
+						pub := &fakePublisher{}
+						c := newTestConsumer(tc.cfg, handler, pub)
+						d, ack := newDelivery("not a json", nil)
+
+						// Act
+						c.handle(context.Background(), d)
+
+						// Assert
+						assert.False(t, called)
+						assert.Equal(t, outcomeAck, ack.result())
+						assert.Equal(t, tc.deadLetter, len(pub.published) == 1)
+					},
+				)
+			}
+		},
+	)
+
+	t.Run(
+		"Handler panic", func(t *testing.T) {
+			// Arrange
+			handler := func(context.Context, testEvent, amqp.Delivery) error {
+				panic("handler panic")
+			}
+			c := newTestConsumer(Config{MaxRetries: 3}, handler, &fakePublisher{})
+			d, ack := newDelivery(`{"id":"1"}`, nil)
+
+			// Act
+			c.handle(context.Background(), d)
+
+			// Assert
+			assert.Equal(t, outcomeReject, ack.result())
+		},
+	)
+}
+
+// blockingHandler blocks handlers until they are released or their context is done, it counts handlers in flight
+type blockingHandler struct {
+	release  chan struct{}
+	started  atomic.Int64
+	inFlight atomic.Int64
+	peak     atomic.Int64
+}
+
+func newBlockingHandler() *blockingHandler {
+	return &blockingHandler{release: make(chan struct{})}
+}
+
+func (h *blockingHandler) handle(ctx context.Context, _ testEvent, _ amqp.Delivery) error {
+	h.started.Add(1)
+	current := h.inFlight.Add(1)
+	defer h.inFlight.Add(-1)
+
+	for {
+		peak := h.peak.Load()
+		if current <= peak || h.peak.CompareAndSwap(peak, current) {
+			break
+		}
+	}
+
+	select {
+	case <-h.release:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// subscribe starts the consumer of the deliveries, the returned channel is closed when Subscribe returns
+func subscribe(ctx context.Context, c *consumer[testEvent], deliveries ...amqp.Delivery) <-chan struct{} {
+	msgCh := make(chan amqp.Delivery, len(deliveries))
+	for _, d := range deliveries {
+		msgCh <- d
+	}
+	c.consume = func(context.Context) <-chan amqp.Delivery { return msgCh }
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		c.Subscribe(ctx)
+	}()
+
+	return done
+}
+
+func TestSubscribe(t *testing.T) {
+	t.Run(
+		"Max in flight", func(t *testing.T) {
+			// Arrange
+			h := newBlockingHandler()
+			c := newTestConsumer(Config{MaxInFlight: 2, DrainTimeout: time.Second}, h.handle, &fakePublisher{})
+
+			deliveries := make([]amqp.Delivery, 0, 5)
+			acks := make([]*fakeAcknowledger, 0, 5)
+			for range 5 {
+				d, ack := newDelivery(`{"id":"1"}`, nil)
+				deliveries = append(deliveries, d)
+				acks = append(acks, ack)
+			}
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			// Act
+			done := subscribe(ctx, c, deliveries...)
+			require.Eventually(
+				t, func() bool { return h.started.Load() == 2 }, time.Second, time.Millisecond,
+			)
+			time.Sleep(20 * time.Millisecond)
+			startedBeforeRelease := h.started.Load()
+			close(h.release)
+
+			require.Eventually(
+				t, func() bool {
+					for _, ack := range acks {
+						if ack.result() != outcomeAck {
+							return false
+						}
+					}
+					return true
+				}, time.Second, time.Millisecond,
+			)
+			cancel()
+			<-done
+
+			// Assert
+			assert.Equal(t, int64(2), startedBeforeRelease)
+			assert.Equal(t, int64(5), h.started.Load())
+			assert.Equal(t, int64(2), h.peak.Load())
+		},
+	)
+
+	t.Run(
+		"Wait for handlers in flight", func(t *testing.T) {
+			// Arrange
+			h := newBlockingHandler()
+			c := newTestConsumer(Config{MaxInFlight: 1, DrainTimeout: time.Minute}, h.handle, &fakePublisher{})
+			d, ack := newDelivery(`{"id":"1"}`, nil)
+
+			ctx, cancel := context.WithCancel(context.Background())
+			done := subscribe(ctx, c, d)
+			require.Eventually(
+				t, func() bool { return h.started.Load() == 1 }, time.Second, time.Millisecond,
+			)
+
+			// Act
+			cancel()
+
+			// Assert
+			select {
+			case <-done:
+				require.Fail(t, "consumer stopped before handlers in flight")
+			case <-time.After(20 * time.Millisecond):
+			}
+
+			close(h.release)
+			<-done
+			assert.Equal(t, outcomeAck, ack.result())
+		},
+	)
+
+	t.Run(
+		"Requeue after drain timeout", func(t *testing.T) {
+			// Arrange
+			h := newBlockingHandler()
+			c := newTestConsumer(
+				Config{MaxInFlight: 1, MaxRetries: 3, DrainTimeout: 10 * time.Millisecond}, h.handle, &fakePublisher{},
+			)
+			d, ack := newDelivery(`{"id":"1"}`, nil)
+
+			ctx, cancel := context.WithCancel(context.Background())
+			done := subscribe(ctx, c, d)
+			require.Eventually(
+				t, func() bool { return h.started.Load() == 1 }, time.Second, time.Millisecond,
+			)
+
+			// Act
+			cancel()
+			<-done
+
+			// Assert
+			assert.Equal(t, outcomeRequeue, ack.result())
+		},
+	)
+
+	t.Run(
+		"Retry after handler timeout", func(t *testing.T) {
+			// Arrange
+			h := newBlockingHandler()
+			c := newTestConsumer(
+				Config{MaxRetries: 3, Timeout: 10 * time.Millisecond, DrainTimeout: time.Second}, h.handle,
+				&fakePublisher{},
+			)
+			d, ack := newDelivery(`{"id":"1"}`, nil)
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			// Act
+			done := subscribe(ctx, c, d)
+			require.Eventually(
+				t, func() bool { return ack.result() != outcomeNone }, time.Second, time.Millisecond,
+			)
+			cancel()
+			<-done
+
+			// Assert
+			assert.Equal(t, outcomeReject, ack.result())
+		},
+	)
+}
@@ -0,0 +1,129 @@
+package deadletter
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+
+	commonamqp "github.com/ptrvsrg/crack-hash/commonlib/bus/amqp"
+)
+
+// Headers of a dead letter, they describe where the message was consumed and why it failed
+const (
+	HeaderQueue    = "x-original-queue"
+	HeaderExchange = "x-original-exchange"
+	HeaderError    = "x-error"
+	HeaderRetries  = "x-retries"
+)
+
+type (
+	// Message is a message, which is failed by the consumer and published to the dead-letter exchange
+	Message struct {
+		// Queue is the queue of the consumer, it is empty for a consumer of an exchange
+		Queue string
+		// Exchange is the exchange of the consumer of an exchange
+		Exchange       string
+		Error          string
+		Retries        int
+		ContentType    string
+		Priority       uint8
+		Body           []byte
+		DeadLetteredAt time.Time
+	}
+
+	// Replayer publishes dead letters to the consumer, which failed them
+	Replayer interface {
+		Replay(ctx context.Context, msg *Message) error
+	}
+
+	replayer struct {
+		ch     *commonamqp.Channel
+		logger zerolog.Logger
+	}
+)
+
+// Build builds the publishing of the dead letter of the delivery
+func Build(d amqp.Delivery, queue, exchange string, err error, retries int) amqp.Publishing {
+	return amqp.Publishing{
+		Headers: amqp.Table{
+			HeaderQueue:    queue,
+			HeaderExchange: exchange,
+			HeaderError:    err.Error(),
+			HeaderRetries:  int64(retries),
+		},
+		ContentType:  d.ContentType,
+		DeliveryMode: amqp.Persistent,
+		Priority:     d.Priority,
+		Timestamp:    time.Now(),
+		Body:         d.Body,
+	}
+}
+
+// Parse parses the dead letter from the delivery of the dead-letter queue
+func Parse(d amqp.Delivery) *Message {
+	msg := &Message{
+		ContentType:    d.ContentType,
+		Priority:       d.Priority,
+		Body:           d.Body,
+		DeadLetteredAt: d.Timestamp,
+	}
+
+	msg.Queue, _ = d.Headers[HeaderQueue].(string)
+	msg.Exchange, _ = d.Headers[HeaderExchange].(string)
+	msg.Error, _ = d.Headers[HeaderError].(string)
+
+	switch retries := d.Headers[HeaderRetries].(type) {
+	case int64:
+		msg.Retries = int(retries)
+	case int32:
+		msg.Retries = int(retries)
+	}
+
+	if msg.DeadLetteredAt.IsZero() {
+		msg.DeadLetteredAt = time.Now()
+	}
+
+	return msg
+}
+
+func NewReplayer(ch *commonamqp.Channel) Replayer {
+	return &replayer{
+		ch: ch,
+		logger: log.With().
+			Str("component", "amqp-dead-letter-replayer").
+			Logger(),
+	}
+}
+
+// Replay publishes the message to the queue of the consumer through the default exchange, so other consumers
+// bound to the same exchange do not get it again. Messages of a consumer of an exchange are published
+// to the exchange
+func (r *replayer) Replay(ctx context.Context, msg *Message) error {
+	exchange, key := "", msg.Queue
+	if msg.Queue == "" {
+		exchange = msg.Exchange
+	}
+
+	r.logger.Debug().Str("exchange", exchange).Str("routing-key", key).Msg("replay message")
+
+	err := r.ch.Publish(
+		ctx, exchange, key, false, false,
+		amqp.Publishing{
+			ContentType:  msg.ContentType,
+			DeliveryMode: amqp.Persistent,
+			Priority:     msg.Priority,
+			Timestamp:    time.Now(),
+			Body:         msg.Body,
+		},
+	)
+	if err != nil {
+		r.logger.Error().Err(err).Stack().Msg("failed to replay message")
+		return fmt.Errorf("failed to replay message: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,84 @@
+// Code generated by mockery v2.53.3. DO NOT EDIT.
+
+package mock
+
+import (
+	context "context"
+
+	deadletter "github.com/ptrvsrg/crack-hash/commonlib/bus/amqp/deadletter"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// ReplayerMock is an autogenerated mock type for the Replayer type
+type ReplayerMock struct {
+	mock.Mock
+}
+
+type ReplayerMock_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *ReplayerMock) EXPECT() *ReplayerMock_Expecter {
+	return &ReplayerMock_Expecter{mock: &_m.Mock}
+}
+
+// Replay provides a mock function with given fields: ctx, msg
+func (_m *ReplayerMock) Replay(ctx context.Context, msg *deadletter.Message) error {
+	ret := _m.Called(ctx, msg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Replay")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *deadletter.Message) error); ok {
+		r0 = rf(ctx, msg)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ReplayerMock_Replay_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Replay'
+type ReplayerMock_Replay_Call struct {
+	*mock.Call
+}
+
+// Replay is a helper method to define mock.On call
+//   - ctx context.Context
+//   - msg *deadletter.Message
+func (_e *ReplayerMock_Expecter) Replay(ctx interface{}, msg interface{}) *ReplayerMock_Replay_Call {
+	return &ReplayerMock_Replay_Call{Call: _e.mock.On("Replay", ctx, msg)}
+}
+
+func (_c *ReplayerMock_Replay_Call) Run(run func(ctx context.Context, msg *deadletter.Message)) *ReplayerMock_Replay_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*deadletter.Message))
+	})
+	return _c
+}
+
+func (_c *ReplayerMock_Replay_Call) Return(_a0 error) *ReplayerMock_Replay_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ReplayerMock_Replay_Call) RunAndReturn(run func(context.Context, *deadletter.Message) error) *ReplayerMock_Replay_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewReplayerMock creates a new instance of ReplayerMock. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewReplayerMock(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ReplayerMock {
+	mock := &ReplayerMock{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
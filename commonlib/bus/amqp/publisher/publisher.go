@@ -18,17 +18,26 @@ const (
 	Persistent DeliveryMode = 2
 )
 
+const (
+	sendAttempts = 3
+	sendDelay    = time.Second
+)
+
 type (
 	DeliveryMode uint8
 
 	Config struct {
-		Exchange    string
-		RoutingKey  string
-		Marshal     func(v any) ([]byte, error)
+		Exchange   string
+		RoutingKey string
+		Marshal    func(v any) ([]byte, error)
+		// Priority returns the priority of the message, messages are published without priority if nil
+		Priority    func(v any) uint8
 		ContentType string
 	}
 
 	Publisher[T any] interface {
+		// SendMessage publishes the message, it returns after the broker confirms the message, if the channel
+		// is in confirm mode
 		SendMessage(ctx context.Context, message *T, mode DeliveryMode, mandatory, immediate bool) error
 	}
 
@@ -77,25 +86,29 @@ func (p *publisher[T]) SendMessage(
 		return fmt.Errorf("failed to marshal message: %w", err)
 	}
 
-	amqpMsg := p.buildMessage(body, mode)
+	amqpMsg := p.buildMessage(message, body, mode)
+
+	// Publish is retried, if the channel is reconnecting or the broker nacks the message
+	for i := 0; i < sendAttempts; i++ {
+		if i > 0 {
+			select {
+			case <-ctx.Done():
+				err = errors.Join(err, ctx.Err())
+				return fmt.Errorf("failed to publish a message: %w", err)
+			case <-time.After(sendDelay):
+			}
+		}
 
-	for i := 0; i < 3; i++ {
 		sendErr := p.sendMessage(ctx, mandatory, immediate, amqpMsg)
 		if sendErr == nil {
-			break
+			return nil
 		}
 
-		p.logger.Error().Err(sendErr).Stack().Msg("failed to publish a message")
+		p.logger.Error().Err(sendErr).Stack().Int("attempt", i+1).Msg("failed to publish a message")
 		err = errors.Join(err, sendErr)
-
-		time.Sleep(1 * time.Second)
 	}
 
-	if err != nil {
-		return fmt.Errorf("failed to publish a message: %w", err)
-	}
-
-	return nil
+	return fmt.Errorf("failed to publish a message: %w", err)
 }
 
 func (p *publisher[T]) sendMessage(ctx context.Context, mandatory, immediate bool, ampqMsg *amqp.Publishing) error {
@@ -113,10 +126,16 @@ func (p *publisher[T]) sendMessage(ctx context.Context, mandatory, immediate boo
 	return nil
 }
 
-func (p *publisher[T]) buildMessage(body []byte, mode DeliveryMode) *amqp.Publishing {
-	return &amqp.Publishing{
+func (p *publisher[T]) buildMessage(message *T, body []byte, mode DeliveryMode) *amqp.Publishing {
+	msg := &amqp.Publishing{
 		DeliveryMode: uint8(mode),
 		ContentType:  p.contentType,
 		Body:         body,
 	}
+
+	if p.config.Priority != nil {
+		msg.Priority = p.config.Priority(message)
+	}
+
+	return msg
 }
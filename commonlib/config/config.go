@@ -5,6 +5,7 @@ import (
 	"os"
 	"strings"
 
+	"github.com/go-playground/validator/v10"
 	_ "github.com/joho/godotenv/autoload"
 	"github.com/num30/config"
 	"github.com/rs/zerolog/log"
@@ -31,6 +32,15 @@ func Load[T any]() (T, error) {
 	return cfg, nil
 }
 
+// Validate validates the part of the config, which is skipped on loading, because it is used only in some modes
+func Validate(cfg any) error {
+	if err := validator.New().Struct(cfg); err != nil {
+		return fmt.Errorf("validation error: %w", err)
+	}
+
+	return nil
+}
+
 func getConfigName() string {
 	configPath := getEnvOrDefault("CONFIG_FILE", "config/config.yaml")
 	oldnew := make([]string, 2*len(viper.SupportedExts))
@@ -42,14 +42,17 @@ func WithRetries(retries int, minWaitTime, maxWaitTime time.Duration) Option {
 	}
 }
 
+// WithLoadBalancer sets the load balancer of hosts, the strategy, health checks and the circuit breaker
+// are chosen by options
 func WithLoadBalancer(urls []string, opts ...loadbalancer.Option) Option {
 	return func(c *resty.Client) error {
-		lb, err := loadbalancer.NewRoundRobin(urls, opts...)
+		lb, err := loadbalancer.New(urls, opts...)
 		if err != nil {
 			return fmt.Errorf("failed to setup load balancer: %w", err)
 		}
 
 		c.SetLoadBalancer(lb)
+		c.SetTransport(lb.WrapTransport(c.Transport()))
 		return nil
 	}
 }
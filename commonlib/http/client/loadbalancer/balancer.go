@@ -0,0 +1,378 @@
+package loadbalancer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+	"github.com/samber/lo"
+	"golang.org/x/sync/errgroup"
+	"resty.dev/v3"
+
+	"github.com/ptrvsrg/crack-hash/commonlib/http/types"
+)
+
+const (
+	HostStateInActive HostState = iota
+	HostStateActive
+)
+
+const (
+	circuitClosed circuitState = iota
+	circuitOpen
+	circuitHalfOpen
+)
+
+const (
+	defaultMaxFailures  = 5
+	defaultEjectTimeout = 30 * time.Second
+)
+
+var (
+	ErrNoHosts        = errors.New("no hosts")
+	ErrInvalidWeights = errors.New("invalid weights")
+)
+
+type (
+	HostState int
+
+	circuitState int
+
+	Host struct {
+		url    string
+		key    string // Scheme and host of the URL, which identify requests to the host
+		weight int
+		state  HostState // State of health checks
+
+		// Weighted strategy
+		currentWeight int
+
+		// Least outstanding requests strategy
+		outstanding atomic.Int64
+
+		// Circuit breaker
+		circuit  circuitState
+		failures int
+		openedAt time.Time
+	}
+
+	// Balancer chooses the host of a request by the strategy among hosts, which pass health checks and
+	// whose circuit is closed. The circuit is opened by max failures in a row reported by the feedback
+	// of the client and is half-opened after the eject timeout to let one request probe the host
+	Balancer struct {
+		hosts    []*Host
+		byKey    map[string]*Host
+		strategy Strategy
+		weights  []int
+		picker   picker
+		lock     *sync.Mutex
+		logger   zerolog.Logger
+
+		// Circuit breaker
+		maxFailures  int
+		ejectTimeout time.Duration
+
+		// Health checks
+		healthPath    string
+		healthTimeout time.Duration
+		healthDelay   time.Duration
+		healthRetries int
+		healthGroup   *errgroup.Group
+		healthCtx     context.Context
+		healthCancel  context.CancelFunc
+	}
+
+	Option func(*Balancer)
+)
+
+func WithHealthChecks(path string, timeout, delay time.Duration, retries int) Option {
+	return func(b *Balancer) {
+		b.healthPath = path
+		b.healthTimeout = timeout
+		b.healthDelay = delay
+		b.healthRetries = retries
+
+		b.healthCtx, b.healthCancel = context.WithCancel(context.Background())
+		b.healthGroup, _ = errgroup.WithContext(b.healthCtx)
+	}
+}
+
+// WithStrategy sets the strategy, round-robin is used by default
+func WithStrategy(strategy Strategy) Option {
+	return func(b *Balancer) {
+		b.strategy = strategy
+	}
+}
+
+// WithWeights sets weights of hosts in the order of URLs for the weighted strategy, every host has weight 1
+// if weights are empty
+func WithWeights(weights ...int) Option {
+	return func(b *Balancer) {
+		b.weights = weights
+	}
+}
+
+// WithCircuitBreaker sets the number of failures in a row, after which the host is ejected, and the timeout,
+// after which the ejected host is probed. Max failures 0 disables ejection
+func WithCircuitBreaker(maxFailures int, ejectTimeout time.Duration) Option {
+	return func(b *Balancer) {
+		b.maxFailures = maxFailures
+		b.ejectTimeout = ejectTimeout
+	}
+}
+
+func New(urls []string, opts ...Option) (*Balancer, error) {
+	if len(urls) == 0 {
+		return nil, ErrNoHosts
+	}
+
+	hosts := make([]*Host, len(urls))
+	byKey := make(map[string]*Host, len(urls))
+	for i, u := range urls {
+		parsed, err := url.Parse(u)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse URL: %w", err)
+		}
+
+		hosts[i] = &Host{
+			url:    u,
+			key:    parsed.Scheme + "://" + parsed.Host,
+			weight: 1,
+			state:  HostStateActive,
+		}
+		byKey[hosts[i].key] = hosts[i]
+	}
+
+	b := &Balancer{
+		hosts:        hosts,
+		byKey:        byKey,
+		strategy:     StrategyRoundRobin,
+		lock:         new(sync.Mutex),
+		maxFailures:  defaultMaxFailures,
+		ejectTimeout: defaultEjectTimeout,
+	}
+
+	for _, opt := range opts {
+		opt(b)
+	}
+
+	if len(b.weights) > 0 {
+		if len(b.weights) != len(b.hosts) {
+			return nil, fmt.Errorf("%w: %d weights for %d hosts", ErrInvalidWeights, len(b.weights), len(b.hosts))
+		}
+
+		for i, h := range b.hosts {
+			if b.weights[i] < 1 {
+				return nil, fmt.Errorf("%w: weight of %s must be positive", ErrInvalidWeights, h.url)
+			}
+
+			h.weight = b.weights[i]
+		}
+	}
+
+	picker, err := newPicker(b.strategy)
+	if err != nil {
+		return nil, err
+	}
+
+	b.picker = picker
+	b.logger = log.With().
+		Str("component", "load-balancer").
+		Str("strategy", string(b.strategy)).
+		Logger()
+
+	if b.healthGroup != nil {
+		go b.healthCheck()
+	}
+
+	return b, nil
+}
+
+func (b *Balancer) Strategy() Strategy {
+	return b.strategy
+}
+
+func (b *Balancer) Next() (string, error) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	now := time.Now()
+	candidates := lo.Filter(
+		b.hosts, func(h *Host, _ int) bool {
+			return b.isAvailable(h, now)
+		},
+	)
+
+	host := b.picker.pick(candidates)
+	if host == nil {
+		return "", resty.ErrNoActiveHost
+	}
+
+	// Let one request probe the ejected host, the next probe is allowed after the eject timeout,
+	// if the feedback of the probe does not come
+	if host.circuit != circuitClosed {
+		if host.circuit == circuitOpen {
+			b.logger.Info().Str("url", host.url).Msg("circuit half-opened")
+		}
+
+		host.circuit = circuitHalfOpen
+		host.openedAt = now
+	}
+
+	return host.url, nil
+}
+
+// Feedback handles the result of a request, the client reports only the last attempt of a request.
+// Requests to absolute URLs are ignored
+func (b *Balancer) Feedback(f *resty.RequestFeedback) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	host, ok := lo.Find(
+		b.hosts, func(h *Host) bool {
+			return h.url == f.BaseURL
+		},
+	)
+	if !ok {
+		return
+	}
+
+	if f.Success {
+		if host.circuit != circuitClosed {
+			b.logger.Info().Str("url", host.url).Msg("circuit closed")
+		}
+
+		host.circuit = circuitClosed
+		host.failures = 0
+		return
+	}
+
+	host.failures++
+	if b.maxFailures == 0 {
+		return
+	}
+
+	if host.circuit == circuitHalfOpen || (host.circuit == circuitClosed && host.failures >= b.maxFailures) {
+		b.logger.Warn().Str("url", host.url).Int("failures", host.failures).Msg("circuit opened")
+
+		host.circuit = circuitOpen
+		host.openedAt = time.Now()
+	}
+}
+
+func (b *Balancer) CountActiveHosts() int {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	now := time.Now()
+	return lo.CountBy(
+		b.hosts, func(h *Host) bool {
+			return b.isAvailable(h, now)
+		},
+	)
+}
+
+func (b *Balancer) Close() error {
+	// The lock is not held, because health checks change states of hosts until they stop
+	if b.healthCancel != nil {
+		b.healthCancel()
+	}
+
+	if b.healthGroup != nil {
+		err := b.healthGroup.Wait()
+		if err != nil {
+			return fmt.Errorf("failed to wait for health check: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// isAvailable reports if the host passes health checks and its circuit is closed or may be probed
+func (b *Balancer) isAvailable(h *Host, now time.Time) bool {
+	if h.state != HostStateActive {
+		return false
+	}
+
+	return h.circuit == circuitClosed || now.Sub(h.openedAt) >= b.ejectTimeout
+}
+
+func (b *Balancer) healthCheck() {
+	client := resty.New()
+
+	for _, host := range b.hosts {
+		healthUrl := fmt.Sprintf("%s%s", host.url, b.healthPath)
+
+		b.healthGroup.Go(
+			func() error {
+				for {
+					select {
+					case <-b.healthCtx.Done():
+						return nil
+
+					default:
+						// Timeout
+						timer := time.NewTimer(b.healthDelay)
+						isCtxDone := false
+
+						select {
+						case <-b.healthCtx.Done():
+							isCtxDone = true
+							break
+						case <-timer.C:
+							break
+						}
+
+						timer.Stop()
+						if isCtxDone {
+							return nil
+						}
+
+						// Health check start
+						b.logger.Debug().Str("url", healthUrl).Msg("health check started")
+
+						errOutput := &types.ErrorOutput{}
+						timeoutCtx, cancel := context.WithTimeout(context.Background(), b.healthTimeout)
+
+						resp, err := client.R().
+							SetContext(timeoutCtx).
+							SetError(errOutput).
+							SetRetryCount(b.healthRetries).
+							Get(healthUrl)
+						cancel()
+
+						if err != nil {
+							b.logger.Error().Err(err).Str("url", healthUrl).Msg("health check failed")
+							b.changeState(host, HostStateInActive)
+							continue
+						}
+
+						if resp.IsError() {
+							err := errors.New(errOutput.Message) // nolint
+							b.logger.Error().Err(err).Str("url", healthUrl).Msg("health check failed")
+							b.changeState(host, HostStateInActive)
+							continue
+						}
+
+						b.changeState(host, HostStateActive)
+
+						b.logger.Debug().Str("url", healthUrl).Msg("health check finished")
+					}
+				}
+			},
+		)
+	}
+}
+
+func (b *Balancer) changeState(host *Host, state HostState) {
+	defer b.lock.Unlock()
+	b.lock.Lock()
+
+	host.state = state
+}
@@ -0,0 +1,394 @@
+package loadbalancer_test
+
+import (
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"resty.dev/v3"
+
+	"github.com/ptrvsrg/crack-hash/commonlib/http/client"
+	"github.com/ptrvsrg/crack-hash/commonlib/http/client/loadbalancer"
+	"github.com/ptrvsrg/crack-hash/commonlib/logging"
+)
+
+var urls = []string{"http://host1:8080", "http://host2:8080", "http://host3:8080"}
+
+func init() {
+	logging.Setup(true)
+}
+
+// nextConcurrently calls Next from the number of goroutines the number of times and counts chosen hosts
+func nextConcurrently(t *testing.T, lb *loadbalancer.Balancer, goroutines, calls int) map[string]int {
+	t.Helper()
+
+	var (
+		wg     sync.WaitGroup
+		mu     sync.Mutex
+		counts = make(map[string]int)
+	)
+	for range goroutines {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			for range calls {
+				host, err := lb.Next()
+				assert.NoError(t, err)
+
+				mu.Lock()
+				counts[host]++
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	return counts
+}
+
+func nextN(t *testing.T, lb *loadbalancer.Balancer, n int) []string {
+	t.Helper()
+
+	hosts := make([]string, n)
+	for i := range n {
+		host, err := lb.Next()
+		require.NoError(t, err)
+
+		hosts[i] = host
+	}
+
+	return hosts
+}
+
+func TestNew(t *testing.T) {
+	t.Run(
+		"Unknown strategy", func(t *testing.T) {
+			// Act
+			lb, err := loadbalancer.New(urls, loadbalancer.WithStrategy("random"))
+
+			// Assert
+			require.ErrorIs(t, err, loadbalancer.ErrUnknownStrategy)
+			require.Nil(t, lb)
+		},
+	)
+
+	t.Run(
+		"Invalid weights", func(t *testing.T) {
+			// Act
+			lbShort, errShort := loadbalancer.New(urls, loadbalancer.WithWeights(1, 2))
+			lbZero, errZero := loadbalancer.New(urls, loadbalancer.WithWeights(1, 0, 2))
+
+			// Assert
+			require.ErrorIs(t, errShort, loadbalancer.ErrInvalidWeights)
+			require.Nil(t, lbShort)
+			require.ErrorIs(t, errZero, loadbalancer.ErrInvalidWeights)
+			require.Nil(t, lbZero)
+		},
+	)
+
+	t.Run(
+		"No hosts", func(t *testing.T) {
+			// Act
+			lb, err := loadbalancer.New(nil)
+
+			// Assert
+			require.ErrorIs(t, err, loadbalancer.ErrNoHosts)
+			require.Nil(t, lb)
+		},
+	)
+}
+
+func TestNext(t *testing.T) {
+	t.Run(
+		"Round-robin", func(t *testing.T) {
+			// Arrange
+			lb, err := loadbalancer.New(urls)
+			require.NoError(t, err)
+
+			// Act
+			hosts := nextN(t, lb, 6)
+
+			// Assert
+			assert.Equal(t, append(urls, urls...), hosts)
+		},
+	)
+
+	t.Run(
+		"Round-robin concurrently", func(t *testing.T) {
+			// Arrange
+			lb, err := loadbalancer.New(urls, loadbalancer.WithStrategy(loadbalancer.StrategyRoundRobin))
+			require.NoError(t, err)
+
+			// Act
+			counts := nextConcurrently(t, lb, 50, 60)
+
+			// Assert
+			assert.Equal(t, map[string]int{urls[0]: 1000, urls[1]: 1000, urls[2]: 1000}, counts)
+		},
+	)
+
+	t.Run(
+		"Weighted", func(t *testing.T) {
+			// Arrange
+			lb, err := loadbalancer.New(
+				urls, loadbalancer.WithStrategy(loadbalancer.StrategyWeighted), loadbalancer.WithWeights(5, 1, 1),
+			)
+			require.NoError(t, err)
+
+			// Act
+			hosts := nextN(t, lb, 7)
+
+			// Assert
+			// The heaviest host is interleaved with others
+			assert.Equal(t, []string{urls[0], urls[0], urls[1], urls[0], urls[2], urls[0], urls[0]}, hosts)
+		},
+	)
+
+	t.Run(
+		"Weighted concurrently", func(t *testing.T) {
+			// Arrange
+			lb, err := loadbalancer.New(
+				urls, loadbalancer.WithStrategy(loadbalancer.StrategyWeighted), loadbalancer.WithWeights(3, 2, 1),
+			)
+			require.NoError(t, err)
+
+			// Act
+			counts := nextConcurrently(t, lb, 50, 60)
+
+			// Assert
+			assert.Equal(t, map[string]int{urls[0]: 1500, urls[1]: 1000, urls[2]: 500}, counts)
+		},
+	)
+
+	t.Run(
+		"Least outstanding without requests", func(t *testing.T) {
+			// Arrange
+			lb, err := loadbalancer.New(urls, loadbalancer.WithStrategy(loadbalancer.StrategyLeastOutstanding))
+			require.NoError(t, err)
+
+			// Act
+			hosts := nextN(t, lb, 3)
+
+			// Assert
+			// Hosts without requests in progress are chosen in turn
+			assert.Equal(t, urls, hosts)
+		},
+	)
+}
+
+func TestFeedback(t *testing.T) {
+	t.Run(
+		"Eject failed host", func(t *testing.T) {
+			// Arrange
+			lb, err := loadbalancer.New(urls, loadbalancer.WithCircuitBreaker(2, time.Hour))
+			require.NoError(t, err)
+
+			// Act
+			lb.Feedback(&resty.RequestFeedback{BaseURL: urls[0], Success: false})
+			activeAfterFailure := lb.CountActiveHosts()
+			lb.Feedback(&resty.RequestFeedback{BaseURL: urls[0], Success: false})
+			activeAfterEjection := lb.CountActiveHosts()
+			hosts := nextN(t, lb, 4)
+
+			// Assert
+			assert.Equal(t, 3, activeAfterFailure)
+			assert.Equal(t, 2, activeAfterEjection)
+			assert.NotContains(t, hosts, urls[0])
+		},
+	)
+
+	t.Run(
+		"Success resets failures", func(t *testing.T) {
+			// Arrange
+			lb, err := loadbalancer.New(urls, loadbalancer.WithCircuitBreaker(2, time.Hour))
+			require.NoError(t, err)
+
+			// Act
+			lb.Feedback(&resty.RequestFeedback{BaseURL: urls[0], Success: false})
+			lb.Feedback(&resty.RequestFeedback{BaseURL: urls[0], Success: true})
+			lb.Feedback(&resty.RequestFeedback{BaseURL: urls[0], Success: false})
+
+			// Assert
+			assert.Equal(t, 3, lb.CountActiveHosts())
+		},
+	)
+
+	t.Run(
+		"Probe ejected host", func(t *testing.T) {
+			// Arrange
+			lb, err := loadbalancer.New(urls[:2], loadbalancer.WithCircuitBreaker(1, 50*time.Millisecond))
+			require.NoError(t, err)
+
+			lb.Feedback(&resty.RequestFeedback{BaseURL: urls[0], Success: false})
+			time.Sleep(60 * time.Millisecond)
+
+			// Act
+			probeHosts := nextN(t, lb, 4)
+			lb.Feedback(&resty.RequestFeedback{BaseURL: urls[0], Success: true})
+			recoveredHosts := nextN(t, lb, 4)
+
+			// Assert
+			// Only one request probes the host until its feedback comes
+			assert.Equal(t, []string{urls[0], urls[1], urls[1], urls[1]}, probeHosts)
+			assert.ElementsMatch(t, []string{urls[0], urls[1], urls[0], urls[1]}, recoveredHosts)
+		},
+	)
+
+	t.Run(
+		"Failed probe ejects host again", func(t *testing.T) {
+			// Arrange
+			lb, err := loadbalancer.New(urls[:2], loadbalancer.WithCircuitBreaker(3, 50*time.Millisecond))
+			require.NoError(t, err)
+
+			for range 3 {
+				lb.Feedback(&resty.RequestFeedback{BaseURL: urls[0], Success: false})
+			}
+			time.Sleep(60 * time.Millisecond)
+
+			// Act
+			probeHost, err := lb.Next()
+			require.NoError(t, err)
+			lb.Feedback(&resty.RequestFeedback{BaseURL: urls[0], Success: false})
+			hosts := nextN(t, lb, 4)
+
+			// Assert
+			assert.Equal(t, urls[0], probeHost)
+			assert.NotContains(t, hosts, urls[0])
+		},
+	)
+
+	t.Run(
+		"All hosts ejected", func(t *testing.T) {
+			// Arrange
+			lb, err := loadbalancer.New(urls[:1], loadbalancer.WithCircuitBreaker(1, time.Hour))
+			require.NoError(t, err)
+
+			// Act
+			lb.Feedback(&resty.RequestFeedback{BaseURL: urls[0], Success: false})
+			host, err := lb.Next()
+
+			// Assert
+			require.ErrorIs(t, err, resty.ErrNoActiveHost)
+			require.Empty(t, host)
+		},
+	)
+
+	t.Run(
+		"Ejection disabled", func(t *testing.T) {
+			// Arrange
+			lb, err := loadbalancer.New(urls, loadbalancer.WithCircuitBreaker(0, time.Hour))
+			require.NoError(t, err)
+
+			// Act
+			for range 100 {
+				lb.Feedback(&resty.RequestFeedback{BaseURL: urls[0], Success: false})
+			}
+
+			// Assert
+			assert.Equal(t, 3, lb.CountActiveHosts())
+		},
+	)
+
+	t.Run(
+		"Unknown host", func(t *testing.T) {
+			// Arrange
+			lb, err := loadbalancer.New(urls, loadbalancer.WithCircuitBreaker(1, time.Hour))
+			require.NoError(t, err)
+
+			// Act
+			lb.Feedback(&resty.RequestFeedback{BaseURL: "", Success: false})
+
+			// Assert
+			assert.Equal(t, 3, lb.CountActiveHosts())
+		},
+	)
+
+	t.Run(
+		"Concurrently with next", func(t *testing.T) {
+			// Arrange
+			lb, err := loadbalancer.New(urls, loadbalancer.WithCircuitBreaker(3, time.Millisecond))
+			require.NoError(t, err)
+
+			// Act
+			var wg sync.WaitGroup
+			for i := range 50 {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+
+					for j := range 100 {
+						host, err := lb.Next()
+						if err != nil {
+							assert.ErrorIs(t, err, resty.ErrNoActiveHost)
+							continue
+						}
+
+						// The first host fails always, others fail sometimes
+						success := host != urls[0] && (i+j)%5 != 0
+						lb.Feedback(&resty.RequestFeedback{BaseURL: host, Success: success})
+					}
+				}()
+			}
+			wg.Wait()
+
+			time.Sleep(2 * time.Millisecond)
+			for _, u := range urls[1:] {
+				lb.Feedback(&resty.RequestFeedback{BaseURL: u, Success: true})
+			}
+
+			// Assert
+			// The first host is ejected or probed, others are active after success
+			assert.GreaterOrEqual(t, lb.CountActiveHosts(), 2)
+		},
+	)
+
+	t.Run(
+		"Keep saturated host", func(t *testing.T) {
+			tests := []struct {
+				name        string
+				status      int
+				activeHosts int
+			}{
+				{name: "Too many requests", status: http.StatusTooManyRequests, activeHosts: 2},
+				{name: "Service unavailable", status: http.StatusServiceUnavailable, activeHosts: 1},
+			}
+
+			for _, tc := range tests {
+				t.Run(
+					tc.name, func(t *testing.T) {
+						// Arrange
+						saturated := newTestServer(
+							t, func(w http.ResponseWriter, _ *http.Request) {
+								w.WriteHeader(tc.status)
+							},
+						)
+						idle := newTestServer(t, ok)
+
+						c, err := client.New(
+							client.WithLoadBalancer(
+								[]string{saturated.URL, idle.URL}, loadbalancer.WithCircuitBreaker(1, time.Hour),
+							),
+						)
+						require.NoError(t, err)
+						defer c.Close()
+
+						// Act
+						for range 4 {
+							_, err := c.R().Get("/")
+							require.NoError(t, err)
+						}
+
+						// Assert
+						lb, isBalancer := c.LoadBalancer().(*loadbalancer.Balancer)
+						require.True(t, isBalancer)
+						assert.Equal(t, tc.activeHosts, lb.CountActiveHosts())
+					},
+				)
+			}
+		},
+	)
+}
@@ -0,0 +1,103 @@
+package loadbalancer
+
+import (
+	"errors"
+	"fmt"
+)
+
+const (
+	// StrategyRoundRobin sends requests to hosts in turn
+	StrategyRoundRobin Strategy = "round-robin"
+	// StrategyLeastOutstanding sends a request to the host with the least number of requests in progress
+	StrategyLeastOutstanding Strategy = "least-outstanding"
+	// StrategyWeighted sends requests to hosts in turn in proportion to their weights
+	StrategyWeighted Strategy = "weighted"
+)
+
+var ErrUnknownStrategy = errors.New("unknown strategy")
+
+type (
+	Strategy string
+
+	// picker chooses one of available hosts, it is called under the lock of the balancer
+	picker interface {
+		pick(hosts []*Host) *Host
+	}
+
+	roundRobinPicker struct {
+		next int
+	}
+
+	leastOutstandingPicker struct {
+		next int
+	}
+
+	// weightedPicker is the smooth weighted round-robin, which interleaves hosts instead of sending
+	// a series of requests to the heaviest host
+	weightedPicker struct{}
+)
+
+func newPicker(strategy Strategy) (picker, error) {
+	switch strategy {
+	case StrategyRoundRobin:
+		return &roundRobinPicker{}, nil
+	case StrategyLeastOutstanding:
+		return &leastOutstandingPicker{}, nil
+	case StrategyWeighted:
+		return &weightedPicker{}, nil
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnknownStrategy, strategy)
+	}
+}
+
+func (p *roundRobinPicker) pick(hosts []*Host) *Host {
+	if len(hosts) == 0 {
+		return nil
+	}
+
+	host := hosts[p.next%len(hosts)]
+	p.next++
+
+	return host
+}
+
+func (p *leastOutstandingPicker) pick(hosts []*Host) *Host {
+	if len(hosts) == 0 {
+		return nil
+	}
+
+	// Hosts with the same number of requests are chosen in turn
+	var best *Host
+	for i := range hosts {
+		host := hosts[(p.next+i)%len(hosts)]
+		if best == nil || host.outstanding.Load() < best.outstanding.Load() {
+			best = host
+		}
+	}
+	p.next++
+
+	return best
+}
+
+func (p *weightedPicker) pick(hosts []*Host) *Host {
+	var (
+		best  *Host
+		total int
+	)
+	for _, host := range hosts {
+		host.currentWeight += host.weight
+		total += host.weight
+
+		if best == nil || host.currentWeight > best.currentWeight {
+			best = host
+		}
+	}
+
+	if best == nil {
+		return nil
+	}
+
+	best.currentWeight -= total
+
+	return best
+}
@@ -0,0 +1,62 @@
+package loadbalancer
+
+import (
+	"io"
+	"net/http"
+	"sync"
+)
+
+type (
+	// countingTransport counts requests in progress of hosts. A request is in progress until its response body
+	// is closed, so every retry is counted unlike with the feedback of the client
+	countingTransport struct {
+		balancer *Balancer
+		next     http.RoundTripper
+	}
+
+	countingBody struct {
+		io.ReadCloser
+		once sync.Once
+		done func()
+	}
+)
+
+// WrapTransport returns the transport counting requests in progress for the least outstanding requests strategy,
+// other strategies do not need it, so the transport is returned as is
+func (b *Balancer) WrapTransport(next http.RoundTripper) http.RoundTripper {
+	if b.strategy != StrategyLeastOutstanding {
+		return next
+	}
+
+	return &countingTransport{
+		balancer: b,
+		next:     next,
+	}
+}
+
+func (t *countingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	host, ok := t.balancer.byKey[req.URL.Scheme+"://"+req.URL.Host]
+	if !ok {
+		return t.next.RoundTrip(req)
+	}
+
+	host.outstanding.Add(1)
+	done := func() { host.outstanding.Add(-1) }
+
+	resp, err := t.next.RoundTrip(req)
+	if err != nil || resp == nil || resp.Body == nil {
+		done()
+		return resp, err
+	}
+
+	resp.Body = &countingBody{ReadCloser: resp.Body, done: done}
+
+	return resp, nil
+}
+
+func (b *countingBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.once.Do(b.done)
+
+	return err
+}
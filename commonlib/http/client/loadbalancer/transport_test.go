@@ -0,0 +1,179 @@
+package loadbalancer_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ptrvsrg/crack-hash/commonlib/http/client"
+	"github.com/ptrvsrg/crack-hash/commonlib/http/client/loadbalancer"
+)
+
+type testServer struct {
+	*httptest.Server
+	hits atomic.Int64
+}
+
+func newTestServer(t *testing.T, handler http.HandlerFunc) *testServer {
+	t.Helper()
+
+	s := &testServer{}
+	s.Server = httptest.NewServer(
+		http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				s.hits.Add(1)
+				handler(w, r)
+			},
+		),
+	)
+	t.Cleanup(s.Close)
+
+	return s
+}
+
+func ok(w http.ResponseWriter, _ *http.Request) {
+	w.WriteHeader(http.StatusOK)
+}
+
+func TestLeastOutstanding(t *testing.T) {
+	t.Run(
+		"Avoid busy host", func(t *testing.T) {
+			// Arrange
+			started := make(chan struct{})
+			release := make(chan struct{})
+			busy := newTestServer(
+				t, func(w http.ResponseWriter, _ *http.Request) {
+					close(started)
+					<-release
+					w.WriteHeader(http.StatusOK)
+				},
+			)
+			idle := newTestServer(t, ok)
+
+			c, err := client.New(
+				client.WithLoadBalancer(
+					[]string{busy.URL, idle.URL},
+					loadbalancer.WithStrategy(loadbalancer.StrategyLeastOutstanding),
+				),
+			)
+			require.NoError(t, err)
+			defer c.Close()
+
+			done := make(chan error, 1)
+			go func() {
+				_, err := c.R().Get("/")
+				done <- err
+			}()
+			<-started
+
+			// Act
+			for range 5 {
+				_, err := c.R().Get("/")
+				require.NoError(t, err)
+			}
+			close(release)
+
+			// Assert
+			require.NoError(t, <-done)
+			assert.Equal(t, int64(1), busy.hits.Load())
+			assert.Equal(t, int64(5), idle.hits.Load())
+		},
+	)
+
+	t.Run(
+		"Concurrently", func(t *testing.T) {
+			// Arrange
+			slow := func(w http.ResponseWriter, _ *http.Request) {
+				time.Sleep(time.Millisecond)
+				w.WriteHeader(http.StatusOK)
+			}
+			server1 := newTestServer(t, slow)
+			server2 := newTestServer(t, slow)
+
+			c, err := client.New(
+				client.WithLoadBalancer(
+					[]string{server1.URL, server2.URL},
+					loadbalancer.WithStrategy(loadbalancer.StrategyLeastOutstanding),
+				),
+			)
+			require.NoError(t, err)
+			defer c.Close()
+
+			// Act
+			var wg sync.WaitGroup
+			for range 20 {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+
+					for range 10 {
+						_, err := c.R().SetContext(context.Background()).Get("/")
+						assert.NoError(t, err)
+					}
+				}()
+			}
+			wg.Wait()
+
+			hitsBefore1, hitsBefore2 := server1.hits.Load(), server2.hits.Load()
+			for range 4 {
+				_, err := c.R().Get("/")
+				require.NoError(t, err)
+			}
+
+			// Assert
+			assert.Equal(t, int64(200), hitsBefore1+hitsBefore2)
+			assert.Positive(t, hitsBefore1)
+			assert.Positive(t, hitsBefore2)
+			// All requests are finished, so idle hosts are chosen in turn again
+			assert.Equal(t, int64(2), server1.hits.Load()-hitsBefore1)
+			assert.Equal(t, int64(2), server2.hits.Load()-hitsBefore2)
+		},
+	)
+}
+
+func TestPassiveEjection(t *testing.T) {
+	t.Run(
+		"Eject host by response status", func(t *testing.T) {
+			// Arrange
+			failing := newTestServer(
+				t, func(w http.ResponseWriter, _ *http.Request) {
+					w.WriteHeader(http.StatusInternalServerError)
+				},
+			)
+			healthy := newTestServer(t, ok)
+
+			c, err := client.New(
+				client.WithLoadBalancer(
+					[]string{failing.URL, healthy.URL}, loadbalancer.WithCircuitBreaker(2, time.Hour),
+				),
+			)
+			require.NoError(t, err)
+			defer c.Close()
+
+			// Act
+			statuses := make([]int, 0, 10)
+			for range 10 {
+				resp, err := c.R().Get("/")
+				require.NoError(t, err)
+
+				statuses = append(statuses, resp.StatusCode())
+			}
+
+			// Assert
+			assert.Equal(t, int64(2), failing.hits.Load())
+			assert.Equal(t, int64(8), healthy.hits.Load())
+			assert.Equal(t, http.StatusInternalServerError, statuses[0])
+			assert.Equal(t, http.StatusInternalServerError, statuses[2])
+			for _, status := range statuses[4:] {
+				assert.Equal(t, http.StatusOK, status)
+			}
+		},
+	)
+}
@@ -0,0 +1,131 @@
+package publisher
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+	"resty.dev/v3"
+
+	"github.com/ptrvsrg/crack-hash/commonlib/bus/amqp/publisher"
+)
+
+var ErrUnexpectedStatus = errors.New("unexpected response status")
+
+type (
+	Config struct {
+		// Path is the path of the endpoint receiving the messages
+		Path string
+	}
+
+	// httpPublisher posts messages to the host chosen by the load balancer of the client
+	httpPublisher[T any] struct {
+		client *resty.Client
+		config Config
+		logger zerolog.Logger
+	}
+
+	// broadcastPublisher posts messages to every host
+	broadcastPublisher[T any] struct {
+		client *resty.Client
+		urls   []string
+		config Config
+		logger zerolog.Logger
+	}
+)
+
+// New creates the publisher posting messages as JSON to the path of the host chosen by the load balancer
+// of the client. The delivery mode and flags of AMQP are ignored
+func New[T any](client *resty.Client, config Config) publisher.Publisher[T] {
+	return &httpPublisher[T]{
+		client: client,
+		config: config,
+		logger: log.With().
+			Str("component", "http-publisher").
+			Type("type", *new(T)).
+			Str("path", config.Path).
+			Logger(),
+	}
+}
+
+// NewBroadcast creates the publisher posting messages as JSON to the path of every URL, like a fanout exchange.
+// Sending fails only if no host receives the message
+func NewBroadcast[T any](client *resty.Client, urls []string, config Config) publisher.Publisher[T] {
+	return &broadcastPublisher[T]{
+		client: client,
+		urls:   urls,
+		config: config,
+		logger: log.With().
+			Str("component", "http-broadcast-publisher").
+			Type("type", *new(T)).
+			Str("path", config.Path).
+			Logger(),
+	}
+}
+
+func (p *httpPublisher[T]) SendMessage(ctx context.Context, message *T, _ publisher.DeliveryMode, _, _ bool) error {
+	p.logger.Debug().Msg("send message")
+
+	if err := post(ctx, p.client, p.config.Path, message); err != nil {
+		p.logger.Error().Err(err).Stack().Msg("failed to post a message")
+		return fmt.Errorf("failed to post a message: %w", err)
+	}
+
+	return nil
+}
+
+func (p *broadcastPublisher[T]) SendMessage(
+	ctx context.Context, message *T, _ publisher.DeliveryMode, _, _ bool,
+) error {
+	p.logger.Debug().Msg("send message")
+
+	var (
+		wg   sync.WaitGroup
+		mu   sync.Mutex
+		errs []error
+	)
+	for _, u := range p.urls {
+		wg.Add(1)
+		go func(url string) {
+			defer wg.Done()
+
+			if err := post(ctx, p.client, url+p.config.Path, message); err != nil {
+				p.logger.Warn().Err(err).Str("url", url).Msg("failed to post a message")
+
+				mu.Lock()
+				errs = append(errs, err)
+				mu.Unlock()
+			}
+		}(strings.TrimSuffix(u, "/"))
+	}
+	wg.Wait()
+
+	if len(errs) == len(p.urls) && len(errs) > 0 {
+		return fmt.Errorf("failed to post a message to any host: %w", errors.Join(errs...))
+	}
+
+	return nil
+}
+
+// post sends the message, POST is retried like the redelivery of AMQP, so receivers must handle duplicates,
+// the load balancer chooses a host again on every attempt
+func post(ctx context.Context, client *resty.Client, url string, message any) error {
+	resp, err := client.R().
+		SetContext(ctx).
+		SetAllowNonIdempotentRetry(true).
+		SetBody(message).
+		Post(url)
+	if err != nil {
+		return fmt.Errorf("failed to send request: %w", err)
+	}
+
+	if resp.IsError() {
+		return fmt.Errorf("%w: %s", ErrUnexpectedStatus, resp.Status())
+	}
+
+	return nil
+}
@@ -0,0 +1,118 @@
+package publisher_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	amqppublisher "github.com/ptrvsrg/crack-hash/commonlib/bus/amqp/publisher"
+	"github.com/ptrvsrg/crack-hash/commonlib/http/client"
+	"github.com/ptrvsrg/crack-hash/commonlib/http/publisher"
+)
+
+type testMessage struct {
+	ID string `json:"id"`
+}
+
+type testServer struct {
+	*httptest.Server
+	hits atomic.Int64
+}
+
+func newTestServer(t *testing.T, status int) *testServer {
+	t.Helper()
+
+	s := &testServer{}
+	s.Server = httptest.NewServer(
+		http.HandlerFunc(
+			func(w http.ResponseWriter, _ *http.Request) {
+				s.hits.Add(1)
+				w.WriteHeader(status)
+			},
+		),
+	)
+	t.Cleanup(s.Close)
+
+	return s
+}
+
+func TestBroadcastSendMessage(t *testing.T) {
+	ctx := context.Background()
+	msg := &testMessage{ID: "1"}
+
+	t.Run(
+		"All hosts succeed", func(t *testing.T) {
+			// Arrange
+			first := newTestServer(t, http.StatusNoContent)
+			second := newTestServer(t, http.StatusNoContent)
+
+			c, err := client.New()
+			require.NoError(t, err)
+			defer c.Close()
+
+			p := publisher.NewBroadcast[testMessage](
+				c, []string{first.URL, second.URL + "/"}, publisher.Config{Path: "/messages"},
+			)
+
+			// Act
+			err = p.SendMessage(ctx, msg, amqppublisher.Persistent, false, false)
+
+			// Assert
+			require.NoError(t, err)
+			assert.Equal(t, int64(1), first.hits.Load())
+			assert.Equal(t, int64(1), second.hits.Load())
+		},
+	)
+
+	t.Run(
+		"Some hosts fail", func(t *testing.T) {
+			// Arrange
+			failed := newTestServer(t, http.StatusInternalServerError)
+			succeeded := newTestServer(t, http.StatusNoContent)
+
+			c, err := client.New()
+			require.NoError(t, err)
+			defer c.Close()
+
+			p := publisher.NewBroadcast[testMessage](
+				c, []string{failed.URL, succeeded.URL}, publisher.Config{Path: "/messages"},
+			)
+
+			// Act
+			err = p.SendMessage(ctx, msg, amqppublisher.Persistent, false, false)
+
+			// Assert
+			require.NoError(t, err)
+			assert.Equal(t, int64(1), failed.hits.Load())
+			assert.Equal(t, int64(1), succeeded.hits.Load())
+		},
+	)
+
+	t.Run(
+		"All hosts fail", func(t *testing.T) {
+			// Arrange
+			first := newTestServer(t, http.StatusInternalServerError)
+			second := newTestServer(t, http.StatusServiceUnavailable)
+
+			c, err := client.New()
+			require.NoError(t, err)
+			defer c.Close()
+
+			p := publisher.NewBroadcast[testMessage](
+				c, []string{first.URL, second.URL}, publisher.Config{Path: "/messages"},
+			)
+
+			// Act
+			err = p.SendMessage(ctx, msg, amqppublisher.Persistent, false, false)
+
+			// Assert
+			require.Error(t, err)
+			require.ErrorIs(t, err, publisher.ErrUnexpectedStatus)
+		},
+	)
+}
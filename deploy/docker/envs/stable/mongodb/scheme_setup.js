@@ -16,6 +16,10 @@ db.createCollection("hash_crack_subtasks", {
                     bsonType: "objectId",
                     description: "ID основной задачи"
                 },
+                algorithm: {
+                    bsonType: "string",
+                    description: "Алгоритм хеширования основной задачи"
+                },
                 partNumber: {
                     bsonType: "int",
                     description: "Номер части задачи",
@@ -28,20 +32,95 @@ db.createCollection("hash_crack_subtasks", {
                     },
                     description: "Данные для перебора"
                 },
+                found: {
+                    bsonType: "array",
+                    items: {
+                        bsonType: "object",
+                        required: ["hash", "word"],
+                        properties: {
+                            hash: {
+                                bsonType: "string",
+                                description: "Взломанный хеш"
+                            },
+                            word: {
+                                bsonType: "string",
+                                description: "Найденное слово"
+                            }
+                        }
+                    },
+                    description: "Найденные слова по хешам (для пакетных задач)"
+                },
+                lines: {
+                    bsonType: "object",
+                    required: ["offset", "count"],
+                    description: "Диапазон строк словаря (для атаки по словарю)",
+                    properties: {
+                        offset: {
+                            bsonType: "int",
+                            description: "Номер первой строки",
+                            minimum: 0
+                        },
+                        count: {
+                            bsonType: "int",
+                            description: "Количество строк",
+                            minimum: 1
+                        }
+                    }
+                },
+                range: {
+                    bsonType: "object",
+                    required: ["start", "end"],
+                    description: "Диапазон индексов кандидатов (для перебора и атаки по маске)",
+                    properties: {
+                        start: {
+                            bsonType: ["int", "long"],
+                            description: "Индекс первого кандидата",
+                            minimum: 0
+                        },
+                        end: {
+                            bsonType: ["int", "long"],
+                            description: "Индекс кандидата после последнего",
+                            minimum: 1
+                        }
+                    }
+                },
                 percent: {
                     bsonType: "double",
                     description: "Процент выполнения",
                     minimum: 0,
                     maximum: 100
                 },
+                checkpoint: {
+                    bsonType: "int",
+                    description: "Количество проверенных кандидатов с начала подзадачи (строк для атаки по словарю)",
+                    minimum: 0
+                },
+                speed: {
+                    bsonType: "double",
+                    description: "Скорость перебора воркером (кандидатов в секунду)",
+                    minimum: 0
+                },
                 status: {
-                    enum: ["PENDING", "IN_PROGRESS", "SUCCESS", "ERROR", "UNKNOWN"],
+                    enum: ["PENDING", "IN_PROGRESS", "SUCCESS", "ERROR", "CANCELLED", "SKIPPED", "UNKNOWN"],
                     description: "Статус выполнения подзадачи"
                 },
                 reason: {
                     bsonType: "string",
                     description: "Причина ошибки (если есть)"
                 },
+                attempts: {
+                    bsonType: "int",
+                    description: "Количество отправок подзадачи воркерам",
+                    minimum: 0
+                },
+                startedAt: {
+                    bsonType: "date",
+                    description: "Время последней отправки подзадачи воркеру"
+                },
+                leaseExpiresAt: {
+                    bsonType: "date",
+                    description: "Время истечения аренды подзадачи воркером"
+                },
                 createdAt: {
                     bsonType: "date",
                     description: "Время создания подзадачи"
@@ -65,6 +144,15 @@ db.hash_crack_subtasks.createIndex({status: 1});
 db.hash_crack_subtasks.createIndex({createdAt: 1});
 
 
+db.hash_crack_subtasks.createIndex({status: 1, leaseExpiresAt: 1});
+
+
+db.hash_crack_subtasks.createIndex({status: 1, startedAt: 1});
+
+
+db.hash_crack_subtasks.createIndex({algorithm: 1, updatedAt: 1}, {partialFilterExpression: {speed: {$gt: 0}}});
+
+
 db.createCollection("hash_crack_tasks", {
     validator: {
         $jsonSchema: {
@@ -75,28 +163,123 @@ db.createCollection("hash_crack_tasks", {
                     bsonType: "objectId",
                     description: "Уникальный идентификатор задачи"
                 },
+                algorithm: {
+                    enum: ["MD5", "SHA1", "SHA256", "SHA512", "NTLM"],
+                    description: "Алгоритм хеширования"
+                },
                 hash: {
                     bsonType: "string",
                     description: "Хеш для подбора"
                 },
-                maxLength: {
+                hashes: {
+                    bsonType: "array",
+                    items: {
+                        bsonType: "string"
+                    },
+                    description: "Список хешей для подбора (для пакетных задач)"
+                },
+                salt: {
+                    bsonType: "object",
+                    required: ["value", "position"],
+                    description: "Соль или ключ HMAC",
+                    properties: {
+                        value: {
+                            bsonType: "string",
+                            description: "Значение соли"
+                        },
+                        position: {
+                            enum: ["PREFIX", "SUFFIX", "HMAC"],
+                            description: "Способ применения соли"
+                        }
+                    }
+                },
+                mode: {
+                    enum: ["BRUTE_FORCE", "DICTIONARY", "MASK"],
+                    description: "Режим атаки"
+                },
+                wordlistId: {
+                    bsonType: "objectId",
+                    description: "ID словаря (для атаки по словарю)"
+                },
+                ruleSetId: {
+                    bsonType: "objectId",
+                    description: "ID набора правил (для атаки по словарю)"
+                },
+                rules: {
+                    bsonType: "array",
+                    description: "Правила модификации слов",
+                    items: {
+                        bsonType: "string"
+                    }
+                },
+                mask: {
+                    bsonType: "object",
+                    description: "Маска (для атаки по маске)",
+                    required: ["pattern", "positions"],
+                    properties: {
+                        pattern: {
+                            bsonType: "string",
+                            description: "Маска в синтаксисе hashcat"
+                        },
+                        charsets: {
+                            bsonType: "array",
+                            description: "Пользовательские наборы символов",
+                            maxItems: 4,
+                            items: {
+                                bsonType: "string"
+                            }
+                        },
+                        positions: {
+                            bsonType: "array",
+                            description: "Наборы символов позиций маски",
+                            minItems: 1,
+                            items: {
+                                bsonType: "string"
+                            }
+                        }
+                    }
+                },
+                alphabet: {
+                    bsonType: "string",
+                    description: "Алфавит (для полного перебора)"
+                },
+                minLength: {
                     bsonType: "int",
-                    description: "Максимальная длина пароля",
+                    description: "Минимальная длина пароля (для полного перебора)",
                     minimum: 1
                 },
+                maxLength: {
+                    bsonType: "int",
+                    description: "Максимальная длина пароля (0 для атаки по словарю и по маске)",
+                    minimum: 0
+                },
+                stopOnFirstMatch: {
+                    bsonType: "bool",
+                    description: "Завершать задачу после нахождения первого совпадения"
+                },
+                priority: {
+                    bsonType: "int",
+                    description: "Приоритет задачи",
+                    minimum: 0,
+                    maximum: 10
+                },
                 partCount: {
                     bsonType: "int",
                     description: "Количество частей",
                     minimum: 1
                 },
                 status: {
-                    enum: ["PENDING", "IN_PROGRESS", "PARTIAL_READY", "READY", "ERROR", "UNKNOWN"],
+                    enum: ["PENDING", "IN_PROGRESS", "PARTIAL_READY", "READY", "ERROR", "CANCELLED", "UNKNOWN"],
                     description: "Статус выполнения задачи"
                 },
                 reason: {
                     bsonType: "string",
                     description: "Причина ошибки (если есть)"
                 },
+                deadline: {
+                    bsonType: "date",
+                    description: "Крайний срок выполнения задачи"
+                },
                 finishedAt: {
                     bsonType: "date",
                     description: "Время завершения задачи (если завершена)"
@@ -115,7 +298,7 @@ db.createCollection("hash_crack_tasks", {
 });
 
 
-db.hash_crack_tasks.createIndex({hash: 1, maxLength: 1});
+db.hash_crack_tasks.createIndex({algorithm: 1, hash: 1, maxLength: 1});
 
 
 db.hash_crack_tasks.createIndex({status: 1});
@@ -124,6 +307,261 @@ db.hash_crack_tasks.createIndex({status: 1});
 db.hash_crack_tasks.createIndex({createdAt: 1});
 
 
+db.hash_crack_tasks.createIndex({wordlistId: 1, status: 1}, {sparse: true});
+
+
+db.hash_crack_tasks.createIndex({status: 1, deadline: 1});
+
+
+db.createCollection("hash_crack_task_locks", {
+    validator: {
+        $jsonSchema: {
+            bsonType: "object",
+            required: ["_id", "version", "lockedAt"],
+            properties: {
+                _id: {
+                    bsonType: "string",
+                    description: "Название блокировки"
+                },
+                version: {
+                    bsonType: ["int", "long"],
+                    description: "Количество взятий блокировки",
+                    minimum: 0
+                },
+                lockedAt: {
+                    bsonType: "date",
+                    description: "Время последнего взятия блокировки"
+                }
+            }
+        }
+    }
+});
+
+
+db.createCollection("wordlists", {
+    validator: {
+        $jsonSchema: {
+            bsonType: "object",
+            required: ["_id", "name", "lineCount", "size", "createdAt"],
+            properties: {
+                _id: {
+                    bsonType: "objectId",
+                    description: "Уникальный идентификатор словаря"
+                },
+                name: {
+                    bsonType: "string",
+                    description: "Название словаря"
+                },
+                lineCount: {
+                    bsonType: "int",
+                    description: "Количество строк",
+                    minimum: 1
+                },
+                size: {
+                    bsonType: "long",
+                    description: "Размер словаря в байтах",
+                    minimum: 0
+                },
+                createdAt: {
+                    bsonType: "date",
+                    description: "Время загрузки словаря"
+                }
+            }
+        }
+    }
+});
+
+
+db.wordlists.createIndex({createdAt: 1});
+
+
+db.createCollection("rule_sets", {
+    validator: {
+        $jsonSchema: {
+            bsonType: "object",
+            required: ["_id", "name", "rules", "createdAt"],
+            properties: {
+                _id: {
+                    bsonType: "objectId",
+                    description: "Уникальный идентификатор набора правил"
+                },
+                name: {
+                    bsonType: "string",
+                    description: "Название набора правил"
+                },
+                rules: {
+                    bsonType: "array",
+                    description: "Правила модификации слов",
+                    minItems: 1,
+                    items: {
+                        bsonType: "string"
+                    }
+                },
+                createdAt: {
+                    bsonType: "date",
+                    description: "Время создания набора правил"
+                }
+            }
+        }
+    }
+});
+
+
+db.rule_sets.createIndex({name: 1}, {unique: true});
+db.rule_sets.createIndex({createdAt: 1});
+
+
+db.createCollection("workers", {
+    validator: {
+        $jsonSchema: {
+            bsonType: "object",
+            required: ["_id", "registeredAt", "lastHeartbeatAt"],
+            properties: {
+                _id: {
+                    bsonType: "string",
+                    description: "Уникальный идентификатор воркера"
+                },
+                version: {
+                    bsonType: "string",
+                    description: "Версия воркера"
+                },
+                cpuCount: {
+                    bsonType: ["int", "long"],
+                    description: "Количество процессоров воркера",
+                    minimum: 0
+                },
+                algorithms: {
+                    bsonType: "array",
+                    description: "Поддерживаемые алгоритмы хеширования",
+                    items: {
+                        bsonType: "string"
+                    }
+                },
+                speed: {
+                    bsonType: "double",
+                    description: "Скорость воркера по результатам бенчмарка (кандидатов в секунду)",
+                    minimum: 0
+                },
+                registeredAt: {
+                    bsonType: "date",
+                    description: "Время регистрации воркера"
+                },
+                lastHeartbeatAt: {
+                    bsonType: "date",
+                    description: "Время последнего сигнала от воркера"
+                }
+            }
+        }
+    }
+});
+
+
+db.workers.createIndex({registeredAt: 1});
+db.workers.createIndex({lastHeartbeatAt: 1}, {expireAfterSeconds: 86400});
+
+
+db.createCollection("dead_letters", {
+    validator: {
+        $jsonSchema: {
+            bsonType: "object",
+            required: ["_id", "body", "deadLetteredAt"],
+            properties: {
+                _id: {
+                    bsonType: "objectId",
+                    description: "Уникальный идентификатор сообщения"
+                },
+                queue: {
+                    bsonType: "string",
+                    description: "Очередь, из которой сообщение не удалось обработать"
+                },
+                exchange: {
+                    bsonType: "string",
+                    description: "Обменник, из которого сообщение не удалось обработать"
+                },
+                error: {
+                    bsonType: "string",
+                    description: "Ошибка обработки сообщения"
+                },
+                retries: {
+                    bsonType: ["int", "long"],
+                    description: "Количество повторных доставок сообщения",
+                    minimum: 0
+                },
+                contentType: {
+                    bsonType: "string",
+                    description: "Тип содержимого сообщения"
+                },
+                priority: {
+                    bsonType: ["int", "long"],
+                    description: "Приоритет сообщения",
+                    minimum: 0
+                },
+                body: {
+                    bsonType: "binData",
+                    description: "Тело сообщения"
+                },
+                deadLetteredAt: {
+                    bsonType: "date",
+                    description: "Время отправки сообщения в очередь недоставленных сообщений"
+                }
+            }
+        }
+    }
+});
+
+
+db.dead_letters.createIndex({deadLetteredAt: 1});
+
+
+db.createCollection("outbox_messages", {
+    validator: {
+        $jsonSchema: {
+            bsonType: "object",
+            required: ["_id", "taskId", "partNumber", "body", "attempts", "lockedUntil", "createdAt"],
+            properties: {
+                _id: {
+                    bsonType: "objectId",
+                    description: "Уникальный идентификатор сообщения"
+                },
+                taskId: {
+                    bsonType: "objectId",
+                    description: "ID основной задачи"
+                },
+                partNumber: {
+                    bsonType: ["int", "long"],
+                    description: "Номер части задачи",
+                    minimum: 0
+                },
+                body: {
+                    bsonType: "binData",
+                    description: "Тело сообщения"
+                },
+                attempts: {
+                    bsonType: ["int", "long"],
+                    description: "Количество неудачных попыток отправки сообщения",
+                    minimum: 0
+                },
+                error: {
+                    bsonType: "string",
+                    description: "Ошибка последней попытки отправки сообщения"
+                },
+                lockedUntil: {
+                    bsonType: "date",
+                    description: "Время, до которого сообщение заблокировано для отправки"
+                },
+                createdAt: {
+                    bsonType: "date",
+                    description: "Время создания сообщения"
+                }
+            }
+        }
+    }
+});
+
+
+db.outbox_messages.createIndex({lockedUntil: 1, createdAt: 1});
+
+
 db.createView("hash_crack_tasks_with_subtasks", "hash_crack_tasks", [
     {
         $lookup: {
@@ -112,6 +112,9 @@ func startCronScheduler(ctx context.Context, c *di.Container) *gocron.Scheduler
 		hashcrack.RegisterDeleteExpiredTaskJob(c),
 		hashcrack.RegisterFinishTimeoutTasksJob(c),
 		hashcrack.RegisterExecutePendingTasksJob(c),
+		hashcrack.RegisterRedeliverExpiredSubtasksJob(c),
+		hashcrack.RegisterRelayOutboxMessagesJob(c),
+		hashcrack.RegisterSplitSlowSubtasksJob(c),
 	)
 
 	scheduler.StartAsync()
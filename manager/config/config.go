@@ -6,19 +6,29 @@ import (
 	_ "github.com/joho/godotenv/autoload"
 )
 
-type Env string
+type (
+	Env       string
+	Transport string
+)
 
 const (
 	EnvDev  Env = "dev"
 	EnvProd Env = "prod"
+
+	TransportAMQP Transport = "amqp"
+	TransportHTTP Transport = "http"
 )
 
 type (
 	Config struct {
-		Server  ServerConfig
-		MongoDB MongoDBConfig
-		AMQP    AMQPConfig
-		Task    TaskConfig
+		Server    ServerConfig
+		MongoDB   MongoDBConfig
+		Transport TransportConfig
+		AMQP      AMQPConfig `validate:"-"`
+		Task      TaskConfig
+		Wordlist  WordlistConfig
+		RuleSet   RuleSetConfig
+		Worker    WorkerConfig
 	}
 
 	ServerConfig struct {
@@ -53,25 +63,74 @@ type (
 		Level string `default:"majority" validate:"required,oneof=local majority available linearizable snapshot"`
 	}
 
+	// TransportConfig selects the transport between the manager and workers. The http transport sends subtasks
+	// to workers directly and gets results by callbacks, it is meant for small deployments without a broker.
+	// The AMQP config and the HTTP config are validated only for their transport
+	TransportConfig struct {
+		Type Transport           `default:"amqp" validate:"required,oneof=amqp http"`
+		HTTP HTTPTransportConfig `validate:"-"`
+	}
+
+	// HTTPTransportConfig configures the http transport. Subtasks are sent to active workers by the strategy
+	// of the load balancer, cancellations and shrinks are sent to all workers. Weights are set in the order
+	// of worker URIs for the weighted strategy. A worker is ejected after max failures in a row and probed
+	// after the eject timeout
+	HTTPTransportConfig struct {
+		WorkerURIs    []string      `validate:"required,min=1,dive,required"`
+		Strategy      string        `default:"round-robin" validate:"oneof=round-robin least-outstanding weighted"`
+		Weights       []int         `validate:"omitempty,dive,min=1"`
+		Retries       int           `default:"3" validate:"min=0"`
+		MinRetryWait  time.Duration `default:"100ms"`
+		MaxRetryWait  time.Duration `default:"2s"`
+		HealthTimeout time.Duration `default:"5s"`
+		HealthDelay   time.Duration `default:"10s"`
+		MaxFailures   int           `default:"5" validate:"min=0"`
+		EjectTimeout  time.Duration `default:"30s" validate:"required"`
+	}
+
+	// AMQPConfig configures the AMQP transport. Handlers of consumers in flight are waited for the drain
+	// timeout on shutdown, then they are cancelled and their messages are requeued
 	AMQPConfig struct {
-		URIs       []string `validate:"required,min=1,dive,required"`
-		Username   string   `validate:"required"`
-		Password   string   `validate:"required"`
-		Prefetch   int      `default:"20" validate:"required,min=1"`
-		Consumers  AMQPConsumersConfig
-		Publishers AMQPPublishersConfig
+		URIs         []string      `validate:"required,min=1,dive,required"`
+		Username     string        `validate:"required"`
+		Password     string        `validate:"required"`
+		Prefetch     int           `default:"20" validate:"required,min=1"`
+		DrainTimeout time.Duration `default:"10s"`
+		Consumers    AMQPConsumersConfig
+		Publishers   AMQPPublishersConfig
+		DeadLetter   AMQPDeadLetterConfig
 	}
 
 	AMQPConsumersConfig struct {
 		TaskResult AMQPConsumerConfig
+		// WorkerHeartbeat consumes heartbeats of workers. Its max retries are ignored, the queue has no delay queue
+		// and a failed heartbeat is superseded by the next one anyway
+		WorkerHeartbeat AMQPConsumerConfig
+		// DeadLetter consumes the dead-letter queue, dead letters of the manager and workers are saved
+		// to be inspected and replayed. Its max retries are ignored, failed dead letters are requeued
+		DeadLetter AMQPConsumerConfig
 	}
 
+	// AMQPConsumerConfig configures a consumer of the queue. A failed message is redelivered max retries
+	// times, the queue must dead-letter rejected messages to the delay queue if max retries is positive.
+	// Messages are handled by max in flight handlers at once, a handler is failed after the timeout
 	AMQPConsumerConfig struct {
-		Queue string `validate:"required"`
+		Queue       string        `validate:"required"`
+		MaxRetries  int           `default:"3" validate:"min=0"`
+		MaxInFlight int           `default:"10" validate:"min=0"`
+		Timeout     time.Duration `default:"30s"`
+	}
+
+	// AMQPDeadLetterConfig configures the exchange getting messages, which consumers failed to handle
+	AMQPDeadLetterConfig struct {
+		Exchange   string `validate:"required"`
+		RoutingKey string `validate:"required"`
 	}
 
 	AMQPPublishersConfig struct {
-		TaskStarted AMQPPublisherConfig
+		TaskStarted   AMQPQueuePublisherConfig
+		TaskCancelled AMQPPublisherConfig
+		TaskShrunk    AMQPPublisherConfig
 	}
 
 	AMQPPublisherConfig struct {
@@ -79,18 +138,88 @@ type (
 		RoutingKey string `validate:"required"`
 	}
 
+	// AMQPQueuePublisherConfig is a publisher, which declares its priority queue and binds it to the exchange
+	AMQPQueuePublisherConfig struct {
+		Exchange        string        `validate:"required"`
+		RoutingKey      string        `validate:"required"`
+		Queue           string        `validate:"required"`
+		ConsumerTimeout time.Duration `default:"1h" validate:"required"`
+	}
+
 	TaskConfig struct {
-		Split        TaskSplitConfig
-		Alphabet     string        `default:"abcdefghijklmnopqrstuvwxyz0123456789" validate:"required"`
-		Timeout      time.Duration `default:"1h" validate:"required"`
-		Limit        int           `default:"10" validate:"required,min=1"`
-		MaxAge       time.Duration `default:"24h" validate:"required"`
-		RestartDelay time.Duration `default:"1m" validate:"required"`
-		FinishDelay  time.Duration `default:"1m" validate:"required"`
+		Split      TaskSplitConfig
+		Alphabet   string        `default:"abcdefghijklmnopqrstuvwxyz0123456789" validate:"required"`
+		Timeout    time.Duration `default:"1h" validate:"required"`
+		Limit      int           `default:"10" validate:"required,min=1"`
+		BatchLimit int           `default:"10000" validate:"required,min=1"`
+		// RetryAfter is the time, after which a client may create a task again, if the limit of active tasks
+		// is reached
+		RetryAfter time.Duration `default:"1m" validate:"required"`
+		// InFlightLimit is the maximum number of subtasks of a task sent to workers at once, 0 means no limit
+		InFlightLimit int           `default:"10" validate:"min=0"`
+		MaxAge        time.Duration `default:"24h" validate:"required"`
+		RestartDelay  time.Duration `default:"1m" validate:"required"`
+		FinishDelay   time.Duration `default:"1m" validate:"required"`
+		// LeaseTimeout is the time, during which a worker must report the progress of a subtask, otherwise
+		// the subtask is sent again. MaxAttempts is the maximum number of sendings of a subtask. ProgressPeriod
+		// is the period of progress reports of workers, the sent subtask is leased for the timeout and the period,
+		// so it is sent again, if no worker reports its first progress
+		LeaseTimeout   time.Duration `default:"1m" validate:"required"`
+		ProgressPeriod time.Duration `default:"5s" validate:"required"`
+		MaxAttempts    int           `default:"3" validate:"required,min=1"`
+		RedeliverDelay time.Duration `default:"1m" validate:"required"`
+		Steal          TaskStealConfig
+		Outbox         TaskOutboxConfig
+	}
+
+	// TaskOutboxConfig configures the relay of the outbox, which keeps messages of subtasks until they are sent
+	// to workers. The relay sends the batch of messages every delay, a message is locked by the relay for the lock
+	// timeout and is sent again after it, if sending fails. The subtask is marked as ERROR after max attempts
+	TaskOutboxConfig struct {
+		Delay       time.Duration `default:"5s" validate:"required"`
+		BatchSize   int           `default:"100" validate:"required,min=1"`
+		LockTimeout time.Duration `default:"30s" validate:"required"`
+		MaxAttempts int           `default:"5" validate:"required,min=1"`
+	}
+
+	// TaskStealConfig configures splitting of slow subtasks. A subtask running longer than the threshold is split
+	// in two, when workers are idle and at least MinSize candidates of every half are left
+	TaskStealConfig struct {
+		Delay     time.Duration `default:"1m" validate:"required"`
+		Threshold time.Duration `default:"10m" validate:"required"`
+		MinSize   int           `default:"1000000" validate:"required,min=1"`
 	}
 
 	TaskSplitConfig struct {
-		Strategy  string `default:"chunk-based" validate:"required,oneof=chunk-based"`
-		ChunkSize int    `default:"10000000" validate:"required,min=1"`
+		Strategy string `default:"chunk-based" validate:"required,oneof=chunk-based adaptive"`
+		// ChunkSize is the number of candidates of a subtask, the adaptive strategy uses it until the speed
+		// of workers is measured
+		ChunkSize int `default:"10000000" validate:"required,min=1"`
+		MaxParts  int `default:"100000" validate:"required,min=1"`
+		Adaptive  TaskAdaptiveSplitConfig
+	}
+
+	// TaskAdaptiveSplitConfig configures the adaptive strategy, which sizes subtasks by the speed of workers
+	// measured during the window, so a subtask takes about the target duration. A task is split in at least
+	// MinParts subtasks of at least MinChunkSize candidates
+	TaskAdaptiveSplitConfig struct {
+		TargetDuration time.Duration `default:"10m" validate:"required"`
+		Window         time.Duration `default:"1h" validate:"required"`
+		MinChunkSize   int           `default:"1000000" validate:"required,min=1"`
+		MinParts       int           `default:"4" validate:"required,min=1"`
+	}
+
+	WordlistConfig struct {
+		MaxSize int64 `default:"1073741824" validate:"required,min=1"`
+	}
+
+	RuleSetConfig struct {
+		MaxRules int `default:"10000" validate:"required,min=1"`
+	}
+
+	// WorkerConfig configures the registry of workers. A worker is offline, when it sends no heartbeat
+	// during the heartbeat timeout
+	WorkerConfig struct {
+		HeartbeatTimeout time.Duration `default:"30s" validate:"required"`
 	}
 )
@@ -67,6 +67,94 @@ const docTemplate = `{
                 }
             }
         },
+        "/internal/v1/tasks/results": {
+            "post": {
+                "description": "Callback of a worker for saving the progress or the result of a subtask in the http transport",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Callback API"
+                ],
+                "summary": "Save result of subtask",
+                "operationId": "SaveTaskResult",
+                "parameters": [
+                    {
+                        "description": "Result of subtask",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/message.HashCrackTaskResult"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorOutput"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorOutput"
+                        }
+                    }
+                }
+            }
+        },
+        "/internal/v1/workers/heartbeats": {
+            "post": {
+                "description": "Callback of a worker for registration and heartbeats in the http transport",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Callback API"
+                ],
+                "summary": "Save heartbeat of worker",
+                "operationId": "SaveWorkerHeartbeat",
+                "parameters": [
+                    {
+                        "description": "Heartbeat of worker",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/message.WorkerHeartbeat"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorOutput"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorOutput"
+                        }
+                    }
+                }
+            }
+        },
         "/swagger/api-docs.json": {
             "get": {
                 "description": "Request for getting swagger specification in JSON",
@@ -109,36 +197,36 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/hash/crack": {
-            "post": {
-                "description": "Request for create new hash crack task",
-                "consumes": [
-                    "application/json"
-                ],
+        "/v1/admin/dead-letters": {
+            "get": {
+                "description": "Request for getting messages, which consumers of the manager and workers failed to handle",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Hash Crack API"
+                    "Dead Letter API"
                 ],
-                "summary": "Create new hash crack task",
-                "operationId": "HashCrack",
+                "summary": "Get dead letters",
+                "operationId": "GetDeadLetters",
                 "parameters": [
                     {
-                        "description": "Hash crack task input",
-                        "name": "input",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/model.HashCrackTaskInput"
-                        }
+                        "type": "integer",
+                        "description": "Limit",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Offset",
+                        "name": "offset",
+                        "in": "query"
                     }
                 ],
                 "responses": {
-                    "202": {
-                        "description": "Accepted",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.HashCrackTaskIDOutput"
+                            "$ref": "#/definitions/model.DeadLettersOutput"
                         }
                     },
                     "400": {
@@ -156,37 +244,72 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/hash/crack/metadatas": {
+        "/v1/admin/dead-letters/{id}": {
             "get": {
-                "description": "Request for getting metadatas of hash crack tasks",
+                "description": "Request for getting dead letter",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Hash Crack API"
+                    "Dead Letter API"
                 ],
-                "summary": "Get metadatas of hash crack tasks",
-                "operationId": "GetTaskMetadatas",
+                "summary": "Get dead letter",
+                "operationId": "GetDeadLetter",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Limit",
-                        "name": "limit",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Offset",
-                        "name": "offset",
-                        "in": "query"
+                        "type": "string",
+                        "description": "Dead letter ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.HashCrackTaskMetadatasOutput"
+                            "$ref": "#/definitions/model.DeadLetterOutput"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorOutput"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorOutput"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorOutput"
                         }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Request for deleting dead letter without replay",
+                "tags": [
+                    "Dead Letter API"
+                ],
+                "summary": "Delete dead letter",
+                "operationId": "DeleteDeadLetter",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Dead letter ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
                     },
                     "400": {
                         "description": "Bad Request",
@@ -194,6 +317,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/model.ErrorOutput"
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorOutput"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -203,31 +332,78 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/hash/crack/status": {
-            "get": {
-                "description": "Request for getting status of hash crack task",
+        "/v1/admin/dead-letters/{id}/replay": {
+            "post": {
+                "description": "Request for publishing dead letter to the queue of the failed consumer again, the dead letter is deleted",
+                "tags": [
+                    "Dead Letter API"
+                ],
+                "summary": "Replay dead letter",
+                "operationId": "ReplayDeadLetter",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Dead letter ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorOutput"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorOutput"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorOutput"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/hash/crack": {
+            "post": {
+                "description": "Request for create new hash crack task",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "Hash Crack API"
                 ],
-                "summary": "Get status of hash crack task",
-                "operationId": "CheckHashCrackStatus",
+                "summary": "Create new hash crack task",
+                "operationId": "HashCrack",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Hash crack task ID",
-                        "name": "requestID",
-                        "in": "query",
-                        "required": true
+                        "description": "Hash crack task input",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.HashCrackTaskInput"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "202": {
+                        "description": "Accepted",
                         "schema": {
-                            "$ref": "#/definitions/model.HashCrackTaskStatusOutput"
+                            "$ref": "#/definitions/model.HashCrackTaskIDOutput"
                         }
                     },
                     "400": {
@@ -242,6 +418,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/model.ErrorOutput"
                         }
                     },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorOutput"
+                        },
+                        "headers": {
+                            "Retry-After": {
+                                "type": "string",
+                                "description": "Seconds to wait before creating a task again"
+                            }
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -250,32 +438,1227 @@ const docTemplate = `{
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "model.ErrorOutput": {
-            "type": "object",
-            "required": [
-                "message",
-                "path",
-                "status",
-                "timestamp"
-            ],
-            "properties": {
-                "message": {
-                    "type": "string"
-                },
-                "path": {
+        },
+        "/v1/hash/crack/batch": {
+            "post": {
+                "description": "Request for create new hash crack task for a list of hashes",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Hash Crack API"
+                ],
+                "summary": "Create new batch hash crack task",
+                "operationId": "HashCrackBatch",
+                "parameters": [
+                    {
+                        "description": "Batch hash crack task input",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.HashCrackBatchTaskInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/model.HashCrackTaskIDOutput"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorOutput"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorOutput"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorOutput"
+                        },
+                        "headers": {
+                            "Retry-After": {
+                                "type": "string",
+                                "description": "Seconds to wait before creating a task again"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorOutput"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/hash/crack/batch/status": {
+            "get": {
+                "description": "Request for getting status of every target hash of hash crack task",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Hash Crack API"
+                ],
+                "summary": "Get per-hash status of hash crack task",
+                "operationId": "CheckHashCrackBatchStatus",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Hash crack task ID",
+                        "name": "requestID",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.HashCrackBatchTaskStatusOutput"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorOutput"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorOutput"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorOutput"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/hash/crack/metadatas": {
+            "get": {
+                "description": "Request for getting metadatas of hash crack tasks",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Hash Crack API"
+                ],
+                "summary": "Get metadatas of hash crack tasks",
+                "operationId": "GetTaskMetadatas",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Limit",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Offset",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.HashCrackTaskMetadatasOutput"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorOutput"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorOutput"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/hash/crack/status": {
+            "get": {
+                "description": "Request for getting status of hash crack task",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Hash Crack API"
+                ],
+                "summary": "Get status of hash crack task",
+                "operationId": "CheckHashCrackStatus",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Hash crack task ID",
+                        "name": "requestID",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.HashCrackTaskStatusOutput"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorOutput"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorOutput"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorOutput"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/hash/crack/{id}": {
+            "delete": {
+                "description": "Request for cancelling hash crack task, workers stop its subtasks and their results are discarded",
+                "tags": [
+                    "Hash Crack API"
+                ],
+                "summary": "Cancel hash crack task",
+                "operationId": "CancelHashCrack",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Hash crack task ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorOutput"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorOutput"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorOutput"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorOutput"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/hash/crack/{id}/extend": {
+            "post": {
+                "description": "Request for extending the deadline of hash crack task, the task is finished with error after it",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Hash Crack API"
+                ],
+                "summary": "Extend hash crack task",
+                "operationId": "ExtendHashCrack",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Hash crack task ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Hash crack task extend input",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.HashCrackTaskExtendInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.HashCrackTaskDeadlineOutput"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorOutput"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorOutput"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorOutput"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorOutput"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/rulesets": {
+            "get": {
+                "description": "Request for getting rule sets",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Rule Set API"
+                ],
+                "summary": "Get rule sets",
+                "operationId": "GetRuleSets",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Limit",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Offset",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.RuleSetsOutput"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorOutput"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorOutput"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Request for creating a named set of word mangling rules in the hashcat rule syntax",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Rule Set API"
+                ],
+                "summary": "Create rule set",
+                "operationId": "CreateRuleSet",
+                "parameters": [
+                    {
+                        "description": "Rule set input",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.RuleSetInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/model.RuleSetOutput"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorOutput"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorOutput"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorOutput"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/rulesets/{id}": {
+            "get": {
+                "description": "Request for getting rule set",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Rule Set API"
+                ],
+                "summary": "Get rule set",
+                "operationId": "GetRuleSet",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Rule set ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.RuleSetOutput"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorOutput"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorOutput"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorOutput"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Request for deleting rule set, tasks already created with it keep their rules",
+                "tags": [
+                    "Rule Set API"
+                ],
+                "summary": "Delete rule set",
+                "operationId": "DeleteRuleSet",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Rule set ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorOutput"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorOutput"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorOutput"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/wordlists": {
+            "get": {
+                "description": "Request for getting uploaded wordlists",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Wordlist API"
+                ],
+                "summary": "Get wordlists",
+                "operationId": "GetWordlists",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Limit",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Offset",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.WordlistsOutput"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorOutput"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorOutput"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Request for uploading a wordlist for dictionary attacks, one word per line",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Wordlist API"
+                ],
+                "summary": "Upload wordlist",
+                "operationId": "UploadWordlist",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "Wordlist file",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Wordlist name, defaults to the file name",
+                        "name": "name",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/model.WordlistOutput"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorOutput"
+                        }
+                    },
+                    "413": {
+                        "description": "Request Entity Too Large",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorOutput"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorOutput"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/wordlists/{id}": {
+            "get": {
+                "description": "Request for getting wordlist metadata",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Wordlist API"
+                ],
+                "summary": "Get wordlist",
+                "operationId": "GetWordlist",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Wordlist ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.WordlistOutput"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorOutput"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorOutput"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorOutput"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Request for deleting wordlist",
+                "tags": [
+                    "Wordlist API"
+                ],
+                "summary": "Delete wordlist",
+                "operationId": "DeleteWordlist",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Wordlist ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorOutput"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorOutput"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorOutput"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorOutput"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/wordlists/{id}/content": {
+            "get": {
+                "description": "Request for streaming a range of wordlist lines",
+                "produces": [
+                    "text/plain"
+                ],
+                "tags": [
+                    "Wordlist API"
+                ],
+                "summary": "Get wordlist content",
+                "operationId": "GetWordlistContent",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Wordlist ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of lines to skip",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of lines, all lines if zero",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorOutput"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorOutput"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorOutput"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/workers": {
+            "get": {
+                "description": "Request for getting registered workers with their capabilities and online status",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Worker API"
+                ],
+                "summary": "Get workers",
+                "operationId": "GetWorkers",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Limit",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Offset",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.WorkersOutput"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorOutput"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorOutput"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "message.Answer": {
+            "type": "object",
+            "required": [
+                "percent",
+                "words"
+            ],
+            "properties": {
+                "found": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/message.FoundHash"
+                    }
+                },
+                "percent": {
+                    "type": "number",
+                    "maximum": 100,
+                    "minimum": 0
+                },
+                "words": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "message.FoundHash": {
+            "type": "object",
+            "required": [
+                "hash",
+                "word"
+            ],
+            "properties": {
+                "hash": {
+                    "type": "string"
+                },
+                "word": {
+                    "type": "string"
+                }
+            }
+        },
+        "message.HashCrackTaskResult": {
+            "type": "object",
+            "required": [
+                "requestID",
+                "status"
+            ],
+            "properties": {
+                "answer": {
+                    "$ref": "#/definitions/message.Answer"
+                },
+                "attempt": {
+                    "description": "Attempt is the attempt of the started part, the results of the previous attempts are rejected.\nThe results without it are sent by workers, which don't report attempts",
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "checkpoint": {
+                    "description": "Checkpoint is the number of leading candidates of the part, which are checked. It is counted like\nthe resume offset of the started task",
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "error": {
+                    "type": "string"
+                },
+                "partNumber": {
+                    "type": "integer"
+                },
+                "requestID": {
+                    "type": "string"
+                },
+                "speed": {
+                    "description": "Speed is the number of candidates checked by the worker per second",
+                    "type": "number",
+                    "minimum": 0
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "IN_PROGRESS",
+                        "SUCCESS",
+                        "ERROR"
+                    ]
+                },
+                "version": {
+                    "type": "integer"
+                }
+            }
+        },
+        "message.WorkerHeartbeat": {
+            "type": "object",
+            "required": [
+                "algorithms",
+                "event",
+                "workerID"
+            ],
+            "properties": {
+                "algorithms": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "cpuCount": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "event": {
+                    "type": "string",
+                    "enum": [
+                        "REGISTERED",
+                        "HEARTBEAT"
+                    ]
+                },
+                "speed": {
+                    "description": "Speed is the number of candidates hashed by the worker per second in the benchmark on startup",
+                    "type": "number",
+                    "minimum": 0
+                },
+                "version": {
+                    "type": "integer"
+                },
+                "workerID": {
+                    "type": "string"
+                },
+                "workerVersion": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.DeadLetterOutput": {
+            "type": "object",
+            "required": [
+                "deadLetteredAt",
+                "id"
+            ],
+            "properties": {
+                "body": {
+                    "type": "string"
+                },
+                "contentType": {
+                    "type": "string"
+                },
+                "deadLetteredAt": {
+                    "type": "string"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "exchange": {
+                    "description": "Exchange is the fanout exchange of the failed consumer",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "queue": {
+                    "description": "Queue is the queue of the failed consumer, it is empty for a consumer of a fanout exchange",
+                    "type": "string"
+                },
+                "retries": {
+                    "type": "integer",
+                    "minimum": 0
+                }
+            }
+        },
+        "model.DeadLettersOutput": {
+            "type": "object",
+            "required": [
+                "count",
+                "deadLetters"
+            ],
+            "properties": {
+                "count": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "deadLetters": {
+                    "type": "array",
+                    "minItems": 0,
+                    "items": {
+                        "$ref": "#/definitions/model.DeadLetterOutput"
+                    }
+                }
+            }
+        },
+        "model.ErrorOutput": {
+            "type": "object",
+            "required": [
+                "message",
+                "path",
+                "status",
+                "timestamp"
+            ],
+            "properties": {
+                "message": {
+                    "type": "string"
+                },
+                "path": {
+                    "type": "string",
+                    "format": "url_path",
+                    "example": "/api/v0/example"
+                },
+                "status": {
+                    "type": "integer",
+                    "maximum": 599,
+                    "minimum": 400
+                },
+                "timestamp": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.HashCrackBatchTaskInput": {
+            "type": "object",
+            "required": [
+                "charsets",
+                "hashes"
+            ],
+            "properties": {
+                "algorithm": {
+                    "type": "string",
+                    "default": "MD5",
+                    "enum": [
+                        "MD5",
+                        "SHA1",
+                        "SHA256",
+                        "SHA512",
+                        "NTLM"
+                    ]
+                },
+                "alphabet": {
+                    "type": "string"
+                },
+                "charsets": {
+                    "type": "array",
+                    "maxItems": 4,
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "hashes": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "mask": {
+                    "type": "string"
+                },
+                "maxLength": {
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "minLength": {
+                    "type": "integer",
+                    "default": 1,
+                    "minimum": 1
+                },
+                "mode": {
+                    "type": "string",
+                    "default": "BRUTE_FORCE",
+                    "enum": [
+                        "BRUTE_FORCE",
+                        "DICTIONARY",
+                        "MASK"
+                    ]
+                },
+                "priority": {
+                    "description": "Priority of the task from 0 to 10, subtasks of tasks with a higher priority are executed first",
+                    "type": "integer",
+                    "maximum": 10,
+                    "minimum": 0
+                },
+                "ruleSetId": {
+                    "type": "string"
+                },
+                "salt": {
+                    "$ref": "#/definitions/model.HashCrackSalt"
+                },
+                "stopOnFirstMatch": {
+                    "description": "StopOnFirstMatch finishes the task as soon as a word of every hash is found",
+                    "type": "boolean"
+                },
+                "timeout": {
+                    "description": "Timeout of the task as a duration, e.g. 30m, the configured timeout is used if empty",
+                    "type": "string"
+                },
+                "wordlistId": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.HashCrackBatchTaskStatusOutput": {
+            "type": "object",
+            "required": [
+                "algorithm",
+                "cracked",
+                "hashes",
+                "mode",
+                "percent",
+                "status",
+                "subtasks",
+                "total"
+            ],
+            "properties": {
+                "algorithm": {
+                    "type": "string",
+                    "enum": [
+                        "MD5",
+                        "SHA1",
+                        "SHA256",
+                        "SHA512",
+                        "NTLM"
+                    ]
+                },
+                "cracked": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "hashes": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/model.HashCrackHashStatusOutput"
+                    }
+                },
+                "mode": {
+                    "type": "string",
+                    "enum": [
+                        "BRUTE_FORCE",
+                        "DICTIONARY",
+                        "MASK"
+                    ]
+                },
+                "percent": {
+                    "type": "number",
+                    "maximum": 100,
+                    "minimum": 0
+                },
+                "salt": {
+                    "$ref": "#/definitions/model.HashCrackSalt"
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "PENDING",
+                        "IN_PROGRESS",
+                        "READY",
+                        "PARTIAL_READY",
+                        "ERROR",
+                        "CANCELLED",
+                        "UNKNOWN"
+                    ]
+                },
+                "subtasks": {
+                    "type": "array",
+                    "minItems": 0,
+                    "items": {
+                        "$ref": "#/definitions/model.HashCrackSubtaskStatusOutput"
+                    }
+                },
+                "total": {
+                    "type": "integer",
+                    "minimum": 1
+                }
+            }
+        },
+        "model.HashCrackHashStatusOutput": {
+            "type": "object",
+            "required": [
+                "hash",
+                "words"
+            ],
+            "properties": {
+                "cracked": {
+                    "type": "boolean"
+                },
+                "hash": {
+                    "type": "string"
+                },
+                "words": {
+                    "type": "array",
+                    "minItems": 0,
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "model.HashCrackSalt": {
+            "type": "object",
+            "required": [
+                "position",
+                "value"
+            ],
+            "properties": {
+                "position": {
                     "type": "string",
-                    "format": "url_path",
-                    "example": "/api/v0/example"
-                },
-                "status": {
-                    "type": "integer",
-                    "maximum": 599,
-                    "minimum": 400
+                    "enum": [
+                        "PREFIX",
+                        "SUFFIX",
+                        "HMAC"
+                    ]
                 },
-                "timestamp": {
+                "value": {
                     "type": "string"
                 }
             }
@@ -307,11 +1690,36 @@ const docTemplate = `{
                         "IN_PROGRESS",
                         "SUCCESS",
                         "ERROR",
+                        "CANCELLED",
+                        "SKIPPED",
                         "UNKNOWN"
                     ]
                 }
             }
         },
+        "model.HashCrackTaskDeadlineOutput": {
+            "type": "object",
+            "required": [
+                "deadline"
+            ],
+            "properties": {
+                "deadline": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.HashCrackTaskExtendInput": {
+            "type": "object",
+            "required": [
+                "timeout"
+            ],
+            "properties": {
+                "timeout": {
+                    "description": "Timeout is the duration, e.g. 30m, the deadline of the task is extended by",
+                    "type": "string"
+                }
+            }
+        },
         "model.HashCrackTaskIDOutput": {
             "type": "object",
             "required": [
@@ -326,42 +1734,174 @@ const docTemplate = `{
         "model.HashCrackTaskInput": {
             "type": "object",
             "required": [
-                "hash",
-                "maxLength"
+                "charsets",
+                "hash"
             ],
             "properties": {
+                "algorithm": {
+                    "type": "string",
+                    "default": "MD5",
+                    "enum": [
+                        "MD5",
+                        "SHA1",
+                        "SHA256",
+                        "SHA512",
+                        "NTLM"
+                    ]
+                },
+                "alphabet": {
+                    "type": "string"
+                },
+                "charsets": {
+                    "type": "array",
+                    "maxItems": 4,
+                    "items": {
+                        "type": "string"
+                    }
+                },
                 "hash": {
                     "type": "string"
                 },
+                "mask": {
+                    "type": "string"
+                },
                 "maxLength": {
                     "type": "integer",
-                    "maximum": 6,
                     "minimum": 1
+                },
+                "minLength": {
+                    "type": "integer",
+                    "default": 1,
+                    "minimum": 1
+                },
+                "mode": {
+                    "type": "string",
+                    "default": "BRUTE_FORCE",
+                    "enum": [
+                        "BRUTE_FORCE",
+                        "DICTIONARY",
+                        "MASK"
+                    ]
+                },
+                "priority": {
+                    "description": "Priority of the task from 0 to 10, subtasks of tasks with a higher priority are executed first",
+                    "type": "integer",
+                    "maximum": 10,
+                    "minimum": 0
+                },
+                "ruleSetId": {
+                    "type": "string"
+                },
+                "salt": {
+                    "$ref": "#/definitions/model.HashCrackSalt"
+                },
+                "stopOnFirstMatch": {
+                    "description": "StopOnFirstMatch finishes the task as soon as a word of the hash is found",
+                    "type": "boolean"
+                },
+                "timeout": {
+                    "description": "Timeout of the task as a duration, e.g. 30m, the configured timeout is used if empty",
+                    "type": "string"
+                },
+                "wordlistId": {
+                    "type": "string"
                 }
             }
         },
         "model.HashCrackTaskMetadataOutput": {
             "type": "object",
             "required": [
+                "algorithm",
+                "charsets",
                 "createdAt",
-                "hash",
-                "maxLength",
-                "requestId"
+                "hashCount",
+                "mode",
+                "requestId",
+                "type"
             ],
             "properties": {
+                "algorithm": {
+                    "type": "string",
+                    "enum": [
+                        "MD5",
+                        "SHA1",
+                        "SHA256",
+                        "SHA512",
+                        "NTLM"
+                    ]
+                },
+                "alphabet": {
+                    "type": "string"
+                },
+                "charsets": {
+                    "type": "array",
+                    "maxItems": 4,
+                    "items": {
+                        "type": "string"
+                    }
+                },
                 "createdAt": {
                     "type": "string"
                 },
+                "deadline": {
+                    "type": "string"
+                },
                 "hash": {
                     "type": "string"
                 },
+                "hashCount": {
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "mask": {
+                    "type": "string"
+                },
                 "maxLength": {
                     "type": "integer",
-                    "maximum": 6,
                     "minimum": 1
                 },
+                "minLength": {
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "mode": {
+                    "type": "string",
+                    "enum": [
+                        "BRUTE_FORCE",
+                        "DICTIONARY",
+                        "MASK"
+                    ]
+                },
+                "priority": {
+                    "type": "integer",
+                    "maximum": 10,
+                    "minimum": 0
+                },
                 "requestId": {
                     "type": "string"
+                },
+                "ruleCount": {
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "ruleSetId": {
+                    "type": "string"
+                },
+                "salt": {
+                    "$ref": "#/definitions/model.HashCrackSalt"
+                },
+                "stopOnFirstMatch": {
+                    "type": "boolean"
+                },
+                "type": {
+                    "type": "string",
+                    "enum": [
+                        "SINGLE",
+                        "BATCH"
+                    ]
+                },
+                "wordlistId": {
+                    "type": "string"
                 }
             }
         },
@@ -388,12 +1928,24 @@ const docTemplate = `{
         "model.HashCrackTaskStatusOutput": {
             "type": "object",
             "required": [
+                "algorithm",
                 "data",
+                "mode",
                 "percent",
                 "status",
                 "subtasks"
             ],
             "properties": {
+                "algorithm": {
+                    "type": "string",
+                    "enum": [
+                        "MD5",
+                        "SHA1",
+                        "SHA256",
+                        "SHA512",
+                        "NTLM"
+                    ]
+                },
                 "data": {
                     "type": "array",
                     "minItems": 0,
@@ -401,11 +1953,25 @@ const docTemplate = `{
                         "type": "string"
                     }
                 },
+                "hash": {
+                    "type": "string"
+                },
+                "mode": {
+                    "type": "string",
+                    "enum": [
+                        "BRUTE_FORCE",
+                        "DICTIONARY",
+                        "MASK"
+                    ]
+                },
                 "percent": {
                     "type": "number",
                     "maximum": 100,
                     "minimum": 0
                 },
+                "salt": {
+                    "$ref": "#/definitions/model.HashCrackSalt"
+                },
                 "status": {
                     "type": "string",
                     "enum": [
@@ -414,6 +1980,7 @@ const docTemplate = `{
                         "READY",
                         "PARTIAL_READY",
                         "ERROR",
+                        "CANCELLED",
                         "UNKNOWN"
                     ]
                 },
@@ -425,6 +1992,187 @@ const docTemplate = `{
                     }
                 }
             }
+        },
+        "model.RuleSetInput": {
+            "type": "object",
+            "required": [
+                "name",
+                "rules"
+            ],
+            "properties": {
+                "name": {
+                    "type": "string"
+                },
+                "rules": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "model.RuleSetOutput": {
+            "type": "object",
+            "required": [
+                "createdAt",
+                "id",
+                "name",
+                "rules"
+            ],
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "rules": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "model.RuleSetsOutput": {
+            "type": "object",
+            "required": [
+                "count",
+                "ruleSets"
+            ],
+            "properties": {
+                "count": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "ruleSets": {
+                    "type": "array",
+                    "minItems": 0,
+                    "items": {
+                        "$ref": "#/definitions/model.RuleSetOutput"
+                    }
+                }
+            }
+        },
+        "model.WordlistOutput": {
+            "type": "object",
+            "required": [
+                "createdAt",
+                "id",
+                "lineCount",
+                "name",
+                "size"
+            ],
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "lineCount": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "name": {
+                    "type": "string"
+                },
+                "size": {
+                    "type": "integer",
+                    "minimum": 0
+                }
+            }
+        },
+        "model.WordlistsOutput": {
+            "type": "object",
+            "required": [
+                "count",
+                "wordlists"
+            ],
+            "properties": {
+                "count": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "wordlists": {
+                    "type": "array",
+                    "minItems": 0,
+                    "items": {
+                        "$ref": "#/definitions/model.WordlistOutput"
+                    }
+                }
+            }
+        },
+        "model.WorkerOutput": {
+            "type": "object",
+            "required": [
+                "algorithms",
+                "id",
+                "lastHeartbeatAt",
+                "registeredAt",
+                "status"
+            ],
+            "properties": {
+                "algorithms": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "cpuCount": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "id": {
+                    "type": "string"
+                },
+                "lastHeartbeatAt": {
+                    "type": "string"
+                },
+                "registeredAt": {
+                    "type": "string"
+                },
+                "speed": {
+                    "description": "Speed is the number of candidates hashed by the worker per second in the benchmark",
+                    "type": "number",
+                    "minimum": 0
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "ONLINE",
+                        "OFFLINE"
+                    ]
+                },
+                "version": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.WorkersOutput": {
+            "type": "object",
+            "required": [
+                "count",
+                "workers"
+            ],
+            "properties": {
+                "count": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "workers": {
+                    "type": "array",
+                    "minItems": 0,
+                    "items": {
+                        "$ref": "#/definitions/model.WorkerOutput"
+                    }
+                }
+            }
         }
     },
     "tags": [
@@ -432,6 +2180,26 @@ const docTemplate = `{
             "description": "API for cracking hashes and checking results",
             "name": "Hash Crack API"
         },
+        {
+            "description": "API for managing wordlists of dictionary attacks",
+            "name": "Wordlist API"
+        },
+        {
+            "description": "API for managing word mangling rules of dictionary attacks",
+            "name": "Rule Set API"
+        },
+        {
+            "description": "API for getting the registry of workers",
+            "name": "Worker API"
+        },
+        {
+            "description": "API for inspecting and replaying messages, which consumers failed to handle",
+            "name": "Dead Letter API"
+        },
+        {
+            "description": "API for callbacks of workers in the http transport",
+            "name": "Callback API"
+        },
         {
             "description": "API for health checks",
             "name": "Health API"
@@ -18,6 +18,7 @@ require (
 	go.uber.org/multierr v1.11.0
 	golang.org/x/sync v0.15.0
 	gopkg.in/resty.v1 v1.12.0
+	resty.dev/v3 v3.0.0-beta.3
 )
 
 require (
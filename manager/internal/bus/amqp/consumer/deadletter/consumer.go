@@ -0,0 +1,46 @@
+package deadletter
+
+import (
+	"context"
+	"fmt"
+
+	amqp1 "github.com/rabbitmq/amqp091-go"
+
+	"github.com/ptrvsrg/crack-hash/commonlib/bus/amqp"
+	"github.com/ptrvsrg/crack-hash/commonlib/bus/amqp/consumer"
+	"github.com/ptrvsrg/crack-hash/commonlib/bus/amqp/deadletter"
+	"github.com/ptrvsrg/crack-hash/manager/config"
+	"github.com/ptrvsrg/crack-hash/manager/internal/service/domain"
+)
+
+func NewConsumer(ch *amqp.Channel, cfg config.AMQPConfig, svc domain.DeadLetter) consumer.Consumer {
+	consumerCfg := cfg.Consumers.DeadLetter
+
+	return consumer.New(
+		ch, handle(svc),
+		consumer.Config{
+			// Bodies of dead letters are saved as is, they may be invalid
+			Unmarshal:    func([]byte, any) error { return nil },
+			Queue:        consumerCfg.Queue,
+			Consumer:     "",
+			AutoAck:      false,
+			Exclusive:    false,
+			NoLocal:      false,
+			NoWait:       false,
+			Requeue:      true,
+			MaxInFlight:  consumerCfg.MaxInFlight,
+			Timeout:      consumerCfg.Timeout,
+			DrainTimeout: cfg.DrainTimeout,
+		},
+	)
+}
+
+func handle(svc domain.DeadLetter) consumer.Handler[struct{}] {
+	return func(ctx context.Context, _ struct{}, delivery amqp1.Delivery) error {
+		if err := svc.SaveDeadLetter(ctx, deadletter.Parse(delivery)); err != nil {
+			return fmt.Errorf("failed to save dead letter: %w", err)
+		}
+
+		return nil
+	}
+}
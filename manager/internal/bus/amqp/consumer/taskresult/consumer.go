@@ -14,35 +14,42 @@ import (
 	"github.com/ptrvsrg/crack-hash/manager/pkg/message"
 )
 
-func NewConsumer(ch *amqp.Channel, cfg config.AMQPConsumerConfig, svc domain.HashCrackTask) consumer.Consumer {
+func NewConsumer(ch *amqp.Channel, cfg config.AMQPConfig, svc domain.HashCrackTask) consumer.Consumer {
+	consumerCfg := cfg.Consumers.TaskResult
+
 	return consumer.New(
 		ch, handle(svc),
 		consumer.Config{
-			Queue:     cfg.Queue,
-			Consumer:  "",
-			AutoAck:   false,
-			Exclusive: false,
-			NoLocal:   false,
-			NoWait:    false,
+			Queue:                consumerCfg.Queue,
+			Consumer:             "",
+			AutoAck:              false,
+			Exclusive:            false,
+			NoLocal:              false,
+			NoWait:               false,
+			MaxRetries:           consumerCfg.MaxRetries,
+			DeadLetterExchange:   cfg.DeadLetter.Exchange,
+			DeadLetterRoutingKey: cfg.DeadLetter.RoutingKey,
+			MaxInFlight:          consumerCfg.MaxInFlight,
+			Timeout:              consumerCfg.Timeout,
+			DrainTimeout:         cfg.DrainTimeout,
 		},
 	)
 }
 
 func handle(svc domain.HashCrackTask) consumer.Handler[message.HashCrackTaskResult] {
-	return func(ctx context.Context, msg message.HashCrackTaskResult, delivery amqp1.Delivery) error {
+	return func(ctx context.Context, msg message.HashCrackTaskResult, _ amqp1.Delivery) error {
 		err := svc.SaveResultSubtask(ctx, &msg)
-		if err != nil && !errors.Is(err, domain.ErrTaskNotFound) && !errors.Is(err, domain.ErrInvalidRequestID) {
-			if err := delivery.Reject(true); err != nil {
-				return fmt.Errorf("failed to reject message: %w", err)
-			}
-
+		switch {
+		case err == nil || errors.Is(err, domain.ErrTaskNotFound) || errors.Is(err, domain.ErrTaskCancelled) ||
+			errors.Is(err, domain.ErrSubtaskSkipped) || errors.Is(err, domain.ErrTaskFinishedByTimeout) ||
+			errors.Is(err, domain.ErrStaleAttempt):
+			return nil
+		case errors.Is(err, domain.ErrInvalidRequestID) || errors.Is(err, domain.ErrSubtaskNotFound) ||
+			errors.Is(err, domain.ErrUnsupportedVersion):
+			// The result is malformed, so it fails again on redelivery
+			return consumer.Permanent(fmt.Errorf("failed to save result task: %w", err))
+		default:
 			return fmt.Errorf("failed to save result task: %w", err)
 		}
-
-		if err := delivery.Ack(false); err != nil {
-			return fmt.Errorf("failed to ack message: %w", err)
-		}
-
-		return nil
 	}
 }
@@ -0,0 +1,49 @@
+package workerheartbeat
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	amqp1 "github.com/rabbitmq/amqp091-go"
+
+	"github.com/ptrvsrg/crack-hash/commonlib/bus/amqp"
+	"github.com/ptrvsrg/crack-hash/commonlib/bus/amqp/consumer"
+	"github.com/ptrvsrg/crack-hash/manager/config"
+	"github.com/ptrvsrg/crack-hash/manager/internal/service/domain"
+	"github.com/ptrvsrg/crack-hash/manager/pkg/message"
+)
+
+func NewConsumer(ch *amqp.Channel, cfg config.AMQPConfig, svc domain.Worker) consumer.Consumer {
+	consumerCfg := cfg.Consumers.WorkerHeartbeat
+
+	return consumer.New(
+		ch, handle(svc),
+		consumer.Config{
+			Queue:                consumerCfg.Queue,
+			Consumer:             "",
+			AutoAck:              false,
+			Exclusive:            false,
+			NoLocal:              false,
+			NoWait:               false,
+			MaxRetries:           0,
+			DeadLetterExchange:   cfg.DeadLetter.Exchange,
+			DeadLetterRoutingKey: cfg.DeadLetter.RoutingKey,
+			MaxInFlight:          consumerCfg.MaxInFlight,
+			Timeout:              consumerCfg.Timeout,
+			DrainTimeout:         cfg.DrainTimeout,
+		},
+	)
+}
+
+func handle(svc domain.Worker) consumer.Handler[message.WorkerHeartbeat] {
+	return func(ctx context.Context, msg message.WorkerHeartbeat, _ amqp1.Delivery) error {
+		// Heartbeats of workers of another version are skipped, the next heartbeat comes soon anyway
+		err := svc.SaveHeartbeat(ctx, &msg)
+		if err != nil && !errors.Is(err, domain.ErrUnsupportedVersion) {
+			return fmt.Errorf("failed to save heartbeat: %w", err)
+		}
+
+		return nil
+	}
+}
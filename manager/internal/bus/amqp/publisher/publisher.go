@@ -6,5 +6,7 @@ import (
 )
 
 type Publishers struct {
-	TaskStarted publisher.Publisher[message.HashCrackTaskStarted]
+	TaskStarted   publisher.Publisher[message.HashCrackTaskStarted]
+	TaskCancelled publisher.Publisher[message.HashCrackTaskCancelled]
+	TaskShrunk    publisher.Publisher[message.HashCrackTaskShrunk]
 }
@@ -4,39 +4,66 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"github.com/ptrvsrg/crack-hash/manager/internal/persistence/repository/mongo/hashcracksubtask"
-	"github.com/ptrvsrg/crack-hash/manager/internal/service/infrastructure/taskwithsubtasks"
-	hashcrackhdlr "github.com/ptrvsrg/crack-hash/manager/internal/transport/http/handler/v1/hashcrack"
 	"time"
 
+	amqp091 "github.com/rabbitmq/amqp091-go"
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
 	"go.mongodb.org/mongo-driver/mongo"
+	"resty.dev/v3"
 
 	"github.com/ptrvsrg/crack-hash/commonlib/bus/amqp"
 	"github.com/ptrvsrg/crack-hash/commonlib/bus/amqp/consumer"
+	commondeadletter "github.com/ptrvsrg/crack-hash/commonlib/bus/amqp/deadletter"
 	"github.com/ptrvsrg/crack-hash/commonlib/bus/amqp/publisher"
+	commonconfig "github.com/ptrvsrg/crack-hash/commonlib/config"
+	"github.com/ptrvsrg/crack-hash/commonlib/http/client"
+	"github.com/ptrvsrg/crack-hash/commonlib/http/client/loadbalancer"
 	"github.com/ptrvsrg/crack-hash/commonlib/http/handler"
+	httppublisher "github.com/ptrvsrg/crack-hash/commonlib/http/publisher"
 	mongo2 "github.com/ptrvsrg/crack-hash/commonlib/storage/mongo"
 	"github.com/ptrvsrg/crack-hash/manager/config"
+	deadletterconsumer "github.com/ptrvsrg/crack-hash/manager/internal/bus/amqp/consumer/deadletter"
 	"github.com/ptrvsrg/crack-hash/manager/internal/bus/amqp/consumer/taskresult"
+	"github.com/ptrvsrg/crack-hash/manager/internal/bus/amqp/consumer/workerheartbeat"
 	publisher2 "github.com/ptrvsrg/crack-hash/manager/internal/bus/amqp/publisher"
 	"github.com/ptrvsrg/crack-hash/manager/internal/persistence/repository"
+	"github.com/ptrvsrg/crack-hash/manager/internal/persistence/repository/mongo/deadletter"
+	"github.com/ptrvsrg/crack-hash/manager/internal/persistence/repository/mongo/hashcracksubtask"
 	"github.com/ptrvsrg/crack-hash/manager/internal/persistence/repository/mongo/hashcracktask"
+	"github.com/ptrvsrg/crack-hash/manager/internal/persistence/repository/mongo/outboxmessage"
+	"github.com/ptrvsrg/crack-hash/manager/internal/persistence/repository/mongo/ruleset"
+	"github.com/ptrvsrg/crack-hash/manager/internal/persistence/repository/mongo/wordlist"
+	"github.com/ptrvsrg/crack-hash/manager/internal/persistence/repository/mongo/worker"
 	"github.com/ptrvsrg/crack-hash/manager/internal/service/domain"
+	deadlettersvc "github.com/ptrvsrg/crack-hash/manager/internal/service/domain/deadletter"
 	"github.com/ptrvsrg/crack-hash/manager/internal/service/domain/hashcrack"
 	"github.com/ptrvsrg/crack-hash/manager/internal/service/domain/health"
+	rulesetsvc "github.com/ptrvsrg/crack-hash/manager/internal/service/domain/ruleset"
+	wordlistsvc "github.com/ptrvsrg/crack-hash/manager/internal/service/domain/wordlist"
+	workersvc "github.com/ptrvsrg/crack-hash/manager/internal/service/domain/worker"
 	"github.com/ptrvsrg/crack-hash/manager/internal/service/infrastructure"
+	"github.com/ptrvsrg/crack-hash/manager/internal/service/infrastructure/taskqueue"
 	"github.com/ptrvsrg/crack-hash/manager/internal/service/infrastructure/tasksplit/factory"
+	"github.com/ptrvsrg/crack-hash/manager/internal/service/infrastructure/taskwithsubtasks"
+	callbackhdlr "github.com/ptrvsrg/crack-hash/manager/internal/transport/http/handler/callback"
 	healthhdlr "github.com/ptrvsrg/crack-hash/manager/internal/transport/http/handler/health"
 	"github.com/ptrvsrg/crack-hash/manager/internal/transport/http/handler/swagger"
+	deadletterhdlr "github.com/ptrvsrg/crack-hash/manager/internal/transport/http/handler/v1/deadletter"
+	hashcrackhdlr "github.com/ptrvsrg/crack-hash/manager/internal/transport/http/handler/v1/hashcrack"
+	rulesethdlr "github.com/ptrvsrg/crack-hash/manager/internal/transport/http/handler/v1/ruleset"
+	wordlisthdlr "github.com/ptrvsrg/crack-hash/manager/internal/transport/http/handler/v1/wordlist"
+	workerhdlr "github.com/ptrvsrg/crack-hash/manager/internal/transport/http/handler/v1/worker"
 	"github.com/ptrvsrg/crack-hash/manager/pkg/message"
 )
 
+// Providers contains the AMQP connection and channel for the amqp transport or the workers HTTP client
+// for the http transport
 type Providers struct {
-	AMQPConn    *amqp.Connection
-	AMQPChannel *amqp.Channel
-	MongoDB     *mongo.Client
+	AMQPConn      *amqp.Connection
+	AMQPChannel   *amqp.Channel
+	WorkersClient *resty.Client
+	MongoDB       *mongo.Client
 }
 
 type Container struct {
@@ -72,14 +99,25 @@ func (c *Container) Close(ctx context.Context) error {
 
 	errs := make([]error, 0)
 
-	c.Logger.Info().Msg("closing AMQP channel")
-	if err := c.Providers.AMQPChannel.Close(); err != nil {
-		errs = append(errs, err)
+	if c.Providers.WorkersClient != nil {
+		c.Logger.Info().Msg("closing workers HTTP client")
+		if err := c.Providers.WorkersClient.Close(); err != nil {
+			errs = append(errs, err)
+		}
 	}
 
-	c.Logger.Info().Msg("closing AMQP connection")
-	if err := c.Providers.AMQPConn.Close(); err != nil {
-		errs = append(errs, err)
+	if c.Providers.AMQPChannel != nil {
+		c.Logger.Info().Msg("closing AMQP channel")
+		if err := c.Providers.AMQPChannel.Close(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if c.Providers.AMQPConn != nil {
+		c.Logger.Info().Msg("closing AMQP connection")
+		if err := c.Providers.AMQPConn.Close(); err != nil {
+			errs = append(errs, err)
+		}
 	}
 
 	c.Logger.Info().Msg("closing MongoDB")
@@ -112,10 +150,28 @@ func (c *Container) setupProviders(ctx context.Context) {
 		c.Logger.Fatal().Err(err).Msg("failed to setup MongoDB client")
 	}
 
+	c.Providers = Providers{
+		MongoDB: mongoClient,
+	}
+
+	switch c.Config.Transport.Type {
+	case config.TransportHTTP:
+		c.setupHTTPProviders(ctx)
+	default:
+		c.setupAMQPProviders(ctx)
+	}
+}
+
+func (c *Container) setupAMQPProviders(ctx context.Context) {
+	if err := commonconfig.Validate(c.Config.AMQP); err != nil {
+		c.Logger.Fatal().Err(err).Msg("invalid AMQP config")
+	}
+
 	c.Logger.Info().Msg("setup AMQP connection")
 
 	var (
 		amqpConn *amqp.Connection
+		err      error
 	)
 	if len(c.Config.AMQP.URIs) == 1 {
 		amqpConn, err = amqp.Dial(
@@ -143,21 +199,47 @@ func (c *Container) setupProviders(ctx context.Context) {
 	}
 
 	c.Logger.Info().Msg("setup AMQP channel")
-	amqpCh, err := amqpConn.Channel(ctx)
+	amqpCh, err := amqpConn.Channel(ctx, amqp.WithConfirms())
 	if err != nil {
 		c.Logger.Fatal().Err(err).Msg("failed to setup AMQP channel")
 	}
 
-	c.Providers = Providers{
-		AMQPConn:    amqpConn,
-		AMQPChannel: amqpCh,
-		MongoDB:     mongoClient,
+	c.Providers.AMQPConn = amqpConn
+	c.Providers.AMQPChannel = amqpCh
+}
+
+func (c *Container) setupHTTPProviders(_ context.Context) {
+	cfg := c.Config.Transport.HTTP
+	if err := commonconfig.Validate(cfg); err != nil {
+		c.Logger.Fatal().Err(err).Msg("invalid HTTP transport config")
+	}
+
+	c.Logger.Info().Msg("setup workers HTTP client")
+	workersClient, err := client.New(
+		client.WithRetries(cfg.Retries, cfg.MinRetryWait, cfg.MaxRetryWait),
+		client.WithLoadBalancer(
+			cfg.WorkerURIs,
+			loadbalancer.WithStrategy(loadbalancer.Strategy(cfg.Strategy)),
+			loadbalancer.WithWeights(cfg.Weights...),
+			loadbalancer.WithCircuitBreaker(cfg.MaxFailures, cfg.EjectTimeout),
+			loadbalancer.WithHealthChecks("/health/liveness", cfg.HealthTimeout, cfg.HealthDelay, cfg.Retries),
+		),
+	)
+	if err != nil {
+		c.Logger.Fatal().Err(err).Msg("failed to setup workers HTTP client")
 	}
+
+	c.Providers.WorkersClient = workersClient
 }
 
 func (c *Container) setupRepositories(_ context.Context) {
 	c.Logger.Info().Msg("setup repositories")
 
+	wordlistRepo, err := wordlist.NewRepo(c.Logger, c.Providers.MongoDB, c.Config.MongoDB)
+	if err != nil {
+		c.Logger.Fatal().Err(err).Msg("failed to setup wordlist repository")
+	}
+
 	c.Repos = repository.Repositories{
 		HashCrackTask: hashcracktask.NewRepo(
 			c.Logger, c.Providers.MongoDB, c.Config.MongoDB,
@@ -165,30 +247,96 @@ func (c *Container) setupRepositories(_ context.Context) {
 		HashCrackSubtask: hashcracksubtask.NewRepo(
 			c.Logger, c.Providers.MongoDB, c.Config.MongoDB,
 		),
+		Wordlist:      wordlistRepo,
+		RuleSet:       ruleset.NewRepo(c.Logger, c.Providers.MongoDB, c.Config.MongoDB),
+		Worker:        worker.NewRepo(c.Logger, c.Providers.MongoDB, c.Config.MongoDB),
+		DeadLetter:    deadletter.NewRepo(c.Logger, c.Providers.MongoDB, c.Config.MongoDB),
+		OutboxMessage: outboxmessage.NewRepo(c.Logger, c.Providers.MongoDB, c.Config.MongoDB),
 	}
 }
 
 func (c *Container) setupPublishers(_ context.Context) {
 	c.Logger.Info().Msg("setup publishers")
 
+	if c.Config.Transport.Type == config.TransportHTTP {
+		c.setupHTTPPublishers()
+		return
+	}
+
+	c.Logger.Info().Msg("declare task queue")
+	taskStartedCfg := c.Config.AMQP.Publishers.TaskStarted
+	if err := c.Providers.AMQPChannel.QueueDeclare(
+		taskStartedCfg.Queue, taskStartedCfg.Exchange, taskStartedCfg.RoutingKey, true, false, false, false,
+		amqp091.Table{
+			"x-max-priority":     message.MaxTaskPriority,
+			"x-consumer-timeout": taskStartedCfg.ConsumerTimeout.Milliseconds(),
+		},
+	); err != nil {
+		c.Logger.Fatal().Err(err).Msg("failed to declare task queue")
+	}
+
 	c.Publishers = publisher2.Publishers{
 		TaskStarted: publisher.New[message.HashCrackTaskStarted](
 			c.Providers.AMQPChannel,
 			publisher.Config{
-				Exchange:   c.Config.AMQP.Publishers.TaskStarted.Exchange,
-				RoutingKey: c.Config.AMQP.Publishers.TaskStarted.RoutingKey,
+				Exchange:   taskStartedCfg.Exchange,
+				RoutingKey: taskStartedCfg.RoutingKey,
+				Priority: func(v any) uint8 {
+					return v.(*message.HashCrackTaskStarted).Priority
+				},
+			},
+		),
+		TaskCancelled: publisher.New[message.HashCrackTaskCancelled](
+			c.Providers.AMQPChannel,
+			publisher.Config{
+				Exchange:   c.Config.AMQP.Publishers.TaskCancelled.Exchange,
+				RoutingKey: c.Config.AMQP.Publishers.TaskCancelled.RoutingKey,
+			},
+		),
+		TaskShrunk: publisher.New[message.HashCrackTaskShrunk](
+			c.Providers.AMQPChannel,
+			publisher.Config{
+				Exchange:   c.Config.AMQP.Publishers.TaskShrunk.Exchange,
+				RoutingKey: c.Config.AMQP.Publishers.TaskShrunk.RoutingKey,
 			},
 		),
 	}
 }
 
+// setupHTTPPublishers sets up publishers of the http transport, subtasks are posted to workers in turn,
+// cancellations and shrinks are posted to every worker
+func (c *Container) setupHTTPPublishers() {
+	workerURIs := c.Config.Transport.HTTP.WorkerURIs
+
+	c.Publishers = publisher2.Publishers{
+		TaskStarted: httppublisher.New[message.HashCrackTaskStarted](
+			c.Providers.WorkersClient, httppublisher.Config{Path: "/internal/v1/tasks"},
+		),
+		TaskCancelled: httppublisher.NewBroadcast[message.HashCrackTaskCancelled](
+			c.Providers.WorkersClient, workerURIs, httppublisher.Config{Path: "/internal/v1/tasks/cancel"},
+		),
+		TaskShrunk: httppublisher.NewBroadcast[message.HashCrackTaskShrunk](
+			c.Providers.WorkersClient, workerURIs, httppublisher.Config{Path: "/internal/v1/tasks/shrink"},
+		),
+	}
+}
+
 func (c *Container) setupServices(_ context.Context) {
 	c.Logger.Info().Msg("setup services")
 
 	c.InfraSVCs = infrastructure.Services{
-		TaskSplit:        factory.NewService(c.Logger, c.Config.Task.Split),
+		TaskSplit:        factory.NewService(c.Logger, c.Config.Task.Split, c.Repos.HashCrackSubtask),
 		TaskWithSubtasks: taskwithsubtasks.NewService(c.Repos.HashCrackTask, c.Repos.HashCrackSubtask),
 	}
+	c.InfraSVCs.WordlistSplit = factory.NewWordlistService(c.Logger, c.InfraSVCs.TaskSplit)
+
+	if c.Config.Transport.Type == config.TransportHTTP {
+		c.InfraSVCs.TaskQueue = taskqueue.NewDirectService(c.Logger)
+	} else {
+		c.InfraSVCs.TaskQueue = taskqueue.NewService(
+			c.Logger, c.Providers.AMQPChannel, c.Config.AMQP.Publishers.TaskStarted.Queue, c.Repos.HashCrackSubtask,
+		)
+	}
 
 	c.DomainSVCs = domain.Services{
 		Health: health.NewService(c.Logger, c.Providers.MongoDB, c.Providers.AMQPConn),
@@ -197,10 +345,27 @@ func (c *Container) setupServices(_ context.Context) {
 			c.Config.Task,
 			c.Repos.HashCrackTask,
 			c.Repos.HashCrackSubtask,
+			c.Repos.OutboxMessage,
+			c.Repos.Wordlist,
+			c.Repos.RuleSet,
 			c.InfraSVCs.TaskSplit,
+			c.InfraSVCs.WordlistSplit,
 			c.InfraSVCs.TaskWithSubtasks,
+			c.InfraSVCs.TaskQueue,
 			c.Publishers.TaskStarted,
+			c.Publishers.TaskCancelled,
+			c.Publishers.TaskShrunk,
 		),
+		Wordlist: wordlistsvc.NewService(c.Logger, c.Config.Wordlist, c.Repos.Wordlist, c.Repos.HashCrackTask),
+		RuleSet:  rulesetsvc.NewService(c.Logger, c.Config.RuleSet, c.Repos.RuleSet),
+		Worker:   workersvc.NewService(c.Logger, c.Config.Worker, c.Repos.Worker),
+	}
+
+	// Dead letters are replayed through the broker, so they are kept only by the amqp transport
+	if c.Config.Transport.Type == config.TransportAMQP {
+		c.DomainSVCs.DeadLetter = deadlettersvc.NewService(
+			c.Logger, c.Repos.DeadLetter, commondeadletter.NewReplayer(c.Providers.AMQPChannel),
+		)
 	}
 }
 
@@ -210,16 +375,32 @@ func (c *Container) setupHandlers(_ context.Context) {
 	c.Handlers = []handler.Handler{
 		healthhdlr.NewHandler(c.Logger, c.DomainSVCs.Health),
 		swagger.NewHandler(c.Logger),
-		hashcrackhdlr.NewHandler(c.Logger, c.DomainSVCs.HashCrackTask),
+		hashcrackhdlr.NewHandler(c.Logger, c.DomainSVCs.HashCrackTask, c.Config.Task.RetryAfter),
+		wordlisthdlr.NewHandler(c.Logger, c.DomainSVCs.Wordlist),
+		rulesethdlr.NewHandler(c.Logger, c.DomainSVCs.RuleSet),
+		workerhdlr.NewHandler(c.Logger, c.DomainSVCs.Worker),
+	}
+
+	if c.Config.Transport.Type == config.TransportHTTP {
+		c.Handlers = append(
+			c.Handlers, callbackhdlr.NewHandler(c.Logger, c.DomainSVCs.HashCrackTask, c.DomainSVCs.Worker),
+		)
+	} else {
+		c.Handlers = append(c.Handlers, deadletterhdlr.NewHandler(c.Logger, c.DomainSVCs.DeadLetter))
 	}
 }
 
 func (c *Container) setupConsumers(_ context.Context) {
 	c.Logger.Info().Msg("setup consumers")
 
+	// Workers call back the manager over HTTP instead
+	if c.Config.Transport.Type == config.TransportHTTP {
+		return
+	}
+
 	c.Consumers = []consumer.Consumer{
-		taskresult.NewConsumer(
-			c.Providers.AMQPChannel, c.Config.AMQP.Consumers.TaskResult, c.DomainSVCs.HashCrackTask,
-		),
+		taskresult.NewConsumer(c.Providers.AMQPChannel, c.Config.AMQP, c.DomainSVCs.HashCrackTask),
+		workerheartbeat.NewConsumer(c.Providers.AMQPChannel, c.Config.AMQP, c.DomainSVCs.Worker),
+		deadletterconsumer.NewConsumer(c.Providers.AMQPChannel, c.Config.AMQP, c.DomainSVCs.DeadLetter),
 	}
 }
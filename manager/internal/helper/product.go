@@ -0,0 +1,13 @@
+package helper
+
+import "math/big"
+
+// Product multiplies the factors, failing if the result exceeds the limits of int
+func Product(factors ...int) (int, error) {
+	result := big.NewInt(1)
+	for _, factor := range factors {
+		result.Mul(result, big.NewInt(int64(factor)))
+	}
+
+	return convertBigToInt(result)
+}
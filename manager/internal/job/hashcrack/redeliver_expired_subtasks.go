@@ -0,0 +1,38 @@
+package hashcrack
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-co-op/gocron"
+
+	"github.com/ptrvsrg/crack-hash/commonlib/cron"
+	"github.com/ptrvsrg/crack-hash/manager/internal/di"
+)
+
+func RegisterRedeliverExpiredSubtasksJob(c *di.Container) cron.RegisterFunc {
+	return func(ctx context.Context, scheduler *gocron.Scheduler) error {
+		logger := c.Logger.With().
+			Str("component", "cron-scheduler").
+			Str("job", "redeliver-expired-subtasks").
+			Logger()
+
+		_, err := scheduler.
+			Every(c.Config.Task.RedeliverDelay).
+			Do(
+				func(ctx context.Context) {
+					logger.Debug().Msg("running cron job")
+
+					if err := c.DomainSVCs.HashCrackTask.RedeliverExpiredSubtasks(ctx); err != nil {
+						logger.Error().Err(err).Stack().Msg("failed to redeliver expired subtasks")
+					}
+				}, ctx,
+			)
+
+		if err != nil {
+			return fmt.Errorf("failed to register cron job: %w", err)
+		}
+
+		return nil
+	}
+}
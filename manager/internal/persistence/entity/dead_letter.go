@@ -0,0 +1,19 @@
+package entity
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type DeadLetter struct {
+	ObjectID       primitive.ObjectID `bson:"_id"`
+	Queue          string             `bson:"queue"`
+	Exchange       string             `bson:"exchange"`
+	Error          string             `bson:"error"`
+	Retries        int                `bson:"retries"`
+	ContentType    string             `bson:"contentType"`
+	Priority       int                `bson:"priority"`
+	Body           []byte             `bson:"body"`
+	DeadLetteredAt time.Time          `bson:"deadLetteredAt"`
+}
@@ -6,15 +6,41 @@ import (
 )
 
 type HashCrackSubtask struct {
-	ObjectID   primitive.ObjectID     `bson:"_id"`
-	TaskID     primitive.ObjectID     `bson:"taskId"`
-	PartNumber int                    `bson:"partNumber"`
-	Data       []string               `bson:"data"`
-	Percent    float64                `bson:"percent"`
-	Status     HashCrackSubtaskStatus `bson:"status"`
-	Reason     *string                `bson:"reason,omitempty"`
-	CreatedAt  time.Time              `bson:"createdAt"`
-	UpdatedAt  time.Time              `bson:"updatedAt"`
+	ObjectID       primitive.ObjectID     `bson:"_id"`
+	TaskID         primitive.ObjectID     `bson:"taskId"`
+	Algorithm      string                 `bson:"algorithm,omitempty"`
+	PartNumber     int                    `bson:"partNumber"`
+	Lines          *HashCrackLineRange    `bson:"lines,omitempty"`
+	Range          *HashCrackKeyRange     `bson:"range,omitempty"`
+	Data           []string               `bson:"data"`
+	Found          []HashCrackFoundHash   `bson:"found,omitempty"`
+	Percent        float64                `bson:"percent"`
+	Checkpoint     int                    `bson:"checkpoint,omitempty"`
+	Speed          float64                `bson:"speed,omitempty"`
+	Status         HashCrackSubtaskStatus `bson:"status"`
+	Reason         *string                `bson:"reason,omitempty"`
+	Attempts       int                    `bson:"attempts,omitempty"`
+	LeaseExpiresAt *time.Time             `bson:"leaseExpiresAt,omitempty"`
+	StartedAt      *time.Time             `bson:"startedAt,omitempty"`
+	CreatedAt      time.Time              `bson:"createdAt"`
+	UpdatedAt      time.Time              `bson:"updatedAt"`
+}
+
+// HashCrackLineRange is a range of wordlist lines checked by a subtask of a dictionary task
+type HashCrackLineRange struct {
+	Offset int `bson:"offset"`
+	Count  int `bson:"count"`
+}
+
+// HashCrackKeyRange is a range of candidate indexes checked by a subtask of a brute force or mask task
+type HashCrackKeyRange struct {
+	Start int `bson:"start"`
+	End   int `bson:"end"`
+}
+
+type HashCrackFoundHash struct {
+	Hash string `bson:"hash"`
+	Word string `bson:"word"`
 }
 
 type HashCrackSubtaskStatus string
@@ -24,6 +50,8 @@ const (
 	HashCrackSubtaskStatusInProgress HashCrackSubtaskStatus = "IN_PROGRESS"
 	HashCrackSubtaskStatusSuccess    HashCrackSubtaskStatus = "SUCCESS"
 	HashCrackSubtaskStatusError      HashCrackSubtaskStatus = "ERROR"
+	HashCrackSubtaskStatusCancelled  HashCrackSubtaskStatus = "CANCELLED"
+	HashCrackSubtaskStatusSkipped    HashCrackSubtaskStatus = "SKIPPED"
 	HashCrackSubtaskStatusUnknown    HashCrackSubtaskStatus = "UNKNOWN"
 )
 
@@ -41,6 +69,10 @@ func ParseHashCrackSubtaskStatus(s string) HashCrackSubtaskStatus {
 		return HashCrackSubtaskStatusSuccess
 	case "ERROR":
 		return HashCrackSubtaskStatusError
+	case "CANCELLED":
+		return HashCrackSubtaskStatusCancelled
+	case "SKIPPED":
+		return HashCrackSubtaskStatusSkipped
 	default:
 		return HashCrackSubtaskStatusUnknown
 	}
@@ -6,44 +6,112 @@ import (
 )
 
 type HashCrackTask struct {
-	ObjectID   primitive.ObjectID  `bson:"_id"`
-	Hash       string              `bson:"hash"`
-	MaxLength  int                 `bson:"maxLength"`
-	PartCount  int                 `bson:"partCount"`
-	Status     HashCrackTaskStatus `bson:"status"`
-	Reason     *string             `bson:"reason,omitempty"`
-	FinishedAt *time.Time          `bson:"finishedAt,omitempty"`
-	CreatedAt  time.Time           `bson:"createdAt"`
-	UpdatedAt  time.Time           `bson:"updatedAt"`
+	ObjectID         primitive.ObjectID  `bson:"_id"`
+	Algorithm        string              `bson:"algorithm"`
+	Hash             string              `bson:"hash"`
+	Hashes           []string            `bson:"hashes,omitempty"`
+	Salt             *HashCrackSalt      `bson:"salt,omitempty"`
+	Mode             string              `bson:"mode,omitempty"`
+	Alphabet         string              `bson:"alphabet,omitempty"`
+	MinLength        int                 `bson:"minLength,omitempty"`
+	MaxLength        int                 `bson:"maxLength"`
+	WordlistID       *primitive.ObjectID `bson:"wordlistId,omitempty"`
+	RuleSetID        *primitive.ObjectID `bson:"ruleSetId,omitempty"`
+	Rules            []string            `bson:"rules,omitempty"`
+	Mask             *HashCrackMask      `bson:"mask,omitempty"`
+	StopOnFirstMatch bool                `bson:"stopOnFirstMatch,omitempty"`
+	Priority         int                 `bson:"priority,omitempty"`
+	PartCount        int                 `bson:"partCount"`
+	Status           HashCrackTaskStatus `bson:"status"`
+	Reason           *string             `bson:"reason,omitempty"`
+	Deadline         *time.Time          `bson:"deadline,omitempty"`
+	FinishedAt       *time.Time          `bson:"finishedAt,omitempty"`
+	CreatedAt        time.Time           `bson:"createdAt"`
+	UpdatedAt        time.Time           `bson:"updatedAt"`
 }
 
 type HashCrackTaskWithSubtasks struct {
-	ObjectID   primitive.ObjectID  `bson:"_id"`
-	Hash       string              `bson:"hash"`
-	MaxLength  int                 `bson:"maxLength"`
-	PartCount  int                 `bson:"partCount"`
-	Status     HashCrackTaskStatus `bson:"status"`
-	Reason     *string             `bson:"reason,omitempty"`
-	FinishedAt *time.Time          `bson:"finishedAt,omitempty"`
-	CreatedAt  time.Time           `bson:"createdAt"`
-	UpdatedAt  time.Time           `bson:"updatedAt"`
-	Subtasks   []*HashCrackSubtask `bson:"subtasks,omitempty"`
+	ObjectID         primitive.ObjectID  `bson:"_id"`
+	Algorithm        string              `bson:"algorithm"`
+	Hash             string              `bson:"hash"`
+	Hashes           []string            `bson:"hashes,omitempty"`
+	Salt             *HashCrackSalt      `bson:"salt,omitempty"`
+	Mode             string              `bson:"mode,omitempty"`
+	Alphabet         string              `bson:"alphabet,omitempty"`
+	MinLength        int                 `bson:"minLength,omitempty"`
+	MaxLength        int                 `bson:"maxLength"`
+	WordlistID       *primitive.ObjectID `bson:"wordlistId,omitempty"`
+	RuleSetID        *primitive.ObjectID `bson:"ruleSetId,omitempty"`
+	Rules            []string            `bson:"rules,omitempty"`
+	Mask             *HashCrackMask      `bson:"mask,omitempty"`
+	StopOnFirstMatch bool                `bson:"stopOnFirstMatch,omitempty"`
+	Priority         int                 `bson:"priority,omitempty"`
+	PartCount        int                 `bson:"partCount"`
+	Status           HashCrackTaskStatus `bson:"status"`
+	Reason           *string             `bson:"reason,omitempty"`
+	Deadline         *time.Time          `bson:"deadline,omitempty"`
+	FinishedAt       *time.Time          `bson:"finishedAt,omitempty"`
+	CreatedAt        time.Time           `bson:"createdAt"`
+	UpdatedAt        time.Time           `bson:"updatedAt"`
+	Subtasks         []*HashCrackSubtask `bson:"subtasks,omitempty"`
 }
 
 func (c *HashCrackTaskWithSubtasks) ToHashCrackTask() *HashCrackTask {
 	return &HashCrackTask{
-		ObjectID:   c.ObjectID,
-		Hash:       c.Hash,
-		MaxLength:  c.MaxLength,
-		PartCount:  c.PartCount,
-		Status:     c.Status,
-		Reason:     c.Reason,
-		FinishedAt: c.FinishedAt,
-		CreatedAt:  c.CreatedAt,
-		UpdatedAt:  c.UpdatedAt,
+		ObjectID:         c.ObjectID,
+		Algorithm:        c.Algorithm,
+		Hash:             c.Hash,
+		Hashes:           c.Hashes,
+		Salt:             c.Salt,
+		Mode:             c.Mode,
+		Alphabet:         c.Alphabet,
+		MinLength:        c.MinLength,
+		MaxLength:        c.MaxLength,
+		WordlistID:       c.WordlistID,
+		RuleSetID:        c.RuleSetID,
+		Rules:            c.Rules,
+		Mask:             c.Mask,
+		StopOnFirstMatch: c.StopOnFirstMatch,
+		Priority:         c.Priority,
+		PartCount:        c.PartCount,
+		Status:           c.Status,
+		Reason:           c.Reason,
+		Deadline:         c.Deadline,
+		FinishedAt:       c.FinishedAt,
+		CreatedAt:        c.CreatedAt,
+		UpdatedAt:        c.UpdatedAt,
 	}
 }
 
+// IsBatch reports whether the task targets a list of hashes instead of a single one
+func (c *HashCrackTask) IsBatch() bool {
+	return len(c.Hashes) > 0
+}
+
+type HashCrackSalt struct {
+	Value    string           `bson:"value"`
+	Position HashSaltPosition `bson:"position"`
+}
+
+// HashCrackMask is a mask of a mask task with the charsets of its positions expanded
+type HashCrackMask struct {
+	Pattern   string   `bson:"pattern"`
+	Charsets  []string `bson:"charsets,omitempty"`
+	Positions []string `bson:"positions"`
+}
+
+type HashSaltPosition string
+
+const (
+	HashSaltPositionPrefix HashSaltPosition = "PREFIX"
+	HashSaltPositionSuffix HashSaltPosition = "SUFFIX"
+	HashSaltPositionHMAC   HashSaltPosition = "HMAC"
+)
+
+func (c HashSaltPosition) String() string {
+	return string(c)
+}
+
 type HashCrackTaskStatus string
 
 const (
@@ -52,6 +120,7 @@ const (
 	HashCrackTaskStatusPartialReady HashCrackTaskStatus = "PARTIAL_READY"
 	HashCrackTaskStatusReady        HashCrackTaskStatus = "READY"
 	HashCrackTaskStatusError        HashCrackTaskStatus = "ERROR"
+	HashCrackTaskStatusCancelled    HashCrackTaskStatus = "CANCELLED"
 	HashCrackTaskStatusUnknown      HashCrackTaskStatus = "UNKNOWN"
 )
 
@@ -71,6 +140,8 @@ func ParseHashCrackTaskStatus(s string) HashCrackTaskStatus {
 		return HashCrackTaskStatusReady
 	case "ERROR":
 		return HashCrackTaskStatusError
+	case "CANCELLED":
+		return HashCrackTaskStatusCancelled
 	default:
 		return HashCrackTaskStatusUnknown
 	}
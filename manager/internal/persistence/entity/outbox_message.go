@@ -0,0 +1,20 @@
+package entity
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// OutboxMessage is a message of the subtask to workers. It is saved in the same transaction as the subtask and
+// is deleted after the relay publishes it, the relay locks the message while publishing it
+type OutboxMessage struct {
+	ObjectID    primitive.ObjectID `bson:"_id"`
+	TaskID      primitive.ObjectID `bson:"taskId"`
+	PartNumber  int                `bson:"partNumber"`
+	Body        []byte             `bson:"body"`
+	Attempts    int                `bson:"attempts"`
+	Error       *string            `bson:"error,omitempty"`
+	LockedUntil time.Time          `bson:"lockedUntil"`
+	CreatedAt   time.Time          `bson:"createdAt"`
+}
@@ -0,0 +1,13 @@
+package entity
+
+import (
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"time"
+)
+
+type RuleSet struct {
+	ObjectID  primitive.ObjectID `bson:"_id"`
+	Name      string             `bson:"name"`
+	Rules     []string           `bson:"rules"`
+	CreatedAt time.Time          `bson:"createdAt"`
+}
@@ -0,0 +1,14 @@
+package entity
+
+import (
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"time"
+)
+
+type Wordlist struct {
+	ObjectID  primitive.ObjectID `bson:"_id"`
+	Name      string             `bson:"name"`
+	LineCount int                `bson:"lineCount"`
+	Size      int64              `bson:"size"`
+	CreatedAt time.Time          `bson:"createdAt"`
+}
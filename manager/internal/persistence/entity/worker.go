@@ -0,0 +1,13 @@
+package entity
+
+import "time"
+
+type Worker struct {
+	ID              string    `bson:"_id"`
+	Version         string    `bson:"version"`
+	CPUCount        int       `bson:"cpuCount"`
+	Algorithms      []string  `bson:"algorithms"`
+	Speed           float64   `bson:"speed"`
+	RegisteredAt    time.Time `bson:"registeredAt"`
+	LastHeartbeatAt time.Time `bson:"lastHeartbeatAt"`
+}
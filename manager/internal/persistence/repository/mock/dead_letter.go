@@ -0,0 +1,308 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mock
+
+import (
+	context "context"
+
+	entity "github.com/ptrvsrg/crack-hash/manager/internal/persistence/entity"
+	mock "github.com/stretchr/testify/mock"
+
+	primitive "go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// DeadLetterMock is an autogenerated mock type for the DeadLetter type
+type DeadLetterMock struct {
+	mock.Mock
+}
+
+type DeadLetterMock_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *DeadLetterMock) EXPECT() *DeadLetterMock_Expecter {
+	return &DeadLetterMock_Expecter{mock: &_m.Mock}
+}
+
+// CountAll provides a mock function with given fields: ctx
+func (_m *DeadLetterMock) CountAll(ctx context.Context) (int64, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountAll")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (int64, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) int64); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeadLetterMock_CountAll_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CountAll'
+type DeadLetterMock_CountAll_Call struct {
+	*mock.Call
+}
+
+// CountAll is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *DeadLetterMock_Expecter) CountAll(ctx interface{}) *DeadLetterMock_CountAll_Call {
+	return &DeadLetterMock_CountAll_Call{Call: _e.mock.On("CountAll", ctx)}
+}
+
+func (_c *DeadLetterMock_CountAll_Call) Run(run func(ctx context.Context)) *DeadLetterMock_CountAll_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *DeadLetterMock_CountAll_Call) Return(_a0 int64, _a1 error) *DeadLetterMock_CountAll_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *DeadLetterMock_CountAll_Call) RunAndReturn(run func(context.Context) (int64, error)) *DeadLetterMock_CountAll_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Create provides a mock function with given fields: ctx, deadLetter
+func (_m *DeadLetterMock) Create(ctx context.Context, deadLetter *entity.DeadLetter) error {
+	ret := _m.Called(ctx, deadLetter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.DeadLetter) error); ok {
+		r0 = rf(ctx, deadLetter)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeadLetterMock_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type DeadLetterMock_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - ctx context.Context
+//   - deadLetter *entity.DeadLetter
+func (_e *DeadLetterMock_Expecter) Create(ctx interface{}, deadLetter interface{}) *DeadLetterMock_Create_Call {
+	return &DeadLetterMock_Create_Call{Call: _e.mock.On("Create", ctx, deadLetter)}
+}
+
+func (_c *DeadLetterMock_Create_Call) Run(run func(ctx context.Context, deadLetter *entity.DeadLetter)) *DeadLetterMock_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*entity.DeadLetter))
+	})
+	return _c
+}
+
+func (_c *DeadLetterMock_Create_Call) Return(_a0 error) *DeadLetterMock_Create_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *DeadLetterMock_Create_Call) RunAndReturn(run func(context.Context, *entity.DeadLetter) error) *DeadLetterMock_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Delete provides a mock function with given fields: ctx, id
+func (_m *DeadLetterMock) Delete(ctx context.Context, id primitive.ObjectID) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, primitive.ObjectID) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeadLetterMock_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
+type DeadLetterMock_Delete_Call struct {
+	*mock.Call
+}
+
+// Delete is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id primitive.ObjectID
+func (_e *DeadLetterMock_Expecter) Delete(ctx interface{}, id interface{}) *DeadLetterMock_Delete_Call {
+	return &DeadLetterMock_Delete_Call{Call: _e.mock.On("Delete", ctx, id)}
+}
+
+func (_c *DeadLetterMock_Delete_Call) Run(run func(ctx context.Context, id primitive.ObjectID)) *DeadLetterMock_Delete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(primitive.ObjectID))
+	})
+	return _c
+}
+
+func (_c *DeadLetterMock_Delete_Call) Return(_a0 error) *DeadLetterMock_Delete_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *DeadLetterMock_Delete_Call) RunAndReturn(run func(context.Context, primitive.ObjectID) error) *DeadLetterMock_Delete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Get provides a mock function with given fields: ctx, id
+func (_m *DeadLetterMock) Get(ctx context.Context, id primitive.ObjectID) (*entity.DeadLetter, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Get")
+	}
+
+	var r0 *entity.DeadLetter
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, primitive.ObjectID) (*entity.DeadLetter, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, primitive.ObjectID) *entity.DeadLetter); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.DeadLetter)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, primitive.ObjectID) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeadLetterMock_Get_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Get'
+type DeadLetterMock_Get_Call struct {
+	*mock.Call
+}
+
+// Get is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id primitive.ObjectID
+func (_e *DeadLetterMock_Expecter) Get(ctx interface{}, id interface{}) *DeadLetterMock_Get_Call {
+	return &DeadLetterMock_Get_Call{Call: _e.mock.On("Get", ctx, id)}
+}
+
+func (_c *DeadLetterMock_Get_Call) Run(run func(ctx context.Context, id primitive.ObjectID)) *DeadLetterMock_Get_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(primitive.ObjectID))
+	})
+	return _c
+}
+
+func (_c *DeadLetterMock_Get_Call) Return(_a0 *entity.DeadLetter, _a1 error) *DeadLetterMock_Get_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *DeadLetterMock_Get_Call) RunAndReturn(run func(context.Context, primitive.ObjectID) (*entity.DeadLetter, error)) *DeadLetterMock_Get_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAll provides a mock function with given fields: ctx, limit, offset
+func (_m *DeadLetterMock) GetAll(ctx context.Context, limit int, offset int) ([]*entity.DeadLetter, error) {
+	ret := _m.Called(ctx, limit, offset)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAll")
+	}
+
+	var r0 []*entity.DeadLetter
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) ([]*entity.DeadLetter, error)); ok {
+		return rf(ctx, limit, offset)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) []*entity.DeadLetter); ok {
+		r0 = rf(ctx, limit, offset)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entity.DeadLetter)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, int) error); ok {
+		r1 = rf(ctx, limit, offset)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeadLetterMock_GetAll_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAll'
+type DeadLetterMock_GetAll_Call struct {
+	*mock.Call
+}
+
+// GetAll is a helper method to define mock.On call
+//   - ctx context.Context
+//   - limit int
+//   - offset int
+func (_e *DeadLetterMock_Expecter) GetAll(ctx interface{}, limit interface{}, offset interface{}) *DeadLetterMock_GetAll_Call {
+	return &DeadLetterMock_GetAll_Call{Call: _e.mock.On("GetAll", ctx, limit, offset)}
+}
+
+func (_c *DeadLetterMock_GetAll_Call) Run(run func(ctx context.Context, limit int, offset int)) *DeadLetterMock_GetAll_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int), args[2].(int))
+	})
+	return _c
+}
+
+func (_c *DeadLetterMock_GetAll_Call) Return(_a0 []*entity.DeadLetter, _a1 error) *DeadLetterMock_GetAll_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *DeadLetterMock_GetAll_Call) RunAndReturn(run func(context.Context, int, int) ([]*entity.DeadLetter, error)) *DeadLetterMock_GetAll_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewDeadLetterMock creates a new instance of DeadLetterMock. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewDeadLetterMock(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *DeadLetterMock {
+	mock := &DeadLetterMock{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
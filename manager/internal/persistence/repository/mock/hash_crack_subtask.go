@@ -9,6 +9,8 @@ import (
 	mock "github.com/stretchr/testify/mock"
 
 	primitive "go.mongodb.org/mongo-driver/bson/primitive"
+
+	time "time"
 )
 
 // HashCrackSubtaskMock is an autogenerated mock type for the HashCrackSubtask type
@@ -24,6 +26,63 @@ func (_m *HashCrackSubtaskMock) EXPECT() *HashCrackSubtaskMock_Expecter {
 	return &HashCrackSubtaskMock_Expecter{mock: &_m.Mock}
 }
 
+// CountAllByStatus provides a mock function with given fields: ctx, status
+func (_m *HashCrackSubtaskMock) CountAllByStatus(ctx context.Context, status entity.HashCrackSubtaskStatus) (int64, error) {
+	ret := _m.Called(ctx, status)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountAllByStatus")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.HashCrackSubtaskStatus) (int64, error)); ok {
+		return rf(ctx, status)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, entity.HashCrackSubtaskStatus) int64); ok {
+		r0 = rf(ctx, status)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, entity.HashCrackSubtaskStatus) error); ok {
+		r1 = rf(ctx, status)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// HashCrackSubtaskMock_CountAllByStatus_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CountAllByStatus'
+type HashCrackSubtaskMock_CountAllByStatus_Call struct {
+	*mock.Call
+}
+
+// CountAllByStatus is a helper method to define mock.On call
+//   - ctx context.Context
+//   - status entity.HashCrackSubtaskStatus
+func (_e *HashCrackSubtaskMock_Expecter) CountAllByStatus(ctx interface{}, status interface{}) *HashCrackSubtaskMock_CountAllByStatus_Call {
+	return &HashCrackSubtaskMock_CountAllByStatus_Call{Call: _e.mock.On("CountAllByStatus", ctx, status)}
+}
+
+func (_c *HashCrackSubtaskMock_CountAllByStatus_Call) Run(run func(ctx context.Context, status entity.HashCrackSubtaskStatus)) *HashCrackSubtaskMock_CountAllByStatus_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(entity.HashCrackSubtaskStatus))
+	})
+	return _c
+}
+
+func (_c *HashCrackSubtaskMock_CountAllByStatus_Call) Return(_a0 int64, _a1 error) *HashCrackSubtaskMock_CountAllByStatus_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *HashCrackSubtaskMock_CountAllByStatus_Call) RunAndReturn(run func(context.Context, entity.HashCrackSubtaskStatus) (int64, error)) *HashCrackSubtaskMock_CountAllByStatus_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Create provides a mock function with given fields: ctx, task
 func (_m *HashCrackSubtaskMock) Create(ctx context.Context, task *entity.HashCrackSubtask) error {
 	ret := _m.Called(ctx, task)
@@ -342,6 +401,181 @@ func (_c *HashCrackSubtaskMock_GetAllByTaskIDs_Call) RunAndReturn(run func(conte
 	return _c
 }
 
+// GetAllLeaseExpired provides a mock function with given fields: ctx
+func (_m *HashCrackSubtaskMock) GetAllLeaseExpired(ctx context.Context) ([]*entity.HashCrackSubtask, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAllLeaseExpired")
+	}
+
+	var r0 []*entity.HashCrackSubtask
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]*entity.HashCrackSubtask, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []*entity.HashCrackSubtask); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entity.HashCrackSubtask)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// HashCrackSubtaskMock_GetAllLeaseExpired_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAllLeaseExpired'
+type HashCrackSubtaskMock_GetAllLeaseExpired_Call struct {
+	*mock.Call
+}
+
+// GetAllLeaseExpired is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *HashCrackSubtaskMock_Expecter) GetAllLeaseExpired(ctx interface{}) *HashCrackSubtaskMock_GetAllLeaseExpired_Call {
+	return &HashCrackSubtaskMock_GetAllLeaseExpired_Call{Call: _e.mock.On("GetAllLeaseExpired", ctx)}
+}
+
+func (_c *HashCrackSubtaskMock_GetAllLeaseExpired_Call) Run(run func(ctx context.Context)) *HashCrackSubtaskMock_GetAllLeaseExpired_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *HashCrackSubtaskMock_GetAllLeaseExpired_Call) Return(_a0 []*entity.HashCrackSubtask, _a1 error) *HashCrackSubtaskMock_GetAllLeaseExpired_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *HashCrackSubtaskMock_GetAllLeaseExpired_Call) RunAndReturn(run func(context.Context) ([]*entity.HashCrackSubtask, error)) *HashCrackSubtaskMock_GetAllLeaseExpired_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAllStartedBefore provides a mock function with given fields: ctx, before
+func (_m *HashCrackSubtaskMock) GetAllStartedBefore(ctx context.Context, before time.Time) ([]*entity.HashCrackSubtask, error) {
+	ret := _m.Called(ctx, before)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAllStartedBefore")
+	}
+
+	var r0 []*entity.HashCrackSubtask
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) ([]*entity.HashCrackSubtask, error)); ok {
+		return rf(ctx, before)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) []*entity.HashCrackSubtask); ok {
+		r0 = rf(ctx, before)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entity.HashCrackSubtask)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, before)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// HashCrackSubtaskMock_GetAllStartedBefore_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAllStartedBefore'
+type HashCrackSubtaskMock_GetAllStartedBefore_Call struct {
+	*mock.Call
+}
+
+// GetAllStartedBefore is a helper method to define mock.On call
+//   - ctx context.Context
+//   - before time.Time
+func (_e *HashCrackSubtaskMock_Expecter) GetAllStartedBefore(ctx interface{}, before interface{}) *HashCrackSubtaskMock_GetAllStartedBefore_Call {
+	return &HashCrackSubtaskMock_GetAllStartedBefore_Call{Call: _e.mock.On("GetAllStartedBefore", ctx, before)}
+}
+
+func (_c *HashCrackSubtaskMock_GetAllStartedBefore_Call) Run(run func(ctx context.Context, before time.Time)) *HashCrackSubtaskMock_GetAllStartedBefore_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Time))
+	})
+	return _c
+}
+
+func (_c *HashCrackSubtaskMock_GetAllStartedBefore_Call) Return(_a0 []*entity.HashCrackSubtask, _a1 error) *HashCrackSubtaskMock_GetAllStartedBefore_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *HashCrackSubtaskMock_GetAllStartedBefore_Call) RunAndReturn(run func(context.Context, time.Time) ([]*entity.HashCrackSubtask, error)) *HashCrackSubtaskMock_GetAllStartedBefore_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAverageSpeed provides a mock function with given fields: ctx, algorithm, since
+func (_m *HashCrackSubtaskMock) GetAverageSpeed(ctx context.Context, algorithm string, since time.Time) (float64, error) {
+	ret := _m.Called(ctx, algorithm, since)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAverageSpeed")
+	}
+
+	var r0 float64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) (float64, error)); ok {
+		return rf(ctx, algorithm, since)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) float64); ok {
+		r0 = rf(ctx, algorithm, since)
+	} else {
+		r0 = ret.Get(0).(float64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, time.Time) error); ok {
+		r1 = rf(ctx, algorithm, since)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// HashCrackSubtaskMock_GetAverageSpeed_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAverageSpeed'
+type HashCrackSubtaskMock_GetAverageSpeed_Call struct {
+	*mock.Call
+}
+
+// GetAverageSpeed is a helper method to define mock.On call
+//   - ctx context.Context
+//   - algorithm string
+//   - since time.Time
+func (_e *HashCrackSubtaskMock_Expecter) GetAverageSpeed(ctx interface{}, algorithm interface{}, since interface{}) *HashCrackSubtaskMock_GetAverageSpeed_Call {
+	return &HashCrackSubtaskMock_GetAverageSpeed_Call{Call: _e.mock.On("GetAverageSpeed", ctx, algorithm, since)}
+}
+
+func (_c *HashCrackSubtaskMock_GetAverageSpeed_Call) Run(run func(ctx context.Context, algorithm string, since time.Time)) *HashCrackSubtaskMock_GetAverageSpeed_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(time.Time))
+	})
+	return _c
+}
+
+func (_c *HashCrackSubtaskMock_GetAverageSpeed_Call) Return(_a0 float64, _a1 error) *HashCrackSubtaskMock_GetAverageSpeed_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *HashCrackSubtaskMock_GetAverageSpeed_Call) RunAndReturn(run func(context.Context, string, time.Time) (float64, error)) *HashCrackSubtaskMock_GetAverageSpeed_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetByTaskIDAndPartNumber provides a mock function with given fields: ctx, taskID, partNumber
 func (_m *HashCrackSubtaskMock) GetByTaskIDAndPartNumber(ctx context.Context, taskID primitive.ObjectID, partNumber int) (*entity.HashCrackSubtask, error) {
 	ret := _m.Called(ctx, taskID, partNumber)
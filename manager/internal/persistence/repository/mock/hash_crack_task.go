@@ -82,6 +82,121 @@ func (_c *HashCrackTaskMock_CountAll_Call) RunAndReturn(run func(context.Context
 	return _c
 }
 
+// CountAllByStatuses provides a mock function with given fields: ctx, statuses
+func (_m *HashCrackTaskMock) CountAllByStatuses(ctx context.Context, statuses []entity.HashCrackTaskStatus) (int64, error) {
+	ret := _m.Called(ctx, statuses)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountAllByStatuses")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []entity.HashCrackTaskStatus) (int64, error)); ok {
+		return rf(ctx, statuses)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []entity.HashCrackTaskStatus) int64); ok {
+		r0 = rf(ctx, statuses)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []entity.HashCrackTaskStatus) error); ok {
+		r1 = rf(ctx, statuses)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// HashCrackTaskMock_CountAllByStatuses_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CountAllByStatuses'
+type HashCrackTaskMock_CountAllByStatuses_Call struct {
+	*mock.Call
+}
+
+// CountAllByStatuses is a helper method to define mock.On call
+//   - ctx context.Context
+//   - statuses []entity.HashCrackTaskStatus
+func (_e *HashCrackTaskMock_Expecter) CountAllByStatuses(ctx interface{}, statuses interface{}) *HashCrackTaskMock_CountAllByStatuses_Call {
+	return &HashCrackTaskMock_CountAllByStatuses_Call{Call: _e.mock.On("CountAllByStatuses", ctx, statuses)}
+}
+
+func (_c *HashCrackTaskMock_CountAllByStatuses_Call) Run(run func(ctx context.Context, statuses []entity.HashCrackTaskStatus)) *HashCrackTaskMock_CountAllByStatuses_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]entity.HashCrackTaskStatus))
+	})
+	return _c
+}
+
+func (_c *HashCrackTaskMock_CountAllByStatuses_Call) Return(_a0 int64, _a1 error) *HashCrackTaskMock_CountAllByStatuses_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *HashCrackTaskMock_CountAllByStatuses_Call) RunAndReturn(run func(context.Context, []entity.HashCrackTaskStatus) (int64, error)) *HashCrackTaskMock_CountAllByStatuses_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CountAllByWordlistAndStatuses provides a mock function with given fields: ctx, wordlistID, statuses
+func (_m *HashCrackTaskMock) CountAllByWordlistAndStatuses(ctx context.Context, wordlistID primitive.ObjectID, statuses []entity.HashCrackTaskStatus) (int64, error) {
+	ret := _m.Called(ctx, wordlistID, statuses)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountAllByWordlistAndStatuses")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, primitive.ObjectID, []entity.HashCrackTaskStatus) (int64, error)); ok {
+		return rf(ctx, wordlistID, statuses)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, primitive.ObjectID, []entity.HashCrackTaskStatus) int64); ok {
+		r0 = rf(ctx, wordlistID, statuses)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, primitive.ObjectID, []entity.HashCrackTaskStatus) error); ok {
+		r1 = rf(ctx, wordlistID, statuses)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// HashCrackTaskMock_CountAllByWordlistAndStatuses_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CountAllByWordlistAndStatuses'
+type HashCrackTaskMock_CountAllByWordlistAndStatuses_Call struct {
+	*mock.Call
+}
+
+// CountAllByWordlistAndStatuses is a helper method to define mock.On call
+//   - ctx context.Context
+//   - wordlistID primitive.ObjectID
+//   - statuses []entity.HashCrackTaskStatus
+func (_e *HashCrackTaskMock_Expecter) CountAllByWordlistAndStatuses(ctx interface{}, wordlistID interface{}, statuses interface{}) *HashCrackTaskMock_CountAllByWordlistAndStatuses_Call {
+	return &HashCrackTaskMock_CountAllByWordlistAndStatuses_Call{Call: _e.mock.On("CountAllByWordlistAndStatuses", ctx, wordlistID, statuses)}
+}
+
+func (_c *HashCrackTaskMock_CountAllByWordlistAndStatuses_Call) Run(run func(ctx context.Context, wordlistID primitive.ObjectID, statuses []entity.HashCrackTaskStatus)) *HashCrackTaskMock_CountAllByWordlistAndStatuses_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(primitive.ObjectID), args[2].([]entity.HashCrackTaskStatus))
+	})
+	return _c
+}
+
+func (_c *HashCrackTaskMock_CountAllByWordlistAndStatuses_Call) Return(_a0 int64, _a1 error) *HashCrackTaskMock_CountAllByWordlistAndStatuses_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *HashCrackTaskMock_CountAllByWordlistAndStatuses_Call) RunAndReturn(run func(context.Context, primitive.ObjectID, []entity.HashCrackTaskStatus) (int64, error)) *HashCrackTaskMock_CountAllByWordlistAndStatuses_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Create provides a mock function with given fields: ctx, task
 func (_m *HashCrackTaskMock) Create(ctx context.Context, task *entity.HashCrackTask) error {
 	ret := _m.Called(ctx, task)
@@ -176,6 +291,64 @@ func (_c *HashCrackTaskMock_DeleteAllByIDs_Call) RunAndReturn(run func(context.C
 	return _c
 }
 
+// ExtendDeadline provides a mock function with given fields: ctx, id, timeout
+func (_m *HashCrackTaskMock) ExtendDeadline(ctx context.Context, id primitive.ObjectID, timeout time.Duration) (time.Time, error) {
+	ret := _m.Called(ctx, id, timeout)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ExtendDeadline")
+	}
+
+	var r0 time.Time
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, primitive.ObjectID, time.Duration) (time.Time, error)); ok {
+		return rf(ctx, id, timeout)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, primitive.ObjectID, time.Duration) time.Time); ok {
+		r0 = rf(ctx, id, timeout)
+	} else {
+		r0 = ret.Get(0).(time.Time)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, primitive.ObjectID, time.Duration) error); ok {
+		r1 = rf(ctx, id, timeout)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// HashCrackTaskMock_ExtendDeadline_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ExtendDeadline'
+type HashCrackTaskMock_ExtendDeadline_Call struct {
+	*mock.Call
+}
+
+// ExtendDeadline is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id primitive.ObjectID
+//   - timeout time.Duration
+func (_e *HashCrackTaskMock_Expecter) ExtendDeadline(ctx interface{}, id interface{}, timeout interface{}) *HashCrackTaskMock_ExtendDeadline_Call {
+	return &HashCrackTaskMock_ExtendDeadline_Call{Call: _e.mock.On("ExtendDeadline", ctx, id, timeout)}
+}
+
+func (_c *HashCrackTaskMock_ExtendDeadline_Call) Run(run func(ctx context.Context, id primitive.ObjectID, timeout time.Duration)) *HashCrackTaskMock_ExtendDeadline_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(primitive.ObjectID), args[2].(time.Duration))
+	})
+	return _c
+}
+
+func (_c *HashCrackTaskMock_ExtendDeadline_Call) Return(_a0 time.Time, _a1 error) *HashCrackTaskMock_ExtendDeadline_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *HashCrackTaskMock_ExtendDeadline_Call) RunAndReturn(run func(context.Context, primitive.ObjectID, time.Duration) (time.Time, error)) *HashCrackTaskMock_ExtendDeadline_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Get provides a mock function with given fields: ctx, id, withSubtasks
 func (_m *HashCrackTaskMock) Get(ctx context.Context, id primitive.ObjectID, withSubtasks bool) (*entity.HashCrackTaskWithSubtasks, error) {
 	ret := _m.Called(ctx, id, withSubtasks)
@@ -416,29 +589,29 @@ func (_c *HashCrackTaskMock_GetAllFinished_Call) RunAndReturn(run func(context.C
 	return _c
 }
 
-// GetByHashAndMaxLength provides a mock function with given fields: ctx, hash, maxLength, withSubtasks
-func (_m *HashCrackTaskMock) GetByHashAndMaxLength(ctx context.Context, hash string, maxLength int, withSubtasks bool) (*entity.HashCrackTaskWithSubtasks, error) {
-	ret := _m.Called(ctx, hash, maxLength, withSubtasks)
+// GetSame provides a mock function with given fields: ctx, task, withSubtasks
+func (_m *HashCrackTaskMock) GetSame(ctx context.Context, task *entity.HashCrackTask, withSubtasks bool) (*entity.HashCrackTaskWithSubtasks, error) {
+	ret := _m.Called(ctx, task, withSubtasks)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetByHashAndMaxLength")
+		panic("no return value specified for GetSame")
 	}
 
 	var r0 *entity.HashCrackTaskWithSubtasks
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, int, bool) (*entity.HashCrackTaskWithSubtasks, error)); ok {
-		return rf(ctx, hash, maxLength, withSubtasks)
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.HashCrackTask, bool) (*entity.HashCrackTaskWithSubtasks, error)); ok {
+		return rf(ctx, task, withSubtasks)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string, int, bool) *entity.HashCrackTaskWithSubtasks); ok {
-		r0 = rf(ctx, hash, maxLength, withSubtasks)
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.HashCrackTask, bool) *entity.HashCrackTaskWithSubtasks); ok {
+		r0 = rf(ctx, task, withSubtasks)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*entity.HashCrackTaskWithSubtasks)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string, int, bool) error); ok {
-		r1 = rf(ctx, hash, maxLength, withSubtasks)
+	if rf, ok := ret.Get(1).(func(context.Context, *entity.HashCrackTask, bool) error); ok {
+		r1 = rf(ctx, task, withSubtasks)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -446,33 +619,78 @@ func (_m *HashCrackTaskMock) GetByHashAndMaxLength(ctx context.Context, hash str
 	return r0, r1
 }
 
-// HashCrackTaskMock_GetByHashAndMaxLength_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByHashAndMaxLength'
-type HashCrackTaskMock_GetByHashAndMaxLength_Call struct {
+// HashCrackTaskMock_GetSame_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetSame'
+type HashCrackTaskMock_GetSame_Call struct {
 	*mock.Call
 }
 
-// GetByHashAndMaxLength is a helper method to define mock.On call
+// GetSame is a helper method to define mock.On call
 //   - ctx context.Context
-//   - hash string
-//   - maxLength int
+//   - task *entity.HashCrackTask
 //   - withSubtasks bool
-func (_e *HashCrackTaskMock_Expecter) GetByHashAndMaxLength(ctx interface{}, hash interface{}, maxLength interface{}, withSubtasks interface{}) *HashCrackTaskMock_GetByHashAndMaxLength_Call {
-	return &HashCrackTaskMock_GetByHashAndMaxLength_Call{Call: _e.mock.On("GetByHashAndMaxLength", ctx, hash, maxLength, withSubtasks)}
+func (_e *HashCrackTaskMock_Expecter) GetSame(ctx interface{}, task interface{}, withSubtasks interface{}) *HashCrackTaskMock_GetSame_Call {
+	return &HashCrackTaskMock_GetSame_Call{Call: _e.mock.On("GetSame", ctx, task, withSubtasks)}
 }
 
-func (_c *HashCrackTaskMock_GetByHashAndMaxLength_Call) Run(run func(ctx context.Context, hash string, maxLength int, withSubtasks bool)) *HashCrackTaskMock_GetByHashAndMaxLength_Call {
+func (_c *HashCrackTaskMock_GetSame_Call) Run(run func(ctx context.Context, task *entity.HashCrackTask, withSubtasks bool)) *HashCrackTaskMock_GetSame_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(string), args[2].(int), args[3].(bool))
+		run(args[0].(context.Context), args[1].(*entity.HashCrackTask), args[2].(bool))
 	})
 	return _c
 }
 
-func (_c *HashCrackTaskMock_GetByHashAndMaxLength_Call) Return(_a0 *entity.HashCrackTaskWithSubtasks, _a1 error) *HashCrackTaskMock_GetByHashAndMaxLength_Call {
+func (_c *HashCrackTaskMock_GetSame_Call) Return(_a0 *entity.HashCrackTaskWithSubtasks, _a1 error) *HashCrackTaskMock_GetSame_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *HashCrackTaskMock_GetByHashAndMaxLength_Call) RunAndReturn(run func(context.Context, string, int, bool) (*entity.HashCrackTaskWithSubtasks, error)) *HashCrackTaskMock_GetByHashAndMaxLength_Call {
+func (_c *HashCrackTaskMock_GetSame_Call) RunAndReturn(run func(context.Context, *entity.HashCrackTask, bool) (*entity.HashCrackTaskWithSubtasks, error)) *HashCrackTaskMock_GetSame_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// LockCreation provides a mock function with given fields: ctx
+func (_m *HashCrackTaskMock) LockCreation(ctx context.Context) error {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for LockCreation")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// HashCrackTaskMock_LockCreation_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'LockCreation'
+type HashCrackTaskMock_LockCreation_Call struct {
+	*mock.Call
+}
+
+// LockCreation is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *HashCrackTaskMock_Expecter) LockCreation(ctx interface{}) *HashCrackTaskMock_LockCreation_Call {
+	return &HashCrackTaskMock_LockCreation_Call{Call: _e.mock.On("LockCreation", ctx)}
+}
+
+func (_c *HashCrackTaskMock_LockCreation_Call) Run(run func(ctx context.Context)) *HashCrackTaskMock_LockCreation_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *HashCrackTaskMock_LockCreation_Call) Return(_a0 error) *HashCrackTaskMock_LockCreation_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *HashCrackTaskMock_LockCreation_Call) RunAndReturn(run func(context.Context) error) *HashCrackTaskMock_LockCreation_Call {
 	_c.Call.Return(run)
 	return _c
 }
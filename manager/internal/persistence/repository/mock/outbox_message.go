@@ -0,0 +1,289 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mock
+
+import (
+	context "context"
+
+	entity "github.com/ptrvsrg/crack-hash/manager/internal/persistence/entity"
+	mock "github.com/stretchr/testify/mock"
+
+	primitive "go.mongodb.org/mongo-driver/bson/primitive"
+
+	time "time"
+)
+
+// OutboxMessageMock is an autogenerated mock type for the OutboxMessage type
+type OutboxMessageMock struct {
+	mock.Mock
+}
+
+type OutboxMessageMock_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *OutboxMessageMock) EXPECT() *OutboxMessageMock_Expecter {
+	return &OutboxMessageMock_Expecter{mock: &_m.Mock}
+}
+
+// CreateAll provides a mock function with given fields: ctx, messages
+func (_m *OutboxMessageMock) CreateAll(ctx context.Context, messages []*entity.OutboxMessage) error {
+	ret := _m.Called(ctx, messages)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateAll")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []*entity.OutboxMessage) error); ok {
+		r0 = rf(ctx, messages)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// OutboxMessageMock_CreateAll_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateAll'
+type OutboxMessageMock_CreateAll_Call struct {
+	*mock.Call
+}
+
+// CreateAll is a helper method to define mock.On call
+//   - ctx context.Context
+//   - messages []*entity.OutboxMessage
+func (_e *OutboxMessageMock_Expecter) CreateAll(ctx interface{}, messages interface{}) *OutboxMessageMock_CreateAll_Call {
+	return &OutboxMessageMock_CreateAll_Call{Call: _e.mock.On("CreateAll", ctx, messages)}
+}
+
+func (_c *OutboxMessageMock_CreateAll_Call) Run(run func(ctx context.Context, messages []*entity.OutboxMessage)) *OutboxMessageMock_CreateAll_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]*entity.OutboxMessage))
+	})
+	return _c
+}
+
+func (_c *OutboxMessageMock_CreateAll_Call) Return(_a0 error) *OutboxMessageMock_CreateAll_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *OutboxMessageMock_CreateAll_Call) RunAndReturn(run func(context.Context, []*entity.OutboxMessage) error) *OutboxMessageMock_CreateAll_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Delete provides a mock function with given fields: ctx, id
+func (_m *OutboxMessageMock) Delete(ctx context.Context, id primitive.ObjectID) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, primitive.ObjectID) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// OutboxMessageMock_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
+type OutboxMessageMock_Delete_Call struct {
+	*mock.Call
+}
+
+// Delete is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id primitive.ObjectID
+func (_e *OutboxMessageMock_Expecter) Delete(ctx interface{}, id interface{}) *OutboxMessageMock_Delete_Call {
+	return &OutboxMessageMock_Delete_Call{Call: _e.mock.On("Delete", ctx, id)}
+}
+
+func (_c *OutboxMessageMock_Delete_Call) Run(run func(ctx context.Context, id primitive.ObjectID)) *OutboxMessageMock_Delete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(primitive.ObjectID))
+	})
+	return _c
+}
+
+func (_c *OutboxMessageMock_Delete_Call) Return(_a0 error) *OutboxMessageMock_Delete_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *OutboxMessageMock_Delete_Call) RunAndReturn(run func(context.Context, primitive.ObjectID) error) *OutboxMessageMock_Delete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAllUnlocked provides a mock function with given fields: ctx, limit
+func (_m *OutboxMessageMock) GetAllUnlocked(ctx context.Context, limit int) ([]*entity.OutboxMessage, error) {
+	ret := _m.Called(ctx, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAllUnlocked")
+	}
+
+	var r0 []*entity.OutboxMessage
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) ([]*entity.OutboxMessage, error)); ok {
+		return rf(ctx, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) []*entity.OutboxMessage); ok {
+		r0 = rf(ctx, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entity.OutboxMessage)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// OutboxMessageMock_GetAllUnlocked_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAllUnlocked'
+type OutboxMessageMock_GetAllUnlocked_Call struct {
+	*mock.Call
+}
+
+// GetAllUnlocked is a helper method to define mock.On call
+//   - ctx context.Context
+//   - limit int
+func (_e *OutboxMessageMock_Expecter) GetAllUnlocked(ctx interface{}, limit interface{}) *OutboxMessageMock_GetAllUnlocked_Call {
+	return &OutboxMessageMock_GetAllUnlocked_Call{Call: _e.mock.On("GetAllUnlocked", ctx, limit)}
+}
+
+func (_c *OutboxMessageMock_GetAllUnlocked_Call) Run(run func(ctx context.Context, limit int)) *OutboxMessageMock_GetAllUnlocked_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int))
+	})
+	return _c
+}
+
+func (_c *OutboxMessageMock_GetAllUnlocked_Call) Return(_a0 []*entity.OutboxMessage, _a1 error) *OutboxMessageMock_GetAllUnlocked_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *OutboxMessageMock_GetAllUnlocked_Call) RunAndReturn(run func(context.Context, int) ([]*entity.OutboxMessage, error)) *OutboxMessageMock_GetAllUnlocked_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Lock provides a mock function with given fields: ctx, id, until
+func (_m *OutboxMessageMock) Lock(ctx context.Context, id primitive.ObjectID, until time.Time) error {
+	ret := _m.Called(ctx, id, until)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Lock")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, primitive.ObjectID, time.Time) error); ok {
+		r0 = rf(ctx, id, until)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// OutboxMessageMock_Lock_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Lock'
+type OutboxMessageMock_Lock_Call struct {
+	*mock.Call
+}
+
+// Lock is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id primitive.ObjectID
+//   - until time.Time
+func (_e *OutboxMessageMock_Expecter) Lock(ctx interface{}, id interface{}, until interface{}) *OutboxMessageMock_Lock_Call {
+	return &OutboxMessageMock_Lock_Call{Call: _e.mock.On("Lock", ctx, id, until)}
+}
+
+func (_c *OutboxMessageMock_Lock_Call) Run(run func(ctx context.Context, id primitive.ObjectID, until time.Time)) *OutboxMessageMock_Lock_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(primitive.ObjectID), args[2].(time.Time))
+	})
+	return _c
+}
+
+func (_c *OutboxMessageMock_Lock_Call) Return(_a0 error) *OutboxMessageMock_Lock_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *OutboxMessageMock_Lock_Call) RunAndReturn(run func(context.Context, primitive.ObjectID, time.Time) error) *OutboxMessageMock_Lock_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Update provides a mock function with given fields: ctx, message
+func (_m *OutboxMessageMock) Update(ctx context.Context, message *entity.OutboxMessage) error {
+	ret := _m.Called(ctx, message)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Update")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.OutboxMessage) error); ok {
+		r0 = rf(ctx, message)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// OutboxMessageMock_Update_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Update'
+type OutboxMessageMock_Update_Call struct {
+	*mock.Call
+}
+
+// Update is a helper method to define mock.On call
+//   - ctx context.Context
+//   - message *entity.OutboxMessage
+func (_e *OutboxMessageMock_Expecter) Update(ctx interface{}, message interface{}) *OutboxMessageMock_Update_Call {
+	return &OutboxMessageMock_Update_Call{Call: _e.mock.On("Update", ctx, message)}
+}
+
+func (_c *OutboxMessageMock_Update_Call) Run(run func(ctx context.Context, message *entity.OutboxMessage)) *OutboxMessageMock_Update_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*entity.OutboxMessage))
+	})
+	return _c
+}
+
+func (_c *OutboxMessageMock_Update_Call) Return(_a0 error) *OutboxMessageMock_Update_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *OutboxMessageMock_Update_Call) RunAndReturn(run func(context.Context, *entity.OutboxMessage) error) *OutboxMessageMock_Update_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewOutboxMessageMock creates a new instance of OutboxMessageMock. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewOutboxMessageMock(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *OutboxMessageMock {
+	mock := &OutboxMessageMock{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
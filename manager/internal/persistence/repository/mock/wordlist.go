@@ -0,0 +1,465 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mock
+
+import (
+	context "context"
+	io "io"
+
+	entity "github.com/ptrvsrg/crack-hash/manager/internal/persistence/entity"
+
+	mock "github.com/stretchr/testify/mock"
+
+	primitive "go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// WordlistMock is an autogenerated mock type for the Wordlist type
+type WordlistMock struct {
+	mock.Mock
+}
+
+type WordlistMock_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *WordlistMock) EXPECT() *WordlistMock_Expecter {
+	return &WordlistMock_Expecter{mock: &_m.Mock}
+}
+
+// CountAll provides a mock function with given fields: ctx
+func (_m *WordlistMock) CountAll(ctx context.Context) (int64, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountAll")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (int64, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) int64); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// WordlistMock_CountAll_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CountAll'
+type WordlistMock_CountAll_Call struct {
+	*mock.Call
+}
+
+// CountAll is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *WordlistMock_Expecter) CountAll(ctx interface{}) *WordlistMock_CountAll_Call {
+	return &WordlistMock_CountAll_Call{Call: _e.mock.On("CountAll", ctx)}
+}
+
+func (_c *WordlistMock_CountAll_Call) Run(run func(ctx context.Context)) *WordlistMock_CountAll_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *WordlistMock_CountAll_Call) Return(_a0 int64, _a1 error) *WordlistMock_CountAll_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *WordlistMock_CountAll_Call) RunAndReturn(run func(context.Context) (int64, error)) *WordlistMock_CountAll_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Create provides a mock function with given fields: ctx, wordlist
+func (_m *WordlistMock) Create(ctx context.Context, wordlist *entity.Wordlist) error {
+	ret := _m.Called(ctx, wordlist)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.Wordlist) error); ok {
+		r0 = rf(ctx, wordlist)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// WordlistMock_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type WordlistMock_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - ctx context.Context
+//   - wordlist *entity.Wordlist
+func (_e *WordlistMock_Expecter) Create(ctx interface{}, wordlist interface{}) *WordlistMock_Create_Call {
+	return &WordlistMock_Create_Call{Call: _e.mock.On("Create", ctx, wordlist)}
+}
+
+func (_c *WordlistMock_Create_Call) Run(run func(ctx context.Context, wordlist *entity.Wordlist)) *WordlistMock_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*entity.Wordlist))
+	})
+	return _c
+}
+
+func (_c *WordlistMock_Create_Call) Return(_a0 error) *WordlistMock_Create_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *WordlistMock_Create_Call) RunAndReturn(run func(context.Context, *entity.Wordlist) error) *WordlistMock_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Delete provides a mock function with given fields: ctx, id
+func (_m *WordlistMock) Delete(ctx context.Context, id primitive.ObjectID) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, primitive.ObjectID) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// WordlistMock_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
+type WordlistMock_Delete_Call struct {
+	*mock.Call
+}
+
+// Delete is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id primitive.ObjectID
+func (_e *WordlistMock_Expecter) Delete(ctx interface{}, id interface{}) *WordlistMock_Delete_Call {
+	return &WordlistMock_Delete_Call{Call: _e.mock.On("Delete", ctx, id)}
+}
+
+func (_c *WordlistMock_Delete_Call) Run(run func(ctx context.Context, id primitive.ObjectID)) *WordlistMock_Delete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(primitive.ObjectID))
+	})
+	return _c
+}
+
+func (_c *WordlistMock_Delete_Call) Return(_a0 error) *WordlistMock_Delete_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *WordlistMock_Delete_Call) RunAndReturn(run func(context.Context, primitive.ObjectID) error) *WordlistMock_Delete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteContent provides a mock function with given fields: ctx, id
+func (_m *WordlistMock) DeleteContent(ctx context.Context, id primitive.ObjectID) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteContent")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, primitive.ObjectID) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// WordlistMock_DeleteContent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteContent'
+type WordlistMock_DeleteContent_Call struct {
+	*mock.Call
+}
+
+// DeleteContent is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id primitive.ObjectID
+func (_e *WordlistMock_Expecter) DeleteContent(ctx interface{}, id interface{}) *WordlistMock_DeleteContent_Call {
+	return &WordlistMock_DeleteContent_Call{Call: _e.mock.On("DeleteContent", ctx, id)}
+}
+
+func (_c *WordlistMock_DeleteContent_Call) Run(run func(ctx context.Context, id primitive.ObjectID)) *WordlistMock_DeleteContent_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(primitive.ObjectID))
+	})
+	return _c
+}
+
+func (_c *WordlistMock_DeleteContent_Call) Return(_a0 error) *WordlistMock_DeleteContent_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *WordlistMock_DeleteContent_Call) RunAndReturn(run func(context.Context, primitive.ObjectID) error) *WordlistMock_DeleteContent_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Get provides a mock function with given fields: ctx, id
+func (_m *WordlistMock) Get(ctx context.Context, id primitive.ObjectID) (*entity.Wordlist, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Get")
+	}
+
+	var r0 *entity.Wordlist
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, primitive.ObjectID) (*entity.Wordlist, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, primitive.ObjectID) *entity.Wordlist); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Wordlist)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, primitive.ObjectID) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// WordlistMock_Get_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Get'
+type WordlistMock_Get_Call struct {
+	*mock.Call
+}
+
+// Get is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id primitive.ObjectID
+func (_e *WordlistMock_Expecter) Get(ctx interface{}, id interface{}) *WordlistMock_Get_Call {
+	return &WordlistMock_Get_Call{Call: _e.mock.On("Get", ctx, id)}
+}
+
+func (_c *WordlistMock_Get_Call) Run(run func(ctx context.Context, id primitive.ObjectID)) *WordlistMock_Get_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(primitive.ObjectID))
+	})
+	return _c
+}
+
+func (_c *WordlistMock_Get_Call) Return(_a0 *entity.Wordlist, _a1 error) *WordlistMock_Get_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *WordlistMock_Get_Call) RunAndReturn(run func(context.Context, primitive.ObjectID) (*entity.Wordlist, error)) *WordlistMock_Get_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAll provides a mock function with given fields: ctx, limit, offset
+func (_m *WordlistMock) GetAll(ctx context.Context, limit int, offset int) ([]*entity.Wordlist, error) {
+	ret := _m.Called(ctx, limit, offset)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAll")
+	}
+
+	var r0 []*entity.Wordlist
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) ([]*entity.Wordlist, error)); ok {
+		return rf(ctx, limit, offset)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) []*entity.Wordlist); ok {
+		r0 = rf(ctx, limit, offset)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entity.Wordlist)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, int) error); ok {
+		r1 = rf(ctx, limit, offset)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// WordlistMock_GetAll_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAll'
+type WordlistMock_GetAll_Call struct {
+	*mock.Call
+}
+
+// GetAll is a helper method to define mock.On call
+//   - ctx context.Context
+//   - limit int
+//   - offset int
+func (_e *WordlistMock_Expecter) GetAll(ctx interface{}, limit interface{}, offset interface{}) *WordlistMock_GetAll_Call {
+	return &WordlistMock_GetAll_Call{Call: _e.mock.On("GetAll", ctx, limit, offset)}
+}
+
+func (_c *WordlistMock_GetAll_Call) Run(run func(ctx context.Context, limit int, offset int)) *WordlistMock_GetAll_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int), args[2].(int))
+	})
+	return _c
+}
+
+func (_c *WordlistMock_GetAll_Call) Return(_a0 []*entity.Wordlist, _a1 error) *WordlistMock_GetAll_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *WordlistMock_GetAll_Call) RunAndReturn(run func(context.Context, int, int) ([]*entity.Wordlist, error)) *WordlistMock_GetAll_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// OpenContent provides a mock function with given fields: ctx, id
+func (_m *WordlistMock) OpenContent(ctx context.Context, id primitive.ObjectID) (io.ReadCloser, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for OpenContent")
+	}
+
+	var r0 io.ReadCloser
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, primitive.ObjectID) (io.ReadCloser, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, primitive.ObjectID) io.ReadCloser); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(io.ReadCloser)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, primitive.ObjectID) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// WordlistMock_OpenContent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'OpenContent'
+type WordlistMock_OpenContent_Call struct {
+	*mock.Call
+}
+
+// OpenContent is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id primitive.ObjectID
+func (_e *WordlistMock_Expecter) OpenContent(ctx interface{}, id interface{}) *WordlistMock_OpenContent_Call {
+	return &WordlistMock_OpenContent_Call{Call: _e.mock.On("OpenContent", ctx, id)}
+}
+
+func (_c *WordlistMock_OpenContent_Call) Run(run func(ctx context.Context, id primitive.ObjectID)) *WordlistMock_OpenContent_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(primitive.ObjectID))
+	})
+	return _c
+}
+
+func (_c *WordlistMock_OpenContent_Call) Return(_a0 io.ReadCloser, _a1 error) *WordlistMock_OpenContent_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *WordlistMock_OpenContent_Call) RunAndReturn(run func(context.Context, primitive.ObjectID) (io.ReadCloser, error)) *WordlistMock_OpenContent_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UploadContent provides a mock function with given fields: ctx, id, name, content
+func (_m *WordlistMock) UploadContent(ctx context.Context, id primitive.ObjectID, name string, content io.Reader) error {
+	ret := _m.Called(ctx, id, name, content)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UploadContent")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, primitive.ObjectID, string, io.Reader) error); ok {
+		r0 = rf(ctx, id, name, content)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// WordlistMock_UploadContent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UploadContent'
+type WordlistMock_UploadContent_Call struct {
+	*mock.Call
+}
+
+// UploadContent is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id primitive.ObjectID
+//   - name string
+//   - content io.Reader
+func (_e *WordlistMock_Expecter) UploadContent(ctx interface{}, id interface{}, name interface{}, content interface{}) *WordlistMock_UploadContent_Call {
+	return &WordlistMock_UploadContent_Call{Call: _e.mock.On("UploadContent", ctx, id, name, content)}
+}
+
+func (_c *WordlistMock_UploadContent_Call) Run(run func(ctx context.Context, id primitive.ObjectID, name string, content io.Reader)) *WordlistMock_UploadContent_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(primitive.ObjectID), args[2].(string), args[3].(io.Reader))
+	})
+	return _c
+}
+
+func (_c *WordlistMock_UploadContent_Call) Return(_a0 error) *WordlistMock_UploadContent_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *WordlistMock_UploadContent_Call) RunAndReturn(run func(context.Context, primitive.ObjectID, string, io.Reader) error) *WordlistMock_UploadContent_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewWordlistMock creates a new instance of WordlistMock. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewWordlistMock(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *WordlistMock {
+	mock := &WordlistMock{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
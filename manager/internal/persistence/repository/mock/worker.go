@@ -0,0 +1,259 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mock
+
+import (
+	context "context"
+
+	entity "github.com/ptrvsrg/crack-hash/manager/internal/persistence/entity"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// WorkerMock is an autogenerated mock type for the Worker type
+type WorkerMock struct {
+	mock.Mock
+}
+
+type WorkerMock_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *WorkerMock) EXPECT() *WorkerMock_Expecter {
+	return &WorkerMock_Expecter{mock: &_m.Mock}
+}
+
+// CountAll provides a mock function with given fields: ctx
+func (_m *WorkerMock) CountAll(ctx context.Context) (int64, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountAll")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (int64, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) int64); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// WorkerMock_CountAll_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CountAll'
+type WorkerMock_CountAll_Call struct {
+	*mock.Call
+}
+
+// CountAll is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *WorkerMock_Expecter) CountAll(ctx interface{}) *WorkerMock_CountAll_Call {
+	return &WorkerMock_CountAll_Call{Call: _e.mock.On("CountAll", ctx)}
+}
+
+func (_c *WorkerMock_CountAll_Call) Run(run func(ctx context.Context)) *WorkerMock_CountAll_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *WorkerMock_CountAll_Call) Return(_a0 int64, _a1 error) *WorkerMock_CountAll_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *WorkerMock_CountAll_Call) RunAndReturn(run func(context.Context) (int64, error)) *WorkerMock_CountAll_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Get provides a mock function with given fields: ctx, id
+func (_m *WorkerMock) Get(ctx context.Context, id string) (*entity.Worker, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Get")
+	}
+
+	var r0 *entity.Worker
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*entity.Worker, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *entity.Worker); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Worker)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// WorkerMock_Get_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Get'
+type WorkerMock_Get_Call struct {
+	*mock.Call
+}
+
+// Get is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *WorkerMock_Expecter) Get(ctx interface{}, id interface{}) *WorkerMock_Get_Call {
+	return &WorkerMock_Get_Call{Call: _e.mock.On("Get", ctx, id)}
+}
+
+func (_c *WorkerMock_Get_Call) Run(run func(ctx context.Context, id string)) *WorkerMock_Get_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *WorkerMock_Get_Call) Return(_a0 *entity.Worker, _a1 error) *WorkerMock_Get_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *WorkerMock_Get_Call) RunAndReturn(run func(context.Context, string) (*entity.Worker, error)) *WorkerMock_Get_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAll provides a mock function with given fields: ctx, limit, offset
+func (_m *WorkerMock) GetAll(ctx context.Context, limit int, offset int) ([]*entity.Worker, error) {
+	ret := _m.Called(ctx, limit, offset)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAll")
+	}
+
+	var r0 []*entity.Worker
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) ([]*entity.Worker, error)); ok {
+		return rf(ctx, limit, offset)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) []*entity.Worker); ok {
+		r0 = rf(ctx, limit, offset)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entity.Worker)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, int) error); ok {
+		r1 = rf(ctx, limit, offset)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// WorkerMock_GetAll_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAll'
+type WorkerMock_GetAll_Call struct {
+	*mock.Call
+}
+
+// GetAll is a helper method to define mock.On call
+//   - ctx context.Context
+//   - limit int
+//   - offset int
+func (_e *WorkerMock_Expecter) GetAll(ctx interface{}, limit interface{}, offset interface{}) *WorkerMock_GetAll_Call {
+	return &WorkerMock_GetAll_Call{Call: _e.mock.On("GetAll", ctx, limit, offset)}
+}
+
+func (_c *WorkerMock_GetAll_Call) Run(run func(ctx context.Context, limit int, offset int)) *WorkerMock_GetAll_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int), args[2].(int))
+	})
+	return _c
+}
+
+func (_c *WorkerMock_GetAll_Call) Return(_a0 []*entity.Worker, _a1 error) *WorkerMock_GetAll_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *WorkerMock_GetAll_Call) RunAndReturn(run func(context.Context, int, int) ([]*entity.Worker, error)) *WorkerMock_GetAll_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Save provides a mock function with given fields: ctx, worker
+func (_m *WorkerMock) Save(ctx context.Context, worker *entity.Worker) error {
+	ret := _m.Called(ctx, worker)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Save")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.Worker) error); ok {
+		r0 = rf(ctx, worker)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// WorkerMock_Save_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Save'
+type WorkerMock_Save_Call struct {
+	*mock.Call
+}
+
+// Save is a helper method to define mock.On call
+//   - ctx context.Context
+//   - worker *entity.Worker
+func (_e *WorkerMock_Expecter) Save(ctx interface{}, worker interface{}) *WorkerMock_Save_Call {
+	return &WorkerMock_Save_Call{Call: _e.mock.On("Save", ctx, worker)}
+}
+
+func (_c *WorkerMock_Save_Call) Run(run func(ctx context.Context, worker *entity.Worker)) *WorkerMock_Save_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*entity.Worker))
+	})
+	return _c
+}
+
+func (_c *WorkerMock_Save_Call) Return(_a0 error) *WorkerMock_Save_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *WorkerMock_Save_Call) RunAndReturn(run func(context.Context, *entity.Worker) error) *WorkerMock_Save_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewWorkerMock creates a new instance of WorkerMock. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewWorkerMock(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *WorkerMock {
+	mock := &WorkerMock{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
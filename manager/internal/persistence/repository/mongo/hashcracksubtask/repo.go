@@ -4,6 +4,8 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
+
 	"github.com/ptrvsrg/crack-hash/manager/config"
 	"github.com/ptrvsrg/crack-hash/manager/internal/persistence/entity"
 	"github.com/ptrvsrg/crack-hash/manager/internal/persistence/repository"
@@ -126,6 +128,91 @@ func (r *repo) GetAllByStatus(ctx context.Context, status entity.HashCrackSubtas
 	return r.findAll(ctx, filter, opts)
 }
 
+func (r *repo) CountAllByStatus(ctx context.Context, status entity.HashCrackSubtaskStatus) (int64, error) {
+	r.logger.Debug().
+		Str("status", status.String()).
+		Msg("count subtasks by status")
+
+	count, err := r.collection.CountDocuments(ctx, bson.M{"status": status})
+	if err != nil {
+		return 0, fmt.Errorf("failed to count documents: %w", err)
+	}
+
+	return count, nil
+}
+
+func (r *repo) GetAllLeaseExpired(ctx context.Context) ([]*entity.HashCrackSubtask, error) {
+	r.logger.Debug().Msg("get subtasks with expired lease")
+
+	filter := bson.M{
+		"$and": []bson.M{
+			{"status": entity.HashCrackSubtaskStatusInProgress},
+			{"leaseExpiresAt": bson.M{"$ne": nil}},
+			{"leaseExpiresAt": bson.M{"$lt": time.Now()}},
+		},
+	}
+	opts := options.Find().SetSort(bson.M{"createdAt": 1})
+
+	return r.findAll(ctx, filter, opts)
+}
+
+func (r *repo) GetAllStartedBefore(ctx context.Context, before time.Time) ([]*entity.HashCrackSubtask, error) {
+	r.logger.Debug().
+		Time("before", before).
+		Msg("get subtasks started before")
+
+	filter := bson.M{
+		"$and": []bson.M{
+			{"status": entity.HashCrackSubtaskStatusInProgress},
+			{"startedAt": bson.M{"$ne": nil}},
+			{"startedAt": bson.M{"$lt": before}},
+		},
+	}
+	opts := options.Find().SetSort(bson.M{"startedAt": 1})
+
+	return r.findAll(ctx, filter, opts)
+}
+
+func (r *repo) GetAverageSpeed(ctx context.Context, algorithm string, since time.Time) (float64, error) {
+	r.logger.Debug().
+		Str("algorithm", algorithm).
+		Time("since", since).
+		Msg("get average speed")
+
+	filter := bson.M{
+		"algorithm": algorithm,
+		"updatedAt": bson.M{"$gte": since},
+		"speed":     bson.M{"$gt": 0},
+	}
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: filter}},
+		{{Key: "$group", Value: bson.M{"_id": nil, "speed": bson.M{"$avg": "$speed"}}}},
+	}
+
+	cursor, err := r.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return 0, fmt.Errorf("failed to aggregate documents: %w", err)
+	}
+	defer func(cursor *mongo.Cursor, ctx context.Context) {
+		if err := cursor.Close(ctx); err != nil {
+			r.logger.Error().Err(err).Msg("failed to close cursor")
+		}
+	}(cursor, ctx)
+
+	var results []struct {
+		Speed float64 `bson:"speed"`
+	}
+	if err := cursor.All(ctx, &results); err != nil {
+		return 0, fmt.Errorf("failed to decode documents: %w", err)
+	}
+
+	if len(results) == 0 {
+		return 0, nil
+	}
+
+	return results[0].Speed, nil
+}
+
 func (r *repo) Create(ctx context.Context, task *entity.HashCrackSubtask) error {
 	r.logger.Debug().Msg("create subtask")
 
@@ -202,7 +289,11 @@ func (r *repo) UpdateAll(ctx context.Context, tasks []*entity.HashCrackSubtask)
 		}
 	}
 
-	return fmt.Errorf("failed to update documents: %w", multierr.Combine(errs...))
+	if err := multierr.Combine(errs...); err != nil {
+		return fmt.Errorf("failed to update documents: %w", err)
+	}
+
+	return nil
 }
 
 func (r *repo) DeleteAllByIDs(ctx context.Context, ids []primitive.ObjectID) error {
@@ -0,0 +1,75 @@
+package hashcracksubtask_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/rs/zerolog/log"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
+
+	"github.com/ptrvsrg/crack-hash/manager/config"
+	"github.com/ptrvsrg/crack-hash/manager/internal/persistence/entity"
+	"github.com/ptrvsrg/crack-hash/manager/internal/persistence/repository/mongo/hashcracksubtask"
+)
+
+var cfg = config.MongoDBConfig{
+	DB:           "test",
+	WriteConcern: config.MongoDBWriteConcernConfig{W: "majority"},
+	ReadConcern:  config.MongoDBReadConcernConfig{Level: "majority"},
+}
+
+func Test_UpdateAll(t *testing.T) {
+	ctx := context.Background()
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	subtasks := []*entity.HashCrackSubtask{
+		{ObjectID: primitive.NewObjectID(), Status: entity.HashCrackSubtaskStatusCancelled},
+		{ObjectID: primitive.NewObjectID(), Status: entity.HashCrackSubtaskStatusCancelled},
+	}
+
+	mt.Run(
+		"Success", func(mt *mtest.T) {
+			// Arrange
+			repo := hashcracksubtask.NewRepo(log.Logger, mt.Client, cfg)
+			mt.AddMockResponses(mtest.CreateSuccessResponse(), mtest.CreateSuccessResponse())
+
+			// Act
+			err := repo.UpdateAll(ctx, subtasks)
+
+			// Assert
+			require.NoError(mt, err)
+		},
+	)
+
+	mt.Run(
+		"No subtasks", func(mt *mtest.T) {
+			// Arrange
+			repo := hashcracksubtask.NewRepo(log.Logger, mt.Client, cfg)
+
+			// Act
+			err := repo.UpdateAll(ctx, nil)
+
+			// Assert
+			require.NoError(mt, err)
+		},
+	)
+
+	mt.Run(
+		"Write error", func(mt *mtest.T) {
+			// Arrange
+			repo := hashcracksubtask.NewRepo(log.Logger, mt.Client, cfg)
+			mt.AddMockResponses(
+				mtest.CreateSuccessResponse(),
+				mtest.CreateWriteErrorsResponse(mtest.WriteError{Index: 0, Code: 11000, Message: "duplicate key"}),
+			)
+
+			// Act
+			err := repo.UpdateAll(ctx, subtasks)
+
+			// Assert
+			require.Error(mt, err)
+		},
+	)
+}
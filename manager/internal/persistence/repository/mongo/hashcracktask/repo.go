@@ -20,12 +20,16 @@ import (
 	"github.com/ptrvsrg/crack-hash/manager/internal/persistence/repository"
 )
 
+// creationLockID is the ID of the lock document, which is updated by every transaction creating a task
+const creationLockID = "creation"
+
 type repo struct {
 	client     *mongo.Client
 	wc         *writeconcern.WriteConcern
 	rc         *readconcern.ReadConcern
 	collection *mongo.Collection
 	view       *mongo.Collection
+	locks      *mongo.Collection
 	logger     zerolog.Logger
 }
 
@@ -54,6 +58,15 @@ func NewRepo(logger zerolog.Logger, client *mongo.Client, cfg config.MongoDBConf
 				Collection().
 				SetReadConcern(rc),
 		)
+	locks := client.
+		Database(cfg.DB).
+		Collection(
+			"hash_crack_task_locks",
+			options.
+				Collection().
+				SetReadConcern(rc).
+				SetWriteConcern(wc),
+		)
 
 	return &repo{
 		client:     client,
@@ -61,6 +74,7 @@ func NewRepo(logger zerolog.Logger, client *mongo.Client, cfg config.MongoDBConf
 		rc:         rc,
 		collection: collection,
 		view:       view,
+		locks:      locks,
 		logger: logger.With().
 			Str("repo", "hash-crack-task").
 			Str("type", "mongo").
@@ -122,20 +136,70 @@ func (r *repo) CountAll(ctx context.Context) (int64, error) {
 	return count, nil
 }
 
-func (r *repo) GetByHashAndMaxLength(
-	ctx context.Context, hash string, maxLength int, withSubtasks bool,
+func (r *repo) CountAllByStatuses(ctx context.Context, statuses []entity.HashCrackTaskStatus) (int64, error) {
+	r.logger.Debug().Interface("statuses", statuses).Msg("count all by statuses")
+
+	count, err := r.collection.CountDocuments(ctx, bson.M{"status": bson.M{"$in": statuses}})
+	if err != nil {
+		return 0, fmt.Errorf("failed to count documents: %w", err)
+	}
+
+	return count, nil
+}
+
+func (r *repo) CountAllByWordlistAndStatuses(
+	ctx context.Context, wordlistID primitive.ObjectID, statuses []entity.HashCrackTaskStatus,
+) (int64, error) {
+	r.logger.Debug().
+		Str("wordlist-id", wordlistID.Hex()).
+		Interface("statuses", statuses).
+		Msg("count all by wordlist and statuses")
+
+	count, err := r.collection.CountDocuments(
+		ctx, bson.M{"wordlistId": wordlistID, "status": bson.M{"$in": statuses}},
+	)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count documents: %w", err)
+	}
+
+	return count, nil
+}
+
+func (r *repo) GetSame(
+	ctx context.Context, task *entity.HashCrackTask, withSubtasks bool,
 ) (*entity.HashCrackTaskWithSubtasks, error) {
 
 	r.logger.Debug().
-		Str("hash", hash).
-		Int("max-length", maxLength).
+		Str("algorithm", task.Algorithm).
+		Str("hash", task.Hash).
+		Int("hash-count", len(task.Hashes)).
+		Str("alphabet", task.Alphabet).
+		Int("min-length", task.MinLength).
+		Int("max-length", task.MaxLength).
+		Bool("stop-on-first-match", task.StopOnFirstMatch).
 		Bool("with-subtasks", withSubtasks).
-		Msg("get by hash and max length")
+		Msg("get same crack task")
+
+	// The flag is omitted when it is false, so a task checking the whole keyspace has no field
+	stopOnFirstMatch := bson.M{"stopOnFirstMatch": bson.M{"$ne": true}}
+	if task.StopOnFirstMatch {
+		stopOnFirstMatch = bson.M{"stopOnFirstMatch": true}
+	}
 
 	filter := bson.M{
 		"$and": []bson.M{
-			{"hash": hash},
-			{"maxLength": maxLength},
+			{"algorithm": task.Algorithm},
+			{"hash": task.Hash},
+			{"hashes": task.Hashes},
+			{"salt": task.Salt},
+			sameOrMissing("mode", task.Mode),
+			sameOrMissing("alphabet", task.Alphabet),
+			sameOrMissing("minLength", task.MinLength),
+			{"maxLength": task.MaxLength},
+			{"wordlistId": task.WordlistID},
+			{"ruleSetId": task.RuleSetID},
+			{"mask": task.Mask},
+			stopOnFirstMatch,
 			{
 				"$or": []bson.M{
 					{"status": entity.HashCrackTaskStatusInProgress},
@@ -155,9 +219,11 @@ func (r *repo) GetAllFinished(ctx context.Context, withSubtasks bool) ([]*entity
 
 	filter := bson.M{
 		"$and": []bson.M{
-			{"status": entity.HashCrackTaskStatusInProgress},
-			{"finishedAt": bson.M{"$ne": nil}},
-			{"finishedAt": bson.M{"$lt": time.Now()}},
+			{"status": bson.M{"$in": []entity.HashCrackTaskStatus{
+				entity.HashCrackTaskStatusPending, entity.HashCrackTaskStatusInProgress,
+			}}},
+			{"deadline": bson.M{"$ne": nil}},
+			{"deadline": bson.M{"$lt": time.Now()}},
 		},
 	}
 	opts := options.Find().SetSort(bson.M{"createdAt": 1})
@@ -210,6 +276,53 @@ func (r *repo) Get(
 	return &task, nil
 }
 
+func (r *repo) ExtendDeadline(ctx context.Context, id primitive.ObjectID, timeout time.Duration) (time.Time, error) {
+	r.logger.Debug().Str("id", id.Hex()).Dur("timeout", timeout).Msg("extend deadline of crack task")
+
+	// The deadline is moved by the pipeline in the same update, so a concurrent update of the task is not lost
+	filter := bson.M{
+		"_id": id,
+		"status": bson.M{"$in": []entity.HashCrackTaskStatus{
+			entity.HashCrackTaskStatusPending, entity.HashCrackTaskStatusInProgress,
+		}},
+	}
+	update := mongo.Pipeline{
+		{{Key: "$set", Value: bson.M{
+			"deadline": bson.M{
+				"$add": bson.A{bson.M{"$ifNull": bson.A{"$deadline", "$$NOW"}}, timeout.Milliseconds()},
+			},
+			"updatedAt": "$$NOW",
+		}}},
+	}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+
+	var task entity.HashCrackTask
+	if err := r.collection.FindOneAndUpdate(ctx, filter, update, opts).Decode(&task); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return time.Time{}, repository.ErrCrackTaskNotFound
+		}
+		return time.Time{}, fmt.Errorf("failed to find one document and update: %w", err)
+	}
+
+	return *task.Deadline, nil
+}
+
+func (r *repo) LockCreation(ctx context.Context) error {
+	r.logger.Debug().Msg("lock creation of crack tasks")
+
+	_, err := r.locks.UpdateOne(
+		ctx,
+		bson.M{"_id": creationLockID},
+		bson.M{"$inc": bson.M{"version": 1}, "$set": bson.M{"lockedAt": time.Now()}},
+		options.Update().SetUpsert(true),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update lock document: %w", err)
+	}
+
+	return nil
+}
+
 func (r *repo) Create(ctx context.Context, task *entity.HashCrackTask) error {
 	r.logger.Debug().Str("id", task.ObjectID.Hex()).Msg("create task")
 
@@ -227,8 +340,21 @@ func (r *repo) Create(ctx context.Context, task *entity.HashCrackTask) error {
 func (r *repo) Update(ctx context.Context, task *entity.HashCrackTask) error {
 	r.logger.Debug().Str("id", task.ObjectID.Hex()).Msg("update crack task")
 
+	raw, err := bson.Marshal(task)
+	if err != nil {
+		return fmt.Errorf("failed to marshal document: %w", err)
+	}
+
+	var doc bson.M
+	if err := bson.Unmarshal(raw, &doc); err != nil {
+		return fmt.Errorf("failed to unmarshal document: %w", err)
+	}
+
+	// The deadline is moved by ExtendDeadline only, so the task read before the extension does not undo it
+	delete(doc, "deadline")
+
 	filter := bson.M{"_id": task.ObjectID}
-	update := bson.M{"$set": task}
+	update := bson.M{"$set": doc}
 
 	result, err := r.collection.UpdateOne(ctx, filter, update)
 	if err != nil {
@@ -306,3 +432,14 @@ func (r *repo) findAll(
 
 	return tasks, nil
 }
+
+// sameOrMissing matches the field equal to the value. The zero value matches the missing field too, because fields
+// with zero values are omitted, e.g. the alphabet and the min length of dictionary and mask tasks
+func sameOrMissing[T comparable](field string, value T) bson.M {
+	var zero T
+	if value == zero {
+		return bson.M{field: bson.M{"$in": bson.A{value, nil}}}
+	}
+
+	return bson.M{field: value}
+}
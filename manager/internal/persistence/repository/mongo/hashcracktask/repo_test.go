@@ -0,0 +1,171 @@
+package hashcracktask_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
+
+	"github.com/ptrvsrg/crack-hash/manager/config"
+	"github.com/ptrvsrg/crack-hash/manager/internal/persistence/entity"
+	"github.com/ptrvsrg/crack-hash/manager/internal/persistence/repository"
+	"github.com/ptrvsrg/crack-hash/manager/internal/persistence/repository/mongo/hashcracktask"
+	"github.com/ptrvsrg/crack-hash/manager/pkg/message"
+)
+
+var cfg = config.MongoDBConfig{
+	DB:           "test",
+	WriteConcern: config.MongoDBWriteConcernConfig{W: "majority"},
+	ReadConcern:  config.MongoDBReadConcernConfig{Level: "majority"},
+}
+
+func Test_Update(t *testing.T) {
+	ctx := context.Background()
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	task := &entity.HashCrackTask{
+		ObjectID: primitive.NewObjectID(),
+		Status:   entity.HashCrackTaskStatusInProgress,
+		Deadline: lo.ToPtr(time.Now()),
+	}
+
+	mt.Run(
+		"Keep deadline", func(mt *mtest.T) {
+			// Arrange
+			repo := hashcracktask.NewRepo(log.Logger, mt.Client, cfg)
+			mt.AddMockResponses(mtest.CreateSuccessResponse(bson.E{Key: "n", Value: 1}))
+
+			// Act
+			err := repo.Update(ctx, task)
+
+			// Assert
+			require.NoError(mt, err)
+
+			set := mt.GetStartedEvent().Command.Lookup("updates", "0", "u", "$set").Document()
+			assert.Equal(mt, string(entity.HashCrackTaskStatusInProgress), set.Lookup("status").StringValue())
+			_, err = set.LookupErr("deadline")
+			require.Error(mt, err)
+		},
+	)
+
+	mt.Run(
+		"Not found", func(mt *mtest.T) {
+			// Arrange
+			repo := hashcracktask.NewRepo(log.Logger, mt.Client, cfg)
+			mt.AddMockResponses(mtest.CreateSuccessResponse(bson.E{Key: "n", Value: 0}))
+
+			// Act
+			err := repo.Update(ctx, task)
+
+			// Assert
+			require.ErrorIs(mt, err, repository.ErrCrackTaskNotFound)
+		},
+	)
+}
+
+func Test_GetSame(t *testing.T) {
+	ctx := context.Background()
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	// condition returns the condition of the field in the filter of the find command
+	condition := func(mt *mtest.T, command bson.Raw, field string) bson.RawValue {
+		mt.Helper()
+
+		conditions, err := command.Lookup("filter", "$and").Array().Values()
+		require.NoError(mt, err)
+
+		for _, c := range conditions {
+			if value, err := c.Document().LookupErr(field); err == nil {
+				return value
+			}
+		}
+
+		require.Failf(mt, "condition not found", "field %s", field)
+		return bson.RawValue{}
+	}
+
+	mt.Run(
+		"Dictionary task", func(mt *mtest.T) {
+			// Arrange
+			repo := hashcracktask.NewRepo(log.Logger, mt.Client, cfg)
+			sameID := primitive.NewObjectID()
+			wordlistID := primitive.NewObjectID()
+			task := &entity.HashCrackTask{
+				ObjectID:   primitive.NewObjectID(),
+				Algorithm:  message.HashAlgorithmMD5,
+				Hash:       "hash",
+				Mode:       message.AttackModeDictionary,
+				WordlistID: &wordlistID,
+			}
+
+			mt.AddMockResponses(
+				mtest.CreateCursorResponse(
+					0, "test.hash_crack_tasks", mtest.FirstBatch, bson.D{
+						{Key: "_id", Value: sameID},
+						{Key: "mode", Value: message.AttackModeDictionary},
+						{Key: "wordlistId", Value: wordlistID},
+						{Key: "status", Value: entity.HashCrackTaskStatusReady},
+					},
+				),
+			)
+
+			// Act
+			same, err := repo.GetSame(ctx, task, false)
+
+			// Assert
+			require.NoError(mt, err)
+			assert.Equal(mt, sameID, same.ObjectID)
+
+			command := mt.GetStartedEvent().Command
+
+			alphabet, err := condition(mt, command, "alphabet").Document().Lookup("$in").Array().Values()
+			require.NoError(mt, err)
+			require.Len(mt, alphabet, 2)
+			assert.Empty(mt, alphabet[0].StringValue())
+			assert.Equal(mt, bson.TypeNull, alphabet[1].Type)
+
+			minLength, err := condition(mt, command, "minLength").Document().Lookup("$in").Array().Values()
+			require.NoError(mt, err)
+			require.Len(mt, minLength, 2)
+			assert.Zero(mt, minLength[0].AsInt64())
+			assert.Equal(mt, bson.TypeNull, minLength[1].Type)
+
+			assert.Equal(mt, message.AttackModeDictionary, condition(mt, command, "mode").StringValue())
+		},
+	)
+
+	mt.Run(
+		"Brute force task", func(mt *mtest.T) {
+			// Arrange
+			repo := hashcracktask.NewRepo(log.Logger, mt.Client, cfg)
+			task := &entity.HashCrackTask{
+				ObjectID:  primitive.NewObjectID(),
+				Algorithm: message.HashAlgorithmMD5,
+				Hash:      "hash",
+				Mode:      message.AttackModeBruteForce,
+				Alphabet:  "abc",
+				MinLength: 2,
+				MaxLength: 4,
+			}
+
+			mt.AddMockResponses(mtest.CreateCursorResponse(0, "test.hash_crack_tasks", mtest.FirstBatch))
+
+			// Act
+			_, err := repo.GetSame(ctx, task, false)
+
+			// Assert
+			require.ErrorIs(mt, err, repository.ErrCrackTaskNotFound)
+
+			command := mt.GetStartedEvent().Command
+			assert.Equal(mt, "abc", condition(mt, command, "alphabet").StringValue())
+			assert.Equal(mt, int64(2), condition(mt, command, "minLength").AsInt64())
+		},
+	)
+}
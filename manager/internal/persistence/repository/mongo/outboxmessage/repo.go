@@ -0,0 +1,151 @@
+package outboxmessage
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/samber/lo"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/mongo/readconcern"
+	"go.mongodb.org/mongo-driver/mongo/writeconcern"
+
+	"github.com/ptrvsrg/crack-hash/manager/config"
+	"github.com/ptrvsrg/crack-hash/manager/internal/persistence/entity"
+	"github.com/ptrvsrg/crack-hash/manager/internal/persistence/repository"
+)
+
+type repo struct {
+	collection *mongo.Collection
+	logger     zerolog.Logger
+}
+
+func NewRepo(logger zerolog.Logger, client *mongo.Client, cfg config.MongoDBConfig) repository.OutboxMessage {
+	wc := &writeconcern.WriteConcern{
+		W:       cfg.WriteConcern.W,
+		Journal: cfg.WriteConcern.Journal,
+	}
+	rc := &readconcern.ReadConcern{
+		Level: cfg.ReadConcern.Level,
+	}
+	collection := client.
+		Database(cfg.DB).
+		Collection(
+			"outbox_messages",
+			options.
+				Collection().
+				SetReadConcern(rc).
+				SetWriteConcern(wc),
+		)
+
+	return &repo{
+		collection: collection,
+		logger: logger.With().
+			Str("repo", "outbox-message").
+			Str("type", "mongo").
+			Logger(),
+	}
+}
+
+func (r *repo) GetAllUnlocked(ctx context.Context, limit int) ([]*entity.OutboxMessage, error) {
+	r.logger.Debug().Int("limit", limit).Msg("get all unlocked")
+
+	filter := bson.M{"lockedUntil": bson.M{"$lte": time.Now()}}
+	opts := options.Find().
+		SetLimit(int64(limit)).
+		SetSort(bson.M{"createdAt": 1})
+
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find documents: %w", err)
+	}
+	defer func(cursor *mongo.Cursor, ctx context.Context) {
+		if err := cursor.Close(ctx); err != nil {
+			r.logger.Error().Err(err).Msg("failed to close cursor")
+		}
+	}(cursor, ctx)
+
+	var messages []*entity.OutboxMessage
+	if err := cursor.All(ctx, &messages); err != nil {
+		return nil, fmt.Errorf("failed to decode documents: %w", err)
+	}
+
+	return messages, nil
+}
+
+func (r *repo) CreateAll(ctx context.Context, messages []*entity.OutboxMessage) error {
+	ids := lo.Map(messages, func(message *entity.OutboxMessage, _ int) string {
+		return message.ObjectID.Hex()
+	})
+
+	r.logger.Debug().
+		Int("count", len(messages)).
+		Strs("ids", ids).
+		Msg("create outbox messages")
+
+	documents := lo.Map(messages, func(message *entity.OutboxMessage, _ int) interface{} {
+		return message
+	})
+
+	_, err := r.collection.InsertMany(ctx, documents)
+	if err != nil {
+		return fmt.Errorf("failed to insert many documents: %w", err)
+	}
+
+	return nil
+}
+
+func (r *repo) Lock(ctx context.Context, id primitive.ObjectID, until time.Time) error {
+	r.logger.Debug().
+		Str("id", id.Hex()).
+		Time("until", until).
+		Msg("lock outbox message")
+
+	filter := bson.M{
+		"_id":         id,
+		"lockedUntil": bson.M{"$lte": time.Now()},
+	}
+	update := bson.M{"$set": bson.M{"lockedUntil": until}}
+
+	result, err := r.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return fmt.Errorf("failed to update one document: %w", err)
+	}
+
+	if result.MatchedCount == 0 {
+		return repository.ErrOutboxMessageLocked
+	}
+
+	return nil
+}
+
+func (r *repo) Update(ctx context.Context, message *entity.OutboxMessage) error {
+	r.logger.Debug().
+		Str("id", message.ObjectID.Hex()).
+		Msg("update outbox message")
+
+	filter := bson.M{"_id": message.ObjectID}
+	update := bson.M{"$set": message}
+
+	_, err := r.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return fmt.Errorf("failed to update one document: %w", err)
+	}
+
+	return nil
+}
+
+func (r *repo) Delete(ctx context.Context, id primitive.ObjectID) error {
+	r.logger.Debug().Str("id", id.Hex()).Msg("delete outbox message")
+
+	_, err := r.collection.DeleteOne(ctx, bson.M{"_id": id})
+	if err != nil {
+		return fmt.Errorf("failed to delete one document: %w", err)
+	}
+
+	return nil
+}
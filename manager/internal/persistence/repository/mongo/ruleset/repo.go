@@ -0,0 +1,139 @@
+package ruleset
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/rs/zerolog"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/mongo/readconcern"
+	"go.mongodb.org/mongo-driver/mongo/writeconcern"
+
+	"github.com/ptrvsrg/crack-hash/manager/config"
+	"github.com/ptrvsrg/crack-hash/manager/internal/persistence/entity"
+	"github.com/ptrvsrg/crack-hash/manager/internal/persistence/repository"
+)
+
+type repo struct {
+	collection *mongo.Collection
+	logger     zerolog.Logger
+}
+
+func NewRepo(logger zerolog.Logger, client *mongo.Client, cfg config.MongoDBConfig) repository.RuleSet {
+	wc := &writeconcern.WriteConcern{
+		W:       cfg.WriteConcern.W,
+		Journal: cfg.WriteConcern.Journal,
+	}
+	rc := &readconcern.ReadConcern{
+		Level: cfg.ReadConcern.Level,
+	}
+	collection := client.
+		Database(cfg.DB).
+		Collection(
+			"rule_sets",
+			options.
+				Collection().
+				SetReadConcern(rc).
+				SetWriteConcern(wc),
+		)
+
+	return &repo{
+		collection: collection,
+		logger: logger.With().
+			Str("repo", "rule-set").
+			Str("type", "mongo").
+			Logger(),
+	}
+}
+
+func (r *repo) GetAll(ctx context.Context, limit, offset int) ([]*entity.RuleSet, error) {
+	r.logger.Debug().
+		Int("limit", limit).
+		Int("offset", offset).
+		Msg("get all")
+
+	opts := options.Find().
+		SetLimit(int64(limit)).
+		SetSkip(int64(offset)).
+		SetSort(bson.M{"createdAt": 1})
+
+	cursor, err := r.collection.Find(ctx, bson.M{}, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find documents: %w", err)
+	}
+	defer func(cursor *mongo.Cursor, ctx context.Context) {
+		if err := cursor.Close(ctx); err != nil {
+			r.logger.Error().Err(err).Msg("failed to close cursor")
+		}
+	}(cursor, ctx)
+
+	var ruleSets []*entity.RuleSet
+	if err := cursor.All(ctx, &ruleSets); err != nil {
+		return nil, fmt.Errorf("failed to decode documents: %w", err)
+	}
+
+	return ruleSets, nil
+}
+
+func (r *repo) CountAll(ctx context.Context) (int64, error) {
+	r.logger.Debug().Msg("count all")
+
+	count, err := r.collection.CountDocuments(ctx, bson.M{})
+	if err != nil {
+		return 0, fmt.Errorf("failed to count documents: %w", err)
+	}
+
+	return count, nil
+}
+
+func (r *repo) Get(ctx context.Context, id primitive.ObjectID) (*entity.RuleSet, error) {
+	r.logger.Debug().Str("id", id.Hex()).Msg("get rule set")
+
+	result := r.collection.FindOne(ctx, bson.M{"_id": id})
+	if result.Err() != nil {
+		if errors.Is(result.Err(), mongo.ErrNoDocuments) {
+			return nil, repository.ErrRuleSetNotFound
+		}
+		return nil, fmt.Errorf("failed to find one document: %w", result.Err())
+	}
+
+	var ruleSet entity.RuleSet
+	if err := result.Decode(&ruleSet); err != nil {
+		return nil, fmt.Errorf("failed to decode document: %w", err)
+	}
+
+	return &ruleSet, nil
+}
+
+func (r *repo) Create(ctx context.Context, ruleSet *entity.RuleSet) error {
+	r.logger.Debug().Str("id", ruleSet.ObjectID.Hex()).Msg("create rule set")
+
+	_, err := r.collection.InsertOne(ctx, ruleSet)
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return repository.ErrRuleSetExists
+		}
+		return fmt.Errorf("failed to insert one document: %w", err)
+	}
+
+	return nil
+}
+
+func (r *repo) Delete(ctx context.Context, id primitive.ObjectID) error {
+	r.logger.Debug().Str("id", id.Hex()).Msg("delete rule set")
+
+	result, err := r.collection.DeleteOne(ctx, bson.M{"_id": id})
+	if err != nil {
+		return fmt.Errorf("failed to delete one document: %w", err)
+	}
+
+	if result.DeletedCount == 0 {
+		return repository.ErrRuleSetNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,191 @@
+package wordlist
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/rs/zerolog"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/gridfs"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/mongo/readconcern"
+	"go.mongodb.org/mongo-driver/mongo/writeconcern"
+
+	"github.com/ptrvsrg/crack-hash/manager/config"
+	"github.com/ptrvsrg/crack-hash/manager/internal/persistence/entity"
+	"github.com/ptrvsrg/crack-hash/manager/internal/persistence/repository"
+)
+
+type repo struct {
+	collection *mongo.Collection
+	bucket     *gridfs.Bucket
+	logger     zerolog.Logger
+}
+
+func NewRepo(logger zerolog.Logger, client *mongo.Client, cfg config.MongoDBConfig) (repository.Wordlist, error) {
+	wc := &writeconcern.WriteConcern{
+		W:       cfg.WriteConcern.W,
+		Journal: cfg.WriteConcern.Journal,
+	}
+	rc := &readconcern.ReadConcern{
+		Level: cfg.ReadConcern.Level,
+	}
+	collection := client.
+		Database(cfg.DB).
+		Collection(
+			"wordlists",
+			options.
+				Collection().
+				SetReadConcern(rc).
+				SetWriteConcern(wc),
+		)
+
+	bucket, err := gridfs.NewBucket(
+		client.Database(cfg.DB),
+		options.GridFSBucket().
+			SetName("wordlist_contents").
+			SetReadConcern(rc).
+			SetWriteConcern(wc),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create GridFS bucket: %w", err)
+	}
+
+	return &repo{
+		collection: collection,
+		bucket:     bucket,
+		logger: logger.With().
+			Str("repo", "wordlist").
+			Str("type", "mongo").
+			Logger(),
+	}, nil
+}
+
+func (r *repo) GetAll(ctx context.Context, limit, offset int) ([]*entity.Wordlist, error) {
+	r.logger.Debug().
+		Int("limit", limit).
+		Int("offset", offset).
+		Msg("get all")
+
+	opts := options.Find().
+		SetLimit(int64(limit)).
+		SetSkip(int64(offset)).
+		SetSort(bson.M{"createdAt": 1})
+
+	cursor, err := r.collection.Find(ctx, bson.M{}, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find documents: %w", err)
+	}
+	defer func(cursor *mongo.Cursor, ctx context.Context) {
+		if err := cursor.Close(ctx); err != nil {
+			r.logger.Error().Err(err).Msg("failed to close cursor")
+		}
+	}(cursor, ctx)
+
+	var wordlists []*entity.Wordlist
+	if err := cursor.All(ctx, &wordlists); err != nil {
+		return nil, fmt.Errorf("failed to decode documents: %w", err)
+	}
+
+	return wordlists, nil
+}
+
+func (r *repo) CountAll(ctx context.Context) (int64, error) {
+	r.logger.Debug().Msg("count all")
+
+	count, err := r.collection.CountDocuments(ctx, bson.M{})
+	if err != nil {
+		return 0, fmt.Errorf("failed to count documents: %w", err)
+	}
+
+	return count, nil
+}
+
+func (r *repo) Get(ctx context.Context, id primitive.ObjectID) (*entity.Wordlist, error) {
+	r.logger.Debug().Str("id", id.Hex()).Msg("get wordlist")
+
+	result := r.collection.FindOne(ctx, bson.M{"_id": id})
+	if result.Err() != nil {
+		if errors.Is(result.Err(), mongo.ErrNoDocuments) {
+			return nil, repository.ErrWordlistNotFound
+		}
+		return nil, fmt.Errorf("failed to find one document: %w", result.Err())
+	}
+
+	var wordlist entity.Wordlist
+	if err := result.Decode(&wordlist); err != nil {
+		return nil, fmt.Errorf("failed to decode document: %w", err)
+	}
+
+	return &wordlist, nil
+}
+
+func (r *repo) Create(ctx context.Context, wordlist *entity.Wordlist) error {
+	r.logger.Debug().Str("id", wordlist.ObjectID.Hex()).Msg("create wordlist")
+
+	_, err := r.collection.InsertOne(ctx, wordlist)
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return repository.ErrWordlistExists
+		}
+		return fmt.Errorf("failed to insert one document: %w", err)
+	}
+
+	return nil
+}
+
+func (r *repo) Delete(ctx context.Context, id primitive.ObjectID) error {
+	r.logger.Debug().Str("id", id.Hex()).Msg("delete wordlist")
+
+	result, err := r.collection.DeleteOne(ctx, bson.M{"_id": id})
+	if err != nil {
+		return fmt.Errorf("failed to delete one document: %w", err)
+	}
+
+	if result.DeletedCount == 0 {
+		return repository.ErrWordlistNotFound
+	}
+
+	return nil
+}
+
+func (r *repo) UploadContent(_ context.Context, id primitive.ObjectID, name string, content io.Reader) error {
+	r.logger.Debug().Str("id", id.Hex()).Str("name", name).Msg("upload wordlist content")
+
+	if err := r.bucket.UploadFromStreamWithID(id, name, content); err != nil {
+		return fmt.Errorf("failed to upload file: %w", err)
+	}
+
+	return nil
+}
+
+func (r *repo) OpenContent(_ context.Context, id primitive.ObjectID) (io.ReadCloser, error) {
+	r.logger.Debug().Str("id", id.Hex()).Msg("open wordlist content")
+
+	stream, err := r.bucket.OpenDownloadStream(id)
+	if err != nil {
+		if errors.Is(err, gridfs.ErrFileNotFound) {
+			return nil, repository.ErrWordlistNotFound
+		}
+		return nil, fmt.Errorf("failed to open download stream: %w", err)
+	}
+
+	return stream, nil
+}
+
+func (r *repo) DeleteContent(ctx context.Context, id primitive.ObjectID) error {
+	r.logger.Debug().Str("id", id.Hex()).Msg("delete wordlist content")
+
+	if err := r.bucket.DeleteContext(ctx, id); err != nil {
+		if errors.Is(err, gridfs.ErrFileNotFound) {
+			return repository.ErrWordlistNotFound
+		}
+		return fmt.Errorf("failed to delete file: %w", err)
+	}
+
+	return nil
+}
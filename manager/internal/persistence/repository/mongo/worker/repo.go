@@ -0,0 +1,120 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/rs/zerolog"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/mongo/readconcern"
+	"go.mongodb.org/mongo-driver/mongo/writeconcern"
+
+	"github.com/ptrvsrg/crack-hash/manager/config"
+	"github.com/ptrvsrg/crack-hash/manager/internal/persistence/entity"
+	"github.com/ptrvsrg/crack-hash/manager/internal/persistence/repository"
+)
+
+type repo struct {
+	collection *mongo.Collection
+	logger     zerolog.Logger
+}
+
+func NewRepo(logger zerolog.Logger, client *mongo.Client, cfg config.MongoDBConfig) repository.Worker {
+	wc := &writeconcern.WriteConcern{
+		W:       cfg.WriteConcern.W,
+		Journal: cfg.WriteConcern.Journal,
+	}
+	rc := &readconcern.ReadConcern{
+		Level: cfg.ReadConcern.Level,
+	}
+	collection := client.
+		Database(cfg.DB).
+		Collection(
+			"workers",
+			options.
+				Collection().
+				SetReadConcern(rc).
+				SetWriteConcern(wc),
+		)
+
+	return &repo{
+		collection: collection,
+		logger: logger.With().
+			Str("repo", "worker").
+			Str("type", "mongo").
+			Logger(),
+	}
+}
+
+func (r *repo) GetAll(ctx context.Context, limit, offset int) ([]*entity.Worker, error) {
+	r.logger.Debug().
+		Int("limit", limit).
+		Int("offset", offset).
+		Msg("get all")
+
+	opts := options.Find().
+		SetLimit(int64(limit)).
+		SetSkip(int64(offset)).
+		SetSort(bson.M{"registeredAt": 1})
+
+	cursor, err := r.collection.Find(ctx, bson.M{}, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find documents: %w", err)
+	}
+	defer func(cursor *mongo.Cursor, ctx context.Context) {
+		if err := cursor.Close(ctx); err != nil {
+			r.logger.Error().Err(err).Msg("failed to close cursor")
+		}
+	}(cursor, ctx)
+
+	var workers []*entity.Worker
+	if err := cursor.All(ctx, &workers); err != nil {
+		return nil, fmt.Errorf("failed to decode documents: %w", err)
+	}
+
+	return workers, nil
+}
+
+func (r *repo) CountAll(ctx context.Context) (int64, error) {
+	r.logger.Debug().Msg("count all")
+
+	count, err := r.collection.CountDocuments(ctx, bson.M{})
+	if err != nil {
+		return 0, fmt.Errorf("failed to count documents: %w", err)
+	}
+
+	return count, nil
+}
+
+func (r *repo) Get(ctx context.Context, id string) (*entity.Worker, error) {
+	r.logger.Debug().Str("id", id).Msg("get worker")
+
+	result := r.collection.FindOne(ctx, bson.M{"_id": id})
+	if result.Err() != nil {
+		if errors.Is(result.Err(), mongo.ErrNoDocuments) {
+			return nil, repository.ErrWorkerNotFound
+		}
+		return nil, fmt.Errorf("failed to find one document: %w", result.Err())
+	}
+
+	var worker entity.Worker
+	if err := result.Decode(&worker); err != nil {
+		return nil, fmt.Errorf("failed to decode document: %w", err)
+	}
+
+	return &worker, nil
+}
+
+func (r *repo) Save(ctx context.Context, worker *entity.Worker) error {
+	r.logger.Debug().Str("id", worker.ID).Msg("save worker")
+
+	_, err := r.collection.ReplaceOne(ctx, bson.M{"_id": worker.ID}, worker, options.Replace().SetUpsert(true))
+	if err != nil {
+		return fmt.Errorf("failed to replace one document: %w", err)
+	}
+
+	return nil
+}
@@ -3,6 +3,7 @@ package repository
 import (
 	"context"
 	"errors"
+	"io"
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson/primitive"
@@ -15,6 +16,13 @@ var (
 	ErrCrackTaskExists      = errors.New("crack task already exists")
 	ErrCrackSubtaskNotFound = errors.New("crack subtask not found")
 	ErrCrackSubtaskExists   = errors.New("crack subtask already exists")
+	ErrWordlistNotFound     = errors.New("wordlist not found")
+	ErrWordlistExists       = errors.New("wordlist already exists")
+	ErrRuleSetNotFound      = errors.New("rule set not found")
+	ErrRuleSetExists        = errors.New("rule set already exists")
+	ErrWorkerNotFound       = errors.New("worker not found")
+	ErrDeadLetterNotFound   = errors.New("dead letter not found")
+	ErrOutboxMessageLocked  = errors.New("outbox message is locked or deleted")
 )
 
 type Transactor interface {
@@ -26,15 +34,24 @@ type HashCrackTask interface {
 
 	GetAll(ctx context.Context, limit, offset int, withSubtasks bool) ([]*entity.HashCrackTaskWithSubtasks, error)
 	CountAll(ctx context.Context) (int64, error)
+	CountAllByStatuses(ctx context.Context, statuses []entity.HashCrackTaskStatus) (int64, error)
+	CountAllByWordlistAndStatuses(
+		ctx context.Context, wordlistID primitive.ObjectID, statuses []entity.HashCrackTaskStatus,
+	) (int64, error)
 	GetAllFinished(ctx context.Context, withSubtasks bool) ([]*entity.HashCrackTaskWithSubtasks, error)
 	GetAllExpired(
 		ctx context.Context, maxAge time.Duration, withSubtasks bool,
 	) ([]*entity.HashCrackTaskWithSubtasks, error)
 	Get(ctx context.Context, id primitive.ObjectID, withSubtasks bool) (*entity.HashCrackTaskWithSubtasks, error)
-	GetByHashAndMaxLength(
-		ctx context.Context, hash string, maxLength int, withSubtasks bool,
-	) (*entity.HashCrackTaskWithSubtasks, error)
+	GetSame(ctx context.Context, task *entity.HashCrackTask, withSubtasks bool) (*entity.HashCrackTaskWithSubtasks, error)
+	// ExtendDeadline moves the deadline of the pending or in progress task by the timeout and returns it, the task
+	// without deadline is extended from now. ErrCrackTaskNotFound is returned, if there is no such unfinished task
+	ExtendDeadline(ctx context.Context, id primitive.ObjectID, timeout time.Duration) (time.Time, error)
+	// LockCreation serializes transactions creating tasks, the concurrent transaction fails with a write conflict
+	// and is retried, so the active tasks counted in the transaction are not changed by another one
+	LockCreation(ctx context.Context) error
 	Create(ctx context.Context, task *entity.HashCrackTask) error
+	// Update saves the task except its deadline, which is moved by ExtendDeadline only
 	Update(ctx context.Context, task *entity.HashCrackTask) error
 	DeleteAllByIDs(ctx context.Context, ids []primitive.ObjectID) error
 }
@@ -48,6 +65,12 @@ type HashCrackSubtask interface {
 	GetAllByTaskID(ctx context.Context, taskID primitive.ObjectID) ([]*entity.HashCrackSubtask, error)
 	GetAllByTaskIDs(ctx context.Context, taskIDs []primitive.ObjectID) ([]*entity.HashCrackSubtask, error)
 	GetAllByStatus(ctx context.Context, status entity.HashCrackSubtaskStatus) ([]*entity.HashCrackSubtask, error)
+	CountAllByStatus(ctx context.Context, status entity.HashCrackSubtaskStatus) (int64, error)
+	GetAllLeaseExpired(ctx context.Context) ([]*entity.HashCrackSubtask, error)
+	GetAllStartedBefore(ctx context.Context, before time.Time) ([]*entity.HashCrackSubtask, error)
+	// GetAverageSpeed returns the average speed of workers reported by subtasks of tasks with the hash algorithm
+	// updated since the time, it returns 0 if no speed is reported
+	GetAverageSpeed(ctx context.Context, algorithm string, since time.Time) (float64, error)
 	Create(ctx context.Context, task *entity.HashCrackSubtask) error
 	CreateAll(ctx context.Context, tasks []*entity.HashCrackSubtask) error
 	Update(ctx context.Context, task *entity.HashCrackSubtask) error
@@ -55,7 +78,58 @@ type HashCrackSubtask interface {
 	DeleteAllByIDs(ctx context.Context, ids []primitive.ObjectID) error
 }
 
+type Wordlist interface {
+	GetAll(ctx context.Context, limit, offset int) ([]*entity.Wordlist, error)
+	CountAll(ctx context.Context) (int64, error)
+	Get(ctx context.Context, id primitive.ObjectID) (*entity.Wordlist, error)
+	Create(ctx context.Context, wordlist *entity.Wordlist) error
+	Delete(ctx context.Context, id primitive.ObjectID) error
+	UploadContent(ctx context.Context, id primitive.ObjectID, name string, content io.Reader) error
+	OpenContent(ctx context.Context, id primitive.ObjectID) (io.ReadCloser, error)
+	DeleteContent(ctx context.Context, id primitive.ObjectID) error
+}
+
+type RuleSet interface {
+	GetAll(ctx context.Context, limit, offset int) ([]*entity.RuleSet, error)
+	CountAll(ctx context.Context) (int64, error)
+	Get(ctx context.Context, id primitive.ObjectID) (*entity.RuleSet, error)
+	Create(ctx context.Context, ruleSet *entity.RuleSet) error
+	Delete(ctx context.Context, id primitive.ObjectID) error
+}
+
+type Worker interface {
+	GetAll(ctx context.Context, limit, offset int) ([]*entity.Worker, error)
+	CountAll(ctx context.Context) (int64, error)
+	Get(ctx context.Context, id string) (*entity.Worker, error)
+	// Save creates the worker or replaces the worker with the same ID
+	Save(ctx context.Context, worker *entity.Worker) error
+}
+
+type DeadLetter interface {
+	GetAll(ctx context.Context, limit, offset int) ([]*entity.DeadLetter, error)
+	CountAll(ctx context.Context) (int64, error)
+	Get(ctx context.Context, id primitive.ObjectID) (*entity.DeadLetter, error)
+	Create(ctx context.Context, deadLetter *entity.DeadLetter) error
+	Delete(ctx context.Context, id primitive.ObjectID) error
+}
+
+type OutboxMessage interface {
+	// GetAllUnlocked returns the oldest messages, which are not locked by relays
+	GetAllUnlocked(ctx context.Context, limit int) ([]*entity.OutboxMessage, error)
+	CreateAll(ctx context.Context, messages []*entity.OutboxMessage) error
+	// Lock locks the unlocked message until the time, it returns ErrOutboxMessageLocked if the message is locked
+	// by another relay or deleted
+	Lock(ctx context.Context, id primitive.ObjectID, until time.Time) error
+	Update(ctx context.Context, message *entity.OutboxMessage) error
+	Delete(ctx context.Context, id primitive.ObjectID) error
+}
+
 type Repositories struct {
 	HashCrackTask    HashCrackTask
 	HashCrackSubtask HashCrackSubtask
+	Wordlist         Wordlist
+	RuleSet          RuleSet
+	Worker           Worker
+	DeadLetter       DeadLetter
+	OutboxMessage    OutboxMessage
 }
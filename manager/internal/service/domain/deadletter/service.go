@@ -0,0 +1,168 @@
+package deadletter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/rs/zerolog"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/ptrvsrg/crack-hash/commonlib/bus/amqp/deadletter"
+	"github.com/ptrvsrg/crack-hash/manager/internal/persistence/entity"
+	"github.com/ptrvsrg/crack-hash/manager/internal/persistence/repository"
+	"github.com/ptrvsrg/crack-hash/manager/internal/service/domain"
+	"github.com/ptrvsrg/crack-hash/manager/pkg/model"
+)
+
+type svc struct {
+	logger   zerolog.Logger
+	repo     repository.DeadLetter
+	replayer deadletter.Replayer
+}
+
+func NewService(logger zerolog.Logger, repo repository.DeadLetter, replayer deadletter.Replayer) domain.DeadLetter {
+	return &svc{
+		logger: logger.With().
+			Str("type", "domain").
+			Str("service", "dead-letter").
+			Logger(),
+		repo:     repo,
+		replayer: replayer,
+	}
+}
+
+func (s *svc) SaveDeadLetter(ctx context.Context, input *deadletter.Message) error {
+	s.logger.Info().
+		Str("queue", input.Queue).
+		Str("exchange", input.Exchange).
+		Int("retries", input.Retries).
+		Msg("save dead letter")
+
+	if err := s.repo.Create(ctx, buildDeadLetterEntity(input)); err != nil {
+		s.logger.Error().Err(err).Stack().Msg("failed to create dead letter")
+		return fmt.Errorf("failed to create dead letter: %w", err)
+	}
+
+	return nil
+}
+
+func (s *svc) GetDeadLetters(ctx context.Context, limit, offset int) (*model.DeadLettersOutput, error) {
+	s.logger.Info().Int("limit", limit).Int("offset", offset).Msg("get dead letters")
+
+	// Get dead letters and count
+	var (
+		deadLetters []*entity.DeadLetter
+		count       int64
+	)
+	group, ctx := errgroup.WithContext(ctx)
+
+	group.Go(
+		func() error {
+			var err error
+			deadLetters, err = s.repo.GetAll(ctx, limit, offset)
+			if err != nil {
+				return fmt.Errorf("failed to get dead letters: %w", err)
+			}
+			return nil
+		},
+	)
+
+	group.Go(
+		func() error {
+			var err error
+			count, err = s.repo.CountAll(ctx)
+			if err != nil {
+				return fmt.Errorf("failed to count dead letters: %w", err)
+			}
+			return nil
+		},
+	)
+
+	if err := group.Wait(); err != nil {
+		s.logger.Error().Err(err).Stack().Msg("failed to get dead letters and count")
+		return nil, fmt.Errorf("failed to get dead letters and count: %w", err)
+	}
+
+	// Convert dead letters
+	return buildDeadLetterOutputs(count, deadLetters), nil
+}
+
+func (s *svc) GetDeadLetter(ctx context.Context, id string) (*model.DeadLetterOutput, error) {
+	s.logger.Info().Str("id", id).Msg("get dead letter")
+
+	deadLetter, err := s.getDeadLetter(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return buildDeadLetterOutput(deadLetter), nil
+}
+
+func (s *svc) ReplayDeadLetter(ctx context.Context, id string) error {
+	s.logger.Info().Str("id", id).Msg("replay dead letter")
+
+	deadLetter, err := s.getDeadLetter(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	// Replay before deletion, the message is replayed again rather than lost if deletion fails
+	if err := s.replayer.Replay(ctx, buildDeadLetterMessage(deadLetter)); err != nil {
+		s.logger.Error().Err(err).Stack().Msg("failed to replay dead letter")
+		return fmt.Errorf("failed to replay dead letter: %w", err)
+	}
+
+	if err := s.repo.Delete(ctx, deadLetter.ObjectID); err != nil {
+		s.logger.Error().Err(err).Stack().Msg("failed to delete dead letter")
+		return fmt.Errorf("failed to delete dead letter: %w", err)
+	}
+
+	return nil
+}
+
+func (s *svc) DeleteDeadLetter(ctx context.Context, id string) error {
+	s.logger.Info().Str("id", id).Msg("delete dead letter")
+
+	// Validate ID
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		s.logger.Error().Err(err).Stack().Msg("failed to validate ID")
+		return domain.ErrInvalidDeadLetterID
+	}
+
+	// Delete dead letter
+	if err := s.repo.Delete(ctx, objID); err != nil {
+		s.logger.Error().Err(err).Stack().Msg("failed to delete dead letter")
+
+		if errors.Is(err, repository.ErrDeadLetterNotFound) {
+			return domain.ErrDeadLetterNotFound
+		}
+		return fmt.Errorf("failed to delete dead letter: %w", err)
+	}
+
+	return nil
+}
+
+func (s *svc) getDeadLetter(ctx context.Context, id string) (*entity.DeadLetter, error) {
+	// Validate ID
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		s.logger.Error().Err(err).Stack().Msg("failed to validate ID")
+		return nil, domain.ErrInvalidDeadLetterID
+	}
+
+	// Get dead letter
+	deadLetter, err := s.repo.Get(ctx, objID)
+	if err != nil {
+		s.logger.Error().Err(err).Stack().Msg("failed to get dead letter")
+
+		if errors.Is(err, repository.ErrDeadLetterNotFound) {
+			return nil, domain.ErrDeadLetterNotFound
+		}
+		return nil, fmt.Errorf("failed to get dead letter: %w", err)
+	}
+
+	return deadLetter, nil
+}
@@ -0,0 +1,300 @@
+package deadletter_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	commondeadletter "github.com/ptrvsrg/crack-hash/commonlib/bus/amqp/deadletter"
+	dlmock "github.com/ptrvsrg/crack-hash/commonlib/bus/amqp/deadletter/mock"
+	"github.com/ptrvsrg/crack-hash/commonlib/logging"
+	"github.com/ptrvsrg/crack-hash/manager/internal/persistence/entity"
+	"github.com/ptrvsrg/crack-hash/manager/internal/persistence/repository"
+	repomock "github.com/ptrvsrg/crack-hash/manager/internal/persistence/repository/mock"
+	"github.com/ptrvsrg/crack-hash/manager/internal/service/domain"
+	"github.com/ptrvsrg/crack-hash/manager/internal/service/domain/deadletter"
+)
+
+func init() {
+	logging.Setup(true)
+}
+
+var (
+	mockRepo     *repomock.DeadLetterMock
+	mockReplayer *dlmock.ReplayerMock
+	service      domain.DeadLetter
+
+	ctx = context.Background()
+)
+
+func TestMain(m *testing.M) {
+	mockRepo = new(repomock.DeadLetterMock)
+	mockReplayer = new(dlmock.ReplayerMock)
+	service = deadletter.NewService(log.Logger, mockRepo, mockReplayer)
+
+	m.Run()
+}
+
+func newDeadLetter() *entity.DeadLetter {
+	return &entity.DeadLetter{
+		ObjectID:       primitive.NewObjectID(),
+		Queue:          "queue.task.result",
+		Error:          "failed to save result task: connection refused",
+		Retries:        3,
+		ContentType:    "application/json",
+		Priority:       2,
+		Body:           []byte(`{"requestId":"id"}`),
+		DeadLetteredAt: time.Now(),
+	}
+}
+
+func Test_SaveDeadLetter(t *testing.T) {
+	t.Run(
+		"Success", func(t *testing.T) {
+			// Arrange
+			input := &commondeadletter.Message{
+				Queue:          "queue.task.result",
+				Error:          "failed to save result task: connection refused",
+				Retries:        3,
+				ContentType:    "application/json",
+				Body:           []byte(`{"requestId":"id"}`),
+				DeadLetteredAt: time.Now(),
+			}
+
+			mockRepo.On("Create", ctx, mock.Anything).Run(
+				func(args mock.Arguments) {
+					dl, ok := args.Get(1).(*entity.DeadLetter)
+					assert.True(t, ok)
+					assert.False(t, dl.ObjectID.IsZero())
+					assert.Equal(t, input.Queue, dl.Queue)
+					assert.Equal(t, input.Error, dl.Error)
+					assert.Equal(t, input.Retries, dl.Retries)
+					assert.Equal(t, input.Body, dl.Body)
+				},
+			).Return(nil).Once()
+
+			// Act
+			err := service.SaveDeadLetter(ctx, input)
+
+			// Assert
+			require.NoError(t, err)
+			mockRepo.AssertExpectations(t)
+		},
+	)
+
+	t.Run(
+		"Repository error", func(t *testing.T) {
+			// Arrange
+			mockRepo.On("Create", ctx, mock.Anything).Return(errors.New("test")).Once()
+
+			// Act
+			err := service.SaveDeadLetter(ctx, &commondeadletter.Message{})
+
+			// Assert
+			require.Error(t, err)
+			mockRepo.AssertExpectations(t)
+		},
+	)
+}
+
+func Test_GetDeadLetters(t *testing.T) {
+	t.Run(
+		"Success", func(t *testing.T) {
+			// Arrange
+			deadLetter := newDeadLetter()
+
+			mockRepo.On("GetAll", mock.Anything, 10, 0).Return([]*entity.DeadLetter{deadLetter}, nil).Once()
+			mockRepo.On("CountAll", mock.Anything).Return(int64(1), nil).Once()
+
+			// Act
+			output, err := service.GetDeadLetters(ctx, 10, 0)
+
+			// Assert
+			require.NoError(t, err)
+			require.Equal(t, int64(1), output.Count)
+			require.Len(t, output.DeadLetters, 1)
+			assert.Equal(t, deadLetter.ObjectID.Hex(), output.DeadLetters[0].ID)
+			assert.Equal(t, `{"requestId":"id"}`, output.DeadLetters[0].Body)
+			mockRepo.AssertExpectations(t)
+		},
+	)
+
+	t.Run(
+		"Repository error", func(t *testing.T) {
+			// Arrange
+			mockRepo.On("GetAll", mock.Anything, 10, 0).Return(nil, errors.New("test")).Once()
+			mockRepo.On("CountAll", mock.Anything).Return(int64(0), nil).Maybe()
+
+			// Act
+			output, err := service.GetDeadLetters(ctx, 10, 0)
+
+			// Assert
+			require.Error(t, err)
+			require.Nil(t, output)
+		},
+	)
+}
+
+func Test_GetDeadLetter(t *testing.T) {
+	t.Run(
+		"Success", func(t *testing.T) {
+			// Arrange
+			deadLetter := newDeadLetter()
+
+			mockRepo.On("Get", ctx, deadLetter.ObjectID).Return(deadLetter, nil).Once()
+
+			// Act
+			output, err := service.GetDeadLetter(ctx, deadLetter.ObjectID.Hex())
+
+			// Assert
+			require.NoError(t, err)
+			assert.Equal(t, deadLetter.Queue, output.Queue)
+			assert.Equal(t, deadLetter.Error, output.Error)
+			assert.Equal(t, deadLetter.Retries, output.Retries)
+			mockRepo.AssertExpectations(t)
+		},
+	)
+
+	t.Run(
+		"Invalid ID", func(t *testing.T) {
+			// Act
+			output, err := service.GetDeadLetter(ctx, "invalid")
+
+			// Assert
+			require.ErrorIs(t, err, domain.ErrInvalidDeadLetterID)
+			require.Nil(t, output)
+		},
+	)
+
+	t.Run(
+		"Not found", func(t *testing.T) {
+			// Arrange
+			id := primitive.NewObjectID()
+
+			mockRepo.On("Get", ctx, id).Return(nil, repository.ErrDeadLetterNotFound).Once()
+
+			// Act
+			output, err := service.GetDeadLetter(ctx, id.Hex())
+
+			// Assert
+			require.ErrorIs(t, err, domain.ErrDeadLetterNotFound)
+			require.Nil(t, output)
+			mockRepo.AssertExpectations(t)
+		},
+	)
+}
+
+func Test_ReplayDeadLetter(t *testing.T) {
+	t.Run(
+		"Success", func(t *testing.T) {
+			// Arrange
+			deadLetter := newDeadLetter()
+
+			mockRepo.On("Get", ctx, deadLetter.ObjectID).Return(deadLetter, nil).Once()
+			mockReplayer.On("Replay", ctx, mock.Anything).Run(
+				func(args mock.Arguments) {
+					msg, ok := args.Get(1).(*commondeadletter.Message)
+					assert.True(t, ok)
+					assert.Equal(t, deadLetter.Queue, msg.Queue)
+					assert.Equal(t, deadLetter.ContentType, msg.ContentType)
+					assert.Equal(t, uint8(2), msg.Priority)
+					assert.Equal(t, deadLetter.Body, msg.Body)
+				},
+			).Return(nil).Once()
+			mockRepo.On("Delete", ctx, deadLetter.ObjectID).Return(nil).Once()
+
+			// Act
+			err := service.ReplayDeadLetter(ctx, deadLetter.ObjectID.Hex())
+
+			// Assert
+			require.NoError(t, err)
+			mockRepo.AssertExpectations(t)
+			mockReplayer.AssertExpectations(t)
+		},
+	)
+
+	t.Run(
+		"Replay error keeps dead letter", func(t *testing.T) {
+			// Arrange
+			deadLetter := newDeadLetter()
+
+			mockRepo.On("Get", ctx, deadLetter.ObjectID).Return(deadLetter, nil).Once()
+			mockReplayer.On("Replay", ctx, mock.Anything).Return(errors.New("test")).Once()
+
+			// Act
+			err := service.ReplayDeadLetter(ctx, deadLetter.ObjectID.Hex())
+
+			// Assert
+			require.Error(t, err)
+			mockRepo.AssertNotCalled(t, "Delete", ctx, deadLetter.ObjectID)
+			mockReplayer.AssertExpectations(t)
+		},
+	)
+
+	t.Run(
+		"Not found", func(t *testing.T) {
+			// Arrange
+			id := primitive.NewObjectID()
+
+			mockRepo.On("Get", ctx, id).Return(nil, repository.ErrDeadLetterNotFound).Once()
+
+			// Act
+			err := service.ReplayDeadLetter(ctx, id.Hex())
+
+			// Assert
+			require.ErrorIs(t, err, domain.ErrDeadLetterNotFound)
+			mockRepo.AssertExpectations(t)
+		},
+	)
+}
+
+func Test_DeleteDeadLetter(t *testing.T) {
+	t.Run(
+		"Success", func(t *testing.T) {
+			// Arrange
+			id := primitive.NewObjectID()
+
+			mockRepo.On("Delete", ctx, id).Return(nil).Once()
+
+			// Act
+			err := service.DeleteDeadLetter(ctx, id.Hex())
+
+			// Assert
+			require.NoError(t, err)
+			mockRepo.AssertExpectations(t)
+		},
+	)
+
+	t.Run(
+		"Invalid ID", func(t *testing.T) {
+			// Act
+			err := service.DeleteDeadLetter(ctx, "invalid")
+
+			// Assert
+			require.ErrorIs(t, err, domain.ErrInvalidDeadLetterID)
+		},
+	)
+
+	t.Run(
+		"Not found", func(t *testing.T) {
+			// Arrange
+			id := primitive.NewObjectID()
+
+			mockRepo.On("Delete", ctx, id).Return(repository.ErrDeadLetterNotFound).Once()
+
+			// Act
+			err := service.DeleteDeadLetter(ctx, id.Hex())
+
+			// Assert
+			require.ErrorIs(t, err, domain.ErrDeadLetterNotFound)
+			mockRepo.AssertExpectations(t)
+		},
+	)
+}
@@ -0,0 +1,61 @@
+package deadletter
+
+import (
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/ptrvsrg/crack-hash/commonlib/bus/amqp/deadletter"
+	"github.com/ptrvsrg/crack-hash/manager/internal/persistence/entity"
+	"github.com/ptrvsrg/crack-hash/manager/pkg/model"
+)
+
+func buildDeadLetterEntity(input *deadletter.Message) *entity.DeadLetter {
+	return &entity.DeadLetter{
+		ObjectID:       primitive.NewObjectID(),
+		Queue:          input.Queue,
+		Exchange:       input.Exchange,
+		Error:          input.Error,
+		Retries:        input.Retries,
+		ContentType:    input.ContentType,
+		Priority:       int(input.Priority),
+		Body:           input.Body,
+		DeadLetteredAt: input.DeadLetteredAt,
+	}
+}
+
+func buildDeadLetterMessage(deadLetter *entity.DeadLetter) *deadletter.Message {
+	return &deadletter.Message{
+		Queue:          deadLetter.Queue,
+		Exchange:       deadLetter.Exchange,
+		Error:          deadLetter.Error,
+		Retries:        deadLetter.Retries,
+		ContentType:    deadLetter.ContentType,
+		Priority:       uint8(deadLetter.Priority),
+		Body:           deadLetter.Body,
+		DeadLetteredAt: deadLetter.DeadLetteredAt,
+	}
+}
+
+func buildDeadLetterOutput(deadLetter *entity.DeadLetter) *model.DeadLetterOutput {
+	return &model.DeadLetterOutput{
+		ID:             deadLetter.ObjectID.Hex(),
+		Queue:          deadLetter.Queue,
+		Exchange:       deadLetter.Exchange,
+		Error:          deadLetter.Error,
+		Retries:        deadLetter.Retries,
+		ContentType:    deadLetter.ContentType,
+		Body:           string(deadLetter.Body),
+		DeadLetteredAt: deadLetter.DeadLetteredAt,
+	}
+}
+
+func buildDeadLetterOutputs(count int64, deadLetters []*entity.DeadLetter) *model.DeadLettersOutput {
+	data := make([]*model.DeadLetterOutput, len(deadLetters))
+	for i, deadLetter := range deadLetters {
+		data[i] = buildDeadLetterOutput(deadLetter)
+	}
+
+	return &model.DeadLettersOutput{
+		Count:       count,
+		DeadLetters: data,
+	}
+}
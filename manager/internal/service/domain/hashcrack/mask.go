@@ -0,0 +1,128 @@
+package hashcrack
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/ptrvsrg/crack-hash/manager/internal/service/domain"
+)
+
+// maxCustomCharsets is the number of custom charsets of a mask, they are referenced as ?1 to ?4
+const maxCustomCharsets = 4
+
+// builtinCharsets maps the built-in charsets of masks to their characters, like in hashcat
+var builtinCharsets = map[rune]string{
+	'l': "abcdefghijklmnopqrstuvwxyz",
+	'u': "ABCDEFGHIJKLMNOPQRSTUVWXYZ",
+	'd': "0123456789",
+	'h': "0123456789abcdef",
+	'H': "0123456789ABCDEF",
+	's': " !\"#$%&'()*+,-./:;<=>?@[\\]^_`{|}~",
+	'a': "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789 !\"#$%&'()*+,-./:;<=>?@[\\]^_`{|}~",
+}
+
+// expandMask expands the mask to the charsets of its positions. A position is either a literal character,
+// a built-in charset (?l, ?u, ?d, ?h, ?H, ?s, ?a), a custom charset (?1 to ?4) or an escaped question mark (??).
+func expandMask(pattern string, charsets []string) ([]string, error) {
+	if len(charsets) > maxCustomCharsets {
+		return nil, fmt.Errorf(
+			"%w: %d custom charsets, limit is %d", domain.ErrInvalidMask, len(charsets), maxCustomCharsets,
+		)
+	}
+
+	// Expand custom charsets, they may contain built-in ones
+	custom := make([]string, len(charsets))
+	for i, charset := range charsets {
+		expanded, err := expandCharset(charset)
+		if err != nil {
+			return nil, fmt.Errorf("%w: custom charset %d: %w", domain.ErrInvalidMask, i+1, err)
+		}
+		custom[i] = expanded
+	}
+
+	// Expand mask positions
+	positions := make([]string, 0, len(pattern))
+	symbols := []rune(pattern)
+	for i := 0; i < len(symbols); i++ {
+		if symbols[i] != '?' {
+			positions = append(positions, string(symbols[i]))
+			continue
+		}
+
+		i++
+		if i == len(symbols) {
+			return nil, fmt.Errorf("%w: mask ends with '?'", domain.ErrInvalidMask)
+		}
+
+		switch key := symbols[i]; {
+		case key == '?':
+			positions = append(positions, "?")
+		case key >= '1' && key <= '0'+maxCustomCharsets:
+			n := int(key - '1')
+			if n >= len(custom) {
+				return nil, fmt.Errorf("%w: custom charset ?%c is not defined", domain.ErrInvalidMask, key)
+			}
+			positions = append(positions, custom[n])
+		default:
+			charset, ok := builtinCharsets[key]
+			if !ok {
+				return nil, fmt.Errorf("%w: unknown charset ?%c", domain.ErrInvalidMask, key)
+			}
+			positions = append(positions, charset)
+		}
+	}
+
+	if len(positions) == 0 {
+		return nil, fmt.Errorf("%w: mask is empty", domain.ErrInvalidMask)
+	}
+
+	return positions, nil
+}
+
+// expandCharset expands the built-in charsets of the custom charset and drops duplicate characters
+func expandCharset(charset string) (string, error) {
+	var (
+		expanded strings.Builder
+		seen     []rune
+	)
+	add := func(chars string) {
+		for _, c := range chars {
+			if !slices.Contains(seen, c) {
+				seen = append(seen, c)
+				expanded.WriteRune(c)
+			}
+		}
+	}
+
+	symbols := []rune(charset)
+	for i := 0; i < len(symbols); i++ {
+		if symbols[i] != '?' {
+			add(string(symbols[i]))
+			continue
+		}
+
+		i++
+		if i == len(symbols) {
+			return "", errors.New("charset ends with '?'")
+		}
+
+		if symbols[i] == '?' {
+			add("?")
+			continue
+		}
+
+		builtin, ok := builtinCharsets[symbols[i]]
+		if !ok {
+			return "", fmt.Errorf("unknown charset ?%c", symbols[i])
+		}
+		add(builtin)
+	}
+
+	if expanded.Len() == 0 {
+		return "", errors.New("charset is empty")
+	}
+
+	return expanded.String(), nil
+}
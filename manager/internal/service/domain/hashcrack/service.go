@@ -4,6 +4,8 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
+	"unicode/utf8"
 
 	"github.com/rs/zerolog"
 	"github.com/samber/lo"
@@ -26,9 +28,29 @@ type svc struct {
 	cfg                 config.TaskConfig
 	taskRepo            repository.HashCrackTask
 	subtaskRepo         repository.HashCrackSubtask
+	outboxRepo          repository.OutboxMessage
+	wordlistRepo        repository.Wordlist
+	ruleSetRepo         repository.RuleSet
 	splitSvc            infrastructure.TaskSplit
+	wordlistSplitSvc    infrastructure.WordlistSplit
 	taskWithSubtasksSvc infrastructure.TaskWithSubtasks
+	taskQueueSvc        infrastructure.TaskQueue
 	publisher           publisher.Publisher[message.HashCrackTaskStarted]
+	cancelPublisher     publisher.Publisher[message.HashCrackTaskCancelled]
+	shrinkPublisher     publisher.Publisher[message.HashCrackTaskShrunk]
+}
+
+// savedResult is the outcome of saving the result of a subtask
+type savedResult struct {
+	stopped  bool
+	messages []*entity.OutboxMessage
+}
+
+// splitResult is the outcome of splitting a subtask, the range of the subtask is shrunk and the rest is split off
+type splitResult struct {
+	task     *entity.HashCrackTaskWithSubtasks
+	subtask  *entity.HashCrackSubtask
+	messages []*entity.OutboxMessage
 }
 
 func NewService(
@@ -36,9 +58,16 @@ func NewService(
 	cfg config.TaskConfig,
 	taskRepo repository.HashCrackTask,
 	subtaskRepo repository.HashCrackSubtask,
+	outboxRepo repository.OutboxMessage,
+	wordlistRepo repository.Wordlist,
+	ruleSetRepo repository.RuleSet,
 	splitSvc infrastructure.TaskSplit,
+	wordlistSplitSvc infrastructure.WordlistSplit,
 	taskWithSubtasksSvc infrastructure.TaskWithSubtasks,
+	taskQueueSvc infrastructure.TaskQueue,
 	publisher publisher.Publisher[message.HashCrackTaskStarted],
+	cancelPublisher publisher.Publisher[message.HashCrackTaskCancelled],
+	shrinkPublisher publisher.Publisher[message.HashCrackTaskShrunk],
 ) domain.HashCrackTask {
 
 	return &svc{
@@ -49,48 +78,71 @@ func NewService(
 		cfg:                 cfg,
 		taskRepo:            taskRepo,
 		subtaskRepo:         subtaskRepo,
+		outboxRepo:          outboxRepo,
+		wordlistRepo:        wordlistRepo,
+		ruleSetRepo:         ruleSetRepo,
 		splitSvc:            splitSvc,
+		wordlistSplitSvc:    wordlistSplitSvc,
 		taskWithSubtasksSvc: taskWithSubtasksSvc,
+		taskQueueSvc:        taskQueueSvc,
 		publisher:           publisher,
+		cancelPublisher:     cancelPublisher,
+		shrinkPublisher:     shrinkPublisher,
 	}
 }
 
 func (s *svc) CreateTask(ctx context.Context, input *model.HashCrackTaskInput) (*model.HashCrackTaskIDOutput, error) {
 	s.logger.Info().
+		Str("algorithm", input.Algorithm).
 		Str("hash", input.Hash).
+		Str("mode", input.Mode).
+		Str("alphabet", input.Alphabet).
+		Int("min_length", input.MinLength).
 		Int("max_length", input.MaxLength).
 		Msg("create task")
 
-	// Get same tasks
-	sameTask, err := s.taskRepo.GetByHashAndMaxLength(ctx, input.Hash, input.MaxLength, false)
-	if err != nil && !errors.Is(err, repository.ErrCrackTaskNotFound) {
-		s.logger.Warn().Err(err).Msg("failed to get same tasks")
-	}
-
-	if sameTask != nil {
-		s.logger.Info().Msg("same task already exists")
-		return buildTaskIDOutput(sameTask.ToHashCrackTask()), nil
+	// Validate input
+	normalizeTaskInput(input, s.cfg.Alphabet)
+	if err := validateTaskInput(input); err != nil {
+		s.logger.Error().Err(err).Msg("failed to validate input")
+		return nil, err
 	}
 
-	// Split task
-	partCount, err := s.splitSvc.Split(ctx, input.MaxLength, len(s.cfg.Alphabet))
+	timeout, err := parseTimeout(input.Timeout, s.cfg.Timeout, s.cfg.MaxAge)
 	if err != nil {
-		s.logger.Error().Err(err).Stack().Msg("failed to split task")
-		return nil, fmt.Errorf("failed to split task: %w", err)
+		s.logger.Error().Err(err).Msg("failed to validate input")
+		return nil, err
 	}
 
-	// Create and save task with subtasks
-	task := buildTaskEntityWithSubtasks(input, partCount)
-	if err := s.taskWithSubtasksSvc.CreateTaskWithSubtasks(ctx, task); err != nil {
-		return nil, fmt.Errorf("failed to create task with subtasks: %w", err)
+	return s.createTask(ctx, buildTaskEntity(input, timeout))
+}
+
+func (s *svc) CreateBatchTask(
+	ctx context.Context, input *model.HashCrackBatchTaskInput,
+) (*model.HashCrackTaskIDOutput, error) {
+	s.logger.Info().
+		Str("algorithm", input.Algorithm).
+		Int("hash_count", len(input.Hashes)).
+		Str("mode", input.Mode).
+		Str("alphabet", input.Alphabet).
+		Int("min_length", input.MinLength).
+		Int("max_length", input.MaxLength).
+		Msg("create batch task")
+
+	// Validate input
+	normalizeBatchTaskInput(input, s.cfg.Alphabet)
+	if err := validateBatchTaskInput(input, s.cfg.BatchLimit); err != nil {
+		s.logger.Error().Err(err).Msg("failed to validate input")
+		return nil, err
 	}
 
-	// Start execute tasks
-	go func() {
-		_ = s.startExecuteTask(ctx, task)
-	}()
+	timeout, err := parseTimeout(input.Timeout, s.cfg.Timeout, s.cfg.MaxAge)
+	if err != nil {
+		s.logger.Error().Err(err).Msg("failed to validate input")
+		return nil, err
+	}
 
-	return buildTaskIDOutput(task.ToHashCrackTask()), nil
+	return s.createTask(ctx, buildBatchTaskEntity(input, timeout))
 }
 
 func (s *svc) GetTaskMetadatas(
@@ -162,6 +214,152 @@ func (s *svc) GetTaskStatus(ctx context.Context, id string) (*model.HashCrackTas
 	return buildTaskStatusOutput(task), nil
 }
 
+func (s *svc) GetBatchTaskStatus(ctx context.Context, id string) (*model.HashCrackBatchTaskStatusOutput, error) {
+	s.logger.Info().Str("id", id).Msg("get batch task status")
+
+	// Validate ID
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		s.logger.Error().Err(err).Stack().Msg("failed to validate ID")
+		return nil, domain.ErrInvalidRequestID
+	}
+
+	// Get task
+	task, err := s.taskRepo.Get(ctx, objID, true)
+	if err != nil {
+		s.logger.Error().Err(err).Stack().Msg("failed to get task")
+
+		if errors.Is(err, repository.ErrCrackTaskNotFound) {
+			return nil, domain.ErrTaskNotFound
+		}
+		return nil, fmt.Errorf("failed to get task: %w", err)
+	}
+
+	// Convert task
+	return buildBatchTaskStatusOutput(task), nil
+}
+
+func (s *svc) CancelTask(ctx context.Context, id string) error {
+	s.logger.Info().Str("id", id).Msg("cancel task")
+
+	// Validate ID
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		s.logger.Error().Err(err).Stack().Msg("failed to validate ID")
+		return domain.ErrInvalidRequestID
+	}
+
+	// Mark task and unfinished subtasks as CANCELLED, the task is read in the transaction, so a concurrent
+	// result or timeout can't finish it in the meantime
+	res, err := s.taskRepo.WithTransaction(
+		ctx, func(ctx context.Context) (any, error) {
+			// Get task
+			task, err := s.taskRepo.Get(ctx, objID, true)
+			if err != nil {
+				s.logger.Error().Err(err).Stack().Msg("failed to get task")
+
+				if errors.Is(err, repository.ErrCrackTaskNotFound) {
+					return false, domain.ErrTaskNotFound
+				}
+				return false, fmt.Errorf("failed to get task: %w", err)
+			}
+
+			switch task.Status {
+			case entity.HashCrackTaskStatusCancelled:
+				s.logger.Debug().Msg("task is already cancelled")
+				return false, nil
+			case entity.HashCrackTaskStatusReady, entity.HashCrackTaskStatusPartialReady, entity.HashCrackTaskStatusError:
+				s.logger.Error().Str("status", task.Status.String()).Msg("task is already finished")
+				return false, domain.ErrTaskFinished
+			}
+
+			s.logger.Debug().Msg("mark task as CANCELLED")
+			markTaskAsCancelled(task)
+
+			for _, subtask := range task.Subtasks {
+				if isSubtaskUnfinished(subtask) {
+					s.logger.Debug().Str("id", subtask.ObjectID.Hex()).Msg("mark subtask as CANCELLED")
+					markSubtaskAsCancelled(subtask)
+				}
+			}
+
+			// Update task with subtasks
+			if err := s.taskRepo.Update(ctx, task.ToHashCrackTask()); err != nil {
+				s.logger.Error().Err(err).Stack().Msg("failed to update task")
+				return false, fmt.Errorf("failed to update task: %w", err)
+			}
+
+			if err := s.subtaskRepo.UpdateAll(ctx, task.Subtasks); err != nil {
+				s.logger.Error().Err(err).Stack().Msg("failed to update subtasks")
+				return false, fmt.Errorf("failed to update subtasks: %w", err)
+			}
+
+			return true, nil
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	if cancelled, ok := res.(bool); !ok || !cancelled {
+		return nil
+	}
+
+	// Stop subtasks on workers
+	s.stopSubtasks(ctx, id)
+
+	return nil
+}
+
+func (s *svc) ExtendTask(
+	ctx context.Context, id string, input *model.HashCrackTaskExtendInput,
+) (*model.HashCrackTaskDeadlineOutput, error) {
+	s.logger.Info().Str("id", id).Str("timeout", input.Timeout).Msg("extend task")
+
+	// Validate input
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		s.logger.Error().Err(err).Stack().Msg("failed to validate ID")
+		return nil, domain.ErrInvalidRequestID
+	}
+
+	if input.Timeout == "" {
+		s.logger.Error().Msg("timeout is empty")
+		return nil, fmt.Errorf("%w: timeout is empty", domain.ErrInvalidTimeout)
+	}
+
+	timeout, err := parseTimeout(input.Timeout, 0, s.cfg.MaxAge)
+	if err != nil {
+		s.logger.Error().Err(err).Msg("failed to validate input")
+		return nil, err
+	}
+
+	// Extend deadline of the unfinished task, a task without deadline is extended from now
+	deadline, err := s.taskRepo.ExtendDeadline(ctx, objID, timeout)
+	if err == nil {
+		return &model.HashCrackTaskDeadlineOutput{Deadline: deadline}, nil
+	}
+
+	if !errors.Is(err, repository.ErrCrackTaskNotFound) {
+		s.logger.Error().Err(err).Stack().Msg("failed to extend deadline")
+		return nil, fmt.Errorf("failed to extend deadline: %w", err)
+	}
+
+	// Get task to find out, why it is not extended
+	task, err := s.taskRepo.Get(ctx, objID, false)
+	if err != nil {
+		s.logger.Error().Err(err).Stack().Msg("failed to get task")
+
+		if errors.Is(err, repository.ErrCrackTaskNotFound) {
+			return nil, domain.ErrTaskNotFound
+		}
+		return nil, fmt.Errorf("failed to get task: %w", err)
+	}
+
+	s.logger.Error().Str("status", task.Status.String()).Msg("task is already finished")
+	return nil, domain.ErrTaskFinished
+}
+
 func (s *svc) SaveResultSubtask(ctx context.Context, input *message.HashCrackTaskResult) error {
 	s.logger.Info().
 		Str("id", input.RequestID).
@@ -175,8 +373,17 @@ func (s *svc) SaveResultSubtask(ctx context.Context, input *message.HashCrackTas
 		return domain.ErrInvalidRequestID
 	}
 
+	// Fail the subtask on results of workers of other versions, they may check other candidates
+	if input.Version != message.Version {
+		s.logger.Error().
+			Int("version", input.Version).
+			Int("expected_version", message.Version).
+			Msg("unsupported message version")
+		input = buildVersionErrorResult(input)
+	}
+
 	// Update subtask and check if task is finished
-	_, err = s.taskRepo.WithTransaction(
+	res, err := s.taskRepo.WithTransaction(
 		ctx, func(ctx context.Context) (any, error) {
 			// Get task
 			taskWithSubtasks, err := s.taskRepo.Get(ctx, objID, true)
@@ -196,6 +403,12 @@ func (s *svc) SaveResultSubtask(ctx context.Context, input *message.HashCrackTas
 				return nil, domain.ErrTaskFinishedByTimeout
 			}
 
+			// Discard results of cancelled task
+			if taskWithSubtasks.Status == entity.HashCrackTaskStatusCancelled {
+				s.logger.Warn().Msg("task is cancelled, discard result")
+				return nil, domain.ErrTaskCancelled
+			}
+
 			// Get subtask
 			var (
 				subtaskIdx int
@@ -213,31 +426,58 @@ func (s *svc) SaveResultSubtask(ctx context.Context, input *message.HashCrackTas
 				return nil, domain.ErrSubtaskNotFound
 			}
 
-			// Update subtask
+			// Discard results of the previous attempts, the subtask is sent again after their lease is expired
+			subtask := taskWithSubtasks.Subtasks[subtaskIdx]
+			if input.Attempt > 0 && input.Attempt != subtask.Attempts {
+				s.logger.Warn().
+					Int("attempt", input.Attempt).
+					Int("current_attempt", subtask.Attempts).
+					Msg("result of previous attempt, discard result")
+				return nil, domain.ErrStaleAttempt
+			}
+
+			// Discard results of skipped subtask
+			if taskWithSubtasks.Subtasks[subtaskIdx].Status == entity.HashCrackSubtaskStatusSkipped {
+				s.logger.Warn().Msg("subtask is skipped, discard result")
+				return nil, domain.ErrSubtaskSkipped
+			}
+
+			// Discard late results of the matching subtask, it is stopped on workers with the task
+			if taskWithSubtasks.StopOnFirstMatch && taskWithSubtasks.Status == entity.HashCrackTaskStatusReady {
+				s.logger.Warn().Msg("task is stopped on first match, discard result")
+				return nil, domain.ErrSubtaskSkipped
+			}
+
+			// Update subtask and renew its lease
 			partialUpdateSubtaskEntity(taskWithSubtasks.Subtasks[subtaskIdx], input)
+			renewSubtaskLease(taskWithSubtasks.Subtasks[subtaskIdx], s.cfg.LeaseTimeout)
 			if err := s.subtaskRepo.Update(ctx, taskWithSubtasks.Subtasks[subtaskIdx]); err != nil {
 				s.logger.Error().Err(err).Stack().Msg("failed to update task")
 				return nil, fmt.Errorf("failed to update task: %w", err)
 			}
 
-			// Check if task is finished
-			s.logger.Debug().Msg("check if task is finished")
-
-			task := taskWithSubtasks.ToHashCrackTask()
-			hasSuccess, hasError, hasInProgress, hasPending := hasSubtaskStatuses(taskWithSubtasks)
-			if !hasInProgress && !hasPending {
-				switch {
-				case hasError && hasSuccess:
-					s.logger.Info().Msg("mark task as PARTIAL_READY")
-					markTaskAsPartialReady(task)
-				case hasError:
-					s.logger.Info().Msg("mark task as ERROR")
-					markTaskAsError(task, taskWithSubtasks.Subtasks)
-				case hasSuccess:
-					s.logger.Info().Msg("mark task as READY")
-					markTaskAsReady(task)
-				}
+			// Stop task on first match
+			if stop, err := s.stopTaskIfMatched(
+				ctx, taskWithSubtasks, taskWithSubtasks.Subtasks[subtaskIdx],
+			); stop || err != nil {
+				return &savedResult{stopped: stop}, err
+			}
+
+			// Release pending subtasks in place of the finished one
+			released, err := s.releaseSubtasks(ctx, taskWithSubtasks)
+			if err != nil {
+				return nil, err
+			}
+
+			messages, err := s.enqueueSubtasks(
+				ctx, taskWithSubtasks.ToHashCrackTask(), released, uncrackedHashes(taskWithSubtasks),
+			)
+			if err != nil {
+				return nil, err
+			}
 
+			// Check if task is finished
+			if task, finished := s.finishTaskIfCompleted(taskWithSubtasks); finished {
 				// Update task
 				if err := s.taskRepo.Update(ctx, task); err != nil {
 					s.logger.Error().Err(err).Stack().Msg("failed to update task")
@@ -247,7 +487,7 @@ func (s *svc) SaveResultSubtask(ctx context.Context, input *message.HashCrackTas
 				s.logger.Info().Msg("task is finished")
 			}
 
-			return nil, nil
+			return &savedResult{messages: messages}, nil
 		},
 	)
 	if err != nil {
@@ -255,6 +495,19 @@ func (s *svc) SaveResultSubtask(ctx context.Context, input *message.HashCrackTas
 		return fmt.Errorf("failed to update subtask and check if task is finished: %w", err)
 	}
 
+	saved, ok := res.(*savedResult)
+	if !ok {
+		return nil
+	}
+
+	// Abandon skipped subtasks on workers
+	if saved.stopped {
+		s.stopSubtasks(ctx, input.RequestID)
+	}
+
+	// Send released subtasks to workers
+	s.sendOutboxMessages(ctx, saved.messages)
+
 	return nil
 }
 
@@ -276,38 +529,156 @@ func (s *svc) ExecutePendingSubtasks(ctx context.Context) error {
 	s.logger.Debug().Int("count", len(subtasks)).Msg("pending subtasks found")
 
 	// Calculate parent tasks
-	subtasksMap := make(map[primitive.ObjectID][]*entity.HashCrackSubtask)
-	for _, subtask := range subtasks {
-		subtasksMapItem, ok := subtasksMap[subtask.TaskID]
-		if !ok {
-			subtasksMapItem = make([]*entity.HashCrackSubtask, 0)
+	taskIDs := lo.Uniq(
+		lo.Map(
+			subtasks, func(subtask *entity.HashCrackSubtask, _ int) primitive.ObjectID {
+				return subtask.TaskID
+			},
+		),
+	)
+
+	// Execute subtasks
+	errs := make([]error, 0)
+	for _, taskID := range taskIDs {
+		if err := s.executePendingSubtasks(ctx, taskID); err != nil {
+			s.logger.Error().Err(err).Stack().Msg("failed to execute subtasks")
+			errs = append(errs, fmt.Errorf("failed to execute subtasks: %w", err))
+			continue
 		}
+	}
 
-		subtasksMapItem = append(subtasksMapItem, subtask)
-		subtasksMap[subtask.TaskID] = subtasksMapItem
+	if len(errs) > 0 {
+		return fmt.Errorf("failed to execute pending subtasks: %w", multierr.Combine(errs...))
 	}
 
-	// Execute subtasks
+	return nil
+}
+
+func (s *svc) RedeliverExpiredSubtasks(ctx context.Context) error {
+	s.logger.Info().Msg("redeliver expired subtasks")
+
+	// Get subtasks with expired lease
+	subtasks, err := s.subtaskRepo.GetAllLeaseExpired(ctx)
+	if err != nil {
+		s.logger.Error().Err(err).Stack().Msg("failed to get expired subtasks")
+		return fmt.Errorf("failed to get expired subtasks: %w", err)
+	}
+
+	if len(subtasks) == 0 {
+		s.logger.Debug().Msg("no expired subtasks found")
+		return nil
+	}
+
+	s.logger.Debug().Int("count", len(subtasks)).Msg("expired subtasks found")
+
+	// Calculate parent tasks
+	taskIDs := lo.Uniq(
+		lo.Map(
+			subtasks, func(subtask *entity.HashCrackSubtask, _ int) primitive.ObjectID {
+				return subtask.TaskID
+			},
+		),
+	)
+
+	// Redeliver subtasks
 	errs := make([]error, 0)
-	for taskID, subtasks := range subtasksMap {
-		// Get task
-		task, err := s.taskRepo.Get(ctx, taskID, false)
-		if err != nil {
-			s.logger.Error().Err(err).Stack().Msg("failed to get task")
-			errs = append(errs, fmt.Errorf("failed to get task: %w", err))
+	for _, taskID := range taskIDs {
+		if err := s.redeliverExpiredSubtasks(ctx, taskID); err != nil {
+			s.logger.Error().Err(err).Stack().Msg("failed to redeliver subtasks")
+			errs = append(errs, fmt.Errorf("failed to redeliver subtasks: %w", err))
 			continue
 		}
+	}
 
-		// Execute subtasks
-		if err := s.startExecuteSubtasks(ctx, task.ToHashCrackTask(), subtasks); err != nil {
-			s.logger.Error().Err(err).Stack().Msg("failed to execute subtasks")
-			errs = append(errs, fmt.Errorf("failed to execute subtasks: %w", err))
+	if len(errs) > 0 {
+		return fmt.Errorf("failed to redeliver expired subtasks: %w", multierr.Combine(errs...))
+	}
+
+	return nil
+}
+
+func (s *svc) SplitSlowSubtasks(ctx context.Context) error {
+	s.logger.Info().Msg("split slow subtasks")
+
+	// Check idle workers
+	capacity, err := s.taskQueueSvc.IdleCapacity(ctx)
+	if err != nil {
+		s.logger.Error().Err(err).Stack().Msg("failed to get idle capacity")
+		return fmt.Errorf("failed to get idle capacity: %w", err)
+	}
+
+	if capacity == 0 {
+		s.logger.Debug().Msg("no idle workers")
+		return nil
+	}
+
+	// Get slow subtasks
+	subtasks, err := s.subtaskRepo.GetAllStartedBefore(ctx, time.Now().Add(-s.cfg.Steal.Threshold))
+	if err != nil {
+		s.logger.Error().Err(err).Stack().Msg("failed to get slow subtasks")
+		return fmt.Errorf("failed to get slow subtasks: %w", err)
+	}
+
+	if len(subtasks) == 0 {
+		s.logger.Debug().Msg("no slow subtasks found")
+		return nil
+	}
+
+	s.logger.Debug().Int("count", len(subtasks)).Int("capacity", capacity).Msg("slow subtasks found")
+
+	// Split the slowest subtasks, one for every idle worker
+	errs := make([]error, 0)
+	for _, subtask := range subtasks {
+		if capacity == 0 {
+			break
+		}
+
+		split, err := s.splitSubtask(ctx, subtask)
+		if err != nil {
+			s.logger.Error().Err(err).Stack().Msg("failed to split subtask")
+			errs = append(errs, err)
 			continue
 		}
+
+		if split {
+			capacity--
+		}
 	}
 
 	if len(errs) > 0 {
-		return fmt.Errorf("failed to execute pending subtasks: %w", multierr.Combine(errs...))
+		return fmt.Errorf("failed to split slow subtasks: %w", multierr.Combine(errs...))
+	}
+
+	return nil
+}
+
+func (s *svc) RelayOutboxMessages(ctx context.Context) error {
+	s.logger.Debug().Msg("relay outbox messages")
+
+	// Get unlocked messages
+	messages, err := s.outboxRepo.GetAllUnlocked(ctx, s.cfg.Outbox.BatchSize)
+	if err != nil {
+		s.logger.Error().Err(err).Stack().Msg("failed to get outbox messages")
+		return fmt.Errorf("failed to get outbox messages: %w", err)
+	}
+
+	if len(messages) == 0 {
+		s.logger.Debug().Msg("no outbox messages found")
+		return nil
+	}
+
+	s.logger.Debug().Int("count", len(messages)).Msg("outbox messages found")
+
+	// Relay messages
+	errs := make([]error, 0)
+	for _, msg := range messages {
+		if err := s.relayOutboxMessage(ctx, msg); err != nil {
+			errs = append(errs, fmt.Errorf("failed to relay outbox message: %w", err))
+		}
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("failed to relay outbox messages: %w", multierr.Combine(errs...))
 	}
 
 	return nil
@@ -333,7 +704,7 @@ func (s *svc) FinishTimeoutTasks(ctx context.Context) error {
 	// Finish tasks
 	errs := make([]error, 0)
 	for _, task := range tasks {
-		if err := s.finishTask(ctx, task); err != nil {
+		if err := s.finishTask(ctx, task.ObjectID); err != nil {
 			errs = append(errs, err)
 		}
 	}
@@ -370,120 +741,820 @@ func (s *svc) DeleteExpiredTasks(ctx context.Context) error {
 	return nil
 }
 
-func (s *svc) startExecuteTask(ctx context.Context, taskWithSubtasks *entity.HashCrackTaskWithSubtasks) error {
-	s.logger.Debug().Str("id", taskWithSubtasks.ObjectID.Hex()).Msg("start execute task")
-
-	// Send tasks to workers
-	for i := 0; i < taskWithSubtasks.PartCount; i++ {
-		s.logger.Debug().
-			Str("id", taskWithSubtasks.Subtasks[i].ObjectID.Hex()).
-			Msg("send message to worker")
+// executePendingSubtasks sends the pending subtasks of the task to workers without exceeding the limit of subtasks
+// in flight. The task is read again in the transaction, so the subtasks claimed or finished since they were found
+// are not sent again
+func (s *svc) executePendingSubtasks(ctx context.Context, taskID primitive.ObjectID) error {
+	s.logger.Debug().Str("id", taskID.Hex()).Msg("execute pending subtasks of task")
 
-		msg := buildTaskMessage(taskWithSubtasks.ToHashCrackTask(), i, s.cfg.Alphabet)
-		err := s.publisher.SendMessage(ctx, msg, publisher.Persistent, false, false)
+	res, err := s.taskRepo.WithTransaction(
+		ctx, func(ctx context.Context) (any, error) {
+			taskWithSubtasks, err := s.getUnfinishedTask(ctx, taskID)
+			if err != nil || taskWithSubtasks == nil {
+				return nil, err
+			}
 
-		if err == nil {
-			s.logger.Debug().Msg("mark subtask as IN_PROGRESS")
-			markSubtaskAsInProgress(taskWithSubtasks.Subtasks[i])
-		} else {
-			s.logger.Error().Err(err).Stack().Msg("failed to send message")
+			pending := lo.Filter(
+				taskWithSubtasks.Subtasks, func(subtask *entity.HashCrackSubtask, _ int) bool {
+					return subtask.Status == entity.HashCrackSubtaskStatusPending
+				},
+			)
 
-			s.logger.Debug().Msg("mark subtask as ERROR")
-			markSubtaskAsErrorWithReason(taskWithSubtasks.Subtasks[i], err.Error())
-		}
+			// Keep the limit of subtasks in flight
+			pending = limitSubtasks(taskWithSubtasks, pending, s.cfg.InFlightLimit)
+			if len(pending) == 0 {
+				s.logger.Debug().Msg("no pending subtasks or limit of subtasks in flight is reached")
+				return nil, nil
+			}
 
-		if err := s.subtaskRepo.Update(ctx, taskWithSubtasks.Subtasks[i]); err != nil {
-			s.logger.Error().Err(err).Stack().Msg("failed to update subtask")
-			return fmt.Errorf("failed to update subtask: %w", err)
-		}
+			return s.claimSubtasks(ctx, taskWithSubtasks, pending)
+		},
+	)
+	if err != nil {
+		s.logger.Error().Err(err).Stack().Msg("failed to start execute subtasks")
+		return fmt.Errorf("failed to start execute subtasks: %w", err)
 	}
 
-	// Mark task as IN_PROGRESS
-	task := taskWithSubtasks.ToHashCrackTask()
-	s.logger.Debug().Msg("mark task as IN_PROGRESS")
-	markTaskAsInProgress(task)
-
-	// Update task with subtasks
-
-	if err := s.taskRepo.Update(ctx, task); err != nil {
-		s.logger.Error().Err(err).Stack().Msg("failed to update task")
-		return fmt.Errorf("failed to update task: %w", err)
-	}
+	// Send tasks to workers
+	messages, _ := res.([]*entity.OutboxMessage)
+	s.sendOutboxMessages(ctx, messages)
 
 	return nil
 }
 
-func (s *svc) startExecuteSubtasks(
-	ctx context.Context, task *entity.HashCrackTask, subtasks []*entity.HashCrackSubtask,
-) error {
-	subtaskIds := lo.Map(
-		subtasks, func(subtask *entity.HashCrackSubtask, _ int) string {
-			return subtask.ObjectID.Hex()
-		},
-	)
+// redeliverExpiredSubtasks sends the subtasks of the task with expired lease to workers again, the subtasks, which
+// run out of attempts, are marked as ERROR. The task is read again in the transaction, so the subtasks finished or
+// renewed by progress since they were found are kept
+func (s *svc) redeliverExpiredSubtasks(ctx context.Context, taskID primitive.ObjectID) error {
+	s.logger.Debug().Str("id", taskID.Hex()).Msg("redeliver expired subtasks of task")
 
-	s.logger.Debug().
-		Str("id", task.ObjectID.Hex()).
-		Strs("subtasks", subtaskIds).
-		Msg("start execute subtasks")
+	res, err := s.taskRepo.WithTransaction(
+		ctx, func(ctx context.Context) (any, error) {
+			taskWithSubtasks, err := s.getUnfinishedTask(ctx, taskID)
+			if err != nil || taskWithSubtasks == nil {
+				return nil, err
+			}
 
-	// Send tasks to workers
-	for _, subtask := range subtasks {
-		s.logger.Debug().
-			Str("id", subtask.ObjectID.Hex()).
-			Msg("send message to worker")
+			now := time.Now()
+			expired := lo.Filter(
+				taskWithSubtasks.Subtasks, func(subtask *entity.HashCrackSubtask, _ int) bool {
+					return isSubtaskLeaseExpired(subtask, now)
+				},
+			)
+			if len(expired) == 0 {
+				s.logger.Debug().Msg("no subtasks with expired lease")
+				return nil, nil
+			}
 
-		msg := buildTaskMessage(task, subtask.PartNumber, s.cfg.Alphabet)
-		err := s.publisher.SendMessage(ctx, msg, publisher.Persistent, false, false)
+			exhausted, retried := lo.FilterReject(
+				expired, func(subtask *entity.HashCrackSubtask, _ int) bool {
+					return subtask.Attempts >= s.cfg.MaxAttempts
+				},
+			)
 
-		if err == nil {
-			s.logger.Debug().Msg("mark subtask as IN_PROGRESS")
-			markSubtaskAsInProgress(subtask)
-		} else {
-			s.logger.Error().Err(err).Stack().Msg("failed to send message")
+			for _, subtask := range exhausted {
+				s.logger.Debug().
+					Str("id", subtask.ObjectID.Hex()).
+					Int("attempts", subtask.Attempts).
+					Msg("attempts are exhausted, mark subtask as ERROR")
+				markSubtaskAsErrorWithReason(
+					subtask, fmt.Sprintf("%s after %d attempts", domain.ErrSubtaskLeaseExpired, subtask.Attempts),
+				)
+
+				if err := s.subtaskRepo.Update(ctx, subtask); err != nil {
+					s.logger.Error().Err(err).Stack().Msg("failed to update subtask")
+					return nil, fmt.Errorf("failed to update subtask: %w", err)
+				}
+			}
+
+			// Send the rest of subtasks again
+			if len(retried) > 0 {
+				return s.claimSubtasks(ctx, taskWithSubtasks, retried)
+			}
+
+			// Check if task is finished
+			if task, finished := s.finishTaskIfCompleted(taskWithSubtasks); finished {
+				if err := s.taskRepo.Update(ctx, task); err != nil {
+					s.logger.Error().Err(err).Stack().Msg("failed to update task")
+					return nil, fmt.Errorf("failed to update task: %w", err)
+				}
+			}
 
-			s.logger.Debug().Msg("mark subtask as ERROR")
-			markSubtaskAsErrorWithReason(subtask, err.Error())
+			return nil, nil
+		},
+	)
+	if err != nil {
+		s.logger.Error().Err(err).Stack().Msg("failed to redeliver subtasks")
+		return fmt.Errorf("failed to redeliver subtasks: %w", err)
+	}
+
+	// Send tasks to workers
+	messages, _ := res.([]*entity.OutboxMessage)
+	s.sendOutboxMessages(ctx, messages)
+
+	return nil
+}
+
+// getUnfinishedTask gets the task with subtasks, nil is returned if the task is deleted or finished, because its
+// subtasks are not executed anymore
+func (s *svc) getUnfinishedTask(
+	ctx context.Context, id primitive.ObjectID,
+) (*entity.HashCrackTaskWithSubtasks, error) {
+	task, err := s.taskRepo.Get(ctx, id, true)
+	if err != nil {
+		if errors.Is(err, repository.ErrCrackTaskNotFound) {
+			s.logger.Debug().Str("id", id.Hex()).Msg("task not found, skip subtasks")
+			return nil, nil
+		}
+
+		s.logger.Error().Err(err).Stack().Msg("failed to get task")
+		return nil, fmt.Errorf("failed to get task: %w", err)
+	}
+
+	if task.Status != entity.HashCrackTaskStatusPending && task.Status != entity.HashCrackTaskStatusInProgress {
+		s.logger.Debug().Str("id", id.Hex()).Msg("task is finished, skip subtasks")
+		return nil, nil
+	}
+
+	return task, nil
+}
+
+// claimSubtasks marks the subtasks of the task as IN_PROGRESS and saves their messages to the outbox in the
+// transaction, the task is marked as IN_PROGRESS unless every subtask is already finished. The subtasks must be
+// read in the same transaction
+func (s *svc) claimSubtasks(
+	ctx context.Context, taskWithSubtasks *entity.HashCrackTaskWithSubtasks, subtasks []*entity.HashCrackSubtask,
+) ([]*entity.OutboxMessage, error) {
+	subtaskIds := lo.Map(
+		subtasks, func(subtask *entity.HashCrackSubtask, _ int) string {
+			return subtask.ObjectID.Hex()
+		},
+	)
+
+	s.logger.Debug().
+		Str("id", taskWithSubtasks.ObjectID.Hex()).
+		Strs("subtasks", subtaskIds).
+		Msg("claim subtasks")
+
+	// Drop cracked hashes
+	hashes := uncrackedHashes(taskWithSubtasks)
+	task := taskWithSubtasks.ToHashCrackTask()
+
+	sent := make([]*entity.HashCrackSubtask, 0, len(subtasks))
+	for _, subtask := range subtasks {
+		if task.IsBatch() && len(hashes) == 0 {
+			s.logger.Debug().
+				Str("id", subtask.ObjectID.Hex()).
+				Msg("all hashes are cracked, mark subtask as SUCCESS")
+			markSubtaskAsSuccess(subtask)
+		} else {
+			s.logger.Debug().
+				Str("id", subtask.ObjectID.Hex()).
+				Int("hash_count", len(hashes)).
+				Msg("mark subtask as IN_PROGRESS")
+			markSubtaskAsSent(subtask, s.cfg.LeaseTimeout+s.cfg.ProgressPeriod)
+			sent = append(sent, subtask)
 		}
 
 		if err := s.subtaskRepo.Update(ctx, subtask); err != nil {
 			s.logger.Error().Err(err).Stack().Msg("failed to update subtask")
-			return fmt.Errorf("failed to update subtask: %w", err)
+			return nil, fmt.Errorf("failed to update subtask: %w", err)
 		}
 	}
 
-	// Mark task as IN_PROGRESS
-	s.logger.Debug().Msg("mark task as IN_PROGRESS")
-	markTaskAsInProgress(task)
+	messages, err := s.enqueueSubtasks(ctx, task, sent, hashes)
+	if err != nil {
+		return nil, err
+	}
 
-	// Update task with subtasks
+	// Mark task as IN_PROGRESS unless every subtask is already finished
+	task, finished := s.finishTaskIfCompleted(taskWithSubtasks)
+	if !finished {
+		s.logger.Debug().Msg("mark task as IN_PROGRESS")
+		markTaskAsInProgress(task)
+	}
 
 	if err := s.taskRepo.Update(ctx, task); err != nil {
 		s.logger.Error().Err(err).Stack().Msg("failed to update task")
-		return fmt.Errorf("failed to update task: %w", err)
+		return nil, fmt.Errorf("failed to update task: %w", err)
+	}
+
+	return messages, nil
+}
+
+// splitSubtask splits the unchecked rest of the range of the running subtask in halves. The worker of the subtask
+// is notified to stop at the middle and the second half is sent to workers as a new subtask. It returns false if
+// the subtask is finished or too small to split
+func (s *svc) splitSubtask(ctx context.Context, subtask *entity.HashCrackSubtask) (bool, error) {
+	s.logger.Debug().Str("id", subtask.ObjectID.Hex()).Msg("split subtask")
+
+	res, err := s.taskRepo.WithTransaction(
+		ctx, func(ctx context.Context) (any, error) {
+			// Get task
+			taskWithSubtasks, err := s.taskRepo.Get(ctx, subtask.TaskID, true)
+			if err != nil {
+				s.logger.Error().Err(err).Stack().Msg("failed to get task")
+				return nil, fmt.Errorf("failed to get task: %w", err)
+			}
+
+			if taskWithSubtasks.Status != entity.HashCrackTaskStatusInProgress {
+				s.logger.Debug().Msg("task is not in progress, skip subtask")
+				return nil, nil
+			}
+
+			// Get the actual state of the subtask
+			current, ok := lo.Find(
+				taskWithSubtasks.Subtasks, func(item *entity.HashCrackSubtask) bool {
+					return item.ObjectID == subtask.ObjectID
+				},
+			)
+			if !ok || current.Status != entity.HashCrackSubtaskStatusInProgress {
+				s.logger.Debug().Msg("subtask is not in progress, skip subtask")
+				return nil, nil
+			}
+
+			// Split the unchecked rest of the range in halves
+			start, end, ok := subtaskBounds(current)
+			position := start + current.Checkpoint
+			if !ok || end-position < 2*s.cfg.Steal.MinSize {
+				s.logger.Debug().Msg("subtask is too small to split")
+				return nil, nil
+			}
+			middle := position + (end-position)/2
+
+			// The split off subtask is sent at once, so it is counted against the limit of subtasks in flight
+			split := buildSplitSubtaskEntity(taskWithSubtasks, current, middle, end)
+			if len(limitSubtasks(taskWithSubtasks, []*entity.HashCrackSubtask{split}, s.cfg.InFlightLimit)) == 0 {
+				s.logger.Debug().Msg("task has too many subtasks in flight, skip subtask")
+				return nil, nil
+			}
+			shrinkSubtask(current, middle)
+
+			// Update subtask and create the split off one, it is claimed to be sent after the transaction
+			if err := s.subtaskRepo.Update(ctx, current); err != nil {
+				s.logger.Error().Err(err).Stack().Msg("failed to update subtask")
+				return nil, fmt.Errorf("failed to update subtask: %w", err)
+			}
+
+			markSubtaskAsSent(split, s.cfg.LeaseTimeout+s.cfg.ProgressPeriod)
+			if err := s.subtaskRepo.Create(ctx, split); err != nil {
+				s.logger.Error().Err(err).Stack().Msg("failed to create subtask")
+				return nil, fmt.Errorf("failed to create subtask: %w", err)
+			}
+
+			messages, err := s.enqueueSubtasks(
+				ctx, taskWithSubtasks.ToHashCrackTask(), []*entity.HashCrackSubtask{split},
+				uncrackedHashes(taskWithSubtasks),
+			)
+			if err != nil {
+				return nil, err
+			}
+
+			// Update task
+			taskWithSubtasks.PartCount++
+			taskWithSubtasks.Subtasks = append(taskWithSubtasks.Subtasks, split)
+			if err := s.taskRepo.Update(ctx, taskWithSubtasks.ToHashCrackTask()); err != nil {
+				s.logger.Error().Err(err).Stack().Msg("failed to update task")
+				return nil, fmt.Errorf("failed to update task: %w", err)
+			}
+
+			return &splitResult{task: taskWithSubtasks, subtask: current, messages: messages}, nil
+		},
+	)
+	if err != nil {
+		return false, fmt.Errorf("failed to split subtask: %w", err)
+	}
+
+	result, ok := res.(*splitResult)
+	if !ok {
+		return false, nil
+	}
+
+	// Stop the worker of the subtask at the middle and send the second half to workers
+	s.shrinkRunningSubtask(ctx, result.task, result.subtask)
+	s.sendOutboxMessages(ctx, result.messages)
+
+	return true, nil
+}
+
+func (s *svc) finishTask(ctx context.Context, id primitive.ObjectID) error {
+	s.logger.Debug().Str("id", id.Hex()).Msg("finish task")
+
+	res, err := s.taskRepo.WithTransaction(
+		ctx, func(ctx context.Context) (any, error) {
+			// Get task again, its deadline may be extended or it may be finished since it was found
+			task, err := s.taskRepo.Get(ctx, id, true)
+			if err != nil {
+				if errors.Is(err, repository.ErrCrackTaskNotFound) {
+					s.logger.Debug().Msg("task not found, skip task")
+					return false, nil
+				}
+
+				s.logger.Error().Err(err).Stack().Msg("failed to get task")
+				return false, fmt.Errorf("failed to get task: %w", err)
+			}
+
+			if !isTaskTimedOut(task, time.Now()) {
+				s.logger.Debug().Msg("task is not timed out, skip task")
+				return false, nil
+			}
+
+			// Mark task as ERROR
+			s.logger.Debug().Msg("mark task as ERROR")
+			markTaskAsFinishedByTimeout(task)
+
+			// Mark unfinished subtasks as ERROR
+			for _, subtask := range task.Subtasks {
+				if isSubtaskUnfinished(subtask) {
+					s.logger.Debug().Str("id", subtask.ObjectID.Hex()).Msg("mark subtask as ERROR")
+					markSubtaskAsErrorWithReason(subtask, domain.ErrTaskFinishedByTimeout.Error())
+				}
+			}
+
+			// Update task with subtasks
+			if err := s.taskRepo.Update(ctx, task.ToHashCrackTask()); err != nil {
+				s.logger.Error().Err(err).Stack().Msg("failed to update task")
+				return false, fmt.Errorf("failed to update task: %w", err)
+			}
+
+			if err := s.subtaskRepo.UpdateAll(ctx, task.Subtasks); err != nil {
+				s.logger.Error().Err(err).Stack().Msg("failed to update subtasks")
+				return false, fmt.Errorf("failed to update subtasks: %w", err)
+			}
+
+			return true, nil
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("failed to finish task: %w", err)
+	}
+
+	if finished, ok := res.(bool); !ok || !finished {
+		return nil
+	}
+
+	// Stop subtasks on workers
+	s.stopSubtasks(ctx, id.Hex())
+
+	return nil
+}
+
+func (s *svc) createTask(
+	ctx context.Context, task *entity.HashCrackTaskWithSubtasks,
+) (*model.HashCrackTaskIDOutput, error) {
+	// Get same tasks
+	sameTask, err := s.taskRepo.GetSame(ctx, task.ToHashCrackTask(), false)
+	if err != nil && !errors.Is(err, repository.ErrCrackTaskNotFound) {
+		s.logger.Warn().Err(err).Msg("failed to get same tasks")
+	}
+
+	if sameTask != nil {
+		s.logger.Info().Msg("same task already exists")
+		return buildTaskIDOutput(sameTask.ToHashCrackTask()), nil
+	}
+
+	// Split task
+	if err := s.splitTask(ctx, task); err != nil {
+		return nil, err
+	}
+
+	if task.PartCount > s.cfg.Split.MaxParts {
+		s.logger.Error().Int("part_count", task.PartCount).Msg("too many subtasks")
+		return nil, fmt.Errorf(
+			"%w: %d subtasks, limit is %d", domain.ErrKeyspaceTooLarge, task.PartCount, s.cfg.Split.MaxParts,
+		)
+	}
+
+	// Create and save task with subtasks, unless the limit of active tasks is reached
+	if err := s.taskWithSubtasksSvc.CreateTaskWithSubtasks(ctx, task, s.cfg.Limit); err != nil {
+		if errors.Is(err, infrastructure.ErrTooManyActiveTasks) {
+			return nil, fmt.Errorf("%w: %w", domain.ErrTooManyTasks, err)
+		}
+		return nil, fmt.Errorf("failed to create task with subtasks: %w", err)
+	}
+
+	// Start execute tasks, the rest of subtasks are released as results arrive. The task is read again, because
+	// it may be cancelled since it is created
+	go func() {
+		_ = s.executePendingSubtasks(ctx, task.ObjectID)
+	}()
+
+	return buildTaskIDOutput(task.ToHashCrackTask()), nil
+}
+
+func (s *svc) splitTask(ctx context.Context, task *entity.HashCrackTaskWithSubtasks) error {
+	switch taskMode(task.Mode) {
+	case message.AttackModeDictionary:
+		return s.splitDictionaryTask(ctx, task)
+	case message.AttackModeMask:
+		return s.splitMaskTask(ctx, task)
+	}
+
+	alphabet := taskAlphabet(task.Alphabet, s.cfg.Alphabet)
+
+	partition, err := s.splitSvc.Split(ctx, task.Algorithm, max(1, task.MinLength), task.MaxLength, len(alphabet))
+	if err != nil {
+		s.logger.Error().Err(err).Stack().Msg("failed to split task")
+
+		if errors.Is(err, infrastructure.ErrKeyspaceTooLarge) {
+			return fmt.Errorf("%w: %w", domain.ErrKeyspaceTooLarge, err)
+		}
+		return fmt.Errorf("failed to split task: %w", err)
+	}
+
+	addKeyRangeSubtaskEntities(task, partition)
+	return nil
+}
+
+func (s *svc) splitMaskTask(ctx context.Context, task *entity.HashCrackTaskWithSubtasks) error {
+	charsetLengths := lo.Map(
+		task.Mask.Positions, func(charset string, _ int) int {
+			return utf8.RuneCountInString(charset)
+		},
+	)
+
+	partition, err := s.splitSvc.SplitMask(ctx, task.Algorithm, charsetLengths)
+	if err != nil {
+		s.logger.Error().Err(err).Stack().Msg("failed to split mask")
+
+		if errors.Is(err, infrastructure.ErrKeyspaceTooLarge) {
+			return fmt.Errorf("%w: %w", domain.ErrKeyspaceTooLarge, err)
+		}
+		return fmt.Errorf("failed to split mask: %w", err)
 	}
 
+	addKeyRangeSubtaskEntities(task, partition)
 	return nil
 }
 
-func (s *svc) finishTask(ctx context.Context, task *entity.HashCrackTaskWithSubtasks) error {
-	s.logger.Debug().Str("id", task.ObjectID.Hex()).Msg("finish task")
+func (s *svc) splitDictionaryTask(ctx context.Context, task *entity.HashCrackTaskWithSubtasks) error {
+
+	// Get wordlist
+	wordlist, err := s.wordlistRepo.Get(ctx, *task.WordlistID)
+	if err != nil {
+		s.logger.Error().Err(err).Stack().Msg("failed to get wordlist")
+
+		if errors.Is(err, repository.ErrWordlistNotFound) {
+			return domain.ErrWordlistNotFound
+		}
+		return fmt.Errorf("failed to get wordlist: %w", err)
+	}
+
+	// Get rules, the task keeps a copy of them so the rule set may change later
+	if task.RuleSetID != nil {
+		ruleSet, err := s.ruleSetRepo.Get(ctx, *task.RuleSetID)
+		if err != nil {
+			s.logger.Error().Err(err).Stack().Msg("failed to get rule set")
+
+			if errors.Is(err, repository.ErrRuleSetNotFound) {
+				return domain.ErrRuleSetNotFound
+			}
+			return fmt.Errorf("failed to get rule set: %w", err)
+		}
+
+		task.Rules = ruleSet.Rules
+	}
+
+	// Split wordlist by lines
+	ranges, err := s.wordlistSplitSvc.SplitLines(ctx, task.Algorithm, wordlist.LineCount, max(1, len(task.Rules)))
+	if err != nil {
+		s.logger.Error().Err(err).Stack().Msg("failed to split wordlist")
+		return fmt.Errorf("failed to split wordlist: %w", err)
+	}
+
+	addLineSubtaskEntities(task, ranges)
+	return nil
+}
+
+// stopTaskIfMatched marks the task with the stopOnFirstMatch option as READY when a word of every target hash is
+// found. The matching subtask is marked as SUCCESS and the other unfinished subtasks as SKIPPED
+func (s *svc) stopTaskIfMatched(
+	ctx context.Context, taskWithSubtasks *entity.HashCrackTaskWithSubtasks, matched *entity.HashCrackSubtask,
+) (bool, error) {
+	if !taskWithSubtasks.StopOnFirstMatch || !allHashesFound(taskWithSubtasks) {
+		return false, nil
+	}
+
+	unfinished := lo.Filter(
+		taskWithSubtasks.Subtasks, func(subtask *entity.HashCrackSubtask, _ int) bool {
+			return isSubtaskUnfinished(subtask)
+		},
+	)
+	if len(unfinished) == 0 {
+		return false, nil
+	}
+
+	// Mark the matching subtask as SUCCESS and other unfinished subtasks as SKIPPED
+	for _, subtask := range unfinished {
+		if subtask == matched {
+			s.logger.Debug().Str("id", subtask.ObjectID.Hex()).Msg("mark subtask as SUCCESS")
+			markSubtaskAsSuccess(subtask)
+			renewSubtaskLease(subtask, s.cfg.LeaseTimeout)
+		} else {
+			s.logger.Debug().Str("id", subtask.ObjectID.Hex()).Msg("mark subtask as SKIPPED")
+			markSubtaskAsSkipped(subtask)
+		}
+
+		if err := s.subtaskRepo.Update(ctx, subtask); err != nil {
+			s.logger.Error().Err(err).Stack().Msg("failed to update subtask")
+			return false, fmt.Errorf("failed to update subtask: %w", err)
+		}
+	}
+
+	// Mark task as READY
+	task := taskWithSubtasks.ToHashCrackTask()
+	s.logger.Info().Msg("hashes are found, mark task as READY")
+	markTaskAsReady(task)
+
+	if err := s.taskRepo.Update(ctx, task); err != nil {
+		s.logger.Error().Err(err).Stack().Msg("failed to update task")
+		return false, fmt.Errorf("failed to update task: %w", err)
+	}
+
+	return true, nil
+}
+
+// releaseSubtasks claims the next pending subtasks of the task by marking them as IN_PROGRESS, so they are sent to
+// workers after the transaction is committed. The pending subtasks are marked as SUCCESS instead, if all hashes of
+// the batch task are cracked
+func (s *svc) releaseSubtasks(
+	ctx context.Context, taskWithSubtasks *entity.HashCrackTaskWithSubtasks,
+) ([]*entity.HashCrackSubtask, error) {
+	pending := lo.Filter(
+		taskWithSubtasks.Subtasks, func(subtask *entity.HashCrackSubtask, _ int) bool {
+			return subtask.Status == entity.HashCrackSubtaskStatusPending
+		},
+	)
+	if len(pending) == 0 {
+		return nil, nil
+	}
+
+	cracked := taskWithSubtasks.ToHashCrackTask().IsBatch() && len(uncrackedHashes(taskWithSubtasks)) == 0
+
+	released := make([]*entity.HashCrackSubtask, 0)
+	for _, subtask := range limitSubtasks(taskWithSubtasks, pending, s.cfg.InFlightLimit) {
+		if cracked {
+			s.logger.Debug().Str("id", subtask.ObjectID.Hex()).Msg("all hashes are cracked, mark subtask as SUCCESS")
+			markSubtaskAsSuccess(subtask)
+		} else {
+			s.logger.Debug().Str("id", subtask.ObjectID.Hex()).Msg("mark subtask as IN_PROGRESS")
+			markSubtaskAsSent(subtask, s.cfg.LeaseTimeout+s.cfg.ProgressPeriod)
+			released = append(released, subtask)
+		}
+
+		if err := s.subtaskRepo.Update(ctx, subtask); err != nil {
+			s.logger.Error().Err(err).Stack().Msg("failed to update subtask")
+			return nil, fmt.Errorf("failed to update subtask: %w", err)
+		}
+	}
+
+	return released, nil
+}
+
+// enqueueSubtasks saves messages of the subtasks to the outbox in the transaction, which marks them as IN_PROGRESS,
+// so the subtasks are sent to workers at least once after the transaction is committed
+func (s *svc) enqueueSubtasks(
+	ctx context.Context, task *entity.HashCrackTask, subtasks []*entity.HashCrackSubtask, hashes []string,
+) ([]*entity.OutboxMessage, error) {
+	if len(subtasks) == 0 {
+		return nil, nil
+	}
+
+	messages := make([]*entity.OutboxMessage, 0, len(subtasks))
+	for _, subtask := range subtasks {
+		msg, err := buildOutboxMessage(
+			task, subtask, buildTaskMessage(task, subtask, hashes, s.cfg.Alphabet, s.cfg.Split.ChunkSize),
+		)
+		if err != nil {
+			s.logger.Error().Err(err).Stack().Msg("failed to build outbox message")
+			return nil, fmt.Errorf("failed to build outbox message: %w", err)
+		}
+
+		messages = append(messages, msg)
+	}
+
+	if err := s.outboxRepo.CreateAll(ctx, messages); err != nil {
+		s.logger.Error().Err(err).Stack().Msg("failed to create outbox messages")
+		return nil, fmt.Errorf("failed to create outbox messages: %w", err)
+	}
+
+	return messages, nil
+}
+
+// sendOutboxMessages sends the messages saved by the committed transaction to workers without waiting for the relay.
+// Failures are only logged, because the relay sends the messages again
+func (s *svc) sendOutboxMessages(ctx context.Context, messages []*entity.OutboxMessage) {
+	for _, msg := range messages {
+		if err := s.relayOutboxMessage(ctx, msg); err != nil {
+			s.logger.Error().Err(err).Stack().Msg("failed to relay outbox message")
+		}
+	}
+}
+
+// relayOutboxMessage locks the message, sends it to workers and deletes it. The failed message is sent again after
+// the lock timeout, its subtask is marked as ERROR when the message runs out of attempts
+func (s *svc) relayOutboxMessage(ctx context.Context, outboxMsg *entity.OutboxMessage) error {
+	s.logger.Debug().
+		Str("id", outboxMsg.ObjectID.Hex()).
+		Str("task_id", outboxMsg.TaskID.Hex()).
+		Int("part_number", outboxMsg.PartNumber).
+		Msg("relay outbox message")
+
+	lockedUntil := time.Now().Add(s.cfg.Outbox.LockTimeout)
+	if err := s.outboxRepo.Lock(ctx, outboxMsg.ObjectID, lockedUntil); err != nil {
+		if errors.Is(err, repository.ErrOutboxMessageLocked) {
+			s.logger.Debug().Msg("outbox message is locked, skip message")
+			return nil
+		}
+
+		s.logger.Error().Err(err).Stack().Msg("failed to lock outbox message")
+		return fmt.Errorf("failed to lock outbox message: %w", err)
+	}
+	outboxMsg.LockedUntil = lockedUntil
+
+	// Send message to workers
+	sendErr := s.sendTaskMessage(ctx, outboxMsg)
+	if sendErr == nil {
+		if err := s.outboxRepo.Delete(ctx, outboxMsg.ObjectID); err != nil {
+			s.logger.Error().Err(err).Stack().Msg("failed to delete outbox message")
+			return fmt.Errorf("failed to delete outbox message: %w", err)
+		}
 
-	// Mark task as ERROR
-	s.logger.Debug().Msg("mark task as ERROR")
-	markTaskAsErrorWithReason(task.ToHashCrackTask(), domain.ErrTaskFinishedByTimeout.Error())
+		return nil
+	}
+
+	s.logger.Error().Err(sendErr).Stack().Msg("failed to send message")
+
+	outboxMsg.Attempts++
+	outboxMsg.Error = lo.ToPtr(sendErr.Error())
+
+	// Keep the message locked until it is sent again
+	if outboxMsg.Attempts < s.cfg.Outbox.MaxAttempts {
+		if err := s.outboxRepo.Update(ctx, outboxMsg); err != nil {
+			s.logger.Error().Err(err).Stack().Msg("failed to update outbox message")
+			return fmt.Errorf("failed to update outbox message: %w", err)
+		}
 
-	// Mark subtasks as ERROR
-	for i := range task.Subtasks {
-		s.logger.Debug().Str("id", task.Subtasks[i].ObjectID.Hex()).Msg("mark subtask as ERROR")
-		markSubtaskAsErrorWithReason(task.Subtasks[i], domain.ErrTaskFinishedByTimeout.Error())
+		return fmt.Errorf("failed to send message: %w", sendErr)
 	}
 
-	// Update task with subtasks
-	if err := s.taskWithSubtasksSvc.UpdateTaskWithSubtasks(ctx, task); err != nil {
-		s.logger.Error().Err(err).Stack().Msg("failed to update task with subtasks")
-		return fmt.Errorf("failed to update task with subtasks: %w", err)
+	if err := s.dropOutboxMessage(ctx, outboxMsg); err != nil {
+		return err
+	}
+
+	return fmt.Errorf("failed to send message: %w", sendErr)
+}
+
+func (s *svc) sendTaskMessage(ctx context.Context, outboxMsg *entity.OutboxMessage) error {
+	msg, err := parseOutboxMessage(outboxMsg)
+	if err != nil {
+		return err
+	}
+
+	if err := s.publisher.SendMessage(ctx, msg, publisher.Persistent, false, false); err != nil {
+		return fmt.Errorf("failed to send message: %w", err)
 	}
 
 	return nil
 }
+
+// dropOutboxMessage deletes the message, which runs out of attempts, and marks its subtask as ERROR. The next pending
+// subtasks are released in place of the failed one and the task is finished if none of its subtasks is left
+func (s *svc) dropOutboxMessage(ctx context.Context, outboxMsg *entity.OutboxMessage) error {
+	s.logger.Debug().Int("attempts", outboxMsg.Attempts).Msg("attempts are exhausted, drop outbox message")
+
+	res, err := s.taskRepo.WithTransaction(
+		ctx, func(ctx context.Context) (any, error) {
+			if err := s.outboxRepo.Delete(ctx, outboxMsg.ObjectID); err != nil {
+				s.logger.Error().Err(err).Stack().Msg("failed to delete outbox message")
+				return nil, fmt.Errorf("failed to delete outbox message: %w", err)
+			}
+
+			// Get task
+			taskWithSubtasks, err := s.taskRepo.Get(ctx, outboxMsg.TaskID, true)
+			if err != nil {
+				if errors.Is(err, repository.ErrCrackTaskNotFound) {
+					s.logger.Debug().Msg("task not found, skip subtask")
+					return nil, nil
+				}
+
+				s.logger.Error().Err(err).Stack().Msg("failed to get task")
+				return nil, fmt.Errorf("failed to get task: %w", err)
+			}
+
+			subtask, ok := lo.Find(
+				taskWithSubtasks.Subtasks, func(subtask *entity.HashCrackSubtask) bool {
+					return subtask.PartNumber == outboxMsg.PartNumber
+				},
+			)
+			if !ok || subtask.Status != entity.HashCrackSubtaskStatusInProgress {
+				s.logger.Debug().Msg("subtask is not in progress, skip subtask")
+				return nil, nil
+			}
+
+			s.logger.Debug().Str("id", subtask.ObjectID.Hex()).Msg("mark subtask as ERROR")
+			markSubtaskAsErrorWithReason(subtask, lo.FromPtr(outboxMsg.Error))
+
+			if err := s.subtaskRepo.Update(ctx, subtask); err != nil {
+				s.logger.Error().Err(err).Stack().Msg("failed to update subtask")
+				return nil, fmt.Errorf("failed to update subtask: %w", err)
+			}
+
+			// Release pending subtasks in place of the failed one
+			released, err := s.releaseSubtasks(ctx, taskWithSubtasks)
+			if err != nil {
+				return nil, err
+			}
+
+			messages, err := s.enqueueSubtasks(
+				ctx, taskWithSubtasks.ToHashCrackTask(), released, uncrackedHashes(taskWithSubtasks),
+			)
+			if err != nil {
+				return nil, err
+			}
+
+			// Check if task is finished
+			if task, finished := s.finishTaskIfCompleted(taskWithSubtasks); finished {
+				// Update task
+				if err := s.taskRepo.Update(ctx, task); err != nil {
+					s.logger.Error().Err(err).Stack().Msg("failed to update task")
+					return nil, fmt.Errorf("failed to update task: %w", err)
+				}
+
+				s.logger.Info().Msg("task is finished")
+			}
+
+			return messages, nil
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("failed to drop outbox message: %w", err)
+	}
+
+	// Send released subtasks to workers
+	messages, _ := res.([]*entity.OutboxMessage)
+	s.sendOutboxMessages(ctx, messages)
+
+	return nil
+}
+
+// shrinkRunningSubtask notifies workers to lower the end of the range of the running subtask. The failure is only
+// logged, because the split off range is checked twice then
+func (s *svc) shrinkRunningSubtask(
+	ctx context.Context, task *entity.HashCrackTaskWithSubtasks, subtask *entity.HashCrackSubtask,
+) {
+	_, end, _ := subtaskBounds(subtask)
+
+	s.logger.Debug().
+		Str("id", subtask.ObjectID.Hex()).
+		Int("end", end).
+		Msg("send shrink message to workers")
+
+	msg := &message.HashCrackTaskShrunk{
+		Version:    message.Version,
+		RequestID:  task.ObjectID.Hex(),
+		PartNumber: subtask.PartNumber,
+		EndIndex:   end,
+	}
+	if err := s.shrinkPublisher.SendMessage(ctx, msg, publisher.Persistent, false, false); err != nil {
+		s.logger.Error().Err(err).Stack().Msg("failed to send shrink message")
+	}
+}
+
+// stopSubtasks notifies workers to stop the subtasks of the task. The failure is only logged,
+// because results of the stopped subtasks are discarded anyway.
+func (s *svc) stopSubtasks(ctx context.Context, id string) {
+	s.logger.Debug().Str("id", id).Msg("send cancel message to workers")
+
+	msg := &message.HashCrackTaskCancelled{RequestID: id}
+	if err := s.cancelPublisher.SendMessage(ctx, msg, publisher.Persistent, false, false); err != nil {
+		s.logger.Error().Err(err).Stack().Msg("failed to send cancel message")
+	}
+}
+
+// finishTaskIfCompleted marks the task as finished when none of its subtasks is pending or in progress
+func (s *svc) finishTaskIfCompleted(
+	taskWithSubtasks *entity.HashCrackTaskWithSubtasks,
+) (*entity.HashCrackTask, bool) {
+	s.logger.Debug().Msg("check if task is finished")
+
+	task := taskWithSubtasks.ToHashCrackTask()
+	hasSuccess, hasError, hasInProgress, hasPending := hasSubtaskStatuses(taskWithSubtasks)
+	if hasInProgress || hasPending || (!hasSuccess && !hasError) {
+		return task, false
+	}
+
+	switch {
+	case hasError && hasSuccess:
+		s.logger.Info().Msg("mark task as PARTIAL_READY")
+		markTaskAsPartialReady(task)
+	case hasError:
+		s.logger.Info().Msg("mark task as ERROR")
+		markTaskAsError(task, taskWithSubtasks.Subtasks)
+	case hasSuccess:
+		s.logger.Info().Msg("mark task as READY")
+		markTaskAsReady(task)
+	}
+
+	return task, true
+}
@@ -4,8 +4,11 @@ import (
 	"context"
 	"crypto/md5"
 	"encoding/hex"
+	"encoding/json"
 	"errors"
+	"fmt"
 	"github.com/samber/lo"
+	"strings"
 	"testing"
 	"time"
 
@@ -14,17 +17,22 @@ import (
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
 
 	"github.com/ptrvsrg/crack-hash/commonlib/bus/amqp/publisher"
 	pubmock "github.com/ptrvsrg/crack-hash/commonlib/bus/amqp/publisher/mock"
 	"github.com/ptrvsrg/crack-hash/commonlib/logging"
 	"github.com/ptrvsrg/crack-hash/manager/config"
+	"github.com/ptrvsrg/crack-hash/manager/internal/helper"
 	"github.com/ptrvsrg/crack-hash/manager/internal/persistence/entity"
 	"github.com/ptrvsrg/crack-hash/manager/internal/persistence/repository"
 	repomock "github.com/ptrvsrg/crack-hash/manager/internal/persistence/repository/mock"
+	"github.com/ptrvsrg/crack-hash/manager/internal/persistence/repository/mongo/hashcracksubtask"
 	"github.com/ptrvsrg/crack-hash/manager/internal/service/domain"
 	"github.com/ptrvsrg/crack-hash/manager/internal/service/domain/hashcrack"
+	"github.com/ptrvsrg/crack-hash/manager/internal/service/infrastructure"
 	infrasvcmock "github.com/ptrvsrg/crack-hash/manager/internal/service/infrastructure/mock"
+	"github.com/ptrvsrg/crack-hash/manager/internal/service/infrastructure/taskwithsubtasks"
 	"github.com/ptrvsrg/crack-hash/manager/pkg/message"
 	"github.com/ptrvsrg/crack-hash/manager/pkg/model"
 )
@@ -36,9 +44,16 @@ func init() {
 var (
 	mockTaskRepo            *repomock.HashCrackTaskMock
 	mockSubtaskRepo         *repomock.HashCrackSubtaskMock
+	mockOutboxRepo          *repomock.OutboxMessageMock
+	mockWordlistRepo        *repomock.WordlistMock
+	mockRuleSetRepo         *repomock.RuleSetMock
 	mockSplitSvc            *infrasvcmock.TaskSplitMock
+	mockWordlistSplitSvc    *infrasvcmock.WordlistSplitMock
 	mockTaskWithSubtasksSvc *infrasvcmock.TaskWithSubtasksMock
+	mockTaskQueueSvc        *infrasvcmock.TaskQueueMock
 	mockPublisher           *pubmock.PublisherMock[message.HashCrackTaskStarted]
+	mockCancelPublisher     *pubmock.PublisherMock[message.HashCrackTaskCancelled]
+	mockShrinkPublisher     *pubmock.PublisherMock[message.HashCrackTaskShrunk]
 	cfg                     config.TaskConfig
 	service                 domain.HashCrackTask
 
@@ -48,40 +63,293 @@ var (
 func TestMain(m *testing.M) {
 	mockTaskRepo = new(repomock.HashCrackTaskMock)
 	mockSubtaskRepo = new(repomock.HashCrackSubtaskMock)
+	mockOutboxRepo = new(repomock.OutboxMessageMock)
+	mockWordlistRepo = new(repomock.WordlistMock)
+	mockRuleSetRepo = new(repomock.RuleSetMock)
 	mockSplitSvc = new(infrasvcmock.TaskSplitMock)
+	mockWordlistSplitSvc = new(infrasvcmock.WordlistSplitMock)
 	mockTaskWithSubtasksSvc = new(infrasvcmock.TaskWithSubtasksMock)
 	mockPublisher = new(pubmock.PublisherMock[message.HashCrackTaskStarted])
+	mockTaskQueueSvc = new(infrasvcmock.TaskQueueMock)
+	mockCancelPublisher = new(pubmock.PublisherMock[message.HashCrackTaskCancelled])
+	mockShrinkPublisher = new(pubmock.PublisherMock[message.HashCrackTaskShrunk])
 	cfg = config.TaskConfig{
 		Split: config.TaskSplitConfig{
 			Strategy:  "chunkBased",
 			ChunkSize: 10,
+			MaxParts:  100,
+		},
+		Alphabet:     "abcdefghijklmnopqrstuvwxyz0123456789",
+		Timeout:      time.Hour,
+		Limit:        10,
+		BatchLimit:   2,
+		MaxAge:       time.Hour * 24,
+		FinishDelay:  time.Minute,
+		LeaseTimeout: time.Minute,
+		MaxAttempts:  3,
+		Steal: config.TaskStealConfig{
+			Delay:     time.Minute,
+			Threshold: 10 * time.Minute,
+			MinSize:   10,
+		},
+		Outbox: config.TaskOutboxConfig{
+			Delay:       time.Second,
+			BatchSize:   10,
+			LockTimeout: time.Minute,
+			MaxAttempts: 2,
 		},
-		Timeout:     time.Hour,
-		Limit:       10,
-		MaxAge:      time.Hour * 24,
-		FinishDelay: time.Minute,
 	}
 	service = hashcrack.NewService(
-		log.Logger, cfg, mockTaskRepo, mockSubtaskRepo, mockSplitSvc, mockTaskWithSubtasksSvc, mockPublisher,
+		log.Logger, cfg, mockTaskRepo, mockSubtaskRepo, mockOutboxRepo, mockWordlistRepo, mockRuleSetRepo, mockSplitSvc,
+		mockWordlistSplitSvc, mockTaskWithSubtasksSvc, mockTaskQueueSvc, mockPublisher, mockCancelPublisher, mockShrinkPublisher,
 	)
 
 	m.Run()
 }
 
+type serviceMocks struct {
+	taskRepo            *repomock.HashCrackTaskMock
+	subtaskRepo         *repomock.HashCrackSubtaskMock
+	outboxRepo          *repomock.OutboxMessageMock
+	wordlistRepo        *repomock.WordlistMock
+	ruleSetRepo         *repomock.RuleSetMock
+	splitSvc            *infrasvcmock.TaskSplitMock
+	wordlistSplitSvc    *infrasvcmock.WordlistSplitMock
+	taskWithSubtasksSvc *infrasvcmock.TaskWithSubtasksMock
+	taskQueueSvc        *infrasvcmock.TaskQueueMock
+	publisher           *pubmock.PublisherMock[message.HashCrackTaskStarted]
+	cancelPublisher     *pubmock.PublisherMock[message.HashCrackTaskCancelled]
+	shrinkPublisher     *pubmock.PublisherMock[message.HashCrackTaskShrunk]
+}
+
+// newServiceWithMocks creates a service with its own mocks, so expectations left by other tests do not interfere
+func newServiceWithMocks() (domain.HashCrackTask, *serviceMocks) {
+	return newServiceWithConfig(cfg)
+}
+
+// newServiceWithConfig creates a service like newServiceWithMocks with the given config
+func newServiceWithConfig(cfg config.TaskConfig) (domain.HashCrackTask, *serviceMocks) {
+	m := &serviceMocks{
+		taskRepo:            new(repomock.HashCrackTaskMock),
+		subtaskRepo:         new(repomock.HashCrackSubtaskMock),
+		outboxRepo:          new(repomock.OutboxMessageMock),
+		wordlistRepo:        new(repomock.WordlistMock),
+		ruleSetRepo:         new(repomock.RuleSetMock),
+		splitSvc:            new(infrasvcmock.TaskSplitMock),
+		wordlistSplitSvc:    new(infrasvcmock.WordlistSplitMock),
+		taskWithSubtasksSvc: new(infrasvcmock.TaskWithSubtasksMock),
+		taskQueueSvc:        new(infrasvcmock.TaskQueueMock),
+		publisher:           new(pubmock.PublisherMock[message.HashCrackTaskStarted]),
+		cancelPublisher:     new(pubmock.PublisherMock[message.HashCrackTaskCancelled]),
+		shrinkPublisher:     new(pubmock.PublisherMock[message.HashCrackTaskShrunk]),
+	}
+
+	svc := hashcrack.NewService(
+		log.Logger, cfg, m.taskRepo, m.subtaskRepo, m.outboxRepo, m.wordlistRepo, m.ruleSetRepo, m.splitSvc,
+		m.wordlistSplitSvc, m.taskWithSubtasksSvc, m.taskQueueSvc, m.publisher, m.cancelPublisher, m.shrinkPublisher,
+	)
+
+	return svc, m
+}
+
+// newServiceWithSubtaskRepo creates a service like newServiceWithMocks with the given repository of subtasks,
+// which is shared with the task with subtasks service
+func newServiceWithSubtaskRepo(subtaskRepo repository.HashCrackSubtask) (domain.HashCrackTask, *serviceMocks) {
+	_, m := newServiceWithMocks()
+
+	svc := hashcrack.NewService(
+		log.Logger, cfg, m.taskRepo, subtaskRepo, m.outboxRepo, m.wordlistRepo, m.ruleSetRepo, m.splitSvc,
+		m.wordlistSplitSvc, taskwithsubtasks.NewService(m.taskRepo, subtaskRepo), m.taskQueueSvc, m.publisher,
+		m.cancelPublisher, m.shrinkPublisher,
+	)
+
+	return svc, m
+}
+
 func Test_CreateTask(t *testing.T) {
+	t.Run(
+		"Invalid input", func(t *testing.T) {
+			cases := []struct {
+				Name        string
+				Input       *model.HashCrackTaskInput
+				ExpectedErr error
+			}{
+				{
+					"Unsupported algorithm",
+					&model.HashCrackTaskInput{Algorithm: "CRC32", Hash: md5Hex("hash"), MaxLength: 5},
+					domain.ErrUnsupportedAlgorithm,
+				},
+				{
+					"Not hexadecimal hash",
+					&model.HashCrackTaskInput{Algorithm: "MD5", Hash: "not a hash", MaxLength: 5},
+					domain.ErrInvalidHash,
+				},
+				{
+					"Digest size mismatch",
+					&model.HashCrackTaskInput{Algorithm: "SHA256", Hash: md5Hex("hash"), MaxLength: 5},
+					domain.ErrInvalidHash,
+				},
+				{
+					"Unknown salt position",
+					&model.HashCrackTaskInput{
+						Hash:      md5Hex("hash"),
+						Salt:      &model.HashCrackSalt{Value: "salt", Position: "MIDDLE"},
+						MaxLength: 5,
+					},
+					domain.ErrInvalidSalt,
+				},
+				{
+					"Empty salt value",
+					&model.HashCrackTaskInput{
+						Hash:      md5Hex("hash"),
+						Salt:      &model.HashCrackSalt{Position: "PREFIX"},
+						MaxLength: 5,
+					},
+					domain.ErrInvalidSalt,
+				},
+				{
+					"Unknown attack mode",
+					&model.HashCrackTaskInput{Hash: md5Hex("hash"), Mode: "RAINBOW", MaxLength: 5},
+					domain.ErrInvalidAttackMode,
+				},
+				{
+					"Dictionary without wordlist",
+					&model.HashCrackTaskInput{Hash: md5Hex("hash"), Mode: "DICTIONARY"},
+					domain.ErrInvalidWordlistID,
+				},
+				{
+					"Rule set without dictionary",
+					&model.HashCrackTaskInput{Hash: md5Hex("hash"), MaxLength: 5, RuleSetID: primitive.NewObjectID().Hex()},
+					domain.ErrInvalidRuleSetID,
+				},
+				{
+					"Invalid rule set ID",
+					&model.HashCrackTaskInput{
+						Hash: md5Hex("hash"), Mode: "DICTIONARY", WordlistID: primitive.NewObjectID().Hex(), RuleSetID: "1",
+					},
+					domain.ErrInvalidRuleSetID,
+				},
+				{
+					"Non-ASCII alphabet",
+					&model.HashCrackTaskInput{Hash: md5Hex("hash"), Alphabet: "abcä", MaxLength: 5},
+					domain.ErrInvalidAlphabet,
+				},
+				{
+					"Missing max length",
+					&model.HashCrackTaskInput{Hash: md5Hex("hash")},
+					domain.ErrInvalidLength,
+				},
+				{
+					"Min length greater than max length",
+					&model.HashCrackTaskInput{Hash: md5Hex("hash"), MinLength: 4, MaxLength: 3},
+					domain.ErrInvalidLength,
+				},
+				{
+					"Mask without mask mode",
+					&model.HashCrackTaskInput{Hash: md5Hex("hash"), MaxLength: 5, Mask: "?l?d"},
+					domain.ErrInvalidMask,
+				},
+				{
+					"Empty mask",
+					&model.HashCrackTaskInput{Hash: md5Hex("hash"), Mode: "MASK"},
+					domain.ErrInvalidMask,
+				},
+				{
+					"Unknown mask charset",
+					&model.HashCrackTaskInput{Hash: md5Hex("hash"), Mode: "MASK", Mask: "?l?x"},
+					domain.ErrInvalidMask,
+				},
+				{
+					"Undefined custom charset",
+					&model.HashCrackTaskInput{Hash: md5Hex("hash"), Mode: "MASK", Mask: "?1?2", Charsets: []string{"ab"}},
+					domain.ErrInvalidMask,
+				},
+				{
+					"Mask ends with question mark",
+					&model.HashCrackTaskInput{Hash: md5Hex("hash"), Mode: "MASK", Mask: "?l?"},
+					domain.ErrInvalidMask,
+				},
+				{
+					"Invalid timeout",
+					&model.HashCrackTaskInput{Hash: md5Hex("hash"), MaxLength: 5, Timeout: "soon"},
+					domain.ErrInvalidTimeout,
+				},
+				{
+					"Timeout exceeds max age",
+					&model.HashCrackTaskInput{Hash: md5Hex("hash"), MaxLength: 5, Timeout: "48h"},
+					domain.ErrInvalidTimeout,
+				},
+				{
+					"Negative priority",
+					&model.HashCrackTaskInput{Hash: md5Hex("hash"), MaxLength: 5, Priority: -1},
+					domain.ErrInvalidPriority,
+				},
+				{
+					"Priority exceeds max priority",
+					&model.HashCrackTaskInput{Hash: md5Hex("hash"), MaxLength: 5, Priority: message.MaxTaskPriority + 1},
+					domain.ErrInvalidPriority,
+				},
+			}
+
+			for _, c := range cases {
+				t.Run(
+					c.Name, func(t *testing.T) {
+						// Act
+						output, err := service.CreateTask(ctx, c.Input)
+
+						// Assert
+						require.Error(t, err)
+						require.ErrorIs(t, err, c.ExpectedErr)
+						require.Nil(t, output)
+					},
+				)
+			}
+		},
+	)
+
+	t.Run(
+		"Success - algorithm defaults to MD5", func(t *testing.T) {
+			// Arrange
+			input := &model.HashCrackTaskInput{
+				MaxLength: 5,
+				Hash:      strings.ToUpper(md5Hex("hash")),
+			}
+
+			sameTask := &entity.HashCrackTaskWithSubtasks{
+				ObjectID: primitive.NewObjectID(),
+			}
+
+			mockTaskRepo.On("GetSame", ctx, mock.Anything, false).Run(
+				func(args mock.Arguments) {
+					task, ok := args.Get(1).(*entity.HashCrackTask)
+					assert.True(t, ok)
+					assert.Equal(t, message.HashAlgorithmMD5, task.Algorithm)
+					assert.Equal(t, md5Hex("hash"), task.Hash)
+					assert.Nil(t, task.Salt)
+				},
+			).Return(sameTask, nil).Once()
+
+			// Act
+			output, err := service.CreateTask(ctx, input)
+
+			// Assert
+			require.NoError(t, err)
+			require.Equal(t, sameTask.ObjectID.Hex(), output.RequestID)
+		},
+	)
+
 	t.Run(
 		"Split error", func(t *testing.T) {
 			// Arrange
 			input := &model.HashCrackTaskInput{
 				MaxLength: 5,
-				Hash:      hex.EncodeToString(md5.New().Sum([]byte("hash"))),
+				Hash:      md5Hex("hash"),
 			}
 			expectedErr := errors.New("split failed")
 
-			mockTaskRepo.On(
-				"GetByHashAndMaxLength", ctx, input.Hash, input.MaxLength, false,
-			).Return(nil, repository.ErrCrackTaskNotFound).Once()
-			mockSplitSvc.On("Split", ctx, input.MaxLength, mock.Anything).Return(0, expectedErr).Once()
+			mockTaskRepo.On("GetSame", ctx, mock.Anything, false).Return(nil, repository.ErrCrackTaskNotFound).Once()
+			mockSplitSvc.On("Split", ctx, mock.Anything, 1, input.MaxLength, mock.Anything).
+				Return(infrastructure.KeyPartition{}, expectedErr).Once()
 
 			// Act
 			output, err := service.CreateTask(ctx, input)
@@ -93,20 +361,104 @@ func Test_CreateTask(t *testing.T) {
 		},
 	)
 
+	t.Run(
+		"Deadline", func(t *testing.T) {
+			cases := []struct {
+				Name            string
+				Timeout         string
+				ExpectedTimeout time.Duration
+			}{
+				{"Configured timeout", "", cfg.Timeout},
+				{"Custom timeout", "30m", 30 * time.Minute},
+			}
+
+			for _, c := range cases {
+				t.Run(
+					c.Name, func(t *testing.T) {
+						// Arrange
+						svc, m := newServiceWithMocks()
+						input := &model.HashCrackTaskInput{
+							MaxLength: 1,
+							Hash:      md5Hex("hash"),
+							Timeout:   c.Timeout,
+						}
+
+						m.taskRepo.On("GetSame", ctx, mock.Anything, false).Return(nil, repository.ErrCrackTaskNotFound).
+							Once()
+						m.splitSvc.On("Split", ctx, mock.Anything, 1, 1, mock.Anything).
+							Return(infrastructure.KeyPartition{Size: 10, ChunkSize: 10}, nil).Once()
+						m.taskWithSubtasksSvc.On("CreateTaskWithSubtasks", ctx, mock.Anything, cfg.Limit).Run(
+							func(args mock.Arguments) {
+								task, ok := args.Get(1).(*entity.HashCrackTaskWithSubtasks)
+								assert.True(t, ok)
+								assert.NotNil(t, task.Deadline)
+								assert.WithinDuration(
+									t, task.CreatedAt.Add(c.ExpectedTimeout), *task.Deadline, time.Second,
+								)
+							},
+						).Return(nil).Once()
+						expectCreatedTaskGet(m.taskRepo, m.taskWithSubtasksSvc)
+						m.taskRepo.EXPECT().WithTransaction(ctx, mock.Anything).RunAndReturn(runInTransaction).Maybe()
+						expectOutbox(m.outboxRepo)
+						m.publisher.On("SendMessage", ctx, mock.Anything, publisher.Persistent, false, false).
+							Return(nil).Maybe()
+						m.subtaskRepo.On("Update", mock.Anything, mock.Anything).Return(nil).Maybe()
+						m.taskRepo.On("Update", mock.Anything, mock.Anything).Return(nil).Maybe()
+
+						// Act
+						output, err := svc.CreateTask(ctx, input)
+
+						// Assert
+						require.NoError(t, err)
+						require.NotEmpty(t, output.RequestID)
+						m.taskWithSubtasksSvc.AssertExpectations(t)
+					},
+				)
+			}
+		},
+	)
+
+	t.Run(
+		"Too many tasks", func(t *testing.T) {
+			// Arrange
+			svc, m := newServiceWithMocks()
+			input := &model.HashCrackTaskInput{
+				MaxLength: 5,
+				Hash:      md5Hex("hash"),
+			}
+
+			m.taskRepo.On("GetSame", ctx, mock.Anything, false).Return(nil, repository.ErrCrackTaskNotFound).Once()
+			m.splitSvc.On("Split", ctx, mock.Anything, 1, input.MaxLength, mock.Anything).
+				Return(infrastructure.KeyPartition{Size: 100, ChunkSize: 10}, nil).Once()
+			m.taskWithSubtasksSvc.On("CreateTaskWithSubtasks", ctx, mock.Anything, cfg.Limit).
+				Return(fmt.Errorf("failed to create task and subtasks: %w", infrastructure.ErrTooManyActiveTasks)).Once()
+
+			// Act
+			output, err := svc.CreateTask(ctx, input)
+
+			// Assert
+			require.ErrorIs(t, err, domain.ErrTooManyTasks)
+			require.Nil(t, output)
+			m.taskWithSubtasksSvc.AssertExpectations(t)
+			m.publisher.AssertNotCalled(
+				t, "SendMessage", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything,
+			)
+		},
+	)
+
 	t.Run(
 		"Create error", func(t *testing.T) {
 			// Arrange
 			input := &model.HashCrackTaskInput{
 				MaxLength: 5,
-				Hash:      hex.EncodeToString(md5.New().Sum([]byte("hash"))),
+				Hash:      md5Hex("hash"),
 			}
 			expectedErr := errors.New("create failed")
 
-			mockTaskRepo.On(
-				"GetByHashAndMaxLength", ctx, input.Hash, input.MaxLength, false,
-			).Return(nil, repository.ErrCrackTaskNotFound).Once()
-			mockSplitSvc.On("Split", ctx, input.MaxLength, mock.Anything).Return(10, nil).Once()
-			mockTaskWithSubtasksSvc.On("CreateTaskWithSubtasks", ctx, mock.Anything).Return(expectedErr).Once()
+			mockTaskRepo.On("GetSame", ctx, mock.Anything, false).Return(nil, repository.ErrCrackTaskNotFound).Once()
+			mockSplitSvc.On("Split", ctx, mock.Anything, 1, input.MaxLength, mock.Anything).
+				Return(infrastructure.KeyPartition{Size: 100, ChunkSize: 10}, nil).Once()
+			mockTaskWithSubtasksSvc.On("CreateTaskWithSubtasks", ctx, mock.Anything, cfg.Limit).Return(expectedErr).Once()
 
 			// Act
 			output, err := service.CreateTask(ctx, input)
@@ -118,20 +470,49 @@ func Test_CreateTask(t *testing.T) {
 		},
 	)
 
+	t.Run(
+		"Success - salted task", func(t *testing.T) {
+			// Arrange
+			input := &model.HashCrackTaskInput{
+				MaxLength: 5,
+				Hash:      md5Hex("salthash"),
+				Salt:      &model.HashCrackSalt{Value: "salt", Position: "prefix"},
+			}
+
+			sameTask := &entity.HashCrackTaskWithSubtasks{
+				ObjectID: primitive.NewObjectID(),
+			}
+
+			mockTaskRepo.On("GetSame", ctx, mock.Anything, false).Run(
+				func(args mock.Arguments) {
+					task, ok := args.Get(1).(*entity.HashCrackTask)
+					assert.True(t, ok)
+					assert.Equal(t, &entity.HashCrackSalt{Value: "salt", Position: entity.HashSaltPositionPrefix}, task.Salt)
+				},
+			).Return(sameTask, nil).Once()
+
+			// Act
+			output, err := service.CreateTask(ctx, input)
+
+			// Assert
+			require.NoError(t, err)
+			require.Equal(t, sameTask.ObjectID.Hex(), output.RequestID)
+		},
+	)
+
 	t.Run(
 		"Success - already exists", func(t *testing.T) {
 			// Arrange
 			input := &model.HashCrackTaskInput{
 				MaxLength: 5,
-				Hash:      hex.EncodeToString(md5.New().Sum([]byte("hash"))),
+				Hash:      md5Hex("hash"),
 			}
 
 			sameTask := &entity.HashCrackTaskWithSubtasks{
 				ObjectID: primitive.NewObjectID(),
 			}
 
-			mockTaskRepo.On("GetByHashAndMaxLength", ctx, input.Hash, input.MaxLength, false).
-				Return(sameTask, nil).Once()
+			mockTaskRepo.On("GetSame", ctx, mock.Anything, false).Return(sameTask, nil).Once()
 
 			// Act
 			output, err := service.CreateTask(ctx, input)
@@ -148,14 +529,13 @@ func Test_CreateTask(t *testing.T) {
 			// Arrange
 			input := &model.HashCrackTaskInput{
 				MaxLength: 5,
-				Hash:      hex.EncodeToString(md5.New().Sum([]byte("hash"))),
+				Hash:      md5Hex("hash"),
 			}
 
-			mockTaskRepo.On(
-				"GetByHashAndMaxLength", ctx, input.Hash, input.MaxLength, false,
-			).Return(&entity.HashCrackTaskWithSubtasks{}, nil).Once()
-			mockSplitSvc.On("Split", ctx, input.MaxLength, mock.Anything).Return(10, nil).Once()
-			mockTaskWithSubtasksSvc.On("CreateTaskWithSubtasks", ctx, mock.Anything).Return(nil).Once()
+			mockTaskRepo.On("GetSame", ctx, mock.Anything, false).Return(&entity.HashCrackTaskWithSubtasks{}, nil).Once()
+			mockSplitSvc.On("Split", ctx, mock.Anything, 1, input.MaxLength, mock.Anything).
+				Return(infrastructure.KeyPartition{Size: 100, ChunkSize: 10}, nil).Once()
+			mockTaskWithSubtasksSvc.On("CreateTaskWithSubtasks", ctx, mock.Anything, cfg.Limit).Return(nil).Once()
 			mockPublisher.On(
 				"SendMessage", ctx, mock.Anything, publisher.Persistent, false,
 				false,
@@ -173,570 +553,2988 @@ func Test_CreateTask(t *testing.T) {
 			require.NotEmpty(t, output.RequestID)
 		},
 	)
+
 }
 
-func Test_GetTaskStatus(t *testing.T) {
+func Test_CreateDictionaryTask(t *testing.T) {
+	svc, m := newServiceWithMocks()
+
 	t.Run(
-		"Success", func(t *testing.T) {
+		"Wordlist not found", func(t *testing.T) {
 			// Arrange
-			objID := primitive.NewObjectID()
-			taskID := objID.Hex()
-			task := &entity.HashCrackTaskWithSubtasks{
-				ObjectID: objID,
-				Status:   entity.HashCrackTaskStatusReady,
-				Subtasks: []*entity.HashCrackSubtask{
-					{
-						ObjectID: objID,
-						Status:   entity.HashCrackSubtaskStatusSuccess,
-						Data:     []string{"data"},
-					},
-				},
+			wordlistID := primitive.NewObjectID()
+			input := &model.HashCrackTaskInput{
+				Hash:       md5Hex("hash"),
+				Mode:       "dictionary",
+				WordlistID: wordlistID.Hex(),
 			}
 
-			mockTaskRepo.On("Get", ctx, mock.Anything, true).Run(
-				func(args mock.Arguments) {
-					objectID, ok := args.Get(1).(primitive.ObjectID)
-					assert.True(t, ok)
-					assert.Equal(t, taskID, objectID.Hex())
-				},
-			).Return(task, nil).Once()
+			m.taskRepo.On("GetSame", ctx, mock.Anything, false).Return(nil, repository.ErrCrackTaskNotFound).Once()
+			m.wordlistRepo.On("Get", ctx, wordlistID).Return(nil, repository.ErrWordlistNotFound).Once()
 
 			// Act
-			output, err := service.GetTaskStatus(ctx, taskID)
+			output, err := svc.CreateTask(ctx, input)
 
 			// Assert
-			require.NoError(t, err)
-			require.Equal(t, entity.HashCrackTaskStatusReady.String(), output.Status)
-			require.Len(t, output.Data, 1)
-			require.Equal(t, task.Subtasks[0].Data[0], output.Data[0])
-			require.Len(t, output.Subtasks, 1)
-			require.Equal(t, entity.HashCrackSubtaskStatusSuccess.String(), output.Subtasks[0].Status)
-			require.Equal(t, task.Subtasks[0].Data, output.Subtasks[0].Data)
+			require.Error(t, err)
+			require.ErrorIs(t, err, domain.ErrWordlistNotFound)
+			require.Nil(t, output)
 		},
 	)
 
 	t.Run(
-		"Task not found", func(t *testing.T) {
+		"Success - dictionary task", func(t *testing.T) {
 			// Arrange
-			objID := primitive.NewObjectID()
-			taskID := objID.Hex()
+			wordlistID := primitive.NewObjectID()
+			input := &model.HashCrackTaskInput{
+				Hash:       md5Hex("hash"),
+				Mode:       "dictionary",
+				WordlistID: wordlistID.Hex(),
+				MaxLength:  5,
+			}
+			ranges := []infrastructure.LineRange{{Offset: 0, Count: 10}, {Offset: 10, Count: 5}}
 
-			mockTaskRepo.On("Get", ctx, mock.Anything, true).Run(
+			m.taskRepo.On("GetSame", ctx, mock.Anything, false).Run(
 				func(args mock.Arguments) {
-					objectID, ok := args.Get(1).(primitive.ObjectID)
+					task, ok := args.Get(1).(*entity.HashCrackTask)
 					assert.True(t, ok)
-					assert.Equal(t, taskID, objectID.Hex())
+					assert.Equal(t, message.AttackModeDictionary, task.Mode)
+					assert.Equal(t, &wordlistID, task.WordlistID)
+					assert.Zero(t, task.MaxLength)
 				},
 			).Return(nil, repository.ErrCrackTaskNotFound).Once()
+			m.wordlistRepo.On("Get", ctx, wordlistID).Return(&entity.Wordlist{ObjectID: wordlistID, LineCount: 15}, nil).
+				Once()
+			m.wordlistSplitSvc.On("SplitLines", ctx, mock.Anything, 15, 1).Return(ranges, nil).Once()
+			m.taskWithSubtasksSvc.On("CreateTaskWithSubtasks", ctx, mock.Anything, cfg.Limit).Run(
+				func(args mock.Arguments) {
+					task, ok := args.Get(1).(*entity.HashCrackTaskWithSubtasks)
+					assert.True(t, ok)
+					assert.Equal(t, len(ranges), task.PartCount)
+					for i, subtask := range task.Subtasks {
+						assert.Equal(t, &entity.HashCrackLineRange{Offset: ranges[i].Offset, Count: ranges[i].Count}, subtask.Lines)
+					}
+				},
+			).Return(nil).Once()
+			expectCreatedTaskGet(m.taskRepo, m.taskWithSubtasksSvc)
+			m.taskRepo.EXPECT().WithTransaction(ctx, mock.Anything).RunAndReturn(runInTransaction).Once()
+			expectOutbox(m.outboxRepo)
+			m.publisher.On("SendMessage", ctx, mock.Anything, publisher.Persistent, false, false).Run(
+				func(args mock.Arguments) {
+					msg, ok := args.Get(1).(*message.HashCrackTaskStarted)
+					assert.True(t, ok)
+					assert.Equal(t, message.AttackModeDictionary, msg.Mode)
+					assert.Empty(t, msg.Alphabet.Symbols)
+					assert.NotNil(t, msg.Wordlist)
+					assert.Equal(t, wordlistID.Hex(), msg.Wordlist.ID)
+					assert.Equal(t, ranges[msg.PartNumber].Count, msg.Wordlist.Count)
+					assert.Empty(t, msg.Rules)
+				},
+			).Return(nil).Times(len(ranges))
+			m.subtaskRepo.On("Update", mock.Anything, mock.Anything).Return(nil).Times(len(ranges))
+			m.taskRepo.On("Update", mock.Anything, mock.Anything).Return(nil).Once()
 
 			// Act
-			output, err := service.GetTaskStatus(ctx, taskID)
+			output, err := svc.CreateTask(ctx, input)
+
+			time.Sleep(time.Second)
 
 			// Assert
-			require.Error(t, err)
-			require.ErrorIs(t, err, domain.ErrTaskNotFound)
-			require.Nil(t, output)
+			require.NoError(t, err)
+			require.NotEmpty(t, output.RequestID)
+			m.taskWithSubtasksSvc.AssertExpectations(t)
+			m.publisher.AssertExpectations(t)
 		},
 	)
-}
 
-func Test_SaveResultTask(t *testing.T) {
 	t.Run(
-		"WithTransaction error", func(t *testing.T) {
+		"Rule set not found", func(t *testing.T) {
 			// Arrange
-			objID := primitive.NewObjectID()
-			input := &message.HashCrackTaskResult{
-				RequestID: objID.Hex(),
-				Answer: &message.Answer{
-					Words:   []string{"word1", "word2"},
-					Percent: 100.0,
-				},
-				Status: entity.HashCrackSubtaskStatusSuccess.String(),
+			wordlistID, ruleSetID := primitive.NewObjectID(), primitive.NewObjectID()
+			input := &model.HashCrackTaskInput{
+				Hash:       md5Hex("hash"),
+				Mode:       "DICTIONARY",
+				WordlistID: wordlistID.Hex(),
+				RuleSetID:  ruleSetID.Hex(),
 			}
 
-			expectedError := errors.New("error")
-
-			mockTaskRepo.EXPECT().WithTransaction(ctx, mock.Anything).Return(nil, expectedError).Once()
+			m.taskRepo.On("GetSame", ctx, mock.Anything, false).Return(nil, repository.ErrCrackTaskNotFound).Once()
+			m.wordlistRepo.On("Get", ctx, wordlistID).Return(&entity.Wordlist{ObjectID: wordlistID, LineCount: 15}, nil).
+				Once()
+			m.ruleSetRepo.On("Get", ctx, ruleSetID).Return(nil, repository.ErrRuleSetNotFound).Once()
 
 			// Act
-			err := service.SaveResultSubtask(ctx, input)
+			output, err := svc.CreateTask(ctx, input)
 
 			// Assert
-			require.Error(t, err)
-			require.ErrorIs(t, err, expectedError)
+			require.ErrorIs(t, err, domain.ErrRuleSetNotFound)
+			require.Nil(t, output)
 		},
 	)
 
 	t.Run(
-		"Task not found", func(t *testing.T) {
+		"Success - dictionary task with rules", func(t *testing.T) {
 			// Arrange
-			objID := primitive.NewObjectID()
-			input := &message.HashCrackTaskResult{
-				RequestID: objID.Hex(),
-				Answer: &message.Answer{
-					Words:   []string{"word1", "word2"},
-					Percent: 100.0,
-				},
-				Status: entity.HashCrackSubtaskStatusSuccess.String(),
+			wordlistID, ruleSetID := primitive.NewObjectID(), primitive.NewObjectID()
+			input := &model.HashCrackTaskInput{
+				Hash:       md5Hex("hash"),
+				Mode:       "DICTIONARY",
+				WordlistID: wordlistID.Hex(),
+				RuleSetID:  ruleSetID.Hex(),
 			}
+			rules := []string{":", "c $1", "u"}
+			ranges := []infrastructure.LineRange{{Offset: 0, Count: 15}}
 
-			mockTaskRepo.EXPECT().WithTransaction(ctx, mock.Anything).RunAndReturn(
-				func(ctx context.Context, fn func(ctx context.Context) (any, error)) (any, error) {
-					return fn(ctx)
-				}).Once()
-			mockTaskRepo.On("Get", mock.Anything, mock.Anything, true).Run(
+			m.taskRepo.On("GetSame", ctx, mock.Anything, false).Run(
 				func(args mock.Arguments) {
-					objectID, ok := args.Get(1).(primitive.ObjectID)
+					task, ok := args.Get(1).(*entity.HashCrackTask)
 					assert.True(t, ok)
-					assert.Equal(t, objID.Hex(), objectID.Hex())
+					assert.Equal(t, &ruleSetID, task.RuleSetID)
 				},
 			).Return(nil, repository.ErrCrackTaskNotFound).Once()
+			m.wordlistRepo.On("Get", ctx, wordlistID).Return(&entity.Wordlist{ObjectID: wordlistID, LineCount: 15}, nil).
+				Once()
+			m.ruleSetRepo.On("Get", ctx, ruleSetID).Return(&entity.RuleSet{ObjectID: ruleSetID, Rules: rules}, nil).Once()
+			m.wordlistSplitSvc.On("SplitLines", ctx, mock.Anything, 15, len(rules)).Return(ranges, nil).Once()
+			m.taskWithSubtasksSvc.On("CreateTaskWithSubtasks", ctx, mock.Anything, cfg.Limit).Run(
+				func(args mock.Arguments) {
+					task, ok := args.Get(1).(*entity.HashCrackTaskWithSubtasks)
+					assert.True(t, ok)
+					assert.Equal(t, rules, task.Rules)
+				},
+			).Return(nil).Once()
+			expectCreatedTaskGet(m.taskRepo, m.taskWithSubtasksSvc)
+			m.taskRepo.EXPECT().WithTransaction(ctx, mock.Anything).RunAndReturn(runInTransaction).Once()
+			expectOutbox(m.outboxRepo)
+			m.publisher.On("SendMessage", ctx, mock.Anything, publisher.Persistent, false, false).Run(
+				func(args mock.Arguments) {
+					msg, ok := args.Get(1).(*message.HashCrackTaskStarted)
+					assert.True(t, ok)
+					assert.Equal(t, rules, msg.Rules)
+				},
+			).Return(nil).Once()
+			m.subtaskRepo.On("Update", mock.Anything, mock.Anything).Return(nil).Once()
+			m.taskRepo.On("Update", mock.Anything, mock.Anything).Return(nil).Once()
 
 			// Act
-			err := service.SaveResultSubtask(ctx, input)
+			output, err := svc.CreateTask(ctx, input)
+
+			time.Sleep(time.Second)
 
 			// Assert
-			require.Error(t, err)
-			require.ErrorIs(t, err, domain.ErrTaskNotFound)
+			require.NoError(t, err)
+			require.NotEmpty(t, output.RequestID)
+			m.taskWithSubtasksSvc.AssertExpectations(t)
+			m.publisher.AssertExpectations(t)
 		},
 	)
+}
+
+func Test_CreateMaskTask(t *testing.T) {
+	svc, m := newServiceWithMocks()
 
 	t.Run(
-		"Success - Finish task", func(t *testing.T) {
+		"Success - mask task", func(t *testing.T) {
+			// Arrange
+			input := &model.HashCrackTaskInput{
+				Hash:     md5Hex("hash"),
+				Mode:     "mask",
+				Mask:     "?u?1x??",
+				Charsets: []string{"ab?d"},
+			}
+			positions := []string{"ABCDEFGHIJKLMNOPQRSTUVWXYZ", "ab0123456789", "x", "?"}
+
+			m.taskRepo.On("GetSame", ctx, mock.Anything, false).Run(
+				func(args mock.Arguments) {
+					task, ok := args.Get(1).(*entity.HashCrackTask)
+					assert.True(t, ok)
+					assert.Equal(t, message.AttackModeMask, task.Mode)
+					assert.Equal(t, "?u?1x??", task.Mask.Pattern)
+					assert.Zero(t, task.MaxLength)
+				},
+			).Return(nil, repository.ErrCrackTaskNotFound).Once()
+			m.splitSvc.On("SplitMask", ctx, mock.Anything, []int{26, 12, 1, 1}).
+				Return(infrastructure.KeyPartition{Size: 20, ChunkSize: 10}, nil).Once()
+			m.taskWithSubtasksSvc.On("CreateTaskWithSubtasks", ctx, mock.Anything, cfg.Limit).Return(nil).Once()
+			expectCreatedTaskGet(m.taskRepo, m.taskWithSubtasksSvc)
+			m.taskRepo.EXPECT().WithTransaction(ctx, mock.Anything).RunAndReturn(runInTransaction).Once()
+			expectOutbox(m.outboxRepo)
+			m.publisher.On("SendMessage", ctx, mock.Anything, publisher.Persistent, false, false).Run(
+				func(args mock.Arguments) {
+					msg, ok := args.Get(1).(*message.HashCrackTaskStarted)
+					assert.True(t, ok)
+					assert.Equal(t, message.AttackModeMask, msg.Mode)
+					assert.Empty(t, msg.Alphabet.Symbols)
+					assert.Nil(t, msg.Wordlist)
+					assert.Equal(t, &message.Mask{Charsets: positions}, msg.Mask)
+				},
+			).Return(nil).Twice()
+			m.subtaskRepo.On("Update", mock.Anything, mock.Anything).Return(nil).Twice()
+			m.taskRepo.On("Update", mock.Anything, mock.Anything).Return(nil).Once()
+
+			// Act
+			output, err := svc.CreateTask(ctx, input)
+			time.Sleep(time.Second)
+
+			// Assert
+			require.NoError(t, err)
+			require.NotEmpty(t, output.RequestID)
+			m.splitSvc.AssertExpectations(t)
+			m.publisher.AssertExpectations(t)
+		},
+	)
+
+	t.Run(
+		"Keyspace too large", func(t *testing.T) {
+			// Arrange
+			input := &model.HashCrackTaskInput{
+				Hash: md5Hex("hash"),
+				Mode: "MASK",
+				Mask: "?a?a?a?a?a?a?a?a?a?a?a?a",
+			}
+
+			m.taskRepo.On("GetSame", ctx, mock.Anything, false).Return(nil, repository.ErrCrackTaskNotFound).Once()
+			m.splitSvc.On("SplitMask", ctx, mock.Anything, mock.Anything).
+				Return(
+					infrastructure.KeyPartition{}, fmt.Errorf("%w: %w", infrastructure.ErrKeyspaceTooLarge, helper.ErrIntLimits),
+				).Once()
+
+			// Act
+			output, err := svc.CreateTask(ctx, input)
+
+			// Assert
+			require.ErrorIs(t, err, domain.ErrKeyspaceTooLarge)
+			require.Nil(t, output)
+		},
+	)
+}
+
+func Test_CreateBruteForceTask(t *testing.T) {
+	svc, m := newServiceWithMocks()
+
+	t.Run(
+		"Success - custom alphabet and min length", func(t *testing.T) {
+			// Arrange
+			input := &model.HashCrackTaskInput{
+				Hash:      md5Hex("hash"),
+				Alphabet:  "abca",
+				MinLength: 2,
+				MaxLength: 4,
+			}
+
+			m.taskRepo.On("GetSame", ctx, mock.Anything, false).Run(
+				func(args mock.Arguments) {
+					task, ok := args.Get(1).(*entity.HashCrackTask)
+					assert.True(t, ok)
+					assert.Equal(t, "abc", task.Alphabet)
+					assert.Equal(t, 2, task.MinLength)
+					assert.Equal(t, 4, task.MaxLength)
+				},
+			).Return(nil, repository.ErrCrackTaskNotFound).Once()
+			m.splitSvc.On("Split", ctx, message.HashAlgorithmMD5, 2, 4, 3).
+				Return(infrastructure.KeyPartition{Size: 117, ChunkSize: 100}, nil).Once() // 3^2 + 3^3 + 3^4 words
+			m.taskWithSubtasksSvc.On("CreateTaskWithSubtasks", ctx, mock.Anything, cfg.Limit).Run(
+				func(args mock.Arguments) {
+					task, ok := args.Get(1).(*entity.HashCrackTaskWithSubtasks)
+					assert.True(t, ok)
+					assert.Equal(t, 2, task.PartCount)
+					assert.Equal(t, &entity.HashCrackKeyRange{Start: 0, End: 100}, task.Subtasks[0].Range)
+					assert.Equal(t, &entity.HashCrackKeyRange{Start: 100, End: 117}, task.Subtasks[1].Range)
+					assert.Equal(t, message.HashAlgorithmMD5, task.Subtasks[0].Algorithm)
+				},
+			).Return(nil).Once()
+			expectCreatedTaskGet(m.taskRepo, m.taskWithSubtasksSvc)
+			m.taskRepo.EXPECT().WithTransaction(ctx, mock.Anything).RunAndReturn(runInTransaction).Once()
+			expectOutbox(m.outboxRepo)
+			m.publisher.On("SendMessage", ctx, mock.Anything, publisher.Persistent, false, false).Run(
+				func(args mock.Arguments) {
+					msg, ok := args.Get(1).(*message.HashCrackTaskStarted)
+					assert.True(t, ok)
+					assert.Equal(t, []string{"a", "b", "c"}, msg.Alphabet.Symbols)
+					assert.Equal(t, 2, msg.MinLength)
+					assert.Equal(t, 4, msg.MaxLength)
+				},
+			).Return(nil).Twice()
+			m.subtaskRepo.On("Update", mock.Anything, mock.Anything).Return(nil).Twice()
+			m.taskRepo.On("Update", mock.Anything, mock.Anything).Return(nil).Once()
+
+			// Act
+			output, err := svc.CreateTask(ctx, input)
+			time.Sleep(time.Second)
+
+			// Assert
+			require.NoError(t, err)
+			require.NotEmpty(t, output.RequestID)
+			m.splitSvc.AssertExpectations(t)
+			m.publisher.AssertExpectations(t)
+		},
+	)
+
+	t.Run(
+		"Too many subtasks", func(t *testing.T) {
+			// Arrange
+			input := &model.HashCrackTaskInput{Hash: md5Hex("hash"), MaxLength: 6}
+
+			m.taskRepo.On("GetSame", ctx, mock.Anything, false).Return(nil, repository.ErrCrackTaskNotFound).Once()
+			m.splitSvc.On("Split", ctx, mock.Anything, 1, 6, len(cfg.Alphabet)).Return(
+				infrastructure.KeyPartition{Size: (cfg.Split.MaxParts + 1) * 10, ChunkSize: 10}, nil,
+			).Once()
+
+			// Act
+			output, err := svc.CreateTask(ctx, input)
+
+			// Assert
+			require.ErrorIs(t, err, domain.ErrKeyspaceTooLarge)
+			require.Nil(t, output)
+		},
+	)
+}
+
+func Test_CreateBatchTask(t *testing.T) {
+	t.Run(
+		"Invalid input", func(t *testing.T) {
 			cases := []struct {
-				Name          string
-				SubtaskStatus entity.HashCrackSubtaskStatus
-				TaskStatus    entity.HashCrackTaskStatus
+				Name        string
+				Input       *model.HashCrackBatchTaskInput
+				ExpectedErr error
 			}{
 				{
-					"SUCCESS webhook - READY status",
-					entity.HashCrackSubtaskStatusSuccess,
-					entity.HashCrackTaskStatusReady,
-				},
-				{
-					"SUCCESS webhook - PARTIAL_READY status",
-					entity.HashCrackSubtaskStatusSuccess,
-					entity.HashCrackTaskStatusPartialReady,
+					"Empty hash list",
+					&model.HashCrackBatchTaskInput{Hashes: []string{}, MaxLength: 5},
+					domain.ErrInvalidHash,
 				},
 				{
-					"ERROR webhook - PARTIAL_READY status",
-					entity.HashCrackSubtaskStatusError,
-					entity.HashCrackTaskStatusPartialReady,
+					"Too many hashes",
+					&model.HashCrackBatchTaskInput{
+						Hashes:    []string{md5Hex("a"), md5Hex("b"), md5Hex("c")},
+						MaxLength: 5,
+					},
+					domain.ErrTooManyHashes,
 				},
 				{
-					"ERROR webhook - ERROR status",
-					entity.HashCrackSubtaskStatusError,
-					entity.HashCrackTaskStatusError,
+					"Digest size mismatch",
+					&model.HashCrackBatchTaskInput{
+						Algorithm: "SHA1",
+						Hashes:    []string{md5Hex("a")},
+						MaxLength: 5,
+					},
+					domain.ErrInvalidHash,
 				},
 				{
-					"IN_PROGRESS webhook - IN_PROGRESS status",
-					entity.HashCrackSubtaskStatusInProgress,
-					entity.HashCrackTaskStatusInProgress,
+					"Priority exceeds max priority",
+					&model.HashCrackBatchTaskInput{
+						Hashes:    []string{md5Hex("a")},
+						MaxLength: 5,
+						Priority:  256,
+					},
+					domain.ErrInvalidPriority,
 				},
 			}
 
 			for _, c := range cases {
 				t.Run(
 					c.Name, func(t *testing.T) {
-						// Arrange
-						objID := primitive.NewObjectID()
-						input := &message.HashCrackTaskResult{
-							RequestID:  objID.Hex(),
-							PartNumber: 0,
-							Status:     c.SubtaskStatus.String(),
-						}
+						// Act
+						output, err := service.CreateBatchTask(ctx, c.Input)
 
-						switch {
-						case c.SubtaskStatus == entity.HashCrackSubtaskStatusSuccess:
-							input.Answer = &message.Answer{
-								Words:   []string{"word1", "word2"},
-								Percent: 100.0,
-							}
+						// Assert
+						require.Error(t, err)
+						require.ErrorIs(t, err, c.ExpectedErr)
+						require.Nil(t, output)
+					},
+				)
+			}
+		},
+	)
 
-						case c.SubtaskStatus == entity.HashCrackSubtaskStatusInProgress:
-							input.Answer = &message.Answer{
-								Words:   []string{"word1", "word2"},
-								Percent: 50.0,
-							}
+	t.Run(
+		"Success - duplicates are removed", func(t *testing.T) {
+			// Arrange
+			input := &model.HashCrackBatchTaskInput{
+				MaxLength: 5,
+				Hashes:    []string{md5Hex("b"), strings.ToUpper(md5Hex("a")), md5Hex("b"), md5Hex("a")},
+			}
 
-						case c.SubtaskStatus == entity.HashCrackSubtaskStatusError:
-							input.Error = lo.ToPtr("error")
-						}
+			sameTask := &entity.HashCrackTaskWithSubtasks{
+				ObjectID: primitive.NewObjectID(),
+			}
 
-						// Task
-						task := &entity.HashCrackTaskWithSubtasks{
-							ObjectID:  objID,
-							PartCount: 2,
-						}
+			mockTaskRepo.On("GetSame", ctx, mock.Anything, false).Run(
+				func(args mock.Arguments) {
+					task, ok := args.Get(1).(*entity.HashCrackTask)
+					assert.True(t, ok)
+					assert.Equal(t, message.HashAlgorithmMD5, task.Algorithm)
+					assert.Empty(t, task.Hash)
+					assert.ElementsMatch(t, []string{md5Hex("a"), md5Hex("b")}, task.Hashes)
+					assert.True(t, task.IsBatch())
+				},
+			).Return(sameTask, nil).Once()
 
-						// Subtask
-						switch {
-						case c.SubtaskStatus == entity.HashCrackSubtaskStatusSuccess &&
-							c.TaskStatus == entity.HashCrackTaskStatusReady ||
-							c.SubtaskStatus == entity.HashCrackSubtaskStatusError &&
-								c.TaskStatus == entity.HashCrackTaskStatusPartialReady:
+			// Act
+			output, err := service.CreateBatchTask(ctx, input)
 
-							task.Subtasks = []*entity.HashCrackSubtask{
-								{
-									PartNumber: 0,
-									Status:     entity.HashCrackSubtaskStatusSuccess,
-								},
-								{
-									PartNumber: 1,
-									Status:     entity.HashCrackSubtaskStatusInProgress,
-								},
-							}
+			// Assert
+			require.NoError(t, err)
+			require.Equal(t, sameTask.ObjectID.Hex(), output.RequestID)
+		},
+	)
+}
 
-						default:
+// runInTransaction runs the function passed to WithTransaction like a committed transaction
+func runInTransaction(ctx context.Context, fn func(ctx context.Context) (any, error)) (any, error) {
+	return fn(ctx)
+}
 
-							task.Subtasks = []*entity.HashCrackSubtask{
-								{
-									PartNumber: 0,
-									Status:     entity.HashCrackSubtaskStatusError,
-								},
-								{
-									PartNumber: 1,
-									Status:     entity.HashCrackSubtaskStatusInProgress,
-								},
-							}
+// expectCreatedTaskGet lets the execution of the created task read it again, the task is the one passed to
+// CreateTaskWithSubtasks
+func expectCreatedTaskGet(
+	taskRepo *repomock.HashCrackTaskMock, taskWithSubtasksSvc *infrasvcmock.TaskWithSubtasksMock,
+) {
+	taskRepo.EXPECT().Get(mock.Anything, mock.Anything, true).RunAndReturn(
+		func(_ context.Context, id primitive.ObjectID, _ bool) (*entity.HashCrackTaskWithSubtasks, error) {
+			for _, call := range taskWithSubtasksSvc.Calls {
+				task, ok := call.Arguments.Get(1).(*entity.HashCrackTaskWithSubtasks)
+				if call.Method == "CreateTaskWithSubtasks" && ok && task.ObjectID == id {
+					return task, nil
+				}
+			}
+
+			return nil, repository.ErrCrackTaskNotFound
+		},
+	).Maybe()
+}
+
+// expectOutbox lets the outbox save messages of subtasks and relay them at once
+func expectOutbox(outboxRepo *repomock.OutboxMessageMock) {
+	outboxRepo.On("CreateAll", mock.Anything, mock.Anything).Return(nil).Maybe()
+	outboxRepo.On("Lock", mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
+	outboxRepo.On("Delete", mock.Anything, mock.Anything).Return(nil).Maybe()
+}
+
+func md5Hex(word string) string {
+	sum := md5.Sum([]byte(word)) // nolint
+	return hex.EncodeToString(sum[:])
+}
+
+func Test_GetTaskStatus(t *testing.T) {
+	t.Run(
+		"Success", func(t *testing.T) {
+			// Arrange
+			objID := primitive.NewObjectID()
+			taskID := objID.Hex()
+			task := &entity.HashCrackTaskWithSubtasks{
+				ObjectID: objID,
+				Status:   entity.HashCrackTaskStatusReady,
+				Subtasks: []*entity.HashCrackSubtask{
+					{
+						ObjectID: objID,
+						Status:   entity.HashCrackSubtaskStatusSuccess,
+						Data:     []string{"data"},
+					},
+				},
+			}
+
+			mockTaskRepo.On("Get", ctx, mock.Anything, true).Run(
+				func(args mock.Arguments) {
+					objectID, ok := args.Get(1).(primitive.ObjectID)
+					assert.True(t, ok)
+					assert.Equal(t, taskID, objectID.Hex())
+				},
+			).Return(task, nil).Once()
+
+			// Act
+			output, err := service.GetTaskStatus(ctx, taskID)
+
+			// Assert
+			require.NoError(t, err)
+			require.Equal(t, entity.HashCrackTaskStatusReady.String(), output.Status)
+			require.Len(t, output.Data, 1)
+			require.Equal(t, task.Subtasks[0].Data[0], output.Data[0])
+			require.Len(t, output.Subtasks, 1)
+			require.Equal(t, entity.HashCrackSubtaskStatusSuccess.String(), output.Subtasks[0].Status)
+			require.Equal(t, task.Subtasks[0].Data, output.Subtasks[0].Data)
+		},
+	)
+
+	t.Run(
+		"Task not found", func(t *testing.T) {
+			// Arrange
+			objID := primitive.NewObjectID()
+			taskID := objID.Hex()
+
+			mockTaskRepo.On("Get", ctx, mock.Anything, true).Run(
+				func(args mock.Arguments) {
+					objectID, ok := args.Get(1).(primitive.ObjectID)
+					assert.True(t, ok)
+					assert.Equal(t, taskID, objectID.Hex())
+				},
+			).Return(nil, repository.ErrCrackTaskNotFound).Once()
+
+			// Act
+			output, err := service.GetTaskStatus(ctx, taskID)
+
+			// Assert
+			require.Error(t, err)
+			require.ErrorIs(t, err, domain.ErrTaskNotFound)
+			require.Nil(t, output)
+		},
+	)
+}
+
+func Test_GetBatchTaskStatus(t *testing.T) {
+	t.Run(
+		"Success", func(t *testing.T) {
+			// Arrange
+			objID := primitive.NewObjectID()
+			task := &entity.HashCrackTaskWithSubtasks{
+				ObjectID:  objID,
+				Hashes:    []string{md5Hex("a"), md5Hex("b")},
+				PartCount: 2,
+				Status:    entity.HashCrackTaskStatusInProgress,
+				Subtasks: []*entity.HashCrackSubtask{
+					{
+						Status:  entity.HashCrackSubtaskStatusSuccess,
+						Data:    []string{"a"},
+						Found:   []entity.HashCrackFoundHash{{Hash: md5Hex("a"), Word: "a"}},
+						Percent: 100,
+					},
+					{
+						Status:  entity.HashCrackSubtaskStatusInProgress,
+						Percent: 50,
+					},
+				},
+			}
+
+			mockTaskRepo.On("Get", ctx, objID, true).Return(task, nil).Once()
+
+			// Act
+			output, err := service.GetBatchTaskStatus(ctx, objID.Hex())
+
+			// Assert
+			require.NoError(t, err)
+			require.Equal(t, entity.HashCrackTaskStatusInProgress.String(), output.Status)
+			require.Equal(t, 2, output.Total)
+			require.Equal(t, 1, output.Cracked)
+			require.Equal(t, 75.0, output.Percent)
+			require.Equal(
+				t, []model.HashCrackHashStatusOutput{
+					{Hash: md5Hex("a"), Cracked: true, Words: []string{"a"}},
+					{Hash: md5Hex("b"), Cracked: false, Words: []string{}},
+				}, output.Hashes,
+			)
+			require.Len(t, output.Subtasks, 2)
+		},
+	)
+
+	t.Run(
+		"Success - single hash task", func(t *testing.T) {
+			// Arrange
+			objID := primitive.NewObjectID()
+			task := &entity.HashCrackTaskWithSubtasks{
+				ObjectID:  objID,
+				Hash:      md5Hex("a"),
+				PartCount: 1,
+				Status:    entity.HashCrackTaskStatusReady,
+				Subtasks: []*entity.HashCrackSubtask{
+					{
+						Status:  entity.HashCrackSubtaskStatusSuccess,
+						Data:    []string{"a"},
+						Percent: 100,
+					},
+				},
+			}
+
+			mockTaskRepo.On("Get", ctx, objID, true).Return(task, nil).Once()
+
+			// Act
+			output, err := service.GetBatchTaskStatus(ctx, objID.Hex())
+
+			// Assert
+			require.NoError(t, err)
+			require.Equal(t, 1, output.Total)
+			require.Equal(t, 1, output.Cracked)
+			require.Equal(t, []string{"a"}, output.Hashes[0].Words)
+		},
+	)
+
+	t.Run(
+		"Invalid ID", func(t *testing.T) {
+			// Act
+			output, err := service.GetBatchTaskStatus(ctx, "invalid")
+
+			// Assert
+			require.ErrorIs(t, err, domain.ErrInvalidRequestID)
+			require.Nil(t, output)
+		},
+	)
+}
+
+func Test_CancelTask(t *testing.T) {
+	t.Run(
+		"Success", func(t *testing.T) {
+			// Arrange
+			svc, m := newServiceWithMocks()
+			objID := primitive.NewObjectID()
+			task := &entity.HashCrackTaskWithSubtasks{
+				ObjectID: objID,
+				Status:   entity.HashCrackTaskStatusInProgress,
+				Subtasks: []*entity.HashCrackSubtask{
+					{PartNumber: 0, Status: entity.HashCrackSubtaskStatusSuccess},
+					{PartNumber: 1, Status: entity.HashCrackSubtaskStatusInProgress},
+					{PartNumber: 2, Status: entity.HashCrackSubtaskStatusPending},
+				},
+			}
+
+			m.taskRepo.EXPECT().WithTransaction(ctx, mock.Anything).RunAndReturn(runInTransaction).Once()
+			m.taskRepo.On("Get", ctx, objID, true).Return(task, nil).Once()
+			m.taskRepo.On("Update", ctx, mock.Anything).Return(nil).Once()
+			m.subtaskRepo.On("UpdateAll", ctx, task.Subtasks).Return(nil).Once()
+			m.cancelPublisher.On(
+				"SendMessage", ctx, &message.HashCrackTaskCancelled{RequestID: objID.Hex()}, publisher.Persistent,
+				false, false,
+			).Return(nil).Once()
+
+			// Act
+			err := svc.CancelTask(ctx, objID.Hex())
+
+			// Assert
+			require.NoError(t, err)
+			assert.Equal(t, entity.HashCrackTaskStatusCancelled, task.Status)
+			assert.Equal(t, entity.HashCrackSubtaskStatusSuccess, task.Subtasks[0].Status)
+			assert.Equal(t, entity.HashCrackSubtaskStatusCancelled, task.Subtasks[1].Status)
+			assert.Equal(t, entity.HashCrackSubtaskStatusCancelled, task.Subtasks[2].Status)
+			m.taskRepo.AssertExpectations(t)
+			m.subtaskRepo.AssertExpectations(t)
+			m.cancelPublisher.AssertExpectations(t)
+		},
+	)
+
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+	mt.Run(
+		"Success with subtask repo", func(mt *mtest.T) {
+			// Arrange
+			svc, m := newServiceWithSubtaskRepo(
+				hashcracksubtask.NewRepo(log.Logger, mt.Client, config.MongoDBConfig{DB: "test"}),
+			)
+			objID := primitive.NewObjectID()
+			task := &entity.HashCrackTaskWithSubtasks{
+				ObjectID: objID,
+				Status:   entity.HashCrackTaskStatusInProgress,
+				Subtasks: []*entity.HashCrackSubtask{
+					{ObjectID: primitive.NewObjectID(), PartNumber: 0, Status: entity.HashCrackSubtaskStatusInProgress},
+					{ObjectID: primitive.NewObjectID(), PartNumber: 1, Status: entity.HashCrackSubtaskStatusPending},
+				},
+			}
+
+			mt.AddMockResponses(mtest.CreateSuccessResponse(), mtest.CreateSuccessResponse())
+			m.taskRepo.EXPECT().WithTransaction(ctx, mock.Anything).RunAndReturn(runInTransaction).Once()
+			m.taskRepo.On("Get", ctx, objID, true).Return(task, nil).Once()
+			m.taskRepo.On("Update", ctx, mock.Anything).Return(nil).Once()
+			m.cancelPublisher.On(
+				"SendMessage", ctx, &message.HashCrackTaskCancelled{RequestID: objID.Hex()}, publisher.Persistent,
+				false, false,
+			).Return(nil).Once()
+
+			// Act
+			err := svc.CancelTask(ctx, objID.Hex())
+
+			// Assert
+			require.NoError(mt, err)
+			assert.Equal(mt, entity.HashCrackTaskStatusCancelled, task.Status)
+			m.cancelPublisher.AssertExpectations(mt)
+		},
+	)
+
+	t.Run(
+		"Publish error", func(t *testing.T) {
+			// Arrange
+			svc, m := newServiceWithMocks()
+			objID := primitive.NewObjectID()
+			task := &entity.HashCrackTaskWithSubtasks{
+				ObjectID: objID,
+				Status:   entity.HashCrackTaskStatusPending,
+			}
+
+			m.taskRepo.EXPECT().WithTransaction(ctx, mock.Anything).RunAndReturn(runInTransaction).Once()
+			m.taskRepo.On("Get", ctx, objID, true).Return(task, nil).Once()
+			m.taskRepo.On("Update", ctx, mock.Anything).Return(nil).Once()
+			m.subtaskRepo.On("UpdateAll", ctx, task.Subtasks).Return(nil).Once()
+			m.cancelPublisher.On("SendMessage", ctx, mock.Anything, publisher.Persistent, false, false).
+				Return(errors.New("publish failed")).Once()
+
+			// Act
+			err := svc.CancelTask(ctx, objID.Hex())
+
+			// Assert
+			require.NoError(t, err)
+			assert.Equal(t, entity.HashCrackTaskStatusCancelled, task.Status)
+		},
+	)
+
+	t.Run(
+		"Already cancelled", func(t *testing.T) {
+			// Arrange
+			svc, m := newServiceWithMocks()
+			objID := primitive.NewObjectID()
+
+			m.taskRepo.EXPECT().WithTransaction(ctx, mock.Anything).RunAndReturn(runInTransaction).Once()
+			m.taskRepo.On("Get", ctx, objID, true).Return(
+				&entity.HashCrackTaskWithSubtasks{ObjectID: objID, Status: entity.HashCrackTaskStatusCancelled}, nil,
+			).Once()
+
+			// Act
+			err := svc.CancelTask(ctx, objID.Hex())
+
+			// Assert
+			require.NoError(t, err)
+			m.taskRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+			m.cancelPublisher.AssertNotCalled(
+				t, "SendMessage", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything,
+			)
+		},
+	)
+
+	t.Run(
+		"Already finished", func(t *testing.T) {
+			for _, status := range []entity.HashCrackTaskStatus{
+				entity.HashCrackTaskStatusReady,
+				entity.HashCrackTaskStatusPartialReady,
+				entity.HashCrackTaskStatusError,
+			} {
+				t.Run(
+					status.String(), func(t *testing.T) {
+						// Arrange
+						svc, m := newServiceWithMocks()
+						objID := primitive.NewObjectID()
+
+						m.taskRepo.EXPECT().WithTransaction(ctx, mock.Anything).RunAndReturn(runInTransaction).Once()
+						m.taskRepo.On("Get", ctx, objID, true).Return(
+							&entity.HashCrackTaskWithSubtasks{ObjectID: objID, Status: status}, nil,
+						).Once()
+
+						// Act
+						err := svc.CancelTask(ctx, objID.Hex())
+
+						// Assert
+						require.ErrorIs(t, err, domain.ErrTaskFinished)
+					},
+				)
+			}
+		},
+	)
+
+	t.Run(
+		"Update error", func(t *testing.T) {
+			// Arrange
+			svc, m := newServiceWithMocks()
+			objID := primitive.NewObjectID()
+			task := &entity.HashCrackTaskWithSubtasks{
+				ObjectID: objID,
+				Status:   entity.HashCrackTaskStatusInProgress,
+			}
+			expectedErr := errors.New("update failed")
+
+			m.taskRepo.EXPECT().WithTransaction(ctx, mock.Anything).RunAndReturn(runInTransaction).Once()
+			m.taskRepo.On("Get", ctx, objID, true).Return(task, nil).Once()
+			m.taskRepo.On("Update", ctx, mock.Anything).Return(expectedErr).Once()
+
+			// Act
+			err := svc.CancelTask(ctx, objID.Hex())
+
+			// Assert
+			require.ErrorIs(t, err, expectedErr)
+			m.cancelPublisher.AssertNotCalled(
+				t, "SendMessage", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything,
+			)
+		},
+	)
+
+	t.Run(
+		"Invalid ID", func(t *testing.T) {
+			// Arrange
+			svc, _ := newServiceWithMocks()
+
+			// Act
+			err := svc.CancelTask(ctx, "invalid")
+
+			// Assert
+			require.ErrorIs(t, err, domain.ErrInvalidRequestID)
+		},
+	)
+
+	t.Run(
+		"Task not found", func(t *testing.T) {
+			// Arrange
+			svc, m := newServiceWithMocks()
+			objID := primitive.NewObjectID()
+
+			m.taskRepo.EXPECT().WithTransaction(ctx, mock.Anything).RunAndReturn(runInTransaction).Once()
+			m.taskRepo.On("Get", ctx, objID, true).Return(nil, repository.ErrCrackTaskNotFound).Once()
+
+			// Act
+			err := svc.CancelTask(ctx, objID.Hex())
+
+			// Assert
+			require.ErrorIs(t, err, domain.ErrTaskNotFound)
+		},
+	)
+}
+
+func Test_SaveResultTask(t *testing.T) {
+	t.Run(
+		"WithTransaction error", func(t *testing.T) {
+			// Arrange
+			objID := primitive.NewObjectID()
+			input := &message.HashCrackTaskResult{
+				Version:   message.Version,
+				RequestID: objID.Hex(),
+				Answer: &message.Answer{
+					Words:   []string{"word1", "word2"},
+					Percent: 100.0,
+				},
+				Status: entity.HashCrackSubtaskStatusSuccess.String(),
+			}
+
+			expectedError := errors.New("error")
+
+			mockTaskRepo.EXPECT().WithTransaction(ctx, mock.Anything).Return(nil, expectedError).Once()
+
+			// Act
+			err := service.SaveResultSubtask(ctx, input)
+
+			// Assert
+			require.Error(t, err)
+			require.ErrorIs(t, err, expectedError)
+		},
+	)
+
+	t.Run(
+		"Task not found", func(t *testing.T) {
+			// Arrange
+			objID := primitive.NewObjectID()
+			input := &message.HashCrackTaskResult{
+				Version:   message.Version,
+				RequestID: objID.Hex(),
+				Answer: &message.Answer{
+					Words:   []string{"word1", "word2"},
+					Percent: 100.0,
+				},
+				Status: entity.HashCrackSubtaskStatusSuccess.String(),
+			}
+
+			mockTaskRepo.EXPECT().WithTransaction(ctx, mock.Anything).RunAndReturn(
+				func(ctx context.Context, fn func(ctx context.Context) (any, error)) (any, error) {
+					return fn(ctx)
+				}).Once()
+			mockTaskRepo.On("Get", mock.Anything, mock.Anything, true).Run(
+				func(args mock.Arguments) {
+					objectID, ok := args.Get(1).(primitive.ObjectID)
+					assert.True(t, ok)
+					assert.Equal(t, objID.Hex(), objectID.Hex())
+				},
+			).Return(nil, repository.ErrCrackTaskNotFound).Once()
+
+			// Act
+			err := service.SaveResultSubtask(ctx, input)
+
+			// Assert
+			require.Error(t, err)
+			require.ErrorIs(t, err, domain.ErrTaskNotFound)
+		},
+	)
+
+	t.Run(
+		"Cancelled task", func(t *testing.T) {
+			// Arrange
+			svc, m := newServiceWithMocks()
+			objID := primitive.NewObjectID()
+			input := &message.HashCrackTaskResult{
+				Version:   message.Version,
+				RequestID: objID.Hex(),
+				Answer: &message.Answer{
+					Words:   []string{"word1"},
+					Percent: 100.0,
+				},
+				Status: entity.HashCrackSubtaskStatusSuccess.String(),
+			}
+
+			m.taskRepo.EXPECT().WithTransaction(ctx, mock.Anything).RunAndReturn(
+				func(ctx context.Context, fn func(ctx context.Context) (any, error)) (any, error) {
+					return fn(ctx)
+				}).Once()
+			m.taskRepo.On("Get", ctx, objID, true).Return(
+				&entity.HashCrackTaskWithSubtasks{
+					ObjectID: objID,
+					Status:   entity.HashCrackTaskStatusCancelled,
+					Subtasks: []*entity.HashCrackSubtask{
+						{PartNumber: 0, Status: entity.HashCrackSubtaskStatusCancelled},
+					},
+				}, nil,
+			).Once()
+
+			// Act
+			err := svc.SaveResultSubtask(ctx, input)
+
+			// Assert
+			require.ErrorIs(t, err, domain.ErrTaskCancelled)
+			m.subtaskRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+		},
+	)
+
+	t.Run(
+		"Stale attempt", func(t *testing.T) {
+			// Arrange
+			svc, m := newServiceWithMocks()
+			objID := primitive.NewObjectID()
+			input := &message.HashCrackTaskResult{
+				Version:    message.Version,
+				RequestID:  objID.Hex(),
+				PartNumber: 0,
+				Attempt:    1,
+				Answer: &message.Answer{
+					Words:   []string{"abc"},
+					Percent: 100.0,
+				},
+				Status: entity.HashCrackSubtaskStatusSuccess.String(),
+			}
+
+			m.taskRepo.EXPECT().WithTransaction(ctx, mock.Anything).RunAndReturn(runInTransaction).Once()
+			m.taskRepo.On("Get", ctx, objID, true).Return(
+				&entity.HashCrackTaskWithSubtasks{
+					ObjectID: objID,
+					Status:   entity.HashCrackTaskStatusInProgress,
+					Subtasks: []*entity.HashCrackSubtask{
+						{PartNumber: 0, Status: entity.HashCrackSubtaskStatusInProgress, Attempts: 2},
+					},
+				}, nil,
+			).Once()
+
+			// Act
+			err := svc.SaveResultSubtask(ctx, input)
+
+			// Assert
+			require.ErrorIs(t, err, domain.ErrStaleAttempt)
+			m.subtaskRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+		},
+	)
+
+	t.Run(
+		"Timed out task", func(t *testing.T) {
+			// Arrange
+			svc, m := newServiceWithMocks()
+			objID := primitive.NewObjectID()
+			input := &message.HashCrackTaskResult{
+				Version:    message.Version,
+				RequestID:  objID.Hex(),
+				PartNumber: 0,
+				Answer: &message.Answer{
+					Words:   []string{"abc"},
+					Percent: 100.0,
+				},
+				Status: entity.HashCrackSubtaskStatusSuccess.String(),
+			}
+
+			m.taskRepo.EXPECT().WithTransaction(ctx, mock.Anything).RunAndReturn(
+				func(ctx context.Context, fn func(ctx context.Context) (any, error)) (any, error) {
+					return fn(ctx)
+				}).Once()
+			m.taskRepo.On("Get", ctx, objID, true).Return(
+				&entity.HashCrackTaskWithSubtasks{
+					ObjectID: objID,
+					Status:   entity.HashCrackTaskStatusError,
+					Reason:   lo.ToPtr(domain.ErrTaskFinishedByTimeout.Error()),
+					Subtasks: []*entity.HashCrackSubtask{
+						{PartNumber: 0, Status: entity.HashCrackSubtaskStatusError},
+					},
+				}, nil,
+			).Once()
+
+			// Act
+			err := svc.SaveResultSubtask(ctx, input)
+
+			// Assert
+			require.ErrorIs(t, err, domain.ErrTaskFinishedByTimeout)
+			m.subtaskRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+		},
+	)
+
+	t.Run(
+		"Release pending subtasks", func(t *testing.T) {
+			// Arrange
+			limitedCfg := cfg
+			limitedCfg.InFlightLimit = 2
+			svc, m := newServiceWithConfig(limitedCfg)
+
+			objID := primitive.NewObjectID()
+			input := &message.HashCrackTaskResult{
+				Version:    message.Version,
+				RequestID:  objID.Hex(),
+				PartNumber: 0,
+				Answer: &message.Answer{
+					Words:   []string{},
+					Percent: 100.0,
+				},
+				Status: entity.HashCrackSubtaskStatusSuccess.String(),
+			}
+			task := &entity.HashCrackTaskWithSubtasks{
+				ObjectID:  objID,
+				Hash:      "900150983cd24fb0d6963f7d28e17f72",
+				PartCount: 4,
+				MaxLength: 3,
+				Priority:  5,
+				Status:    entity.HashCrackTaskStatusInProgress,
+				Subtasks: []*entity.HashCrackSubtask{
+					{PartNumber: 0, Status: entity.HashCrackSubtaskStatusInProgress},
+					{PartNumber: 1, Status: entity.HashCrackSubtaskStatusInProgress},
+					{PartNumber: 3, Status: entity.HashCrackSubtaskStatusPending},
+					{PartNumber: 2, Status: entity.HashCrackSubtaskStatusPending},
+				},
+			}
+
+			m.taskRepo.EXPECT().WithTransaction(ctx, mock.Anything).RunAndReturn(
+				func(ctx context.Context, fn func(ctx context.Context) (any, error)) (any, error) {
+					return fn(ctx)
+				}).Once()
+			m.taskRepo.On("Get", ctx, objID, true).Return(task, nil).Once()
+			m.subtaskRepo.On("Update", ctx, mock.Anything).Return(nil).Twice()
+			m.outboxRepo.On(
+				"CreateAll", ctx, mock.MatchedBy(
+					func(messages []*entity.OutboxMessage) bool {
+						return len(messages) == 1 && messages[0].TaskID == objID && messages[0].PartNumber == 2
+					},
+				),
+			).Return(nil).Once()
+			m.outboxRepo.On("Lock", ctx, mock.Anything, mock.Anything).Return(nil).Once()
+			m.publisher.On(
+				"SendMessage", ctx, mock.MatchedBy(
+					func(msg *message.HashCrackTaskStarted) bool {
+						return msg.PartNumber == 2 && msg.Priority == 5
+					},
+				), publisher.Persistent, false, false,
+			).Return(nil).Once()
+			m.outboxRepo.On("Delete", ctx, mock.Anything).Return(nil).Once()
+
+			// Act
+			err := svc.SaveResultSubtask(ctx, input)
+
+			// Assert
+			require.NoError(t, err)
+			assert.Equal(t, entity.HashCrackSubtaskStatusSuccess, task.Subtasks[0].Status)
+			assert.Equal(t, entity.HashCrackSubtaskStatusPending, task.Subtasks[2].Status)
+			assert.Equal(t, entity.HashCrackSubtaskStatusInProgress, task.Subtasks[3].Status)
+			assert.Equal(t, 1, task.Subtasks[3].Attempts)
+			m.subtaskRepo.AssertExpectations(t)
+			m.outboxRepo.AssertExpectations(t)
+			m.publisher.AssertExpectations(t)
+			m.taskRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+		},
+	)
+
+	t.Run(
+		"Renew lease", func(t *testing.T) {
+			// Arrange
+			svc, m := newServiceWithMocks()
+			objID := primitive.NewObjectID()
+			input := &message.HashCrackTaskResult{
+				Version:    message.Version,
+				RequestID:  objID.Hex(),
+				PartNumber: 0,
+				Answer: &message.Answer{
+					Words:   []string{},
+					Percent: 40.0,
+				},
+				Status: entity.HashCrackSubtaskStatusInProgress.String(),
+			}
+			task := &entity.HashCrackTaskWithSubtasks{
+				ObjectID:  objID,
+				Hash:      "900150983cd24fb0d6963f7d28e17f72",
+				PartCount: 2,
+				MaxLength: 3,
+				Status:    entity.HashCrackTaskStatusInProgress,
+				Subtasks: []*entity.HashCrackSubtask{
+					{PartNumber: 0, Status: entity.HashCrackSubtaskStatusInProgress, Attempts: 1},
+					{PartNumber: 1, Status: entity.HashCrackSubtaskStatusInProgress, Attempts: 1},
+				},
+			}
+
+			m.taskRepo.EXPECT().WithTransaction(ctx, mock.Anything).RunAndReturn(
+				func(ctx context.Context, fn func(ctx context.Context) (any, error)) (any, error) {
+					return fn(ctx)
+				}).Once()
+			m.taskRepo.On("Get", ctx, objID, true).Return(task, nil).Once()
+			m.subtaskRepo.On("Update", ctx, task.Subtasks[0]).Return(nil).Once()
+
+			// Act
+			err := svc.SaveResultSubtask(ctx, input)
+
+			// Assert
+			require.NoError(t, err)
+			require.NotNil(t, task.Subtasks[0].LeaseExpiresAt)
+			assert.WithinDuration(t, time.Now().Add(cfg.LeaseTimeout), *task.Subtasks[0].LeaseExpiresAt, time.Second)
+			assert.Nil(t, task.Subtasks[1].LeaseExpiresAt)
+			m.subtaskRepo.AssertExpectations(t)
+			m.taskRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+		},
+	)
+
+	t.Run(
+		"Unsupported message version", func(t *testing.T) {
+			// Arrange
+			svc, m := newServiceWithMocks()
+			objID := primitive.NewObjectID()
+			input := &message.HashCrackTaskResult{
+				RequestID:  objID.Hex(),
+				PartNumber: 0,
+				Answer: &message.Answer{
+					Words:   []string{"abc"},
+					Percent: 100.0,
+				},
+				Status: entity.HashCrackSubtaskStatusSuccess.String(),
+			}
+			task := &entity.HashCrackTaskWithSubtasks{
+				ObjectID:  objID,
+				Hash:      "900150983cd24fb0d6963f7d28e17f72",
+				PartCount: 2,
+				MaxLength: 3,
+				Status:    entity.HashCrackTaskStatusInProgress,
+				Subtasks: []*entity.HashCrackSubtask{
+					{PartNumber: 0, Status: entity.HashCrackSubtaskStatusInProgress, Attempts: 1},
+					{PartNumber: 1, Status: entity.HashCrackSubtaskStatusInProgress, Attempts: 1},
+				},
+			}
+
+			m.taskRepo.EXPECT().WithTransaction(ctx, mock.Anything).RunAndReturn(
+				func(ctx context.Context, fn func(ctx context.Context) (any, error)) (any, error) {
+					return fn(ctx)
+				}).Once()
+			m.taskRepo.On("Get", ctx, objID, true).Return(task, nil).Once()
+			m.subtaskRepo.On("Update", ctx, task.Subtasks[0]).Return(nil).Once()
+
+			// Act
+			err := svc.SaveResultSubtask(ctx, input)
+
+			// Assert
+			require.NoError(t, err)
+			assert.Equal(t, entity.HashCrackSubtaskStatusError, task.Subtasks[0].Status)
+			assert.Equal(t, lo.ToPtr("unsupported message version: 0, expected 1"), task.Subtasks[0].Reason)
+			assert.Empty(t, task.Subtasks[0].Data)
+			m.subtaskRepo.AssertExpectations(t)
+		},
+	)
+
+	t.Run(
+		"Save checkpoint", func(t *testing.T) {
+			// Arrange
+			svc, m := newServiceWithMocks()
+			objID := primitive.NewObjectID()
+			input := &message.HashCrackTaskResult{
+				Version:    message.Version,
+				RequestID:  objID.Hex(),
+				PartNumber: 0,
+				Answer: &message.Answer{
+					Words:   []string{"xyz"},
+					Percent: 70.0,
+				},
+				Status:     entity.HashCrackSubtaskStatusInProgress.String(),
+				Checkpoint: 70,
+				Speed:      1500.0,
+			}
+			task := &entity.HashCrackTaskWithSubtasks{
+				ObjectID:  objID,
+				Hashes:    []string{"900150983cd24fb0d6963f7d28e17f72", "d16fb36f0911f878998c136191af705e"},
+				PartCount: 1,
+				MaxLength: 3,
+				Status:    entity.HashCrackTaskStatusInProgress,
+				Subtasks: []*entity.HashCrackSubtask{
+					{
+						PartNumber: 0,
+						Status:     entity.HashCrackSubtaskStatusInProgress,
+						Data:       []string{"abc"},
+						Found:      []entity.HashCrackFoundHash{{Hash: "900150983cd24fb0d6963f7d28e17f72", Word: "abc"}},
+						Checkpoint: 50,
+						Attempts:   2,
+					},
+				},
+			}
+
+			m.taskRepo.EXPECT().WithTransaction(ctx, mock.Anything).RunAndReturn(
+				func(ctx context.Context, fn func(ctx context.Context) (any, error)) (any, error) {
+					return fn(ctx)
+				}).Once()
+			m.taskRepo.On("Get", ctx, objID, true).Return(task, nil).Once()
+			m.subtaskRepo.On("Update", ctx, task.Subtasks[0]).Return(nil).Once()
+
+			// Act
+			err := svc.SaveResultSubtask(ctx, input)
+
+			// Assert
+			require.NoError(t, err)
+			assert.Equal(t, 70, task.Subtasks[0].Checkpoint)
+			assert.Equal(t, 1500.0, task.Subtasks[0].Speed)
+			assert.Equal(t, []string{"abc", "xyz"}, task.Subtasks[0].Data)
+			assert.Len(t, task.Subtasks[0].Found, 1)
+			m.subtaskRepo.AssertExpectations(t)
+		},
+	)
+
+	t.Run(
+		"Stop on first match", func(t *testing.T) {
+			// Arrange
+			svc, m := newServiceWithMocks()
+			objID := primitive.NewObjectID()
+			input := &message.HashCrackTaskResult{
+				Version:    message.Version,
+				RequestID:  objID.Hex(),
+				PartNumber: 0,
+				Answer: &message.Answer{
+					Words:   []string{"abc"},
+					Percent: 40.0,
+				},
+				Status: entity.HashCrackSubtaskStatusInProgress.String(),
+			}
+			task := &entity.HashCrackTaskWithSubtasks{
+				ObjectID:         objID,
+				Hash:             "900150983cd24fb0d6963f7d28e17f72",
+				PartCount:        3,
+				StopOnFirstMatch: true,
+				Status:           entity.HashCrackTaskStatusInProgress,
+				Subtasks: []*entity.HashCrackSubtask{
+					{PartNumber: 0, Status: entity.HashCrackSubtaskStatusInProgress},
+					{PartNumber: 1, Status: entity.HashCrackSubtaskStatusSuccess},
+					{PartNumber: 2, Status: entity.HashCrackSubtaskStatusPending},
+				},
+			}
+
+			m.taskRepo.EXPECT().WithTransaction(ctx, mock.Anything).RunAndReturn(
+				func(ctx context.Context, fn func(ctx context.Context) (any, error)) (any, error) {
+					return fn(ctx)
+				}).Once()
+			m.taskRepo.On("Get", ctx, objID, true).Return(task, nil).Once()
+			m.subtaskRepo.On("Update", ctx, mock.Anything).Return(nil).Times(3)
+			m.taskRepo.On("Update", ctx, mock.Anything).Return(nil).Once()
+			m.cancelPublisher.On(
+				"SendMessage", ctx, &message.HashCrackTaskCancelled{RequestID: objID.Hex()}, publisher.Persistent,
+				false, false,
+			).Return(nil).Once()
+
+			// Act
+			err := svc.SaveResultSubtask(ctx, input)
+
+			// Assert
+			require.NoError(t, err)
+			assert.Equal(t, entity.HashCrackSubtaskStatusSuccess, task.Subtasks[0].Status)
+			assert.Nil(t, task.Subtasks[0].LeaseExpiresAt)
+			assert.Equal(t, entity.HashCrackSubtaskStatusSuccess, task.Subtasks[1].Status)
+			assert.Equal(t, entity.HashCrackSubtaskStatusSkipped, task.Subtasks[2].Status)
+			assert.Equal(t, []string{"abc"}, task.Subtasks[0].Data)
+			m.taskRepo.AssertCalled(
+				t, "Update", ctx, mock.MatchedBy(
+					func(task *entity.HashCrackTask) bool {
+						return task.Status == entity.HashCrackTaskStatusReady
+					},
+				),
+			)
+			m.subtaskRepo.AssertExpectations(t)
+			m.cancelPublisher.AssertExpectations(t)
+		},
+	)
+
+	t.Run(
+		"Skipped subtask", func(t *testing.T) {
+			// Arrange
+			svc, m := newServiceWithMocks()
+			objID := primitive.NewObjectID()
+			input := &message.HashCrackTaskResult{
+				Version:    message.Version,
+				RequestID:  objID.Hex(),
+				PartNumber: 1,
+				Answer: &message.Answer{
+					Words:   []string{"abc"},
+					Percent: 100.0,
+				},
+				Status: entity.HashCrackSubtaskStatusSuccess.String(),
+			}
+
+			m.taskRepo.EXPECT().WithTransaction(ctx, mock.Anything).RunAndReturn(
+				func(ctx context.Context, fn func(ctx context.Context) (any, error)) (any, error) {
+					return fn(ctx)
+				}).Once()
+			m.taskRepo.On("Get", ctx, objID, true).Return(
+				&entity.HashCrackTaskWithSubtasks{
+					ObjectID:         objID,
+					StopOnFirstMatch: true,
+					Status:           entity.HashCrackTaskStatusReady,
+					Subtasks: []*entity.HashCrackSubtask{
+						{PartNumber: 0, Status: entity.HashCrackSubtaskStatusSkipped, Data: []string{"abc"}},
+						{PartNumber: 1, Status: entity.HashCrackSubtaskStatusSkipped},
+					},
+				}, nil,
+			).Once()
+
+			// Act
+			err := svc.SaveResultSubtask(ctx, input)
+
+			// Assert
+			require.ErrorIs(t, err, domain.ErrSubtaskSkipped)
+			m.subtaskRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+			m.cancelPublisher.AssertNotCalled(
+				t, "SendMessage", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything,
+			)
+		},
+	)
+
+	t.Run(
+		"Late result of matching subtask", func(t *testing.T) {
+			// Arrange
+			svc, m := newServiceWithMocks()
+			objID := primitive.NewObjectID()
+			input := &message.HashCrackTaskResult{
+				Version:    message.Version,
+				RequestID:  objID.Hex(),
+				PartNumber: 0,
+				Answer: &message.Answer{
+					Words:   []string{"abc"},
+					Percent: 40.0,
+				},
+				Status: entity.HashCrackSubtaskStatusError.String(),
+				Error:  lo.ToPtr("context canceled"),
+			}
+
+			m.taskRepo.EXPECT().WithTransaction(ctx, mock.Anything).RunAndReturn(runInTransaction).Once()
+			m.taskRepo.On("Get", ctx, objID, true).Return(
+				&entity.HashCrackTaskWithSubtasks{
+					ObjectID:         objID,
+					StopOnFirstMatch: true,
+					Status:           entity.HashCrackTaskStatusReady,
+					Subtasks: []*entity.HashCrackSubtask{
+						{PartNumber: 0, Status: entity.HashCrackSubtaskStatusSuccess, Data: []string{"abc"}},
+						{PartNumber: 1, Status: entity.HashCrackSubtaskStatusSkipped},
+					},
+				}, nil,
+			).Once()
+
+			// Act
+			err := svc.SaveResultSubtask(ctx, input)
+
+			// Assert
+			require.ErrorIs(t, err, domain.ErrSubtaskSkipped)
+			m.subtaskRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+		},
+	)
+
+	t.Run(
+		"Success - Finish task", func(t *testing.T) {
+			cases := []struct {
+				Name          string
+				SubtaskStatus entity.HashCrackSubtaskStatus
+				TaskStatus    entity.HashCrackTaskStatus
+			}{
+				{
+					"SUCCESS webhook - READY status",
+					entity.HashCrackSubtaskStatusSuccess,
+					entity.HashCrackTaskStatusReady,
+				},
+				{
+					"SUCCESS webhook - PARTIAL_READY status",
+					entity.HashCrackSubtaskStatusSuccess,
+					entity.HashCrackTaskStatusPartialReady,
+				},
+				{
+					"ERROR webhook - PARTIAL_READY status",
+					entity.HashCrackSubtaskStatusError,
+					entity.HashCrackTaskStatusPartialReady,
+				},
+				{
+					"ERROR webhook - ERROR status",
+					entity.HashCrackSubtaskStatusError,
+					entity.HashCrackTaskStatusError,
+				},
+				{
+					"IN_PROGRESS webhook - IN_PROGRESS status",
+					entity.HashCrackSubtaskStatusInProgress,
+					entity.HashCrackTaskStatusInProgress,
+				},
+			}
+
+			for _, c := range cases {
+				t.Run(
+					c.Name, func(t *testing.T) {
+						// Arrange
+						objID := primitive.NewObjectID()
+						input := &message.HashCrackTaskResult{
+							Version:    message.Version,
+							RequestID:  objID.Hex(),
+							PartNumber: 0,
+							Status:     c.SubtaskStatus.String(),
+						}
+
+						switch {
+						case c.SubtaskStatus == entity.HashCrackSubtaskStatusSuccess:
+							input.Answer = &message.Answer{
+								Words:   []string{"word1", "word2"},
+								Percent: 100.0,
+							}
+
+						case c.SubtaskStatus == entity.HashCrackSubtaskStatusInProgress:
+							input.Answer = &message.Answer{
+								Words:   []string{"word1", "word2"},
+								Percent: 50.0,
+							}
+
+						case c.SubtaskStatus == entity.HashCrackSubtaskStatusError:
+							input.Error = lo.ToPtr("error")
+						}
+
+						// Task
+						task := &entity.HashCrackTaskWithSubtasks{
+							ObjectID:  objID,
+							PartCount: 2,
+						}
+
+						// Subtask
+						switch {
+						case c.SubtaskStatus == entity.HashCrackSubtaskStatusSuccess &&
+							c.TaskStatus == entity.HashCrackTaskStatusReady ||
+							c.SubtaskStatus == entity.HashCrackSubtaskStatusError &&
+								c.TaskStatus == entity.HashCrackTaskStatusPartialReady:
+
+							task.Subtasks = []*entity.HashCrackSubtask{
+								{
+									PartNumber: 0,
+									Status:     entity.HashCrackSubtaskStatusSuccess,
+								},
+								{
+									PartNumber: 1,
+									Status:     entity.HashCrackSubtaskStatusInProgress,
+								},
+							}
+
+						default:
+
+							task.Subtasks = []*entity.HashCrackSubtask{
+								{
+									PartNumber: 0,
+									Status:     entity.HashCrackSubtaskStatusError,
+								},
+								{
+									PartNumber: 1,
+									Status:     entity.HashCrackSubtaskStatusInProgress,
+								},
+							}
+
+						}
+
+						mockTaskRepo.EXPECT().WithTransaction(ctx, mock.Anything).RunAndReturn(
+							func(ctx context.Context, fn func(ctx context.Context) (any, error)) (any, error) {
+								return fn(ctx)
+							}).Once()
+						mockTaskRepo.On("Get", mock.Anything, mock.Anything, true).Run(
+							func(args mock.Arguments) {
+								objectID, ok := args.Get(1).(primitive.ObjectID)
+								assert.True(t, ok)
+								assert.Equal(t, objID.Hex(), objectID.Hex())
+							},
+						).Return(task, nil).Once()
+
+						mockSubtaskRepo.On("Update", mock.Anything, mock.Anything).Return(nil).Once()
+						call := mockTaskRepo.On("Update", mock.Anything, mock.Anything).Run(
+							func(args mock.Arguments) {
+								task, ok := args.Get(1).(*entity.HashCrackTask)
+								assert.True(t, ok)
+
+								if task.Status == "" {
+									return
+								}
+								assert.Equal(t, c.TaskStatus, task.Status)
+							},
+						).Return(nil)
+
+						if c.SubtaskStatus == entity.HashCrackSubtaskStatusInProgress {
+							call.Once()
+						} else {
+							call.Twice()
+						}
+
+						// Act
+						err := service.SaveResultSubtask(ctx, input)
+
+						// Assert
+						require.NoError(t, err)
+					},
+				)
+			}
+		},
+	)
+
+	t.Run(
+		"Success - Not finish task", func(t *testing.T) {
+			// Arrange
+			objID := primitive.NewObjectID()
+			input := &message.HashCrackTaskResult{
+				Version:    message.Version,
+				RequestID:  objID.Hex(),
+				PartNumber: 0,
+				Answer: &message.Answer{
+					Words:   []string{"word1", "word2"},
+					Percent: 100.0,
+				},
+				Status: entity.HashCrackSubtaskStatusSuccess.String(),
+			}
+
+			task := &entity.HashCrackTaskWithSubtasks{
+				ObjectID:  objID,
+				PartCount: 2,
+				Subtasks: []*entity.HashCrackSubtask{
+					{
+						PartNumber: 0,
+						Status:     entity.HashCrackSubtaskStatusInProgress,
+					},
+					{
+						PartNumber: 1,
+						Status:     entity.HashCrackSubtaskStatusInProgress,
+					},
+				},
+			}
+
+			mockTaskRepo.EXPECT().WithTransaction(ctx, mock.Anything).RunAndReturn(
+				func(ctx context.Context, fn func(ctx context.Context) (any, error)) (any, error) {
+					return fn(ctx)
+				}).Once()
+			mockTaskRepo.On("Get", mock.Anything, mock.Anything, true).Run(
+				func(args mock.Arguments) {
+					objectID, ok := args.Get(1).(primitive.ObjectID)
+					assert.True(t, ok)
+					assert.Equal(t, objID.Hex(), objectID.Hex())
+				},
+			).Return(task, nil).Once()
+			mockTaskRepo.On("Update", mock.Anything, mock.Anything).Return(nil).Once()
+			mockSubtaskRepo.On("Update", mock.Anything, mock.Anything).Return(nil).Once()
+
+			// Act
+			err := service.SaveResultSubtask(ctx, input)
+
+			// Assert
+			require.NoError(t, err)
+		},
+	)
+}
+
+func Test_FinishTasks(t *testing.T) {
+	newTimedOutTask := func() *entity.HashCrackTaskWithSubtasks {
+		return &entity.HashCrackTaskWithSubtasks{
+			ObjectID: primitive.NewObjectID(),
+			Status:   entity.HashCrackTaskStatusInProgress,
+			Deadline: lo.ToPtr(time.Now().Add(-time.Minute)),
+			Subtasks: []*entity.HashCrackSubtask{
+				{
+					ObjectID:   primitive.NewObjectID(),
+					PartNumber: 0,
+					Status:     entity.HashCrackSubtaskStatusSuccess,
+					Data:       []string{"abc"},
+				},
+				{ObjectID: primitive.NewObjectID(), PartNumber: 1, Status: entity.HashCrackSubtaskStatusInProgress},
+				{ObjectID: primitive.NewObjectID(), PartNumber: 2, Status: entity.HashCrackSubtaskStatusPending},
+			},
+		}
+	}
+
+	t.Run(
+		"Success", func(t *testing.T) {
+			// Arrange
+			svc, m := newServiceWithMocks()
+			task := newTimedOutTask()
+
+			m.taskRepo.On("GetAllFinished", ctx, true).Return([]*entity.HashCrackTaskWithSubtasks{task}, nil).Once()
+			m.taskRepo.EXPECT().WithTransaction(ctx, mock.Anything).RunAndReturn(runInTransaction).Once()
+			m.taskRepo.On("Get", ctx, task.ObjectID, true).Return(task, nil).Once()
+			m.taskRepo.On("Update", ctx, mock.Anything).Return(nil).Once()
+			m.subtaskRepo.On("UpdateAll", ctx, task.Subtasks).Return(nil).Once()
+			m.cancelPublisher.On(
+				"SendMessage", ctx, &message.HashCrackTaskCancelled{RequestID: task.ObjectID.Hex()},
+				publisher.Persistent, false, false,
+			).Return(nil).Once()
+
+			// Act
+			err := svc.FinishTimeoutTasks(ctx)
+
+			// Assert
+			require.NoError(t, err)
+			m.taskRepo.AssertExpectations(t)
+			m.subtaskRepo.AssertExpectations(t)
+			m.cancelPublisher.AssertExpectations(t)
+		},
+	)
+
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+	mt.Run(
+		"Finalise timed out task", func(mt *mtest.T) {
+			// Arrange
+			svc, m := newServiceWithSubtaskRepo(
+				hashcracksubtask.NewRepo(log.Logger, mt.Client, config.MongoDBConfig{DB: "test"}),
+			)
+			task := newTimedOutTask()
+
+			mt.AddMockResponses(
+				mtest.CreateSuccessResponse(), mtest.CreateSuccessResponse(), mtest.CreateSuccessResponse(),
+			)
+			m.taskRepo.On("GetAllFinished", ctx, true).Return([]*entity.HashCrackTaskWithSubtasks{task}, nil).Once()
+			m.taskRepo.EXPECT().WithTransaction(ctx, mock.Anything).RunAndReturn(runInTransaction).Once()
+			m.taskRepo.On("Get", ctx, task.ObjectID, true).Return(task, nil).Once()
+			m.taskRepo.On("Update", ctx, mock.Anything).Return(nil).Once()
+			m.cancelPublisher.On(
+				"SendMessage", ctx, &message.HashCrackTaskCancelled{RequestID: task.ObjectID.Hex()},
+				publisher.Persistent, false, false,
+			).Return(nil).Once()
+
+			// Act
+			err := svc.FinishTimeoutTasks(ctx)
+
+			// Assert
+			require.NoError(mt, err)
+			assert.Equal(mt, entity.HashCrackTaskStatusError, task.Status)
+			assert.Equal(mt, lo.ToPtr(domain.ErrTaskFinishedByTimeout.Error()), task.Reason)
+			assert.Equal(mt, entity.HashCrackSubtaskStatusSuccess, task.Subtasks[0].Status)
+			assert.Equal(mt, entity.HashCrackSubtaskStatusError, task.Subtasks[1].Status)
+			assert.Equal(mt, entity.HashCrackSubtaskStatusError, task.Subtasks[2].Status)
+			m.taskRepo.AssertExpectations(mt)
+			m.cancelPublisher.AssertExpectations(mt)
+		},
+	)
+
+	t.Run(
+		"Deadline extended", func(t *testing.T) {
+			// Arrange
+			svc, m := newServiceWithMocks()
+			task := newTimedOutTask()
+			extended := newTimedOutTask()
+			extended.ObjectID = task.ObjectID
+			extended.Deadline = lo.ToPtr(time.Now().Add(time.Minute))
+
+			m.taskRepo.On("GetAllFinished", ctx, true).Return([]*entity.HashCrackTaskWithSubtasks{task}, nil).Once()
+			m.taskRepo.EXPECT().WithTransaction(ctx, mock.Anything).RunAndReturn(runInTransaction).Once()
+			m.taskRepo.On("Get", ctx, task.ObjectID, true).Return(extended, nil).Once()
+
+			// Act
+			err := svc.FinishTimeoutTasks(ctx)
+
+			// Assert
+			require.NoError(t, err)
+			assert.Equal(t, entity.HashCrackTaskStatusInProgress, extended.Status)
+			m.taskRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+			m.subtaskRepo.AssertNotCalled(t, "UpdateAll", mock.Anything, mock.Anything)
+			m.cancelPublisher.AssertNotCalled(
+				t, "SendMessage", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything,
+			)
+		},
+	)
+
+	t.Run(
+		"Task finished meanwhile", func(t *testing.T) {
+			// Arrange
+			svc, m := newServiceWithMocks()
+			task := newTimedOutTask()
+			cancelled := newTimedOutTask()
+			cancelled.ObjectID = task.ObjectID
+			cancelled.Status = entity.HashCrackTaskStatusCancelled
+
+			m.taskRepo.On("GetAllFinished", ctx, true).Return([]*entity.HashCrackTaskWithSubtasks{task}, nil).Once()
+			m.taskRepo.EXPECT().WithTransaction(ctx, mock.Anything).RunAndReturn(runInTransaction).Once()
+			m.taskRepo.On("Get", ctx, task.ObjectID, true).Return(cancelled, nil).Once()
+
+			// Act
+			err := svc.FinishTimeoutTasks(ctx)
+
+			// Assert
+			require.NoError(t, err)
+			assert.Equal(t, entity.HashCrackTaskStatusCancelled, cancelled.Status)
+			m.taskRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+			m.cancelPublisher.AssertNotCalled(
+				t, "SendMessage", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything,
+			)
+		},
+	)
+
+	t.Run(
+		"No tasks", func(t *testing.T) {
+			// Arrange
+			mockTaskRepo.On("GetAllFinished", ctx, true).Return([]*entity.HashCrackTaskWithSubtasks{}, nil).Once()
+
+			// Act
+			err := service.FinishTimeoutTasks(ctx)
+
+			// Assert
+			require.NoError(t, err)
+		},
+	)
+
+	t.Run(
+		"GetAllFinished error", func(t *testing.T) {
+			// Arrange
+			expectedError := errors.New("get all finished failed")
+			mockTaskRepo.On("GetAllFinished", ctx, true).Return(nil, expectedError).Once()
+
+			// Act
+			err := service.FinishTimeoutTasks(ctx)
+
+			// Assert
+			require.Error(t, err)
+			require.ErrorIs(t, err, expectedError)
+		},
+	)
+
+	t.Run(
+		"Update error", func(t *testing.T) {
+			// Arrange
+			svc, m := newServiceWithMocks()
+			task := newTimedOutTask()
+			expectedError := errors.New("update failed")
+
+			m.taskRepo.On("GetAllFinished", ctx, true).Return([]*entity.HashCrackTaskWithSubtasks{task}, nil).Once()
+			m.taskRepo.EXPECT().WithTransaction(ctx, mock.Anything).RunAndReturn(runInTransaction).Once()
+			m.taskRepo.On("Get", ctx, task.ObjectID, true).Return(task, nil).Once()
+			m.taskRepo.On("Update", ctx, mock.Anything).Return(expectedError).Once()
+
+			// Act
+			err := svc.FinishTimeoutTasks(ctx)
+
+			// Assert
+			require.Error(t, err)
+			require.ErrorIs(t, err, expectedError)
+			m.cancelPublisher.AssertNotCalled(
+				t, "SendMessage", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything,
+			)
+		},
+	)
+}
+
+func Test_DeleteExpiredTasks(t *testing.T) {
+	t.Run(
+		"Success", func(t *testing.T) {
+			// Arrange
+			tasks := []*entity.HashCrackTaskWithSubtasks{
+				{
+					ObjectID: primitive.NewObjectID(),
+					Status:   "PENDING",
+				},
+			}
+
+			mockTaskRepo.On("GetAllExpired", ctx, mock.Anything, true).Return(tasks, nil).Once()
+			mockTaskWithSubtasksSvc.On("DeleteTasksWithSubtasks", ctx, tasks).Return(nil).Once()
+
+			// Act
+			err := service.DeleteExpiredTasks(ctx)
+
+			// Assert
+			require.NoError(t, err)
+		},
+	)
+
+	t.Run(
+		"GetAllExpired error", func(t *testing.T) {
+			// Arrange
+			expectedErr := errors.New("repo error")
+			mockTaskRepo.On("GetAllExpired", ctx, mock.Anything, true).Return(nil, expectedErr).Once()
+
+			// Act
+			err := service.DeleteExpiredTasks(ctx)
+
+			// Assert
+			require.Error(t, err)
+			require.ErrorIs(t, err, expectedErr)
+		},
+	)
+
+	t.Run(
+		"No tasks", func(t *testing.T) {
+			// Arrange
+			tasks := []*entity.HashCrackTaskWithSubtasks{}
+
+			mockTaskRepo.On("GetAllExpired", ctx, mock.Anything, true).Return(tasks, nil).Once()
+			mockTaskWithSubtasksSvc.On("DeleteTaskWithSubtasks", ctx, mock.Anything).Return(nil).Once()
+
+			// Act
+			err := service.DeleteExpiredTasks(ctx)
+
+			// Assert
+			require.NoError(t, err)
+		},
+	)
+
+	t.Run(
+		"DeleteTaskWithSubtasks error", func(t *testing.T) {
+			// Arrange
+			tasks := []*entity.HashCrackTaskWithSubtasks{
+				{
+					ObjectID: primitive.NewObjectID(),
+					Status:   "PENDING",
+				},
+			}
+
+			expectedErr := errors.New("repo error")
+
+			mockTaskRepo.On("GetAllExpired", ctx, mock.Anything, true).Return(tasks, nil).Once()
+			mockTaskWithSubtasksSvc.On("DeleteTasksWithSubtasks", ctx, tasks).Return(expectedErr).Once()
+
+			// Act
+			err := service.DeleteExpiredTasks(ctx)
+
+			// Assert
+			require.Error(t, err)
+			require.ErrorIs(t, err, expectedErr)
+		},
+	)
+}
+
+func Test_ExecutePendingSubtasks(t *testing.T) {
+	t.Run(
+		"GetAllByStatus error", func(t *testing.T) {
+			// Arrange
+			svc, m := newServiceWithMocks()
+			expectedErr := errors.New("repo error")
+
+			m.subtaskRepo.EXPECT().GetAllByStatus(ctx, entity.HashCrackSubtaskStatusPending).
+				Return(nil, expectedErr).Once()
+
+			// Act
+			err := svc.ExecutePendingSubtasks(ctx)
+
+			// Assert
+			require.Error(t, err)
+			require.ErrorIs(t, err, expectedErr)
+		},
+	)
+
+	t.Run(
+		"Get task error", func(t *testing.T) {
+			// Arrange
+			svc, m := newServiceWithMocks()
+			subtasks := []*entity.HashCrackSubtask{
+				{
+					ObjectID: primitive.NewObjectID(),
+					Status:   entity.HashCrackSubtaskStatusPending,
+					TaskID:   primitive.NewObjectID(),
+				},
+			}
+
+			expectedErr := errors.New("repo error")
+
+			m.subtaskRepo.EXPECT().GetAllByStatus(ctx, entity.HashCrackSubtaskStatusPending).
+				Return(subtasks, nil).Once()
+			m.taskRepo.EXPECT().WithTransaction(ctx, mock.Anything).RunAndReturn(runInTransaction).Once()
+			m.taskRepo.EXPECT().Get(ctx, subtasks[0].TaskID, true).
+				Return(nil, expectedErr).Once()
+
+			// Act
+			err := svc.ExecutePendingSubtasks(ctx)
+
+			// Assert
+			require.Error(t, err)
+			require.ErrorIs(t, err, expectedErr)
+		},
+	)
+
+	t.Run(
+		"Success - No subtasks", func(t *testing.T) {
+			// Arrange
+			svc, m := newServiceWithMocks()
+
+			m.subtaskRepo.EXPECT().GetAllByStatus(ctx, entity.HashCrackSubtaskStatusPending).
+				Return([]*entity.HashCrackSubtask{}, nil).Once()
+
+			// Act
+			err := svc.ExecutePendingSubtasks(ctx)
+
+			// Assert
+			require.NoError(t, err)
+			m.taskRepo.AssertNotCalled(t, "WithTransaction", mock.Anything, mock.Anything)
+		},
+	)
+
+	t.Run(
+		"Success - Has subtasks", func(t *testing.T) {
+			// Arrange
+			svc, m := newServiceWithMocks()
+			taskID := primitive.NewObjectID()
+			subtask := &entity.HashCrackSubtask{
+				ObjectID: primitive.NewObjectID(),
+				Status:   entity.HashCrackSubtaskStatusPending,
+				TaskID:   taskID,
+			}
+			task := &entity.HashCrackTaskWithSubtasks{
+				ObjectID: taskID,
+				Status:   entity.HashCrackTaskStatusPending,
+				Subtasks: []*entity.HashCrackSubtask{subtask},
+			}
+
+			m.subtaskRepo.EXPECT().GetAllByStatus(ctx, entity.HashCrackSubtaskStatusPending).
+				Return([]*entity.HashCrackSubtask{{ObjectID: subtask.ObjectID, TaskID: taskID}}, nil).Once()
+			m.taskRepo.EXPECT().WithTransaction(ctx, mock.Anything).RunAndReturn(runInTransaction).Once()
+			m.taskRepo.EXPECT().Get(ctx, taskID, true).Return(task, nil).Once()
+			m.subtaskRepo.EXPECT().Update(ctx, subtask).Return(nil).Once()
+			m.taskRepo.EXPECT().Update(ctx, mock.Anything).Run(
+				func(_ context.Context, task *entity.HashCrackTask) {
+					assert.Equal(t, entity.HashCrackTaskStatusInProgress, task.Status)
+				},
+			).Return(nil).Once()
+			expectOutbox(m.outboxRepo)
+			m.publisher.EXPECT().SendMessage(ctx, mock.Anything, publisher.Persistent, false, false).
+				Return(nil).Once()
+
+			// Act
+			err := svc.ExecutePendingSubtasks(ctx)
+
+			// Assert
+			require.NoError(t, err)
+			require.Equal(t, entity.HashCrackSubtaskStatusInProgress, subtask.Status)
+			m.taskRepo.AssertExpectations(t)
+			m.subtaskRepo.AssertExpectations(t)
+			m.publisher.AssertExpectations(t)
+		},
+	)
+
+	t.Run(
+		"Success - all hashes cracked", func(t *testing.T) {
+			// Arrange
+			svc, m := newServiceWithMocks()
+			taskID := primitive.NewObjectID()
+			subtask := &entity.HashCrackSubtask{
+				ObjectID:   primitive.NewObjectID(),
+				PartNumber: 1,
+				Status:     entity.HashCrackSubtaskStatusPending,
+				TaskID:     taskID,
+			}
+			task := &entity.HashCrackTaskWithSubtasks{
+				ObjectID:  taskID,
+				Hashes:    []string{md5Hex("a")},
+				PartCount: 2,
+				Status:    entity.HashCrackTaskStatusInProgress,
+				Subtasks: []*entity.HashCrackSubtask{
+					{
+						ObjectID:   primitive.NewObjectID(),
+						PartNumber: 0,
+						Status:     entity.HashCrackSubtaskStatusSuccess,
+						Found:      []entity.HashCrackFoundHash{{Hash: md5Hex("a"), Word: "a"}},
+						TaskID:     taskID,
+					},
+					subtask,
+				},
+			}
+
+			m.subtaskRepo.EXPECT().GetAllByStatus(ctx, entity.HashCrackSubtaskStatusPending).
+				Return([]*entity.HashCrackSubtask{{ObjectID: subtask.ObjectID, TaskID: taskID}}, nil).Once()
+			m.taskRepo.EXPECT().WithTransaction(ctx, mock.Anything).RunAndReturn(runInTransaction).Once()
+			m.taskRepo.EXPECT().Get(ctx, taskID, true).Return(task, nil).Once()
+			m.subtaskRepo.EXPECT().Update(ctx, subtask).Return(nil).Once()
+			m.taskRepo.EXPECT().Update(ctx, mock.Anything).Run(
+				func(_ context.Context, task *entity.HashCrackTask) {
+					assert.Equal(t, entity.HashCrackTaskStatusReady, task.Status)
+				},
+			).Return(nil).Once()
+
+			// Act
+			err := svc.ExecutePendingSubtasks(ctx)
+
+			// Assert
+			require.NoError(t, err)
+			require.Equal(t, entity.HashCrackSubtaskStatusSuccess, subtask.Status)
+			m.taskRepo.AssertExpectations(t)
+			m.publisher.AssertNotCalled(
+				t, "SendMessage", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything,
+			)
+		},
+	)
+
+	t.Run(
+		"Success - Limit of subtasks in flight", func(t *testing.T) {
+			// Arrange
+			limitedCfg := cfg
+			limitedCfg.InFlightLimit = 1
+			svc, m := newServiceWithConfig(limitedCfg)
+
+			taskID := primitive.NewObjectID()
+			subtask := &entity.HashCrackSubtask{
+				ObjectID:   primitive.NewObjectID(),
+				PartNumber: 1,
+				Status:     entity.HashCrackSubtaskStatusPending,
+				TaskID:     taskID,
+			}
+			task := &entity.HashCrackTaskWithSubtasks{
+				ObjectID:  taskID,
+				PartCount: 2,
+				Status:    entity.HashCrackTaskStatusInProgress,
+				Subtasks: []*entity.HashCrackSubtask{
+					{
+						ObjectID:   primitive.NewObjectID(),
+						PartNumber: 0,
+						Status:     entity.HashCrackSubtaskStatusInProgress,
+						TaskID:     taskID,
+					},
+					subtask,
+				},
+			}
+
+			m.subtaskRepo.EXPECT().GetAllByStatus(ctx, entity.HashCrackSubtaskStatusPending).
+				Return([]*entity.HashCrackSubtask{{ObjectID: subtask.ObjectID, TaskID: taskID}}, nil).Once()
+			m.taskRepo.EXPECT().WithTransaction(ctx, mock.Anything).RunAndReturn(runInTransaction).Once()
+			m.taskRepo.EXPECT().Get(ctx, taskID, true).Return(task, nil).Once()
+
+			// Act
+			err := svc.ExecutePendingSubtasks(ctx)
+
+			// Assert
+			require.NoError(t, err)
+			require.Equal(t, entity.HashCrackSubtaskStatusPending, subtask.Status)
+			m.publisher.AssertNotCalled(
+				t, "SendMessage", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything,
+			)
+			m.subtaskRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+			m.taskRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+		},
+	)
+
+	t.Run(
+		"Subtask claimed meanwhile", func(t *testing.T) {
+			// Arrange
+			svc, m := newServiceWithMocks()
+			taskID := primitive.NewObjectID()
+			subtask := &entity.HashCrackSubtask{
+				ObjectID: primitive.NewObjectID(),
+				Status:   entity.HashCrackSubtaskStatusSuccess,
+				TaskID:   taskID,
+			}
+			task := &entity.HashCrackTaskWithSubtasks{
+				ObjectID: taskID,
+				Status:   entity.HashCrackTaskStatusInProgress,
+				Subtasks: []*entity.HashCrackSubtask{
+					subtask,
+					{ObjectID: primitive.NewObjectID(), PartNumber: 1, Status: entity.HashCrackSubtaskStatusInProgress},
+				},
+			}
+
+			m.subtaskRepo.EXPECT().GetAllByStatus(ctx, entity.HashCrackSubtaskStatusPending).
+				Return(
+					[]*entity.HashCrackSubtask{
+						{ObjectID: subtask.ObjectID, TaskID: taskID, Status: entity.HashCrackSubtaskStatusPending},
+					}, nil,
+				).Once()
+			m.taskRepo.EXPECT().WithTransaction(ctx, mock.Anything).RunAndReturn(runInTransaction).Once()
+			m.taskRepo.EXPECT().Get(ctx, taskID, true).Return(task, nil).Once()
+
+			// Act
+			err := svc.ExecutePendingSubtasks(ctx)
+
+			// Assert
+			require.NoError(t, err)
+			assert.Equal(t, entity.HashCrackSubtaskStatusSuccess, subtask.Status)
+			m.subtaskRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+			m.taskRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+			m.publisher.AssertNotCalled(
+				t, "SendMessage", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything,
+			)
+		},
+	)
+
+	t.Run(
+		"Task cancelled meanwhile", func(t *testing.T) {
+			// Arrange
+			svc, m := newServiceWithMocks()
+			taskID := primitive.NewObjectID()
+			subtask := &entity.HashCrackSubtask{
+				ObjectID: primitive.NewObjectID(),
+				Status:   entity.HashCrackSubtaskStatusCancelled,
+				TaskID:   taskID,
+			}
+			task := &entity.HashCrackTaskWithSubtasks{
+				ObjectID: taskID,
+				Status:   entity.HashCrackTaskStatusCancelled,
+				Subtasks: []*entity.HashCrackSubtask{subtask},
+			}
+
+			m.subtaskRepo.EXPECT().GetAllByStatus(ctx, entity.HashCrackSubtaskStatusPending).
+				Return(
+					[]*entity.HashCrackSubtask{
+						{ObjectID: subtask.ObjectID, TaskID: taskID, Status: entity.HashCrackSubtaskStatusPending},
+					}, nil,
+				).Once()
+			m.taskRepo.EXPECT().WithTransaction(ctx, mock.Anything).RunAndReturn(runInTransaction).Once()
+			m.taskRepo.EXPECT().Get(ctx, taskID, true).Return(task, nil).Once()
+
+			// Act
+			err := svc.ExecutePendingSubtasks(ctx)
+
+			// Assert
+			require.NoError(t, err)
+			assert.Equal(t, entity.HashCrackTaskStatusCancelled, task.Status)
+			m.subtaskRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+			m.taskRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+			m.outboxRepo.AssertNotCalled(t, "CreateAll", mock.Anything, mock.Anything)
+			m.publisher.AssertNotCalled(
+				t, "SendMessage", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything,
+			)
+		},
+	)
+}
+
+func Test_RedeliverExpiredSubtasks(t *testing.T) {
+	t.Run(
+		"GetAllLeaseExpired error", func(t *testing.T) {
+			// Arrange
+			svc, m := newServiceWithMocks()
+			expectedError := errors.New("error")
+
+			m.subtaskRepo.EXPECT().GetAllLeaseExpired(ctx).Return(nil, expectedError).Once()
+
+			// Act
+			err := svc.RedeliverExpiredSubtasks(ctx)
+
+			// Assert
+			require.Error(t, err)
+			require.ErrorIs(t, err, expectedError)
+		},
+	)
+
+	t.Run(
+		"No expired subtasks", func(t *testing.T) {
+			// Arrange
+			svc, m := newServiceWithMocks()
+
+			m.subtaskRepo.EXPECT().GetAllLeaseExpired(ctx).Return([]*entity.HashCrackSubtask{}, nil).Once()
+
+			// Act
+			err := svc.RedeliverExpiredSubtasks(ctx)
+
+			// Assert
+			require.NoError(t, err)
+			m.taskRepo.AssertNotCalled(t, "Get", mock.Anything, mock.Anything, mock.Anything)
+		},
+	)
+
+	t.Run(
+		"Success", func(t *testing.T) {
+			// Arrange
+			svc, m := newServiceWithMocks()
+			taskID := primitive.NewObjectID()
+			leaseExpiresAt := lo.ToPtr(time.Now().Add(-time.Minute))
+			subtasks := []*entity.HashCrackSubtask{
+				{
+					ObjectID:       primitive.NewObjectID(),
+					TaskID:         taskID,
+					PartNumber:     0,
+					Status:         entity.HashCrackSubtaskStatusInProgress,
+					Checkpoint:     40,
+					Attempts:       1,
+					LeaseExpiresAt: leaseExpiresAt,
+				},
+				{
+					ObjectID:       primitive.NewObjectID(),
+					TaskID:         taskID,
+					PartNumber:     1,
+					Status:         entity.HashCrackSubtaskStatusInProgress,
+					Attempts:       3,
+					LeaseExpiresAt: leaseExpiresAt,
+				},
+			}
+			task := &entity.HashCrackTaskWithSubtasks{
+				ObjectID:  taskID,
+				Hash:      "900150983cd24fb0d6963f7d28e17f72",
+				PartCount: 3,
+				MaxLength: 3,
+				Status:    entity.HashCrackTaskStatusInProgress,
+				Subtasks: []*entity.HashCrackSubtask{
+					subtasks[0],
+					subtasks[1],
+					{ObjectID: primitive.NewObjectID(), PartNumber: 2, Status: entity.HashCrackSubtaskStatusSuccess},
+				},
+			}
+
+			m.subtaskRepo.EXPECT().GetAllLeaseExpired(ctx).Return(subtasks, nil).Once()
+			m.taskRepo.EXPECT().WithTransaction(ctx, mock.Anything).RunAndReturn(runInTransaction).Once()
+			m.taskRepo.On("Get", ctx, taskID, true).Return(task, nil).Once()
+			m.subtaskRepo.On("Update", ctx, mock.Anything).Return(nil).Twice()
+			expectOutbox(m.outboxRepo)
+			m.publisher.On(
+				"SendMessage", ctx, mock.MatchedBy(
+					func(msg *message.HashCrackTaskStarted) bool {
+						return msg.PartNumber == 0 && msg.ResumeOffset == 40
+					},
+				), publisher.Persistent, false, false,
+			).Return(nil).Once()
+			m.taskRepo.On("Update", ctx, mock.Anything).Run(
+				func(args mock.Arguments) {
+					task, ok := args.Get(1).(*entity.HashCrackTask)
+					assert.True(t, ok)
+					assert.Equal(t, entity.HashCrackTaskStatusInProgress, task.Status)
+				},
+			).Return(nil).Once()
+
+			// Act
+			err := svc.RedeliverExpiredSubtasks(ctx)
+
+			// Assert
+			require.NoError(t, err)
+			assert.Equal(t, entity.HashCrackSubtaskStatusInProgress, subtasks[0].Status)
+			assert.Equal(t, 2, subtasks[0].Attempts)
+			require.NotNil(t, subtasks[0].LeaseExpiresAt)
+			assert.True(t, subtasks[0].LeaseExpiresAt.After(time.Now()))
+			assert.Equal(t, entity.HashCrackSubtaskStatusError, subtasks[1].Status)
+			assert.Equal(t, lo.ToPtr("subtask lease expired after 3 attempts"), subtasks[1].Reason)
+			m.subtaskRepo.AssertExpectations(t)
+			m.publisher.AssertExpectations(t)
+			m.taskRepo.AssertExpectations(t)
+		},
+	)
+
+	t.Run(
+		"Attempts exhausted", func(t *testing.T) {
+			// Arrange
+			svc, m := newServiceWithMocks()
+			taskID := primitive.NewObjectID()
+			subtask := &entity.HashCrackSubtask{
+				ObjectID:       primitive.NewObjectID(),
+				TaskID:         taskID,
+				PartNumber:     0,
+				Status:         entity.HashCrackSubtaskStatusInProgress,
+				Attempts:       3,
+				LeaseExpiresAt: lo.ToPtr(time.Now().Add(-time.Minute)),
+			}
+			task := &entity.HashCrackTaskWithSubtasks{
+				ObjectID:  taskID,
+				PartCount: 2,
+				Status:    entity.HashCrackTaskStatusInProgress,
+				Subtasks: []*entity.HashCrackSubtask{
+					subtask,
+					{ObjectID: primitive.NewObjectID(), PartNumber: 1, Status: entity.HashCrackSubtaskStatusSuccess},
+				},
+			}
+
+			m.subtaskRepo.EXPECT().GetAllLeaseExpired(ctx).
+				Return([]*entity.HashCrackSubtask{subtask}, nil).Once()
+			m.taskRepo.EXPECT().WithTransaction(ctx, mock.Anything).RunAndReturn(runInTransaction).Once()
+			m.taskRepo.On("Get", ctx, taskID, true).Return(task, nil).Once()
+			m.subtaskRepo.On("Update", ctx, subtask).Return(nil).Once()
+			m.taskRepo.On("Update", ctx, mock.Anything).Run(
+				func(args mock.Arguments) {
+					task, ok := args.Get(1).(*entity.HashCrackTask)
+					assert.True(t, ok)
+					assert.Equal(t, entity.HashCrackTaskStatusPartialReady, task.Status)
+				},
+			).Return(nil).Once()
+
+			// Act
+			err := svc.RedeliverExpiredSubtasks(ctx)
+
+			// Assert
+			require.NoError(t, err)
+			assert.Equal(t, entity.HashCrackSubtaskStatusError, subtask.Status)
+			m.publisher.AssertNotCalled(
+				t, "SendMessage", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything,
+			)
+			m.taskRepo.AssertExpectations(t)
+		},
+	)
+
+	t.Run(
+		"Lease renewed meanwhile", func(t *testing.T) {
+			// Arrange
+			svc, m := newServiceWithMocks()
+			taskID := primitive.NewObjectID()
+			found := &entity.HashCrackSubtask{
+				ObjectID:       primitive.NewObjectID(),
+				TaskID:         taskID,
+				Status:         entity.HashCrackSubtaskStatusInProgress,
+				Attempts:       1,
+				LeaseExpiresAt: lo.ToPtr(time.Now().Add(-time.Minute)),
+			}
+			renewed := &entity.HashCrackSubtask{
+				ObjectID:       found.ObjectID,
+				TaskID:         taskID,
+				Status:         entity.HashCrackSubtaskStatusInProgress,
+				Checkpoint:     40,
+				Attempts:       1,
+				LeaseExpiresAt: lo.ToPtr(time.Now().Add(time.Minute)),
+			}
+
+			m.subtaskRepo.EXPECT().GetAllLeaseExpired(ctx).
+				Return([]*entity.HashCrackSubtask{found}, nil).Once()
+			m.taskRepo.EXPECT().WithTransaction(ctx, mock.Anything).RunAndReturn(runInTransaction).Once()
+			m.taskRepo.On("Get", ctx, taskID, true).Return(
+				&entity.HashCrackTaskWithSubtasks{
+					ObjectID: taskID,
+					Status:   entity.HashCrackTaskStatusInProgress,
+					Subtasks: []*entity.HashCrackSubtask{renewed},
+				}, nil,
+			).Once()
+
+			// Act
+			err := svc.RedeliverExpiredSubtasks(ctx)
+
+			// Assert
+			require.NoError(t, err)
+			assert.Equal(t, 1, renewed.Attempts)
+			m.subtaskRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+			m.taskRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+			m.publisher.AssertNotCalled(
+				t, "SendMessage", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything,
+			)
+		},
+	)
+
+	t.Run(
+		"Result saved meanwhile", func(t *testing.T) {
+			// Arrange
+			svc, m := newServiceWithMocks()
+			taskID := primitive.NewObjectID()
+			found := &entity.HashCrackSubtask{
+				ObjectID:       primitive.NewObjectID(),
+				TaskID:         taskID,
+				Status:         entity.HashCrackSubtaskStatusInProgress,
+				Attempts:       1,
+				LeaseExpiresAt: lo.ToPtr(time.Now().Add(-time.Minute)),
+			}
+			finished := &entity.HashCrackSubtask{
+				ObjectID: found.ObjectID,
+				TaskID:   taskID,
+				Status:   entity.HashCrackSubtaskStatusSuccess,
+				Attempts: 1,
+			}
+
+			m.subtaskRepo.EXPECT().GetAllLeaseExpired(ctx).
+				Return([]*entity.HashCrackSubtask{found}, nil).Once()
+			m.taskRepo.EXPECT().WithTransaction(ctx, mock.Anything).RunAndReturn(runInTransaction).Once()
+			m.taskRepo.On("Get", ctx, taskID, true).Return(
+				&entity.HashCrackTaskWithSubtasks{
+					ObjectID: taskID,
+					Status:   entity.HashCrackTaskStatusReady,
+					Subtasks: []*entity.HashCrackSubtask{finished},
+				}, nil,
+			).Once()
+
+			// Act
+			err := svc.RedeliverExpiredSubtasks(ctx)
+
+			// Assert
+			require.NoError(t, err)
+			assert.Equal(t, entity.HashCrackSubtaskStatusSuccess, finished.Status)
+			m.subtaskRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+			m.taskRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+			m.publisher.AssertNotCalled(
+				t, "SendMessage", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything,
+			)
+		},
+	)
+
+	t.Run(
+		"Finished task", func(t *testing.T) {
+			// Arrange
+			svc, m := newServiceWithMocks()
+			taskID := primitive.NewObjectID()
+			subtask := &entity.HashCrackSubtask{
+				ObjectID:       primitive.NewObjectID(),
+				TaskID:         taskID,
+				Status:         entity.HashCrackSubtaskStatusInProgress,
+				Attempts:       1,
+				LeaseExpiresAt: lo.ToPtr(time.Now().Add(-time.Minute)),
+			}
+
+			m.subtaskRepo.EXPECT().GetAllLeaseExpired(ctx).
+				Return([]*entity.HashCrackSubtask{subtask}, nil).Once()
+			m.taskRepo.EXPECT().WithTransaction(ctx, mock.Anything).RunAndReturn(runInTransaction).Once()
+			m.taskRepo.On("Get", ctx, taskID, true).Return(
+				&entity.HashCrackTaskWithSubtasks{
+					ObjectID: taskID,
+					Status:   entity.HashCrackTaskStatusCancelled,
+					Subtasks: []*entity.HashCrackSubtask{subtask},
+				}, nil,
+			).Once()
+
+			// Act
+			err := svc.RedeliverExpiredSubtasks(ctx)
+
+			// Assert
+			require.NoError(t, err)
+			m.subtaskRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+			m.taskRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+			m.publisher.AssertNotCalled(
+				t, "SendMessage", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything,
+			)
+		},
+	)
+}
+
+func Test_SplitSlowSubtasks(t *testing.T) {
+	t.Run(
+		"IdleCapacity error", func(t *testing.T) {
+			// Arrange
+			svc, m := newServiceWithMocks()
+			expectedError := errors.New("error")
 
-						}
+			m.taskQueueSvc.EXPECT().IdleCapacity(ctx).Return(0, expectedError).Once()
 
-						mockTaskRepo.EXPECT().WithTransaction(ctx, mock.Anything).RunAndReturn(
-							func(ctx context.Context, fn func(ctx context.Context) (any, error)) (any, error) {
-								return fn(ctx)
-							}).Once()
-						mockTaskRepo.On("Get", mock.Anything, mock.Anything, true).Run(
-							func(args mock.Arguments) {
-								objectID, ok := args.Get(1).(primitive.ObjectID)
-								assert.True(t, ok)
-								assert.Equal(t, objID.Hex(), objectID.Hex())
-							},
-						).Return(task, nil).Once()
+			// Act
+			err := svc.SplitSlowSubtasks(ctx)
 
-						mockSubtaskRepo.On("Update", mock.Anything, mock.Anything).Return(nil).Once()
-						call := mockTaskRepo.On("Update", mock.Anything, mock.Anything).Run(
-							func(args mock.Arguments) {
-								task, ok := args.Get(1).(*entity.HashCrackTask)
-								assert.True(t, ok)
+			// Assert
+			require.Error(t, err)
+			require.ErrorIs(t, err, expectedError)
+		},
+	)
 
-								if task.Status == "" {
-									return
-								}
-								assert.Equal(t, c.TaskStatus, task.Status)
-							},
-						).Return(nil)
+	t.Run(
+		"No idle workers", func(t *testing.T) {
+			// Arrange
+			svc, m := newServiceWithMocks()
 
-						if c.SubtaskStatus == entity.HashCrackSubtaskStatusInProgress {
-							call.Once()
-						} else {
-							call.Twice()
-						}
+			m.taskQueueSvc.EXPECT().IdleCapacity(ctx).Return(0, nil).Once()
 
-						// Act
-						err := service.SaveResultSubtask(ctx, input)
+			// Act
+			err := svc.SplitSlowSubtasks(ctx)
 
-						// Assert
-						require.NoError(t, err)
-					},
-				)
-			}
+			// Assert
+			require.NoError(t, err)
+			m.subtaskRepo.AssertNotCalled(t, "GetAllStartedBefore", mock.Anything, mock.Anything)
 		},
 	)
 
 	t.Run(
-		"Success - Not finish task", func(t *testing.T) {
+		"Success", func(t *testing.T) {
 			// Arrange
-			objID := primitive.NewObjectID()
-			input := &message.HashCrackTaskResult{
-				RequestID:  objID.Hex(),
-				PartNumber: 0,
-				Answer: &message.Answer{
-					Words:   []string{"word1", "word2"},
-					Percent: 100.0,
+			svc, m := newServiceWithMocks()
+			taskID := primitive.NewObjectID()
+			subtasks := []*entity.HashCrackSubtask{
+				{
+					ObjectID:   primitive.NewObjectID(),
+					TaskID:     taskID,
+					PartNumber: 0,
+					Status:     entity.HashCrackSubtaskStatusInProgress,
+					Range:      &entity.HashCrackKeyRange{Start: 0, End: 100},
+				},
+				{
+					ObjectID:   primitive.NewObjectID(),
+					TaskID:     taskID,
+					PartNumber: 1,
+					Status:     entity.HashCrackSubtaskStatusInProgress,
+					Range:      &entity.HashCrackKeyRange{Start: 100, End: 200},
 				},
-				Status: entity.HashCrackSubtaskStatusSuccess.String(),
 			}
-
 			task := &entity.HashCrackTaskWithSubtasks{
-				ObjectID:  objID,
-				PartCount: 2,
+				ObjectID:  taskID,
+				Hash:      "900150983cd24fb0d6963f7d28e17f72",
+				PartCount: 3,
+				MaxLength: 3,
+				Status:    entity.HashCrackTaskStatusInProgress,
 				Subtasks: []*entity.HashCrackSubtask{
 					{
+						ObjectID:   subtasks[0].ObjectID,
+						TaskID:     taskID,
 						PartNumber: 0,
 						Status:     entity.HashCrackSubtaskStatusInProgress,
+						Checkpoint: 20,
+						Range:      &entity.HashCrackKeyRange{Start: 0, End: 100},
 					},
-					{
-						PartNumber: 1,
-						Status:     entity.HashCrackSubtaskStatusInProgress,
-					},
+					{ObjectID: subtasks[1].ObjectID, PartNumber: 1, Status: entity.HashCrackSubtaskStatusInProgress},
+					{ObjectID: primitive.NewObjectID(), PartNumber: 2, Status: entity.HashCrackSubtaskStatusSuccess},
 				},
 			}
 
-			mockTaskRepo.EXPECT().WithTransaction(ctx, mock.Anything).RunAndReturn(
+			m.taskQueueSvc.EXPECT().IdleCapacity(ctx).Return(1, nil).Once()
+			m.subtaskRepo.EXPECT().GetAllStartedBefore(ctx, mock.Anything).Return(subtasks, nil).Once()
+			m.taskRepo.EXPECT().WithTransaction(ctx, mock.Anything).RunAndReturn(
 				func(ctx context.Context, fn func(ctx context.Context) (any, error)) (any, error) {
 					return fn(ctx)
-				}).Once()
-			mockTaskRepo.On("Get", mock.Anything, mock.Anything, true).Run(
-				func(args mock.Arguments) {
-					objectID, ok := args.Get(1).(primitive.ObjectID)
-					assert.True(t, ok)
-					assert.Equal(t, objID.Hex(), objectID.Hex())
 				},
-			).Return(task, nil).Once()
-			mockTaskRepo.On("Update", mock.Anything, mock.Anything).Return(nil).Once()
-			mockSubtaskRepo.On("Update", mock.Anything, mock.Anything).Return(nil).Once()
+			).Once()
+			m.taskRepo.On("Get", ctx, taskID, true).Return(task, nil).Once()
+			m.subtaskRepo.On(
+				"Update", ctx, mock.MatchedBy(
+					func(subtask *entity.HashCrackSubtask) bool {
+						return subtask.ObjectID == subtasks[0].ObjectID && subtask.Range.End == 60
+					},
+				),
+			).Return(nil).Once()
+			m.subtaskRepo.On(
+				"Create", ctx, mock.MatchedBy(
+					func(subtask *entity.HashCrackSubtask) bool {
+						return subtask.PartNumber == 3 && subtask.Status == entity.HashCrackSubtaskStatusInProgress &&
+							*subtask.Range == entity.HashCrackKeyRange{Start: 60, End: 100}
+					},
+				),
+			).Return(nil).Once()
+			m.taskRepo.On(
+				"Update", ctx, mock.MatchedBy(
+					func(task *entity.HashCrackTask) bool {
+						return task.PartCount == 4
+					},
+				),
+			).Return(nil).Once()
+			expectOutbox(m.outboxRepo)
+			m.shrinkPublisher.On(
+				"SendMessage", ctx,
+				&message.HashCrackTaskShrunk{
+					Version: message.Version, RequestID: taskID.Hex(), PartNumber: 0, EndIndex: 60,
+				},
+				publisher.Persistent, false, false,
+			).Return(nil).Once()
+			m.publisher.On(
+				"SendMessage", ctx, mock.MatchedBy(
+					func(msg *message.HashCrackTaskStarted) bool {
+						return msg.PartNumber == 3 && msg.StartIndex == 60 && msg.EndIndex == 100
+					},
+				), publisher.Persistent, false, false,
+			).Return(nil).Once()
 
 			// Act
-			err := service.SaveResultSubtask(ctx, input)
+			err := svc.SplitSlowSubtasks(ctx)
 
 			// Assert
 			require.NoError(t, err)
+			m.taskRepo.AssertExpectations(t)
+			m.subtaskRepo.AssertExpectations(t)
+			m.shrinkPublisher.AssertExpectations(t)
+			m.publisher.AssertExpectations(t)
 		},
 	)
-}
 
-func Test_FinishTasks(t *testing.T) {
 	t.Run(
-		"Success", func(t *testing.T) {
+		"Too small subtask", func(t *testing.T) {
 			// Arrange
-			tasks := []*entity.HashCrackTaskWithSubtasks{
-				{
-					ObjectID: primitive.NewObjectID(),
-					Status:   "PENDING",
-				},
+			svc, m := newServiceWithMocks()
+			taskID := primitive.NewObjectID()
+			subtask := &entity.HashCrackSubtask{
+				ObjectID:   primitive.NewObjectID(),
+				TaskID:     taskID,
+				PartNumber: 0,
+				Status:     entity.HashCrackSubtaskStatusInProgress,
+				Checkpoint: 15,
+				Range:      &entity.HashCrackKeyRange{Start: 0, End: 30},
+			}
+			task := &entity.HashCrackTaskWithSubtasks{
+				ObjectID:  taskID,
+				Hash:      "900150983cd24fb0d6963f7d28e17f72",
+				PartCount: 1,
+				MaxLength: 3,
+				Status:    entity.HashCrackTaskStatusInProgress,
+				Subtasks:  []*entity.HashCrackSubtask{subtask},
 			}
 
-			mockTaskRepo.On("GetAllFinished", ctx, true).Return(tasks, nil).Once()
-			mockTaskWithSubtasksSvc.On("UpdateTaskWithSubtasks", ctx, mock.Anything).Return(nil).Once()
+			m.taskQueueSvc.EXPECT().IdleCapacity(ctx).Return(1, nil).Once()
+			m.subtaskRepo.EXPECT().GetAllStartedBefore(ctx, mock.Anything).
+				Return([]*entity.HashCrackSubtask{subtask}, nil).Once()
+			m.taskRepo.EXPECT().WithTransaction(ctx, mock.Anything).RunAndReturn(
+				func(ctx context.Context, fn func(ctx context.Context) (any, error)) (any, error) {
+					return fn(ctx)
+				},
+			).Once()
+			m.taskRepo.On("Get", ctx, taskID, true).Return(task, nil).Once()
 
 			// Act
-			err := service.FinishTimeoutTasks(ctx)
+			err := svc.SplitSlowSubtasks(ctx)
 
 			// Assert
 			require.NoError(t, err)
+			m.subtaskRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+			m.shrinkPublisher.AssertNotCalled(
+				t, "SendMessage", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything,
+			)
 		},
 	)
 
 	t.Run(
-		"No tasks", func(t *testing.T) {
+		"Limit of subtasks in flight", func(t *testing.T) {
 			// Arrange
-			mockTaskRepo.On("GetAllFinished", ctx, true).Return([]*entity.HashCrackTaskWithSubtasks{}, nil).Once()
+			limitedCfg := cfg
+			limitedCfg.InFlightLimit = 2
+			svc, m := newServiceWithConfig(limitedCfg)
+			taskID := primitive.NewObjectID()
+			subtask := &entity.HashCrackSubtask{
+				ObjectID:   primitive.NewObjectID(),
+				TaskID:     taskID,
+				PartNumber: 0,
+				Status:     entity.HashCrackSubtaskStatusInProgress,
+				Range:      &entity.HashCrackKeyRange{Start: 0, End: 100},
+			}
+			task := &entity.HashCrackTaskWithSubtasks{
+				ObjectID:  taskID,
+				Hash:      "900150983cd24fb0d6963f7d28e17f72",
+				PartCount: 2,
+				MaxLength: 3,
+				Status:    entity.HashCrackTaskStatusInProgress,
+				Subtasks: []*entity.HashCrackSubtask{
+					subtask,
+					{ObjectID: primitive.NewObjectID(), PartNumber: 1, Status: entity.HashCrackSubtaskStatusInProgress},
+				},
+			}
+
+			m.taskQueueSvc.EXPECT().IdleCapacity(ctx).Return(1, nil).Once()
+			m.subtaskRepo.EXPECT().GetAllStartedBefore(ctx, mock.Anything).
+				Return([]*entity.HashCrackSubtask{subtask}, nil).Once()
+			m.taskRepo.EXPECT().WithTransaction(ctx, mock.Anything).RunAndReturn(runInTransaction).Once()
+			m.taskRepo.On("Get", ctx, taskID, true).Return(task, nil).Once()
 
 			// Act
-			err := service.FinishTimeoutTasks(ctx)
+			err := svc.SplitSlowSubtasks(ctx)
 
 			// Assert
 			require.NoError(t, err)
+			assert.Equal(t, 100, subtask.Range.End)
+			m.subtaskRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+			m.subtaskRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+			m.shrinkPublisher.AssertNotCalled(
+				t, "SendMessage", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything,
+			)
 		},
 	)
+}
+
+func Test_RelayOutboxMessages(t *testing.T) {
+	newOutboxMessage := func(t *testing.T, attempts int) *entity.OutboxMessage {
+		t.Helper()
+
+		taskID := primitive.NewObjectID()
+		body, err := json.Marshal(
+			&message.HashCrackTaskStarted{Version: message.Version, RequestID: taskID.Hex(), PartNumber: 1},
+		)
+		require.NoError(t, err)
+
+		return &entity.OutboxMessage{
+			ObjectID:   primitive.NewObjectID(),
+			TaskID:     taskID,
+			PartNumber: 1,
+			Body:       body,
+			Attempts:   attempts,
+			CreatedAt:  time.Now(),
+		}
+	}
 
 	t.Run(
-		"GetAllFinished error", func(t *testing.T) {
+		"GetAllUnlocked error", func(t *testing.T) {
 			// Arrange
-			expectedError := errors.New("get all finished failed")
-			mockTaskRepo.On("GetAllFinished", ctx, true).Return(nil, expectedError).Once()
+			svc, m := newServiceWithMocks()
+			expectedError := errors.New("error")
+
+			m.outboxRepo.EXPECT().GetAllUnlocked(ctx, cfg.Outbox.BatchSize).Return(nil, expectedError).Once()
 
 			// Act
-			err := service.FinishTimeoutTasks(ctx)
+			err := svc.RelayOutboxMessages(ctx)
 
 			// Assert
-			require.Error(t, err)
 			require.ErrorIs(t, err, expectedError)
 		},
 	)
 
 	t.Run(
-		"Update error", func(t *testing.T) {
+		"Success", func(t *testing.T) {
 			// Arrange
-			tasks := []*entity.HashCrackTaskWithSubtasks{
-				{
-					ObjectID: primitive.NewObjectID(),
-					Status:   "PENDING",
-				},
-			}
-
-			expectedError := errors.New("update failed")
-
-			mockTaskRepo.On("GetAllFinished", ctx, true).Return(tasks, nil).Once()
-			mockTaskWithSubtasksSvc.On("UpdateTaskWithSubtasks", ctx, mock.Anything).Return(expectedError).Once()
+			svc, m := newServiceWithMocks()
+			outboxMsg := newOutboxMessage(t, 0)
+
+			m.outboxRepo.EXPECT().GetAllUnlocked(ctx, cfg.Outbox.BatchSize).
+				Return([]*entity.OutboxMessage{outboxMsg}, nil).Once()
+			m.outboxRepo.EXPECT().Lock(ctx, outboxMsg.ObjectID, mock.Anything).Return(nil).Once()
+			m.publisher.On(
+				"SendMessage", ctx, mock.MatchedBy(
+					func(msg *message.HashCrackTaskStarted) bool {
+						return msg.RequestID == outboxMsg.TaskID.Hex() && msg.PartNumber == 1
+					},
+				), publisher.Persistent, false, false,
+			).Return(nil).Once()
+			m.outboxRepo.EXPECT().Delete(ctx, outboxMsg.ObjectID).Return(nil).Once()
 
 			// Act
-			err := service.FinishTimeoutTasks(ctx)
+			err := svc.RelayOutboxMessages(ctx)
 
 			// Assert
-			require.Error(t, err)
-			require.ErrorIs(t, err, expectedError)
+			require.NoError(t, err)
+			assert.True(t, outboxMsg.LockedUntil.After(time.Now()))
+			m.outboxRepo.AssertExpectations(t)
+			m.publisher.AssertExpectations(t)
 		},
 	)
-}
 
-func Test_DeleteExpiredTasks(t *testing.T) {
 	t.Run(
-		"Success", func(t *testing.T) {
+		"Locked by another relay", func(t *testing.T) {
 			// Arrange
-			tasks := []*entity.HashCrackTaskWithSubtasks{
-				{
-					ObjectID: primitive.NewObjectID(),
-					Status:   "PENDING",
-				},
-			}
+			svc, m := newServiceWithMocks()
+			outboxMsg := newOutboxMessage(t, 0)
 
-			mockTaskRepo.On("GetAllExpired", ctx, mock.Anything, true).Return(tasks, nil).Once()
-			mockTaskWithSubtasksSvc.On("DeleteTasksWithSubtasks", ctx, tasks).Return(nil).Once()
+			m.outboxRepo.EXPECT().GetAllUnlocked(ctx, cfg.Outbox.BatchSize).
+				Return([]*entity.OutboxMessage{outboxMsg}, nil).Once()
+			m.outboxRepo.EXPECT().Lock(ctx, outboxMsg.ObjectID, mock.Anything).
+				Return(repository.ErrOutboxMessageLocked).Once()
 
 			// Act
-			err := service.DeleteExpiredTasks(ctx)
+			err := svc.RelayOutboxMessages(ctx)
 
 			// Assert
 			require.NoError(t, err)
+			m.publisher.AssertNotCalled(
+				t, "SendMessage", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything,
+			)
+			m.outboxRepo.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
 		},
 	)
 
 	t.Run(
-		"GetAllExpired error", func(t *testing.T) {
+		"Send error keeps message", func(t *testing.T) {
 			// Arrange
-			expectedErr := errors.New("repo error")
-			mockTaskRepo.On("GetAllExpired", ctx, mock.Anything, true).Return(nil, expectedErr).Once()
+			svc, m := newServiceWithMocks()
+			outboxMsg := newOutboxMessage(t, 0)
+			expectedError := errors.New("message is nacked by broker")
+
+			m.outboxRepo.EXPECT().GetAllUnlocked(ctx, cfg.Outbox.BatchSize).
+				Return([]*entity.OutboxMessage{outboxMsg}, nil).Once()
+			m.outboxRepo.EXPECT().Lock(ctx, outboxMsg.ObjectID, mock.Anything).Return(nil).Once()
+			m.publisher.On("SendMessage", ctx, mock.Anything, publisher.Persistent, false, false).
+				Return(expectedError).Once()
+			m.outboxRepo.EXPECT().Update(ctx, outboxMsg).Return(nil).Once()
 
 			// Act
-			err := service.DeleteExpiredTasks(ctx)
+			err := svc.RelayOutboxMessages(ctx)
 
 			// Assert
-			require.Error(t, err)
-			require.ErrorIs(t, err, expectedErr)
+			require.ErrorIs(t, err, expectedError)
+			assert.Equal(t, 1, outboxMsg.Attempts)
+			require.NotNil(t, outboxMsg.Error)
+			assert.Contains(t, *outboxMsg.Error, expectedError.Error())
+			m.outboxRepo.AssertExpectations(t)
+			m.outboxRepo.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+			m.subtaskRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
 		},
 	)
 
 	t.Run(
-		"No tasks", func(t *testing.T) {
+		"Attempts exhausted releases pending subtask", func(t *testing.T) {
 			// Arrange
-			tasks := []*entity.HashCrackTaskWithSubtasks{}
-
-			mockTaskRepo.On("GetAllExpired", ctx, mock.Anything, true).Return(tasks, nil).Once()
-			mockTaskWithSubtasksSvc.On("DeleteTaskWithSubtasks", ctx, mock.Anything).Return(nil).Once()
+			svc, m := newServiceWithMocks()
+			outboxMsg := newOutboxMessage(t, cfg.Outbox.MaxAttempts-1)
+			failed := &entity.HashCrackSubtask{
+				ObjectID:   primitive.NewObjectID(),
+				TaskID:     outboxMsg.TaskID,
+				PartNumber: 1,
+				Status:     entity.HashCrackSubtaskStatusInProgress,
+			}
+			pending := &entity.HashCrackSubtask{
+				ObjectID:   primitive.NewObjectID(),
+				TaskID:     outboxMsg.TaskID,
+				PartNumber: 2,
+				Status:     entity.HashCrackSubtaskStatusPending,
+			}
+			task := &entity.HashCrackTaskWithSubtasks{
+				ObjectID:  outboxMsg.TaskID,
+				Hash:      "hash",
+				MaxLength: 4,
+				PartCount: 3,
+				Status:    entity.HashCrackTaskStatusInProgress,
+				Subtasks:  []*entity.HashCrackSubtask{failed, pending},
+			}
+			expectedError := errors.New("message is nacked by broker")
+
+			m.outboxRepo.EXPECT().GetAllUnlocked(ctx, cfg.Outbox.BatchSize).
+				Return([]*entity.OutboxMessage{outboxMsg}, nil).Once()
+			m.outboxRepo.EXPECT().Lock(ctx, outboxMsg.ObjectID, mock.Anything).Return(nil).Once()
+			m.publisher.On(
+				"SendMessage", ctx, mock.MatchedBy(
+					func(msg *message.HashCrackTaskStarted) bool { return msg.PartNumber == 1 },
+				), publisher.Persistent, false, false,
+			).Return(expectedError).Once()
+			m.publisher.On(
+				"SendMessage", ctx, mock.MatchedBy(
+					func(msg *message.HashCrackTaskStarted) bool { return msg.PartNumber == 2 },
+				), publisher.Persistent, false, false,
+			).Return(nil).Once()
+			m.taskRepo.EXPECT().WithTransaction(ctx, mock.Anything).RunAndReturn(runInTransaction).Once()
+			m.taskRepo.EXPECT().Get(ctx, outboxMsg.TaskID, true).Return(task, nil).Once()
+			m.subtaskRepo.EXPECT().Update(ctx, failed).Return(nil).Once()
+			m.subtaskRepo.EXPECT().Update(ctx, pending).Return(nil).Once()
+			expectOutbox(m.outboxRepo)
 
 			// Act
-			err := service.DeleteExpiredTasks(ctx)
+			err := svc.RelayOutboxMessages(ctx)
 
 			// Assert
-			require.NoError(t, err)
+			require.ErrorIs(t, err, expectedError)
+			assert.Equal(t, entity.HashCrackSubtaskStatusError, failed.Status)
+			require.NotNil(t, failed.Reason)
+			assert.Contains(t, *failed.Reason, expectedError.Error())
+			assert.Equal(t, entity.HashCrackSubtaskStatusInProgress, pending.Status)
+			m.outboxRepo.AssertCalled(t, "Delete", ctx, outboxMsg.ObjectID)
+			m.outboxRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+			m.subtaskRepo.AssertExpectations(t)
+			m.publisher.AssertExpectations(t)
+			m.taskRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
 		},
 	)
 
 	t.Run(
-		"DeleteTaskWithSubtasks error", func(t *testing.T) {
+		"Attempts exhausted finishes task", func(t *testing.T) {
 			// Arrange
-			tasks := []*entity.HashCrackTaskWithSubtasks{
-				{
-					ObjectID: primitive.NewObjectID(),
-					Status:   "PENDING",
-				},
+			svc, m := newServiceWithMocks()
+			outboxMsg := newOutboxMessage(t, cfg.Outbox.MaxAttempts-1)
+			failed := &entity.HashCrackSubtask{
+				ObjectID:   primitive.NewObjectID(),
+				TaskID:     outboxMsg.TaskID,
+				PartNumber: 1,
+				Status:     entity.HashCrackSubtaskStatusInProgress,
 			}
-
-			expectedErr := errors.New("repo error")
-
-			mockTaskRepo.On("GetAllExpired", ctx, mock.Anything, true).Return(tasks, nil).Once()
-			mockTaskWithSubtasksSvc.On("DeleteTasksWithSubtasks", ctx, tasks).Return(expectedErr).Once()
+			succeeded := &entity.HashCrackSubtask{
+				ObjectID:   primitive.NewObjectID(),
+				TaskID:     outboxMsg.TaskID,
+				PartNumber: 0,
+				Status:     entity.HashCrackSubtaskStatusSuccess,
+			}
+			task := &entity.HashCrackTaskWithSubtasks{
+				ObjectID:  outboxMsg.TaskID,
+				PartCount: 2,
+				Status:    entity.HashCrackTaskStatusInProgress,
+				Subtasks:  []*entity.HashCrackSubtask{succeeded, failed},
+			}
+			expectedError := errors.New("message is nacked by broker")
+
+			m.outboxRepo.EXPECT().GetAllUnlocked(ctx, cfg.Outbox.BatchSize).
+				Return([]*entity.OutboxMessage{outboxMsg}, nil).Once()
+			m.outboxRepo.EXPECT().Lock(ctx, outboxMsg.ObjectID, mock.Anything).Return(nil).Once()
+			m.publisher.On("SendMessage", ctx, mock.Anything, publisher.Persistent, false, false).
+				Return(expectedError).Once()
+			m.taskRepo.EXPECT().WithTransaction(ctx, mock.Anything).RunAndReturn(runInTransaction).Once()
+			m.taskRepo.EXPECT().Get(ctx, outboxMsg.TaskID, true).Return(task, nil).Once()
+			m.subtaskRepo.EXPECT().Update(ctx, failed).Return(nil).Once()
+			m.taskRepo.EXPECT().Update(
+				ctx, mock.MatchedBy(
+					func(task *entity.HashCrackTask) bool {
+						return task.Status == entity.HashCrackTaskStatusPartialReady
+					},
+				),
+			).Return(nil).Once()
+			m.outboxRepo.EXPECT().Delete(ctx, outboxMsg.ObjectID).Return(nil).Once()
 
 			// Act
-			err := service.DeleteExpiredTasks(ctx)
+			err := svc.RelayOutboxMessages(ctx)
 
 			// Assert
-			require.Error(t, err)
-			require.ErrorIs(t, err, expectedErr)
+			require.ErrorIs(t, err, expectedError)
+			assert.Equal(t, entity.HashCrackSubtaskStatusError, failed.Status)
+			m.outboxRepo.AssertExpectations(t)
+			m.subtaskRepo.AssertExpectations(t)
+			m.taskRepo.AssertExpectations(t)
+			m.outboxRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+			m.outboxRepo.AssertNotCalled(t, "CreateAll", mock.Anything, mock.Anything)
 		},
 	)
 }
 
-func Test_ExecutePendingSubtasks(t *testing.T) {
+func Test_ExtendTask(t *testing.T) {
 	t.Run(
-		"GetAllByStatus error", func(t *testing.T) {
+		"Success", func(t *testing.T) {
 			// Arrange
-			expectedErr := errors.New("repo error")
+			svc, m := newServiceWithMocks()
+			objID := primitive.NewObjectID()
+			deadline := time.Now().Add(time.Hour)
 
-			mockSubtaskRepo.EXPECT().GetAllByStatus(ctx, entity.HashCrackSubtaskStatusPending).
-				Return(nil, expectedErr).Once()
+			m.taskRepo.On("ExtendDeadline", ctx, objID, time.Hour).Return(deadline, nil).Once()
 
 			// Act
-			err := service.ExecutePendingSubtasks(ctx)
+			output, err := svc.ExtendTask(ctx, objID.Hex(), &model.HashCrackTaskExtendInput{Timeout: "1h"})
 
 			// Assert
-			require.Error(t, err)
-			require.ErrorIs(t, err, expectedErr)
+			require.NoError(t, err)
+			require.Equal(t, deadline, output.Deadline)
+			m.taskRepo.AssertExpectations(t)
+			m.taskRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
 		},
 	)
 
 	t.Run(
-		"Get task error", func(t *testing.T) {
+		"Already finished", func(t *testing.T) {
 			// Arrange
-			subtasks := []*entity.HashCrackSubtask{
-				{
-					ObjectID: primitive.NewObjectID(),
-					Status:   entity.HashCrackSubtaskStatusPending,
-					TaskID:   primitive.NewObjectID(),
-				},
-			}
-
-			expectedErr := errors.New("repo error")
+			svc, m := newServiceWithMocks()
+			objID := primitive.NewObjectID()
 
-			mockSubtaskRepo.EXPECT().GetAllByStatus(ctx, entity.HashCrackSubtaskStatusPending).
-				Return(subtasks, nil).Once()
-			mockTaskRepo.EXPECT().Get(ctx, subtasks[0].TaskID, false).
-				Return(nil, expectedErr).Once()
+			m.taskRepo.On("ExtendDeadline", ctx, objID, time.Hour).
+				Return(time.Time{}, repository.ErrCrackTaskNotFound).Once()
+			m.taskRepo.On("Get", ctx, objID, false).Return(
+				&entity.HashCrackTaskWithSubtasks{
+					ObjectID: objID,
+					Status:   entity.HashCrackTaskStatusError,
+					Reason:   lo.ToPtr(domain.ErrTaskFinishedByTimeout.Error()),
+				}, nil,
+			).Once()
 
 			// Act
-			err := service.ExecutePendingSubtasks(ctx)
+			output, err := svc.ExtendTask(ctx, objID.Hex(), &model.HashCrackTaskExtendInput{Timeout: "1h"})
 
 			// Assert
-			require.Error(t, err)
-			require.ErrorIs(t, err, expectedErr)
+			require.ErrorIs(t, err, domain.ErrTaskFinished)
+			require.Nil(t, output)
+			m.taskRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
 		},
 	)
 
 	t.Run(
-		"Success - No subtasks", func(t *testing.T) {
+		"Task not found", func(t *testing.T) {
 			// Arrange
-			mockSubtaskRepo.EXPECT().GetAllByStatus(ctx, entity.HashCrackSubtaskStatusPending).
-				Return([]*entity.HashCrackSubtask{}, nil).Once()
+			svc, m := newServiceWithMocks()
+			objID := primitive.NewObjectID()
+
+			m.taskRepo.On("ExtendDeadline", ctx, objID, time.Hour).
+				Return(time.Time{}, repository.ErrCrackTaskNotFound).Once()
+			m.taskRepo.On("Get", ctx, objID, false).Return(nil, repository.ErrCrackTaskNotFound).Once()
 
 			// Act
-			err := service.ExecutePendingSubtasks(ctx)
+			output, err := svc.ExtendTask(ctx, objID.Hex(), &model.HashCrackTaskExtendInput{Timeout: "1h"})
 
 			// Assert
-			require.NoError(t, err)
+			require.ErrorIs(t, err, domain.ErrTaskNotFound)
+			require.Nil(t, output)
 		},
 	)
 
 	t.Run(
-		"Success - Has subtasks", func(t *testing.T) {
+		"Extend error", func(t *testing.T) {
 			// Arrange
-			subtasks := []*entity.HashCrackSubtask{
-				{
-					ObjectID: primitive.NewObjectID(),
-					Status:   entity.HashCrackSubtaskStatusPending,
-					TaskID:   primitive.NewObjectID(),
-				},
-			}
-			task := &entity.HashCrackTaskWithSubtasks{
-				ObjectID: subtasks[0].TaskID,
-				Status:   entity.HashCrackTaskStatusInProgress,
-			}
+			svc, m := newServiceWithMocks()
+			objID := primitive.NewObjectID()
+			expectedErr := errors.New("update failed")
 
-			mockSubtaskRepo.EXPECT().GetAllByStatus(ctx, entity.HashCrackSubtaskStatusPending).
-				Return(subtasks, nil).Once()
-			mockTaskRepo.EXPECT().Get(ctx, subtasks[0].TaskID, false).
-				Return(task, nil).Once()
-			mockPublisher.EXPECT().SendMessage(ctx, mock.Anything, publisher.Persistent, false, false).
-				Return(nil).Times(1)
-			mockTaskRepo.EXPECT().Update(ctx, task.ToHashCrackTask()).Return(nil).Once()
-			mockSubtaskRepo.EXPECT().Update(ctx, subtasks[0]).Return(nil).Times(1)
+			m.taskRepo.On("ExtendDeadline", ctx, objID, time.Hour).Return(time.Time{}, expectedErr).Once()
 
 			// Act
-			err := service.ExecutePendingSubtasks(ctx)
+			output, err := svc.ExtendTask(ctx, objID.Hex(), &model.HashCrackTaskExtendInput{Timeout: "1h"})
 
 			// Assert
-			require.NoError(t, err)
+			require.ErrorIs(t, err, expectedErr)
+			require.Nil(t, output)
+			m.taskRepo.AssertNotCalled(t, "Get", mock.Anything, mock.Anything, mock.Anything)
+		},
+	)
+
+	t.Run(
+		"Invalid input", func(t *testing.T) {
+			cases := []struct {
+				Name        string
+				ID          string
+				Timeout     string
+				ExpectedErr error
+			}{
+				{"Invalid ID", "1", "1h", domain.ErrInvalidRequestID},
+				{"Empty timeout", primitive.NewObjectID().Hex(), "", domain.ErrInvalidTimeout},
+				{"Negative timeout", primitive.NewObjectID().Hex(), "-1h", domain.ErrInvalidTimeout},
+				{"Timeout exceeds max age", primitive.NewObjectID().Hex(), "25h", domain.ErrInvalidTimeout},
+			}
+
+			for _, c := range cases {
+				t.Run(
+					c.Name, func(t *testing.T) {
+						// Arrange
+						svc, _ := newServiceWithMocks()
+
+						// Act
+						output, err := svc.ExtendTask(ctx, c.ID, &model.HashCrackTaskExtendInput{Timeout: c.Timeout})
+
+						// Assert
+						require.ErrorIs(t, err, c.ExpectedErr)
+						require.Nil(t, output)
+					},
+				)
+			}
 		},
 	)
 }
@@ -1,8 +1,15 @@
 package hashcrack
 
 import (
+	"crypto/md5"  // nolint
+	"crypto/sha1" // nolint
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	"math"
+	"slices"
 	"strings"
 	"time"
 
@@ -10,10 +17,284 @@ import (
 	"go.mongodb.org/mongo-driver/bson/primitive"
 
 	"github.com/ptrvsrg/crack-hash/manager/internal/persistence/entity"
+	"github.com/ptrvsrg/crack-hash/manager/internal/service/domain"
+	"github.com/ptrvsrg/crack-hash/manager/internal/service/infrastructure"
 	"github.com/ptrvsrg/crack-hash/manager/pkg/message"
 	"github.com/ptrvsrg/crack-hash/manager/pkg/model"
 )
 
+// digestSizes maps supported hash algorithms to the size of their digests in bytes
+var digestSizes = map[string]int{
+	message.HashAlgorithmMD5:    md5.Size,
+	message.HashAlgorithmSHA1:   sha1.Size,
+	message.HashAlgorithmSHA256: sha256.Size,
+	message.HashAlgorithmSHA512: sha512.Size,
+	message.HashAlgorithmNTLM:   md5.Size, // NTLM is MD4 over UTF-16LE, MD4 digests are as long as MD5 ones
+}
+
+// Task types
+const (
+	taskTypeSingle = "SINGLE"
+	taskTypeBatch  = "BATCH"
+)
+
+func normalizeTaskInput(input *model.HashCrackTaskInput, defaultAlphabet string) {
+	input.Algorithm = normalizeAlgorithm(input.Algorithm)
+	input.Hash = normalizeHash(input.Hash)
+	normalizeSalt(input.Salt)
+	input.Mode = normalizeMode(input.Mode)
+	input.Alphabet = normalizeAlphabet(input.Alphabet, defaultAlphabet)
+	input.MinLength = normalizeMinLength(input.MinLength)
+	input.WordlistID = strings.TrimSpace(input.WordlistID)
+	input.RuleSetID = strings.TrimSpace(input.RuleSetID)
+}
+
+func normalizeBatchTaskInput(input *model.HashCrackBatchTaskInput, defaultAlphabet string) {
+	input.Algorithm = normalizeAlgorithm(input.Algorithm)
+
+	hashes := lo.Uniq(lo.Map(input.Hashes, func(hash string, _ int) string { return normalizeHash(hash) }))
+	slices.Sort(hashes)
+	input.Hashes = hashes
+
+	normalizeSalt(input.Salt)
+	input.Mode = normalizeMode(input.Mode)
+	input.Alphabet = normalizeAlphabet(input.Alphabet, defaultAlphabet)
+	input.MinLength = normalizeMinLength(input.MinLength)
+	input.WordlistID = strings.TrimSpace(input.WordlistID)
+	input.RuleSetID = strings.TrimSpace(input.RuleSetID)
+}
+
+func normalizeMode(mode string) string {
+	mode = strings.ToUpper(strings.TrimSpace(mode))
+	if mode == "" {
+		return message.AttackModeBruteForce
+	}
+
+	return mode
+}
+
+// normalizeAlphabet drops duplicate symbols of the alphabet, the alphabet from the config is used by default
+func normalizeAlphabet(alphabet, defaultAlphabet string) string {
+	if alphabet == "" {
+		alphabet = defaultAlphabet
+	}
+
+	return string(lo.Uniq([]rune(alphabet)))
+}
+
+func normalizeMinLength(minLength int) int {
+	if minLength == 0 {
+		return 1
+	}
+
+	return minLength
+}
+
+func normalizeAlgorithm(algorithm string) string {
+	algorithm = strings.ToUpper(strings.TrimSpace(algorithm))
+	if algorithm == "" {
+		return message.HashAlgorithmMD5
+	}
+
+	return algorithm
+}
+
+func normalizeHash(hash string) string {
+	return strings.ToLower(strings.TrimSpace(hash))
+}
+
+func normalizeSalt(salt *model.HashCrackSalt) {
+	if salt != nil {
+		salt.Position = strings.ToUpper(strings.TrimSpace(salt.Position))
+	}
+}
+
+func validateTaskInput(input *model.HashCrackTaskInput) error {
+	if err := validateAlgorithm(input.Algorithm); err != nil {
+		return err
+	}
+
+	if err := validateHash(input.Algorithm, input.Hash); err != nil {
+		return err
+	}
+
+	if err := validateSalt(input.Salt); err != nil {
+		return err
+	}
+
+	if err := validateMode(input.Mode, input.WordlistID, input.RuleSetID, input.Mask, input.Charsets); err != nil {
+		return err
+	}
+
+	if err := validatePriority(input.Priority); err != nil {
+		return err
+	}
+
+	return validateBruteForce(input.Mode, input.Alphabet, input.MinLength, input.MaxLength)
+}
+
+func validateBatchTaskInput(input *model.HashCrackBatchTaskInput, limit int) error {
+	if err := validateAlgorithm(input.Algorithm); err != nil {
+		return err
+	}
+
+	if len(input.Hashes) == 0 {
+		return fmt.Errorf("%w: hash list must not be empty", domain.ErrInvalidHash)
+	}
+
+	if len(input.Hashes) > limit {
+		return fmt.Errorf("%w: got %d, limit is %d", domain.ErrTooManyHashes, len(input.Hashes), limit)
+	}
+
+	for _, hash := range input.Hashes {
+		if err := validateHash(input.Algorithm, hash); err != nil {
+			return err
+		}
+	}
+
+	if err := validateSalt(input.Salt); err != nil {
+		return err
+	}
+
+	if err := validateMode(input.Mode, input.WordlistID, input.RuleSetID, input.Mask, input.Charsets); err != nil {
+		return err
+	}
+
+	if err := validatePriority(input.Priority); err != nil {
+		return err
+	}
+
+	return validateBruteForce(input.Mode, input.Alphabet, input.MinLength, input.MaxLength)
+}
+
+func validateMode(mode, wordlistID, ruleSetID, mask string, charsets []string) error {
+	if mode != message.AttackModeDictionary && ruleSetID != "" {
+		return fmt.Errorf("%w: rules require the %s mode", domain.ErrInvalidRuleSetID, message.AttackModeDictionary)
+	}
+
+	if mode != message.AttackModeMask && (mask != "" || len(charsets) > 0) {
+		return fmt.Errorf("%w: masks require the %s mode", domain.ErrInvalidMask, message.AttackModeMask)
+	}
+
+	switch mode {
+	case message.AttackModeBruteForce:
+	case message.AttackModeDictionary:
+		if !primitive.IsValidObjectID(wordlistID) {
+			return fmt.Errorf("%w: %q", domain.ErrInvalidWordlistID, wordlistID)
+		}
+		if ruleSetID != "" && !primitive.IsValidObjectID(ruleSetID) {
+			return fmt.Errorf("%w: %q", domain.ErrInvalidRuleSetID, ruleSetID)
+		}
+	case message.AttackModeMask:
+		if _, err := expandMask(mask, charsets); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("%w: %s", domain.ErrInvalidAttackMode, mode)
+	}
+
+	return nil
+}
+
+// validatePriority checks the priority fits the queue of started tasks, it is sent to the broker as a byte
+func validatePriority(priority int) error {
+	if priority < 0 || priority > message.MaxTaskPriority {
+		return fmt.Errorf("%w: got %d, must be from 0 to %d", domain.ErrInvalidPriority, priority, message.MaxTaskPriority)
+	}
+
+	return nil
+}
+
+// validateBruteForce validates the alphabet and the word lengths, they are used by brute force tasks only
+func validateBruteForce(mode, alphabet string, minLength, maxLength int) error {
+	if mode != message.AttackModeBruteForce {
+		return nil
+	}
+
+	if alphabet == "" {
+		return fmt.Errorf("%w: alphabet is empty", domain.ErrInvalidAlphabet)
+	}
+
+	// Workers iterate over the alphabet byte by byte, so it is limited to printable ASCII symbols
+	for _, symbol := range alphabet {
+		if symbol < ' ' || symbol > '~' {
+			return fmt.Errorf("%w: symbol %q is not a printable ASCII one", domain.ErrInvalidAlphabet, symbol)
+		}
+	}
+
+	if maxLength <= 0 {
+		return fmt.Errorf("%w: max length must be positive, got %d", domain.ErrInvalidLength, maxLength)
+	}
+
+	if minLength <= 0 || minLength > maxLength {
+		return fmt.Errorf(
+			"%w: min length must be between 1 and %d, got %d", domain.ErrInvalidLength, maxLength, minLength,
+		)
+	}
+
+	return nil
+}
+
+// parseTimeout parses the timeout of the task, which must be positive and not exceed the max age of tasks.
+// The default timeout is used if it is empty
+func parseTimeout(timeout string, defaultTimeout, maxTimeout time.Duration) (time.Duration, error) {
+	if timeout == "" {
+		return defaultTimeout, nil
+	}
+
+	duration, err := time.ParseDuration(timeout)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %w", domain.ErrInvalidTimeout, err)
+	}
+
+	if duration <= 0 || duration > maxTimeout {
+		return 0, fmt.Errorf("%w: must be between 0 and %s, got %s", domain.ErrInvalidTimeout, maxTimeout, duration)
+	}
+
+	return duration, nil
+}
+
+func validateAlgorithm(algorithm string) error {
+	if _, ok := digestSizes[algorithm]; !ok {
+		return fmt.Errorf("%w: %s", domain.ErrUnsupportedAlgorithm, algorithm)
+	}
+
+	return nil
+}
+
+func validateHash(algorithm, hash string) error {
+	digest, err := hex.DecodeString(hash)
+	if err != nil {
+		return fmt.Errorf("%w: %w", domain.ErrInvalidHash, err)
+	}
+
+	if digestSize := digestSizes[algorithm]; len(digest) != digestSize {
+		return fmt.Errorf(
+			"%w: %s digest must be %d bytes long, got %d", domain.ErrInvalidHash, algorithm, digestSize, len(digest),
+		)
+	}
+
+	return nil
+}
+
+func validateSalt(salt *model.HashCrackSalt) error {
+	if salt == nil {
+		return nil
+	}
+
+	switch salt.Position {
+	case message.SaltPositionPrefix, message.SaltPositionSuffix:
+		if salt.Value == "" {
+			return fmt.Errorf("%w: salt value must not be empty", domain.ErrInvalidSalt)
+		}
+	case message.SaltPositionHMAC:
+	default:
+		return fmt.Errorf("%w: unknown position %q", domain.ErrInvalidSalt, salt.Position)
+	}
+
+	return nil
+}
+
 func hasSubtaskStatuses(task *entity.HashCrackTaskWithSubtasks) (bool, bool, bool, bool) {
 	var (
 		hasSuccess    = false
@@ -31,7 +312,8 @@ func hasSubtaskStatuses(task *entity.HashCrackTaskWithSubtasks) (bool, bool, boo
 			hasInProgress = true
 		case entity.HashCrackSubtaskStatusPending:
 			hasPending = true
-		case entity.HashCrackSubtaskStatusUnknown:
+		case entity.HashCrackSubtaskStatusCancelled, entity.HashCrackSubtaskStatusSkipped,
+			entity.HashCrackSubtaskStatusUnknown:
 		}
 	}
 
@@ -78,12 +360,34 @@ func markSubtaskAsErrorWithReason(task *entity.HashCrackSubtask, reason string)
 	task.Reason = lo.ToPtr(reason)
 }
 
+func markSubtaskAsSuccess(task *entity.HashCrackSubtask) {
+	task.Status = entity.HashCrackSubtaskStatusSuccess
+	task.Percent = 100.0
+}
+
 func markTaskAsInProgress(task *entity.HashCrackTask) {
 	task.Status = entity.HashCrackTaskStatusInProgress
 }
 
-func markSubtaskAsInProgress(task *entity.HashCrackSubtask) {
+// markSubtaskAsSent marks the subtask as IN_PROGRESS and counts the attempt to execute it. The subtask is leased
+// until the worker reports its first progress, the lease is renewed by every progress message
+func markSubtaskAsSent(task *entity.HashCrackSubtask, lease time.Duration) {
+	now := time.Now()
+
 	task.Status = entity.HashCrackSubtaskStatusInProgress
+	task.Attempts++
+	task.LeaseExpiresAt = lo.ToPtr(now.Add(lease))
+	task.StartedAt = lo.ToPtr(now)
+}
+
+// renewSubtaskLease extends the lease of the subtask in progress, the lease of the finished subtask is released
+func renewSubtaskLease(task *entity.HashCrackSubtask, timeout time.Duration) {
+	if task.Status != entity.HashCrackSubtaskStatusInProgress {
+		task.LeaseExpiresAt = nil
+		return
+	}
+
+	task.LeaseExpiresAt = lo.ToPtr(time.Now().Add(timeout))
 }
 
 func markTaskAsPartialReady(task *entity.HashCrackTask) {
@@ -94,36 +398,229 @@ func markTaskAsReady(task *entity.HashCrackTask) {
 	task.Status = entity.HashCrackTaskStatusReady
 }
 
+func markTaskAsFinishedByTimeout(task *entity.HashCrackTaskWithSubtasks) {
+	task.Status = entity.HashCrackTaskStatusError
+	task.Reason = lo.ToPtr(domain.ErrTaskFinishedByTimeout.Error())
+}
+
+func markTaskAsCancelled(task *entity.HashCrackTaskWithSubtasks) {
+	task.Status = entity.HashCrackTaskStatusCancelled
+}
+
+func markSubtaskAsCancelled(task *entity.HashCrackSubtask) {
+	task.Status = entity.HashCrackSubtaskStatusCancelled
+}
+
+func markSubtaskAsSkipped(task *entity.HashCrackSubtask) {
+	task.Status = entity.HashCrackSubtaskStatusSkipped
+}
+
+// isSubtaskLeaseExpired reports whether the worker of the subtask in progress has not renewed its lease in time
+func isSubtaskLeaseExpired(subtask *entity.HashCrackSubtask, now time.Time) bool {
+	return subtask.Status == entity.HashCrackSubtaskStatusInProgress &&
+		subtask.LeaseExpiresAt != nil && subtask.LeaseExpiresAt.Before(now)
+}
+
+// isTaskTimedOut reports whether the pending or in progress task has passed its deadline
+func isTaskTimedOut(task *entity.HashCrackTaskWithSubtasks, now time.Time) bool {
+	unfinished := task.Status == entity.HashCrackTaskStatusPending || task.Status == entity.HashCrackTaskStatusInProgress
+
+	return unfinished && task.Deadline != nil && task.Deadline.Before(now)
+}
+
+// isSubtaskUnfinished reports whether the subtask is pending or in progress
+func isSubtaskUnfinished(subtask *entity.HashCrackSubtask) bool {
+	return subtask.Status == entity.HashCrackSubtaskStatusPending ||
+		subtask.Status == entity.HashCrackSubtaskStatusInProgress
+}
+
+// limitSubtasks returns the pending subtasks, which can be sent to workers without exceeding the limit of subtasks
+// in flight of the task. All pending subtasks are returned if there is no limit or all hashes of the batch task are
+// cracked, because such subtasks are finished without workers
+func limitSubtasks(
+	task *entity.HashCrackTaskWithSubtasks, pending []*entity.HashCrackSubtask, limit int,
+) []*entity.HashCrackSubtask {
+	if limit <= 0 || task.ToHashCrackTask().IsBatch() && len(uncrackedHashes(task)) == 0 {
+		return pending
+	}
+
+	inFlight := lo.CountBy(
+		task.Subtasks, func(subtask *entity.HashCrackSubtask) bool {
+			return subtask.Status == entity.HashCrackSubtaskStatusInProgress
+		},
+	)
+
+	pending = slices.Clone(pending)
+	slices.SortFunc(
+		pending, func(a, b *entity.HashCrackSubtask) int {
+			return a.PartNumber - b.PartNumber
+		},
+	)
+
+	return pending[:max(0, min(len(pending), limit-inFlight))]
+}
+
 func buildTaskIDOutput(task *entity.HashCrackTask) *model.HashCrackTaskIDOutput {
 	return &model.HashCrackTaskIDOutput{
 		RequestID: task.ObjectID.Hex(),
 	}
 }
 
-func buildTaskEntityWithSubtasks(input *model.HashCrackTaskInput, partCount int) *entity.HashCrackTaskWithSubtasks {
-	task := &entity.HashCrackTaskWithSubtasks{
-		ObjectID:   primitive.NewObjectID(),
-		Hash:       input.Hash,
-		MaxLength:  input.MaxLength,
-		PartCount:  partCount,
-		Status:     entity.HashCrackTaskStatusPending,
-		Reason:     nil,
-		FinishedAt: nil,
-		CreatedAt:  time.Now(),
-		UpdatedAt:  time.Now(),
+func buildTaskEntity(input *model.HashCrackTaskInput, timeout time.Duration) *entity.HashCrackTaskWithSubtasks {
+	return &entity.HashCrackTaskWithSubtasks{
+		ObjectID:         primitive.NewObjectID(),
+		Algorithm:        input.Algorithm,
+		Hash:             input.Hash,
+		Salt:             buildSaltEntity(input.Salt),
+		Mode:             input.Mode,
+		Alphabet:         buildAlphabet(input.Mode, input.Alphabet),
+		MinLength:        buildMinLength(input.Mode, input.MinLength),
+		MaxLength:        buildMaxLength(input.Mode, input.MaxLength),
+		WordlistID:       buildDictionaryObjectID(input.Mode, input.WordlistID),
+		RuleSetID:        buildDictionaryObjectID(input.Mode, input.RuleSetID),
+		Mask:             buildMaskEntity(input.Mode, input.Mask, input.Charsets),
+		StopOnFirstMatch: input.StopOnFirstMatch,
+		Priority:         input.Priority,
+		Status:           entity.HashCrackTaskStatusPending,
+		Reason:           nil,
+		Deadline:         lo.ToPtr(time.Now().Add(timeout)),
+		FinishedAt:       nil,
+		CreatedAt:        time.Now(),
+		UpdatedAt:        time.Now(),
+	}
+}
+
+func buildBatchTaskEntity(
+	input *model.HashCrackBatchTaskInput, timeout time.Duration,
+) *entity.HashCrackTaskWithSubtasks {
+	return &entity.HashCrackTaskWithSubtasks{
+		ObjectID:         primitive.NewObjectID(),
+		Algorithm:        input.Algorithm,
+		Hashes:           input.Hashes,
+		Salt:             buildSaltEntity(input.Salt),
+		Mode:             input.Mode,
+		Alphabet:         buildAlphabet(input.Mode, input.Alphabet),
+		MinLength:        buildMinLength(input.Mode, input.MinLength),
+		MaxLength:        buildMaxLength(input.Mode, input.MaxLength),
+		WordlistID:       buildDictionaryObjectID(input.Mode, input.WordlistID),
+		RuleSetID:        buildDictionaryObjectID(input.Mode, input.RuleSetID),
+		Mask:             buildMaskEntity(input.Mode, input.Mask, input.Charsets),
+		StopOnFirstMatch: input.StopOnFirstMatch,
+		Priority:         input.Priority,
+		Status:           entity.HashCrackTaskStatusPending,
+		Reason:           nil,
+		Deadline:         lo.ToPtr(time.Now().Add(timeout)),
+		FinishedAt:       nil,
+		CreatedAt:        time.Now(),
+		UpdatedAt:        time.Now(),
+	}
+}
+
+// buildAlphabet drops the alphabet of dictionary and mask tasks, the words come from the wordlist or the mask
+func buildAlphabet(mode, alphabet string) string {
+	if mode != message.AttackModeBruteForce {
+		return ""
+	}
+
+	return alphabet
+}
+
+// buildMinLength drops the min length of dictionary and mask tasks, the words come from the wordlist or the mask
+func buildMinLength(mode string, minLength int) int {
+	if mode != message.AttackModeBruteForce {
+		return 0
 	}
 
-	task.Subtasks = buildSubtaskEntities(partCount, task.ObjectID)
+	return minLength
+}
+
+// buildMaxLength drops the max length of dictionary and mask tasks, the words come from the wordlist or the mask
+func buildMaxLength(mode string, maxLength int) int {
+	if mode != message.AttackModeBruteForce {
+		return 0
+	}
+
+	return maxLength
+}
+
+// buildDictionaryObjectID converts the ID of a wordlist or a rule set, they are used by dictionary tasks only
+func buildDictionaryObjectID(mode, id string) *primitive.ObjectID {
+	if mode != message.AttackModeDictionary {
+		return nil
+	}
+
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil
+	}
+
+	return &objID
+}
+
+// buildMaskEntity expands the mask of a mask task, the mask is validated beforehand
+func buildMaskEntity(mode, pattern string, charsets []string) *entity.HashCrackMask {
+	if mode != message.AttackModeMask {
+		return nil
+	}
+
+	positions, err := expandMask(pattern, charsets)
+	if err != nil {
+		return nil
+	}
 
-	return task
+	return &entity.HashCrackMask{
+		Pattern:   pattern,
+		Charsets:  charsets,
+		Positions: positions,
+	}
 }
 
-func buildSubtaskEntities(partCount int, taskID primitive.ObjectID) []*entity.HashCrackSubtask {
+func buildSaltEntity(salt *model.HashCrackSalt) *entity.HashCrackSalt {
+	if salt == nil {
+		return nil
+	}
+
+	return &entity.HashCrackSalt{
+		Value:    salt.Value,
+		Position: entity.HashSaltPosition(salt.Position),
+	}
+}
+
+func addSubtaskEntities(task *entity.HashCrackTaskWithSubtasks, partCount int) {
+	task.PartCount = partCount
+	task.Subtasks = buildSubtaskEntities(partCount, task.ObjectID, task.Algorithm)
+}
+
+// addKeyRangeSubtaskEntities adds subtasks checking consecutive chunks of the keyspace of a brute force or mask task
+func addKeyRangeSubtaskEntities(task *entity.HashCrackTaskWithSubtasks, partition infrastructure.KeyPartition) {
+	addSubtaskEntities(task, partition.PartCount())
+
+	for i, subtask := range task.Subtasks {
+		subtask.Range = &entity.HashCrackKeyRange{
+			Start: i * partition.ChunkSize,
+			End:   min((i+1)*partition.ChunkSize, partition.Size),
+		}
+	}
+}
+
+func addLineSubtaskEntities(task *entity.HashCrackTaskWithSubtasks, ranges []infrastructure.LineRange) {
+	addSubtaskEntities(task, len(ranges))
+
+	for i, lines := range ranges {
+		task.Subtasks[i].Lines = &entity.HashCrackLineRange{
+			Offset: lines.Offset,
+			Count:  lines.Count,
+		}
+	}
+}
+
+func buildSubtaskEntities(partCount int, taskID primitive.ObjectID, algorithm string) []*entity.HashCrackSubtask {
 	subtasks := make([]*entity.HashCrackSubtask, partCount)
 	for i := 0; i < partCount; i++ {
 		subtasks[i] = &entity.HashCrackSubtask{
 			ObjectID:   primitive.NewObjectID(),
 			TaskID:     taskID,
+			Algorithm:  algorithm,
 			PartNumber: i,
 			Status:     entity.HashCrackSubtaskStatusPending,
 			Data:       nil,
@@ -134,41 +631,251 @@ func buildSubtaskEntities(partCount int, taskID primitive.ObjectID) []*entity.Ha
 	return subtasks
 }
 
+// allHashesFound reports whether a word is found for every target hash of the task
+func allHashesFound(task *entity.HashCrackTaskWithSubtasks) bool {
+	if task.ToHashCrackTask().IsBatch() {
+		return len(uncrackedHashes(task)) == 0
+	}
+
+	return len(foundWords(task)[task.Hash]) > 0
+}
+
+// foundWords groups the words found by the subtasks by hash
+func foundWords(task *entity.HashCrackTaskWithSubtasks) map[string][]string {
+	words := make(map[string][]string)
+
+	for _, subtask := range task.Subtasks {
+		// All words found for a single hash task belong to its hash
+		if !task.ToHashCrackTask().IsBatch() {
+			words[task.Hash] = append(words[task.Hash], subtask.Data...)
+			continue
+		}
+
+		for _, found := range subtask.Found {
+			words[found.Hash] = append(words[found.Hash], found.Word)
+		}
+	}
+
+	return words
+}
+
+// uncrackedHashes returns the hashes of the batch task that none of the subtasks has cracked yet
+func uncrackedHashes(task *entity.HashCrackTaskWithSubtasks) []string {
+	if len(task.Hashes) == 0 {
+		return nil
+	}
+
+	words := foundWords(task)
+
+	return lo.Filter(
+		task.Hashes, func(hash string, _ int) bool {
+			return len(words[hash]) == 0
+		},
+	)
+}
+
 func buildTaskStatusOutput(task *entity.HashCrackTaskWithSubtasks) *model.HashCrackTaskStatusOutput {
 	allData := make([]string, 0)
-	averagePercent := 0.0
-	subtaskOutputs := make([]model.HashCrackSubtaskStatusOutput, len(task.Subtasks))
+	if task.Status != entity.HashCrackTaskStatusError {
+		for _, subtask := range task.Subtasks {
+			allData = append(allData, subtask.Data...)
+		}
+	}
 
-	for i, subtask := range task.Subtasks {
-		if task.PartCount > 0 {
-			averagePercent += subtask.Percent / float64(task.PartCount)
+	return &model.HashCrackTaskStatusOutput{
+		Algorithm: taskAlgorithm(task.Algorithm),
+		Hash:      task.Hash,
+		Salt:      buildSaltOutput(task.Salt),
+		Mode:      taskMode(task.Mode),
+		Status:    task.Status.String(),
+		Data:      allData,
+		Percent:   taskPercent(task),
+		Subtasks:  buildSubtaskStatusOutputs(task.Subtasks),
+	}
+}
+
+func buildBatchTaskStatusOutput(task *entity.HashCrackTaskWithSubtasks) *model.HashCrackBatchTaskStatusOutput {
+	words := make(map[string][]string)
+	if task.Status != entity.HashCrackTaskStatusError {
+		words = foundWords(task)
+	}
+
+	hashes := task.Hashes
+	if len(hashes) == 0 {
+		hashes = []string{task.Hash}
+	}
+
+	cracked := 0
+	hashOutputs := make([]model.HashCrackHashStatusOutput, len(hashes))
+	for i, hash := range hashes {
+		hashWords := lo.Uniq(words[hash])
+		if len(hashWords) > 0 {
+			cracked++
 		}
 
-		if task.Status != entity.HashCrackTaskStatusError {
-			allData = append(allData, subtask.Data...)
+		hashOutputs[i] = model.HashCrackHashStatusOutput{
+			Hash:    hash,
+			Cracked: len(hashWords) > 0,
+			Words:   hashWords,
 		}
+	}
 
-		subtaskOutputs[i] = model.HashCrackSubtaskStatusOutput{
+	return &model.HashCrackBatchTaskStatusOutput{
+		Algorithm: taskAlgorithm(task.Algorithm),
+		Salt:      buildSaltOutput(task.Salt),
+		Mode:      taskMode(task.Mode),
+		Status:    task.Status.String(),
+		Total:     len(hashes),
+		Cracked:   cracked,
+		Hashes:    hashOutputs,
+		Percent:   taskPercent(task),
+		Subtasks:  buildSubtaskStatusOutputs(task.Subtasks),
+	}
+}
+
+func buildSubtaskStatusOutputs(subtasks []*entity.HashCrackSubtask) []model.HashCrackSubtaskStatusOutput {
+	outputs := make([]model.HashCrackSubtaskStatusOutput, len(subtasks))
+	for i, subtask := range subtasks {
+		outputs[i] = model.HashCrackSubtaskStatusOutput{
 			Status:  subtask.Status.String(),
 			Data:    subtask.Data,
 			Percent: subtask.Percent,
 		}
 	}
 
-	return &model.HashCrackTaskStatusOutput{
-		Status:   task.Status.String(),
-		Data:     allData,
-		Percent:  math.Min(100.0, averagePercent),
-		Subtasks: subtaskOutputs,
+	return outputs
+}
+
+// taskPercent returns the percent of the task weighted by the sizes of its subtasks, which differ after splitting
+func taskPercent(task *entity.HashCrackTaskWithSubtasks) float64 {
+	totalSize := lo.SumBy(task.Subtasks, subtaskSize)
+	if task.PartCount == 0 || totalSize == 0 {
+		return 0.0
+	}
+
+	averagePercent := 0.0
+	for _, subtask := range task.Subtasks {
+		weight := float64(subtaskSize(subtask)) / float64(totalSize)
+
+		// Skipped subtasks are not needed anymore, so they are done
+		if subtask.Status == entity.HashCrackSubtaskStatusSkipped {
+			averagePercent += 100.0 * weight
+			continue
+		}
+
+		averagePercent += subtask.Percent * weight
+	}
+
+	return math.Min(100.0, averagePercent)
+}
+
+// subtaskSize returns the number of candidates or wordlist lines of the subtask, the subtasks created before ranges
+// are equal
+func subtaskSize(subtask *entity.HashCrackSubtask) int {
+	start, end, ok := subtaskBounds(subtask)
+	if !ok {
+		return 1
+	}
+
+	return end - start
+}
+
+// subtaskBounds returns the range of candidate indexes of a brute force or mask subtask and the range of wordlist
+// lines of a dictionary subtask, ok is false for the subtasks created before ranges
+func subtaskBounds(subtask *entity.HashCrackSubtask) (int, int, bool) {
+	switch {
+	case subtask.Range != nil:
+		return subtask.Range.Start, subtask.Range.End, true
+	case subtask.Lines != nil:
+		return subtask.Lines.Offset, subtask.Lines.Offset + subtask.Lines.Count, true
+	default:
+		return 0, 0, false
+	}
+}
+
+// shrinkSubtask lowers the end of the range of the subtask
+func shrinkSubtask(subtask *entity.HashCrackSubtask, end int) {
+	switch {
+	case subtask.Range != nil:
+		subtask.Range.End = end
+	case subtask.Lines != nil:
+		subtask.Lines.Count = end - subtask.Lines.Offset
 	}
 }
 
+// buildSplitSubtaskEntity builds the subtask checking the rest of the range split off the subtask
+func buildSplitSubtaskEntity(
+	task *entity.HashCrackTaskWithSubtasks, subtask *entity.HashCrackSubtask, start, end int,
+) *entity.HashCrackSubtask {
+	split := &entity.HashCrackSubtask{
+		ObjectID:   primitive.NewObjectID(),
+		TaskID:     task.ObjectID,
+		Algorithm:  task.Algorithm,
+		PartNumber: task.PartCount,
+		Status:     entity.HashCrackSubtaskStatusPending,
+		CreatedAt:  time.Now(),
+	}
+
+	if subtask.Lines != nil {
+		split.Lines = &entity.HashCrackLineRange{Offset: start, Count: end - start}
+	} else {
+		split.Range = &entity.HashCrackKeyRange{Start: start, End: end}
+	}
+
+	return split
+}
+
 func buildTaskMetadataOutput(task *entity.HashCrackTaskWithSubtasks) *model.HashCrackTaskMetadataOutput {
+	taskType, hashCount := taskTypeSingle, 1
+	if task.ToHashCrackTask().IsBatch() {
+		taskType, hashCount = taskTypeBatch, len(task.Hashes)
+	}
+
+	var (
+		wordlistID, ruleSetID, mask string
+		charsets                    []string
+	)
+	if task.WordlistID != nil {
+		wordlistID = task.WordlistID.Hex()
+	}
+	if task.RuleSetID != nil {
+		ruleSetID = task.RuleSetID.Hex()
+	}
+	if task.Mask != nil {
+		mask, charsets = task.Mask.Pattern, task.Mask.Charsets
+	}
+
 	return &model.HashCrackTaskMetadataOutput{
-		RequestID: task.ObjectID.Hex(),
-		Hash:      task.Hash,
-		MaxLength: task.MaxLength,
-		CreatedAt: task.CreatedAt,
+		RequestID:        task.ObjectID.Hex(),
+		Type:             taskType,
+		Algorithm:        taskAlgorithm(task.Algorithm),
+		Hash:             task.Hash,
+		HashCount:        hashCount,
+		Salt:             buildSaltOutput(task.Salt),
+		Mode:             taskMode(task.Mode),
+		Alphabet:         task.Alphabet,
+		MinLength:        task.MinLength,
+		MaxLength:        task.MaxLength,
+		WordlistID:       wordlistID,
+		RuleSetID:        ruleSetID,
+		RuleCount:        len(task.Rules),
+		Mask:             mask,
+		Charsets:         charsets,
+		StopOnFirstMatch: task.StopOnFirstMatch,
+		Priority:         task.Priority,
+		Deadline:         task.Deadline,
+		CreatedAt:        task.CreatedAt,
+	}
+}
+
+func buildSaltOutput(salt *entity.HashCrackSalt) *model.HashCrackSalt {
+	if salt == nil {
+		return nil
+	}
+
+	return &model.HashCrackSalt{
+		Value:    salt.Value,
+		Position: salt.Position.String(),
 	}
 }
 
@@ -187,25 +894,161 @@ func buildTaskMetadataOutputs(
 	}
 }
 
-func buildTaskMessage(task *entity.HashCrackTask, i int, alphabet string) *message.HashCrackTaskStarted {
-	symbols := strings.Split(alphabet, "")
+func buildTaskMessage(
+	task *entity.HashCrackTask, subtask *entity.HashCrackSubtask, hashes []string, alphabet string, chunkSize int,
+) *message.HashCrackTaskStarted {
+	msg := &message.HashCrackTaskStarted{
+		Version:      message.Version,
+		RequestID:    task.ObjectID.Hex(),
+		Algorithm:    taskAlgorithm(task.Algorithm),
+		Hash:         task.Hash,
+		Hashes:       hashes,
+		Salt:         buildSaltMessage(task.Salt),
+		Mode:         taskMode(task.Mode),
+		MinLength:    task.MinLength,
+		MaxLength:    task.MaxLength,
+		PartNumber:   subtask.PartNumber,
+		PartCount:    task.PartCount,
+		Priority:     uint8(task.Priority),
+		ResumeOffset: subtask.Checkpoint,
+		Attempt:      subtask.Attempts,
+	}
+
+	switch {
+	case task.WordlistID != nil && subtask.Lines != nil:
+		msg.Wordlist = &message.Wordlist{
+			ID:     task.WordlistID.Hex(),
+			Offset: subtask.Lines.Offset,
+			Count:  subtask.Lines.Count,
+		}
+		msg.Rules = task.Rules
+	case task.Mask != nil:
+		msg.Mask = &message.Mask{Charsets: task.Mask.Positions}
+		msg.StartIndex, msg.EndIndex = subtaskKeyRange(subtask, chunkSize)
+	default:
+		msg.Alphabet = message.Alphabet{Symbols: strings.Split(taskAlphabet(task.Alphabet, alphabet), "")}
+		msg.StartIndex, msg.EndIndex = subtaskKeyRange(subtask, chunkSize)
+	}
+
+	return msg
+}
+
+// subtaskKeyRange returns the range of candidate indexes of the subtask, subtasks created before ranges
+// are located by their part number
+func subtaskKeyRange(subtask *entity.HashCrackSubtask, chunkSize int) (int, int) {
+	if subtask.Range != nil {
+		return subtask.Range.Start, subtask.Range.End
+	}
+
+	return subtask.PartNumber * chunkSize, (subtask.PartNumber + 1) * chunkSize
+}
+
+// buildOutboxMessage builds the outbox message of the subtask, the message is unlocked, so the relay sends it
+// at once
+func buildOutboxMessage(
+	task *entity.HashCrackTask, subtask *entity.HashCrackSubtask, msg *message.HashCrackTaskStarted,
+) (*entity.OutboxMessage, error) {
+	body, err := json.Marshal(msg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal message: %w", err)
+	}
+
+	now := time.Now()
+
+	return &entity.OutboxMessage{
+		ObjectID:    primitive.NewObjectID(),
+		TaskID:      task.ObjectID,
+		PartNumber:  subtask.PartNumber,
+		Body:        body,
+		LockedUntil: now,
+		CreatedAt:   now,
+	}, nil
+}
+
+func parseOutboxMessage(outboxMsg *entity.OutboxMessage) (*message.HashCrackTaskStarted, error) {
+	msg := new(message.HashCrackTaskStarted)
+	if err := json.Unmarshal(outboxMsg.Body, msg); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal message: %w", err)
+	}
+
+	return msg, nil
+}
+
+// buildVersionErrorResult replaces the result of a worker of another version with the error result
+func buildVersionErrorResult(input *message.HashCrackTaskResult) *message.HashCrackTaskResult {
+	reason := fmt.Sprintf(
+		"%s: %d, expected %d", domain.ErrUnsupportedVersion, input.Version, message.Version,
+	)
 
-	return &message.HashCrackTaskStarted{
-		RequestID:  task.ObjectID.Hex(),
-		Hash:       task.Hash,
-		MaxLength:  task.MaxLength,
-		Alphabet:   message.Alphabet{Symbols: symbols},
-		PartNumber: i,
-		PartCount:  task.PartCount,
+	return &message.HashCrackTaskResult{
+		Version:    message.Version,
+		RequestID:  input.RequestID,
+		PartNumber: input.PartNumber,
+		Status:     string(entity.HashCrackSubtaskStatusError),
+		Error:      &reason,
+		Attempt:    input.Attempt,
 	}
 }
 
+func buildSaltMessage(salt *entity.HashCrackSalt) *message.Salt {
+	if salt == nil {
+		return nil
+	}
+
+	return &message.Salt{
+		Value:    salt.Value,
+		Position: salt.Position.String(),
+	}
+}
+
+// taskMode returns the attack mode of the task, tasks created before attack modes are brute force ones
+func taskMode(mode string) string {
+	if mode == "" {
+		return message.AttackModeBruteForce
+	}
+
+	return mode
+}
+
+// taskAlphabet returns the alphabet of the task, tasks created before per-task alphabets use the one from the config
+func taskAlphabet(alphabet, defaultAlphabet string) string {
+	if alphabet == "" {
+		return defaultAlphabet
+	}
+
+	return alphabet
+}
+
+// taskAlgorithm returns the algorithm of the task, tasks created before algorithm selection are MD5
+func taskAlgorithm(algorithm string) string {
+	if algorithm == "" {
+		return message.HashAlgorithmMD5
+	}
+
+	return algorithm
+}
+
+// partialUpdateSubtaskEntity applies the progress of the worker to the subtask. The words found by the previous
+// attempts are kept, because a resumed subtask does not check their candidates again
 func partialUpdateSubtaskEntity(subtask *entity.HashCrackSubtask, input *message.HashCrackTaskResult) {
 	subtask.Status = entity.ParseHashCrackSubtaskStatus(input.Status)
 	subtask.Reason = input.Error
+	subtask.Checkpoint = max(subtask.Checkpoint, input.Checkpoint)
+	subtask.UpdatedAt = time.Now()
+
+	if input.Speed > 0 {
+		subtask.Speed = input.Speed
+	}
 
 	if input.Answer != nil {
-		subtask.Data = input.Answer.Words
+		subtask.Data = lo.Union(subtask.Data, input.Answer.Words)
+		subtask.Found = lo.Union(
+			subtask.Found, lo.Map(
+				input.Answer.Found, func(found message.FoundHash, _ int) entity.HashCrackFoundHash {
+					return entity.HashCrackFoundHash{Hash: found.Hash, Word: found.Word}
+				},
+			),
+		)
 		subtask.Percent = input.Answer.Percent
 	}
 }
@@ -42,7 +42,8 @@ func (s *svc) Health(ctx context.Context) error {
 		return fmt.Errorf("failed to check mongo client: %w", err)
 	}
 
-	if s.amqpConn.IsReconnect() {
+	// The connection is absent for the http transport
+	if s.amqpConn != nil && s.amqpConn.IsReconnect() {
 		s.logger.Error().Err(errAMQPReconnect).Msg("failed to check amqp connection")
 		return fmt.Errorf("failed to check amqp connection: %w", errAMQPReconnect)
 	}
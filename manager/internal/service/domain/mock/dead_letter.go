@@ -0,0 +1,300 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mock
+
+import (
+	context "context"
+
+	deadletter "github.com/ptrvsrg/crack-hash/commonlib/bus/amqp/deadletter"
+
+	mock "github.com/stretchr/testify/mock"
+
+	model "github.com/ptrvsrg/crack-hash/manager/pkg/model"
+)
+
+// DeadLetterMock is an autogenerated mock type for the DeadLetter type
+type DeadLetterMock struct {
+	mock.Mock
+}
+
+type DeadLetterMock_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *DeadLetterMock) EXPECT() *DeadLetterMock_Expecter {
+	return &DeadLetterMock_Expecter{mock: &_m.Mock}
+}
+
+// DeleteDeadLetter provides a mock function with given fields: ctx, id
+func (_m *DeadLetterMock) DeleteDeadLetter(ctx context.Context, id string) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteDeadLetter")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeadLetterMock_DeleteDeadLetter_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteDeadLetter'
+type DeadLetterMock_DeleteDeadLetter_Call struct {
+	*mock.Call
+}
+
+// DeleteDeadLetter is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *DeadLetterMock_Expecter) DeleteDeadLetter(ctx interface{}, id interface{}) *DeadLetterMock_DeleteDeadLetter_Call {
+	return &DeadLetterMock_DeleteDeadLetter_Call{Call: _e.mock.On("DeleteDeadLetter", ctx, id)}
+}
+
+func (_c *DeadLetterMock_DeleteDeadLetter_Call) Run(run func(ctx context.Context, id string)) *DeadLetterMock_DeleteDeadLetter_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *DeadLetterMock_DeleteDeadLetter_Call) Return(_a0 error) *DeadLetterMock_DeleteDeadLetter_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *DeadLetterMock_DeleteDeadLetter_Call) RunAndReturn(run func(context.Context, string) error) *DeadLetterMock_DeleteDeadLetter_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetDeadLetter provides a mock function with given fields: ctx, id
+func (_m *DeadLetterMock) GetDeadLetter(ctx context.Context, id string) (*model.DeadLetterOutput, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDeadLetter")
+	}
+
+	var r0 *model.DeadLetterOutput
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*model.DeadLetterOutput, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *model.DeadLetterOutput); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.DeadLetterOutput)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeadLetterMock_GetDeadLetter_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetDeadLetter'
+type DeadLetterMock_GetDeadLetter_Call struct {
+	*mock.Call
+}
+
+// GetDeadLetter is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *DeadLetterMock_Expecter) GetDeadLetter(ctx interface{}, id interface{}) *DeadLetterMock_GetDeadLetter_Call {
+	return &DeadLetterMock_GetDeadLetter_Call{Call: _e.mock.On("GetDeadLetter", ctx, id)}
+}
+
+func (_c *DeadLetterMock_GetDeadLetter_Call) Run(run func(ctx context.Context, id string)) *DeadLetterMock_GetDeadLetter_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *DeadLetterMock_GetDeadLetter_Call) Return(_a0 *model.DeadLetterOutput, _a1 error) *DeadLetterMock_GetDeadLetter_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *DeadLetterMock_GetDeadLetter_Call) RunAndReturn(run func(context.Context, string) (*model.DeadLetterOutput, error)) *DeadLetterMock_GetDeadLetter_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetDeadLetters provides a mock function with given fields: ctx, limit, offset
+func (_m *DeadLetterMock) GetDeadLetters(ctx context.Context, limit int, offset int) (*model.DeadLettersOutput, error) {
+	ret := _m.Called(ctx, limit, offset)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDeadLetters")
+	}
+
+	var r0 *model.DeadLettersOutput
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) (*model.DeadLettersOutput, error)); ok {
+		return rf(ctx, limit, offset)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) *model.DeadLettersOutput); ok {
+		r0 = rf(ctx, limit, offset)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.DeadLettersOutput)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, int) error); ok {
+		r1 = rf(ctx, limit, offset)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeadLetterMock_GetDeadLetters_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetDeadLetters'
+type DeadLetterMock_GetDeadLetters_Call struct {
+	*mock.Call
+}
+
+// GetDeadLetters is a helper method to define mock.On call
+//   - ctx context.Context
+//   - limit int
+//   - offset int
+func (_e *DeadLetterMock_Expecter) GetDeadLetters(ctx interface{}, limit interface{}, offset interface{}) *DeadLetterMock_GetDeadLetters_Call {
+	return &DeadLetterMock_GetDeadLetters_Call{Call: _e.mock.On("GetDeadLetters", ctx, limit, offset)}
+}
+
+func (_c *DeadLetterMock_GetDeadLetters_Call) Run(run func(ctx context.Context, limit int, offset int)) *DeadLetterMock_GetDeadLetters_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int), args[2].(int))
+	})
+	return _c
+}
+
+func (_c *DeadLetterMock_GetDeadLetters_Call) Return(_a0 *model.DeadLettersOutput, _a1 error) *DeadLetterMock_GetDeadLetters_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *DeadLetterMock_GetDeadLetters_Call) RunAndReturn(run func(context.Context, int, int) (*model.DeadLettersOutput, error)) *DeadLetterMock_GetDeadLetters_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ReplayDeadLetter provides a mock function with given fields: ctx, id
+func (_m *DeadLetterMock) ReplayDeadLetter(ctx context.Context, id string) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReplayDeadLetter")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeadLetterMock_ReplayDeadLetter_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReplayDeadLetter'
+type DeadLetterMock_ReplayDeadLetter_Call struct {
+	*mock.Call
+}
+
+// ReplayDeadLetter is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *DeadLetterMock_Expecter) ReplayDeadLetter(ctx interface{}, id interface{}) *DeadLetterMock_ReplayDeadLetter_Call {
+	return &DeadLetterMock_ReplayDeadLetter_Call{Call: _e.mock.On("ReplayDeadLetter", ctx, id)}
+}
+
+func (_c *DeadLetterMock_ReplayDeadLetter_Call) Run(run func(ctx context.Context, id string)) *DeadLetterMock_ReplayDeadLetter_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *DeadLetterMock_ReplayDeadLetter_Call) Return(_a0 error) *DeadLetterMock_ReplayDeadLetter_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *DeadLetterMock_ReplayDeadLetter_Call) RunAndReturn(run func(context.Context, string) error) *DeadLetterMock_ReplayDeadLetter_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SaveDeadLetter provides a mock function with given fields: ctx, input
+func (_m *DeadLetterMock) SaveDeadLetter(ctx context.Context, input *deadletter.Message) error {
+	ret := _m.Called(ctx, input)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveDeadLetter")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *deadletter.Message) error); ok {
+		r0 = rf(ctx, input)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeadLetterMock_SaveDeadLetter_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SaveDeadLetter'
+type DeadLetterMock_SaveDeadLetter_Call struct {
+	*mock.Call
+}
+
+// SaveDeadLetter is a helper method to define mock.On call
+//   - ctx context.Context
+//   - input *deadletter.Message
+func (_e *DeadLetterMock_Expecter) SaveDeadLetter(ctx interface{}, input interface{}) *DeadLetterMock_SaveDeadLetter_Call {
+	return &DeadLetterMock_SaveDeadLetter_Call{Call: _e.mock.On("SaveDeadLetter", ctx, input)}
+}
+
+func (_c *DeadLetterMock_SaveDeadLetter_Call) Run(run func(ctx context.Context, input *deadletter.Message)) *DeadLetterMock_SaveDeadLetter_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*deadletter.Message))
+	})
+	return _c
+}
+
+func (_c *DeadLetterMock_SaveDeadLetter_Call) Return(_a0 error) *DeadLetterMock_SaveDeadLetter_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *DeadLetterMock_SaveDeadLetter_Call) RunAndReturn(run func(context.Context, *deadletter.Message) error) *DeadLetterMock_SaveDeadLetter_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewDeadLetterMock creates a new instance of DeadLetterMock. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewDeadLetterMock(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *DeadLetterMock {
+	mock := &DeadLetterMock{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
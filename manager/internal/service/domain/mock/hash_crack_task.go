@@ -25,6 +25,112 @@ func (_m *HashCrackTaskMock) EXPECT() *HashCrackTaskMock_Expecter {
 	return &HashCrackTaskMock_Expecter{mock: &_m.Mock}
 }
 
+// CancelTask provides a mock function with given fields: ctx, id
+func (_m *HashCrackTaskMock) CancelTask(ctx context.Context, id string) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CancelTask")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// HashCrackTaskMock_CancelTask_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CancelTask'
+type HashCrackTaskMock_CancelTask_Call struct {
+	*mock.Call
+}
+
+// CancelTask is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *HashCrackTaskMock_Expecter) CancelTask(ctx interface{}, id interface{}) *HashCrackTaskMock_CancelTask_Call {
+	return &HashCrackTaskMock_CancelTask_Call{Call: _e.mock.On("CancelTask", ctx, id)}
+}
+
+func (_c *HashCrackTaskMock_CancelTask_Call) Run(run func(ctx context.Context, id string)) *HashCrackTaskMock_CancelTask_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *HashCrackTaskMock_CancelTask_Call) Return(_a0 error) *HashCrackTaskMock_CancelTask_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *HashCrackTaskMock_CancelTask_Call) RunAndReturn(run func(context.Context, string) error) *HashCrackTaskMock_CancelTask_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateBatchTask provides a mock function with given fields: ctx, input
+func (_m *HashCrackTaskMock) CreateBatchTask(ctx context.Context, input *model.HashCrackBatchTaskInput) (*model.HashCrackTaskIDOutput, error) {
+	ret := _m.Called(ctx, input)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateBatchTask")
+	}
+
+	var r0 *model.HashCrackTaskIDOutput
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.HashCrackBatchTaskInput) (*model.HashCrackTaskIDOutput, error)); ok {
+		return rf(ctx, input)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *model.HashCrackBatchTaskInput) *model.HashCrackTaskIDOutput); ok {
+		r0 = rf(ctx, input)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.HashCrackTaskIDOutput)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *model.HashCrackBatchTaskInput) error); ok {
+		r1 = rf(ctx, input)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// HashCrackTaskMock_CreateBatchTask_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateBatchTask'
+type HashCrackTaskMock_CreateBatchTask_Call struct {
+	*mock.Call
+}
+
+// CreateBatchTask is a helper method to define mock.On call
+//   - ctx context.Context
+//   - input *model.HashCrackBatchTaskInput
+func (_e *HashCrackTaskMock_Expecter) CreateBatchTask(ctx interface{}, input interface{}) *HashCrackTaskMock_CreateBatchTask_Call {
+	return &HashCrackTaskMock_CreateBatchTask_Call{Call: _e.mock.On("CreateBatchTask", ctx, input)}
+}
+
+func (_c *HashCrackTaskMock_CreateBatchTask_Call) Run(run func(ctx context.Context, input *model.HashCrackBatchTaskInput)) *HashCrackTaskMock_CreateBatchTask_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*model.HashCrackBatchTaskInput))
+	})
+	return _c
+}
+
+func (_c *HashCrackTaskMock_CreateBatchTask_Call) Return(_a0 *model.HashCrackTaskIDOutput, _a1 error) *HashCrackTaskMock_CreateBatchTask_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *HashCrackTaskMock_CreateBatchTask_Call) RunAndReturn(run func(context.Context, *model.HashCrackBatchTaskInput) (*model.HashCrackTaskIDOutput, error)) *HashCrackTaskMock_CreateBatchTask_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // CreateTask provides a mock function with given fields: ctx, input
 func (_m *HashCrackTaskMock) CreateTask(ctx context.Context, input *model.HashCrackTaskInput) (*model.HashCrackTaskIDOutput, error) {
 	ret := _m.Called(ctx, input)
@@ -176,6 +282,66 @@ func (_c *HashCrackTaskMock_ExecutePendingSubtasks_Call) RunAndReturn(run func(c
 	return _c
 }
 
+// ExtendTask provides a mock function with given fields: ctx, id, input
+func (_m *HashCrackTaskMock) ExtendTask(ctx context.Context, id string, input *model.HashCrackTaskExtendInput) (*model.HashCrackTaskDeadlineOutput, error) {
+	ret := _m.Called(ctx, id, input)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ExtendTask")
+	}
+
+	var r0 *model.HashCrackTaskDeadlineOutput
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *model.HashCrackTaskExtendInput) (*model.HashCrackTaskDeadlineOutput, error)); ok {
+		return rf(ctx, id, input)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, *model.HashCrackTaskExtendInput) *model.HashCrackTaskDeadlineOutput); ok {
+		r0 = rf(ctx, id, input)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.HashCrackTaskDeadlineOutput)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, *model.HashCrackTaskExtendInput) error); ok {
+		r1 = rf(ctx, id, input)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// HashCrackTaskMock_ExtendTask_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ExtendTask'
+type HashCrackTaskMock_ExtendTask_Call struct {
+	*mock.Call
+}
+
+// ExtendTask is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - input *model.HashCrackTaskExtendInput
+func (_e *HashCrackTaskMock_Expecter) ExtendTask(ctx interface{}, id interface{}, input interface{}) *HashCrackTaskMock_ExtendTask_Call {
+	return &HashCrackTaskMock_ExtendTask_Call{Call: _e.mock.On("ExtendTask", ctx, id, input)}
+}
+
+func (_c *HashCrackTaskMock_ExtendTask_Call) Run(run func(ctx context.Context, id string, input *model.HashCrackTaskExtendInput)) *HashCrackTaskMock_ExtendTask_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(*model.HashCrackTaskExtendInput))
+	})
+	return _c
+}
+
+func (_c *HashCrackTaskMock_ExtendTask_Call) Return(_a0 *model.HashCrackTaskDeadlineOutput, _a1 error) *HashCrackTaskMock_ExtendTask_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *HashCrackTaskMock_ExtendTask_Call) RunAndReturn(run func(context.Context, string, *model.HashCrackTaskExtendInput) (*model.HashCrackTaskDeadlineOutput, error)) *HashCrackTaskMock_ExtendTask_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // FinishTimeoutTasks provides a mock function with given fields: ctx
 func (_m *HashCrackTaskMock) FinishTimeoutTasks(ctx context.Context) error {
 	ret := _m.Called(ctx)
@@ -222,6 +388,65 @@ func (_c *HashCrackTaskMock_FinishTimeoutTasks_Call) RunAndReturn(run func(conte
 	return _c
 }
 
+// GetBatchTaskStatus provides a mock function with given fields: ctx, id
+func (_m *HashCrackTaskMock) GetBatchTaskStatus(ctx context.Context, id string) (*model.HashCrackBatchTaskStatusOutput, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBatchTaskStatus")
+	}
+
+	var r0 *model.HashCrackBatchTaskStatusOutput
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*model.HashCrackBatchTaskStatusOutput, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *model.HashCrackBatchTaskStatusOutput); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.HashCrackBatchTaskStatusOutput)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// HashCrackTaskMock_GetBatchTaskStatus_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBatchTaskStatus'
+type HashCrackTaskMock_GetBatchTaskStatus_Call struct {
+	*mock.Call
+}
+
+// GetBatchTaskStatus is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *HashCrackTaskMock_Expecter) GetBatchTaskStatus(ctx interface{}, id interface{}) *HashCrackTaskMock_GetBatchTaskStatus_Call {
+	return &HashCrackTaskMock_GetBatchTaskStatus_Call{Call: _e.mock.On("GetBatchTaskStatus", ctx, id)}
+}
+
+func (_c *HashCrackTaskMock_GetBatchTaskStatus_Call) Run(run func(ctx context.Context, id string)) *HashCrackTaskMock_GetBatchTaskStatus_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *HashCrackTaskMock_GetBatchTaskStatus_Call) Return(_a0 *model.HashCrackBatchTaskStatusOutput, _a1 error) *HashCrackTaskMock_GetBatchTaskStatus_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *HashCrackTaskMock_GetBatchTaskStatus_Call) RunAndReturn(run func(context.Context, string) (*model.HashCrackBatchTaskStatusOutput, error)) *HashCrackTaskMock_GetBatchTaskStatus_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetTaskMetadatas provides a mock function with given fields: ctx, limit, offset
 func (_m *HashCrackTaskMock) GetTaskMetadatas(ctx context.Context, limit int, offset int) (*model.HashCrackTaskMetadatasOutput, error) {
 	ret := _m.Called(ctx, limit, offset)
@@ -341,6 +566,98 @@ func (_c *HashCrackTaskMock_GetTaskStatus_Call) RunAndReturn(run func(context.Co
 	return _c
 }
 
+// RedeliverExpiredSubtasks provides a mock function with given fields: ctx
+func (_m *HashCrackTaskMock) RedeliverExpiredSubtasks(ctx context.Context) error {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RedeliverExpiredSubtasks")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// HashCrackTaskMock_RedeliverExpiredSubtasks_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RedeliverExpiredSubtasks'
+type HashCrackTaskMock_RedeliverExpiredSubtasks_Call struct {
+	*mock.Call
+}
+
+// RedeliverExpiredSubtasks is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *HashCrackTaskMock_Expecter) RedeliverExpiredSubtasks(ctx interface{}) *HashCrackTaskMock_RedeliverExpiredSubtasks_Call {
+	return &HashCrackTaskMock_RedeliverExpiredSubtasks_Call{Call: _e.mock.On("RedeliverExpiredSubtasks", ctx)}
+}
+
+func (_c *HashCrackTaskMock_RedeliverExpiredSubtasks_Call) Run(run func(ctx context.Context)) *HashCrackTaskMock_RedeliverExpiredSubtasks_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *HashCrackTaskMock_RedeliverExpiredSubtasks_Call) Return(_a0 error) *HashCrackTaskMock_RedeliverExpiredSubtasks_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *HashCrackTaskMock_RedeliverExpiredSubtasks_Call) RunAndReturn(run func(context.Context) error) *HashCrackTaskMock_RedeliverExpiredSubtasks_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RelayOutboxMessages provides a mock function with given fields: ctx
+func (_m *HashCrackTaskMock) RelayOutboxMessages(ctx context.Context) error {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RelayOutboxMessages")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// HashCrackTaskMock_RelayOutboxMessages_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RelayOutboxMessages'
+type HashCrackTaskMock_RelayOutboxMessages_Call struct {
+	*mock.Call
+}
+
+// RelayOutboxMessages is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *HashCrackTaskMock_Expecter) RelayOutboxMessages(ctx interface{}) *HashCrackTaskMock_RelayOutboxMessages_Call {
+	return &HashCrackTaskMock_RelayOutboxMessages_Call{Call: _e.mock.On("RelayOutboxMessages", ctx)}
+}
+
+func (_c *HashCrackTaskMock_RelayOutboxMessages_Call) Run(run func(ctx context.Context)) *HashCrackTaskMock_RelayOutboxMessages_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *HashCrackTaskMock_RelayOutboxMessages_Call) Return(_a0 error) *HashCrackTaskMock_RelayOutboxMessages_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *HashCrackTaskMock_RelayOutboxMessages_Call) RunAndReturn(run func(context.Context) error) *HashCrackTaskMock_RelayOutboxMessages_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // SaveResultSubtask provides a mock function with given fields: ctx, input
 func (_m *HashCrackTaskMock) SaveResultSubtask(ctx context.Context, input *message.HashCrackTaskResult) error {
 	ret := _m.Called(ctx, input)
@@ -388,6 +705,52 @@ func (_c *HashCrackTaskMock_SaveResultSubtask_Call) RunAndReturn(run func(contex
 	return _c
 }
 
+// SplitSlowSubtasks provides a mock function with given fields: ctx
+func (_m *HashCrackTaskMock) SplitSlowSubtasks(ctx context.Context) error {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SplitSlowSubtasks")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// HashCrackTaskMock_SplitSlowSubtasks_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SplitSlowSubtasks'
+type HashCrackTaskMock_SplitSlowSubtasks_Call struct {
+	*mock.Call
+}
+
+// SplitSlowSubtasks is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *HashCrackTaskMock_Expecter) SplitSlowSubtasks(ctx interface{}) *HashCrackTaskMock_SplitSlowSubtasks_Call {
+	return &HashCrackTaskMock_SplitSlowSubtasks_Call{Call: _e.mock.On("SplitSlowSubtasks", ctx)}
+}
+
+func (_c *HashCrackTaskMock_SplitSlowSubtasks_Call) Run(run func(ctx context.Context)) *HashCrackTaskMock_SplitSlowSubtasks_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *HashCrackTaskMock_SplitSlowSubtasks_Call) Return(_a0 error) *HashCrackTaskMock_SplitSlowSubtasks_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *HashCrackTaskMock_SplitSlowSubtasks_Call) RunAndReturn(run func(context.Context) error) *HashCrackTaskMock_SplitSlowSubtasks_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewHashCrackTaskMock creates a new instance of HashCrackTaskMock. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewHashCrackTaskMock(t interface {
@@ -0,0 +1,263 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mock
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	model "github.com/ptrvsrg/crack-hash/manager/pkg/model"
+)
+
+// RuleSetMock is an autogenerated mock type for the RuleSet type
+type RuleSetMock struct {
+	mock.Mock
+}
+
+type RuleSetMock_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *RuleSetMock) EXPECT() *RuleSetMock_Expecter {
+	return &RuleSetMock_Expecter{mock: &_m.Mock}
+}
+
+// CreateRuleSet provides a mock function with given fields: ctx, input
+func (_m *RuleSetMock) CreateRuleSet(ctx context.Context, input *model.RuleSetInput) (*model.RuleSetOutput, error) {
+	ret := _m.Called(ctx, input)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateRuleSet")
+	}
+
+	var r0 *model.RuleSetOutput
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.RuleSetInput) (*model.RuleSetOutput, error)); ok {
+		return rf(ctx, input)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *model.RuleSetInput) *model.RuleSetOutput); ok {
+		r0 = rf(ctx, input)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.RuleSetOutput)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *model.RuleSetInput) error); ok {
+		r1 = rf(ctx, input)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RuleSetMock_CreateRuleSet_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateRuleSet'
+type RuleSetMock_CreateRuleSet_Call struct {
+	*mock.Call
+}
+
+// CreateRuleSet is a helper method to define mock.On call
+//   - ctx context.Context
+//   - input *model.RuleSetInput
+func (_e *RuleSetMock_Expecter) CreateRuleSet(ctx interface{}, input interface{}) *RuleSetMock_CreateRuleSet_Call {
+	return &RuleSetMock_CreateRuleSet_Call{Call: _e.mock.On("CreateRuleSet", ctx, input)}
+}
+
+func (_c *RuleSetMock_CreateRuleSet_Call) Run(run func(ctx context.Context, input *model.RuleSetInput)) *RuleSetMock_CreateRuleSet_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*model.RuleSetInput))
+	})
+	return _c
+}
+
+func (_c *RuleSetMock_CreateRuleSet_Call) Return(_a0 *model.RuleSetOutput, _a1 error) *RuleSetMock_CreateRuleSet_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *RuleSetMock_CreateRuleSet_Call) RunAndReturn(run func(context.Context, *model.RuleSetInput) (*model.RuleSetOutput, error)) *RuleSetMock_CreateRuleSet_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteRuleSet provides a mock function with given fields: ctx, id
+func (_m *RuleSetMock) DeleteRuleSet(ctx context.Context, id string) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteRuleSet")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RuleSetMock_DeleteRuleSet_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteRuleSet'
+type RuleSetMock_DeleteRuleSet_Call struct {
+	*mock.Call
+}
+
+// DeleteRuleSet is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *RuleSetMock_Expecter) DeleteRuleSet(ctx interface{}, id interface{}) *RuleSetMock_DeleteRuleSet_Call {
+	return &RuleSetMock_DeleteRuleSet_Call{Call: _e.mock.On("DeleteRuleSet", ctx, id)}
+}
+
+func (_c *RuleSetMock_DeleteRuleSet_Call) Run(run func(ctx context.Context, id string)) *RuleSetMock_DeleteRuleSet_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *RuleSetMock_DeleteRuleSet_Call) Return(_a0 error) *RuleSetMock_DeleteRuleSet_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *RuleSetMock_DeleteRuleSet_Call) RunAndReturn(run func(context.Context, string) error) *RuleSetMock_DeleteRuleSet_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetRuleSet provides a mock function with given fields: ctx, id
+func (_m *RuleSetMock) GetRuleSet(ctx context.Context, id string) (*model.RuleSetOutput, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRuleSet")
+	}
+
+	var r0 *model.RuleSetOutput
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*model.RuleSetOutput, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *model.RuleSetOutput); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.RuleSetOutput)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RuleSetMock_GetRuleSet_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetRuleSet'
+type RuleSetMock_GetRuleSet_Call struct {
+	*mock.Call
+}
+
+// GetRuleSet is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *RuleSetMock_Expecter) GetRuleSet(ctx interface{}, id interface{}) *RuleSetMock_GetRuleSet_Call {
+	return &RuleSetMock_GetRuleSet_Call{Call: _e.mock.On("GetRuleSet", ctx, id)}
+}
+
+func (_c *RuleSetMock_GetRuleSet_Call) Run(run func(ctx context.Context, id string)) *RuleSetMock_GetRuleSet_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *RuleSetMock_GetRuleSet_Call) Return(_a0 *model.RuleSetOutput, _a1 error) *RuleSetMock_GetRuleSet_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *RuleSetMock_GetRuleSet_Call) RunAndReturn(run func(context.Context, string) (*model.RuleSetOutput, error)) *RuleSetMock_GetRuleSet_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetRuleSets provides a mock function with given fields: ctx, limit, offset
+func (_m *RuleSetMock) GetRuleSets(ctx context.Context, limit int, offset int) (*model.RuleSetsOutput, error) {
+	ret := _m.Called(ctx, limit, offset)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRuleSets")
+	}
+
+	var r0 *model.RuleSetsOutput
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) (*model.RuleSetsOutput, error)); ok {
+		return rf(ctx, limit, offset)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) *model.RuleSetsOutput); ok {
+		r0 = rf(ctx, limit, offset)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.RuleSetsOutput)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, int) error); ok {
+		r1 = rf(ctx, limit, offset)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RuleSetMock_GetRuleSets_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetRuleSets'
+type RuleSetMock_GetRuleSets_Call struct {
+	*mock.Call
+}
+
+// GetRuleSets is a helper method to define mock.On call
+//   - ctx context.Context
+//   - limit int
+//   - offset int
+func (_e *RuleSetMock_Expecter) GetRuleSets(ctx interface{}, limit interface{}, offset interface{}) *RuleSetMock_GetRuleSets_Call {
+	return &RuleSetMock_GetRuleSets_Call{Call: _e.mock.On("GetRuleSets", ctx, limit, offset)}
+}
+
+func (_c *RuleSetMock_GetRuleSets_Call) Run(run func(ctx context.Context, limit int, offset int)) *RuleSetMock_GetRuleSets_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int), args[2].(int))
+	})
+	return _c
+}
+
+func (_c *RuleSetMock_GetRuleSets_Call) Return(_a0 *model.RuleSetsOutput, _a1 error) *RuleSetMock_GetRuleSets_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *RuleSetMock_GetRuleSets_Call) RunAndReturn(run func(context.Context, int, int) (*model.RuleSetsOutput, error)) *RuleSetMock_GetRuleSets_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewRuleSetMock creates a new instance of RuleSetMock. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewRuleSetMock(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *RuleSetMock {
+	mock := &RuleSetMock{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,327 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mock
+
+import (
+	context "context"
+
+	io "io"
+
+	mock "github.com/stretchr/testify/mock"
+
+	model "github.com/ptrvsrg/crack-hash/manager/pkg/model"
+)
+
+// WordlistMock is an autogenerated mock type for the Wordlist type
+type WordlistMock struct {
+	mock.Mock
+}
+
+type WordlistMock_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *WordlistMock) EXPECT() *WordlistMock_Expecter {
+	return &WordlistMock_Expecter{mock: &_m.Mock}
+}
+
+// DeleteWordlist provides a mock function with given fields: ctx, id
+func (_m *WordlistMock) DeleteWordlist(ctx context.Context, id string) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteWordlist")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// WordlistMock_DeleteWordlist_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteWordlist'
+type WordlistMock_DeleteWordlist_Call struct {
+	*mock.Call
+}
+
+// DeleteWordlist is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *WordlistMock_Expecter) DeleteWordlist(ctx interface{}, id interface{}) *WordlistMock_DeleteWordlist_Call {
+	return &WordlistMock_DeleteWordlist_Call{Call: _e.mock.On("DeleteWordlist", ctx, id)}
+}
+
+func (_c *WordlistMock_DeleteWordlist_Call) Run(run func(ctx context.Context, id string)) *WordlistMock_DeleteWordlist_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *WordlistMock_DeleteWordlist_Call) Return(_a0 error) *WordlistMock_DeleteWordlist_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *WordlistMock_DeleteWordlist_Call) RunAndReturn(run func(context.Context, string) error) *WordlistMock_DeleteWordlist_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetWordlist provides a mock function with given fields: ctx, id
+func (_m *WordlistMock) GetWordlist(ctx context.Context, id string) (*model.WordlistOutput, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetWordlist")
+	}
+
+	var r0 *model.WordlistOutput
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*model.WordlistOutput, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *model.WordlistOutput); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.WordlistOutput)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// WordlistMock_GetWordlist_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWordlist'
+type WordlistMock_GetWordlist_Call struct {
+	*mock.Call
+}
+
+// GetWordlist is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *WordlistMock_Expecter) GetWordlist(ctx interface{}, id interface{}) *WordlistMock_GetWordlist_Call {
+	return &WordlistMock_GetWordlist_Call{Call: _e.mock.On("GetWordlist", ctx, id)}
+}
+
+func (_c *WordlistMock_GetWordlist_Call) Run(run func(ctx context.Context, id string)) *WordlistMock_GetWordlist_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *WordlistMock_GetWordlist_Call) Return(_a0 *model.WordlistOutput, _a1 error) *WordlistMock_GetWordlist_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *WordlistMock_GetWordlist_Call) RunAndReturn(run func(context.Context, string) (*model.WordlistOutput, error)) *WordlistMock_GetWordlist_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetWordlists provides a mock function with given fields: ctx, limit, offset
+func (_m *WordlistMock) GetWordlists(ctx context.Context, limit int, offset int) (*model.WordlistsOutput, error) {
+	ret := _m.Called(ctx, limit, offset)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetWordlists")
+	}
+
+	var r0 *model.WordlistsOutput
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) (*model.WordlistsOutput, error)); ok {
+		return rf(ctx, limit, offset)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) *model.WordlistsOutput); ok {
+		r0 = rf(ctx, limit, offset)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.WordlistsOutput)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, int) error); ok {
+		r1 = rf(ctx, limit, offset)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// WordlistMock_GetWordlists_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWordlists'
+type WordlistMock_GetWordlists_Call struct {
+	*mock.Call
+}
+
+// GetWordlists is a helper method to define mock.On call
+//   - ctx context.Context
+//   - limit int
+//   - offset int
+func (_e *WordlistMock_Expecter) GetWordlists(ctx interface{}, limit interface{}, offset interface{}) *WordlistMock_GetWordlists_Call {
+	return &WordlistMock_GetWordlists_Call{Call: _e.mock.On("GetWordlists", ctx, limit, offset)}
+}
+
+func (_c *WordlistMock_GetWordlists_Call) Run(run func(ctx context.Context, limit int, offset int)) *WordlistMock_GetWordlists_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int), args[2].(int))
+	})
+	return _c
+}
+
+func (_c *WordlistMock_GetWordlists_Call) Return(_a0 *model.WordlistsOutput, _a1 error) *WordlistMock_GetWordlists_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *WordlistMock_GetWordlists_Call) RunAndReturn(run func(context.Context, int, int) (*model.WordlistsOutput, error)) *WordlistMock_GetWordlists_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// OpenWordlistContent provides a mock function with given fields: ctx, id, offset, limit
+func (_m *WordlistMock) OpenWordlistContent(ctx context.Context, id string, offset int, limit int) (io.ReadCloser, error) {
+	ret := _m.Called(ctx, id, offset, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for OpenWordlistContent")
+	}
+
+	var r0 io.ReadCloser
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int, int) (io.ReadCloser, error)); ok {
+		return rf(ctx, id, offset, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, int, int) io.ReadCloser); ok {
+		r0 = rf(ctx, id, offset, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(io.ReadCloser)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, int, int) error); ok {
+		r1 = rf(ctx, id, offset, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// WordlistMock_OpenWordlistContent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'OpenWordlistContent'
+type WordlistMock_OpenWordlistContent_Call struct {
+	*mock.Call
+}
+
+// OpenWordlistContent is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - offset int
+//   - limit int
+func (_e *WordlistMock_Expecter) OpenWordlistContent(ctx interface{}, id interface{}, offset interface{}, limit interface{}) *WordlistMock_OpenWordlistContent_Call {
+	return &WordlistMock_OpenWordlistContent_Call{Call: _e.mock.On("OpenWordlistContent", ctx, id, offset, limit)}
+}
+
+func (_c *WordlistMock_OpenWordlistContent_Call) Run(run func(ctx context.Context, id string, offset int, limit int)) *WordlistMock_OpenWordlistContent_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(int), args[3].(int))
+	})
+	return _c
+}
+
+func (_c *WordlistMock_OpenWordlistContent_Call) Return(_a0 io.ReadCloser, _a1 error) *WordlistMock_OpenWordlistContent_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *WordlistMock_OpenWordlistContent_Call) RunAndReturn(run func(context.Context, string, int, int) (io.ReadCloser, error)) *WordlistMock_OpenWordlistContent_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UploadWordlist provides a mock function with given fields: ctx, name, content
+func (_m *WordlistMock) UploadWordlist(ctx context.Context, name string, content io.Reader) (*model.WordlistOutput, error) {
+	ret := _m.Called(ctx, name, content)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UploadWordlist")
+	}
+
+	var r0 *model.WordlistOutput
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, io.Reader) (*model.WordlistOutput, error)); ok {
+		return rf(ctx, name, content)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, io.Reader) *model.WordlistOutput); ok {
+		r0 = rf(ctx, name, content)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.WordlistOutput)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, io.Reader) error); ok {
+		r1 = rf(ctx, name, content)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// WordlistMock_UploadWordlist_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UploadWordlist'
+type WordlistMock_UploadWordlist_Call struct {
+	*mock.Call
+}
+
+// UploadWordlist is a helper method to define mock.On call
+//   - ctx context.Context
+//   - name string
+//   - content io.Reader
+func (_e *WordlistMock_Expecter) UploadWordlist(ctx interface{}, name interface{}, content interface{}) *WordlistMock_UploadWordlist_Call {
+	return &WordlistMock_UploadWordlist_Call{Call: _e.mock.On("UploadWordlist", ctx, name, content)}
+}
+
+func (_c *WordlistMock_UploadWordlist_Call) Run(run func(ctx context.Context, name string, content io.Reader)) *WordlistMock_UploadWordlist_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(io.Reader))
+	})
+	return _c
+}
+
+func (_c *WordlistMock_UploadWordlist_Call) Return(_a0 *model.WordlistOutput, _a1 error) *WordlistMock_UploadWordlist_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *WordlistMock_UploadWordlist_Call) RunAndReturn(run func(context.Context, string, io.Reader) (*model.WordlistOutput, error)) *WordlistMock_UploadWordlist_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewWordlistMock creates a new instance of WordlistMock. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewWordlistMock(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *WordlistMock {
+	mock := &WordlistMock{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
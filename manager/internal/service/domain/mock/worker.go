@@ -0,0 +1,147 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mock
+
+import (
+	context "context"
+
+	message "github.com/ptrvsrg/crack-hash/manager/pkg/message"
+
+	mock "github.com/stretchr/testify/mock"
+
+	model "github.com/ptrvsrg/crack-hash/manager/pkg/model"
+)
+
+// WorkerMock is an autogenerated mock type for the Worker type
+type WorkerMock struct {
+	mock.Mock
+}
+
+type WorkerMock_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *WorkerMock) EXPECT() *WorkerMock_Expecter {
+	return &WorkerMock_Expecter{mock: &_m.Mock}
+}
+
+// GetWorkers provides a mock function with given fields: ctx, limit, offset
+func (_m *WorkerMock) GetWorkers(ctx context.Context, limit int, offset int) (*model.WorkersOutput, error) {
+	ret := _m.Called(ctx, limit, offset)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetWorkers")
+	}
+
+	var r0 *model.WorkersOutput
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) (*model.WorkersOutput, error)); ok {
+		return rf(ctx, limit, offset)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) *model.WorkersOutput); ok {
+		r0 = rf(ctx, limit, offset)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.WorkersOutput)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, int) error); ok {
+		r1 = rf(ctx, limit, offset)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// WorkerMock_GetWorkers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWorkers'
+type WorkerMock_GetWorkers_Call struct {
+	*mock.Call
+}
+
+// GetWorkers is a helper method to define mock.On call
+//   - ctx context.Context
+//   - limit int
+//   - offset int
+func (_e *WorkerMock_Expecter) GetWorkers(ctx interface{}, limit interface{}, offset interface{}) *WorkerMock_GetWorkers_Call {
+	return &WorkerMock_GetWorkers_Call{Call: _e.mock.On("GetWorkers", ctx, limit, offset)}
+}
+
+func (_c *WorkerMock_GetWorkers_Call) Run(run func(ctx context.Context, limit int, offset int)) *WorkerMock_GetWorkers_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int), args[2].(int))
+	})
+	return _c
+}
+
+func (_c *WorkerMock_GetWorkers_Call) Return(_a0 *model.WorkersOutput, _a1 error) *WorkerMock_GetWorkers_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *WorkerMock_GetWorkers_Call) RunAndReturn(run func(context.Context, int, int) (*model.WorkersOutput, error)) *WorkerMock_GetWorkers_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SaveHeartbeat provides a mock function with given fields: ctx, input
+func (_m *WorkerMock) SaveHeartbeat(ctx context.Context, input *message.WorkerHeartbeat) error {
+	ret := _m.Called(ctx, input)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveHeartbeat")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *message.WorkerHeartbeat) error); ok {
+		r0 = rf(ctx, input)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// WorkerMock_SaveHeartbeat_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SaveHeartbeat'
+type WorkerMock_SaveHeartbeat_Call struct {
+	*mock.Call
+}
+
+// SaveHeartbeat is a helper method to define mock.On call
+//   - ctx context.Context
+//   - input *message.WorkerHeartbeat
+func (_e *WorkerMock_Expecter) SaveHeartbeat(ctx interface{}, input interface{}) *WorkerMock_SaveHeartbeat_Call {
+	return &WorkerMock_SaveHeartbeat_Call{Call: _e.mock.On("SaveHeartbeat", ctx, input)}
+}
+
+func (_c *WorkerMock_SaveHeartbeat_Call) Run(run func(ctx context.Context, input *message.WorkerHeartbeat)) *WorkerMock_SaveHeartbeat_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*message.WorkerHeartbeat))
+	})
+	return _c
+}
+
+func (_c *WorkerMock_SaveHeartbeat_Call) Return(_a0 error) *WorkerMock_SaveHeartbeat_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *WorkerMock_SaveHeartbeat_Call) RunAndReturn(run func(context.Context, *message.WorkerHeartbeat) error) *WorkerMock_SaveHeartbeat_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewWorkerMock creates a new instance of WorkerMock. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewWorkerMock(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *WorkerMock {
+	mock := &WorkerMock{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,148 @@
+package ruleset
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/rs/zerolog"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/ptrvsrg/crack-hash/manager/config"
+	"github.com/ptrvsrg/crack-hash/manager/internal/persistence/entity"
+	"github.com/ptrvsrg/crack-hash/manager/internal/persistence/repository"
+	"github.com/ptrvsrg/crack-hash/manager/internal/service/domain"
+	"github.com/ptrvsrg/crack-hash/manager/pkg/model"
+)
+
+type svc struct {
+	logger zerolog.Logger
+	cfg    config.RuleSetConfig
+	repo   repository.RuleSet
+}
+
+func NewService(logger zerolog.Logger, cfg config.RuleSetConfig, repo repository.RuleSet) domain.RuleSet {
+	return &svc{
+		logger: logger.With().
+			Str("type", "domain").
+			Str("service", "rule-set").
+			Logger(),
+		cfg:  cfg,
+		repo: repo,
+	}
+}
+
+func (s *svc) CreateRuleSet(ctx context.Context, input *model.RuleSetInput) (*model.RuleSetOutput, error) {
+	s.logger.Info().Str("name", input.Name).Int("rule_count", len(input.Rules)).Msg("create rule set")
+
+	// Normalize and validate input
+	normalizeRuleSetInput(input)
+
+	if err := validateRuleSetInput(input, s.cfg.MaxRules); err != nil {
+		s.logger.Error().Err(err).Msg("failed to validate input")
+		return nil, err
+	}
+
+	// Save rule set
+	ruleSet := buildRuleSetEntity(input)
+
+	if err := s.repo.Create(ctx, ruleSet); err != nil {
+		s.logger.Error().Err(err).Stack().Msg("failed to create rule set")
+
+		if errors.Is(err, repository.ErrRuleSetExists) {
+			return nil, domain.ErrRuleSetExists
+		}
+		return nil, fmt.Errorf("failed to create rule set: %w", err)
+	}
+
+	return buildRuleSetOutput(ruleSet), nil
+}
+
+func (s *svc) GetRuleSets(ctx context.Context, limit, offset int) (*model.RuleSetsOutput, error) {
+	s.logger.Info().Int("limit", limit).Int("offset", offset).Msg("get rule sets")
+
+	// Get rule sets and count
+	var (
+		ruleSets []*entity.RuleSet
+		count    int64
+	)
+	group, ctx := errgroup.WithContext(ctx)
+
+	group.Go(
+		func() error {
+			var err error
+			ruleSets, err = s.repo.GetAll(ctx, limit, offset)
+			if err != nil {
+				return fmt.Errorf("failed to get rule sets: %w", err)
+			}
+			return nil
+		},
+	)
+
+	group.Go(
+		func() error {
+			var err error
+			count, err = s.repo.CountAll(ctx)
+			if err != nil {
+				return fmt.Errorf("failed to count rule sets: %w", err)
+			}
+			return nil
+		},
+	)
+
+	if err := group.Wait(); err != nil {
+		s.logger.Error().Err(err).Stack().Msg("failed to get rule sets and count")
+		return nil, fmt.Errorf("failed to get rule sets and count: %w", err)
+	}
+
+	// Convert rule sets
+	return buildRuleSetOutputs(count, ruleSets), nil
+}
+
+func (s *svc) GetRuleSet(ctx context.Context, id string) (*model.RuleSetOutput, error) {
+	s.logger.Info().Str("id", id).Msg("get rule set")
+
+	// Validate ID
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		s.logger.Error().Err(err).Stack().Msg("failed to validate ID")
+		return nil, domain.ErrInvalidRuleSetID
+	}
+
+	// Get rule set
+	ruleSet, err := s.repo.Get(ctx, objID)
+	if err != nil {
+		s.logger.Error().Err(err).Stack().Msg("failed to get rule set")
+
+		if errors.Is(err, repository.ErrRuleSetNotFound) {
+			return nil, domain.ErrRuleSetNotFound
+		}
+		return nil, fmt.Errorf("failed to get rule set: %w", err)
+	}
+
+	return buildRuleSetOutput(ruleSet), nil
+}
+
+func (s *svc) DeleteRuleSet(ctx context.Context, id string) error {
+	s.logger.Info().Str("id", id).Msg("delete rule set")
+
+	// Validate ID
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		s.logger.Error().Err(err).Stack().Msg("failed to validate ID")
+		return domain.ErrInvalidRuleSetID
+	}
+
+	// Delete rule set
+	if err := s.repo.Delete(ctx, objID); err != nil {
+		s.logger.Error().Err(err).Stack().Msg("failed to delete rule set")
+
+		if errors.Is(err, repository.ErrRuleSetNotFound) {
+			return domain.ErrRuleSetNotFound
+		}
+		return fmt.Errorf("failed to delete rule set: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,192 @@
+package ruleset_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/rs/zerolog/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/ptrvsrg/crack-hash/commonlib/logging"
+	"github.com/ptrvsrg/crack-hash/manager/config"
+	"github.com/ptrvsrg/crack-hash/manager/internal/persistence/entity"
+	"github.com/ptrvsrg/crack-hash/manager/internal/persistence/repository"
+	repomock "github.com/ptrvsrg/crack-hash/manager/internal/persistence/repository/mock"
+	"github.com/ptrvsrg/crack-hash/manager/internal/service/domain"
+	"github.com/ptrvsrg/crack-hash/manager/internal/service/domain/ruleset"
+	"github.com/ptrvsrg/crack-hash/manager/pkg/model"
+)
+
+func init() {
+	logging.Setup(true)
+}
+
+var (
+	mockRepo *repomock.RuleSetMock
+	service  domain.RuleSet
+
+	ctx = context.Background()
+)
+
+func TestMain(m *testing.M) {
+	mockRepo = new(repomock.RuleSetMock)
+	service = ruleset.NewService(log.Logger, config.RuleSetConfig{MaxRules: 3}, mockRepo)
+
+	m.Run()
+}
+
+func Test_CreateRuleSet(t *testing.T) {
+	t.Run(
+		"Success", func(t *testing.T) {
+			// Arrange
+			input := &model.RuleSetInput{
+				Name:  " leet ",
+				Rules: []string{"# capitalize and append digits", "c $1", "", "sa@ so0"},
+			}
+
+			mockRepo.On("Create", ctx, mock.Anything).Run(
+				func(args mock.Arguments) {
+					rs, ok := args.Get(1).(*entity.RuleSet)
+					assert.True(t, ok)
+					assert.Equal(t, "leet", rs.Name)
+					assert.Equal(t, []string{"c $1", "sa@ so0"}, rs.Rules)
+				},
+			).Return(nil).Once()
+
+			// Act
+			output, err := service.CreateRuleSet(ctx, input)
+
+			// Assert
+			require.NoError(t, err)
+			require.Equal(t, "leet", output.Name)
+			require.Len(t, output.Rules, 2)
+			mockRepo.AssertExpectations(t)
+		},
+	)
+
+	t.Run(
+		"Invalid input", func(t *testing.T) {
+			cases := []struct {
+				Name  string
+				Input *model.RuleSetInput
+			}{
+				{"Empty name", &model.RuleSetInput{Name: " ", Rules: []string{"c"}}},
+				{"No rules", &model.RuleSetInput{Name: "rules", Rules: []string{"# comment", ""}}},
+				{"Too many rules", &model.RuleSetInput{Name: "rules", Rules: []string{"c", "u", "l", "r"}}},
+				{"Unknown function", &model.RuleSetInput{Name: "rules", Rules: []string{"c!"}}},
+				{"Missing argument", &model.RuleSetInput{Name: "rules", Rules: []string{"c $"}}},
+				{"Invalid position", &model.RuleSetInput{Name: "rules", Rules: []string{"Tz"}}},
+			}
+
+			for _, c := range cases {
+				t.Run(
+					c.Name, func(t *testing.T) {
+						// Act
+						output, err := service.CreateRuleSet(ctx, c.Input)
+
+						// Assert
+						require.ErrorIs(t, err, domain.ErrInvalidRuleSet)
+						require.Nil(t, output)
+					},
+				)
+			}
+		},
+	)
+
+	t.Run(
+		"Already exists", func(t *testing.T) {
+			// Arrange
+			mockRepo.On("Create", ctx, mock.Anything).Return(repository.ErrRuleSetExists).Once()
+
+			// Act
+			output, err := service.CreateRuleSet(ctx, &model.RuleSetInput{Name: "rules", Rules: []string{"c"}})
+
+			// Assert
+			require.ErrorIs(t, err, domain.ErrRuleSetExists)
+			require.Nil(t, output)
+		},
+	)
+}
+
+func Test_GetRuleSet(t *testing.T) {
+	t.Run(
+		"Success", func(t *testing.T) {
+			// Arrange
+			id := primitive.NewObjectID()
+
+			mockRepo.On("Get", ctx, id).
+				Return(&entity.RuleSet{ObjectID: id, Name: "rules", Rules: []string{"c"}}, nil).Once()
+
+			// Act
+			output, err := service.GetRuleSet(ctx, id.Hex())
+
+			// Assert
+			require.NoError(t, err)
+			require.Equal(t, id.Hex(), output.ID)
+			require.Equal(t, []string{"c"}, output.Rules)
+		},
+	)
+
+	t.Run(
+		"Invalid ID", func(t *testing.T) {
+			// Act
+			output, err := service.GetRuleSet(ctx, "invalid")
+
+			// Assert
+			require.ErrorIs(t, err, domain.ErrInvalidRuleSetID)
+			require.Nil(t, output)
+		},
+	)
+
+	t.Run(
+		"Not found", func(t *testing.T) {
+			// Arrange
+			id := primitive.NewObjectID()
+
+			mockRepo.On("Get", ctx, id).Return(nil, repository.ErrRuleSetNotFound).Once()
+
+			// Act
+			output, err := service.GetRuleSet(ctx, id.Hex())
+
+			// Assert
+			require.ErrorIs(t, err, domain.ErrRuleSetNotFound)
+			require.Nil(t, output)
+		},
+	)
+}
+
+func Test_DeleteRuleSet(t *testing.T) {
+	t.Run(
+		"Success", func(t *testing.T) {
+			// Arrange
+			id := primitive.NewObjectID()
+
+			mockRepo.On("Delete", ctx, id).Return(nil).Once()
+
+			// Act
+			err := service.DeleteRuleSet(ctx, id.Hex())
+
+			// Assert
+			require.NoError(t, err)
+			mockRepo.AssertExpectations(t)
+		},
+	)
+
+	t.Run(
+		"Not found", func(t *testing.T) {
+			// Arrange
+			id := primitive.NewObjectID()
+
+			mockRepo.On("Delete", ctx, id).Return(repository.ErrRuleSetNotFound).Once()
+
+			// Act
+			err := service.DeleteRuleSet(ctx, id.Hex())
+
+			// Assert
+			require.ErrorIs(t, err, domain.ErrRuleSetNotFound)
+		},
+	)
+}
@@ -0,0 +1,80 @@
+package ruleset
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/ptrvsrg/crack-hash/manager/internal/persistence/entity"
+	"github.com/ptrvsrg/crack-hash/manager/internal/service/domain"
+	"github.com/ptrvsrg/crack-hash/manager/pkg/mangling"
+	"github.com/ptrvsrg/crack-hash/manager/pkg/model"
+)
+
+func normalizeRuleSetInput(input *model.RuleSetInput) {
+	input.Name = strings.TrimSpace(input.Name)
+
+	// Drop empty lines and comments, like the rule files of hashcat
+	rules := make([]string, 0, len(input.Rules))
+	for _, rule := range input.Rules {
+		if strings.TrimSpace(rule) == "" || strings.HasPrefix(rule, "#") {
+			continue
+		}
+		rules = append(rules, rule)
+	}
+	input.Rules = rules
+}
+
+func validateRuleSetInput(input *model.RuleSetInput, maxRules int) error {
+	if input.Name == "" {
+		return fmt.Errorf("%w: name is empty", domain.ErrInvalidRuleSet)
+	}
+
+	if len(input.Rules) == 0 {
+		return fmt.Errorf("%w: no rules", domain.ErrInvalidRuleSet)
+	}
+
+	if len(input.Rules) > maxRules {
+		return fmt.Errorf("%w: %d rules, limit is %d", domain.ErrInvalidRuleSet, len(input.Rules), maxRules)
+	}
+
+	for i, rule := range input.Rules {
+		if _, err := mangling.Parse(rule); err != nil {
+			return fmt.Errorf("%w: rule %d %q: %w", domain.ErrInvalidRuleSet, i+1, rule, err)
+		}
+	}
+
+	return nil
+}
+
+func buildRuleSetEntity(input *model.RuleSetInput) *entity.RuleSet {
+	return &entity.RuleSet{
+		ObjectID:  primitive.NewObjectID(),
+		Name:      input.Name,
+		Rules:     input.Rules,
+		CreatedAt: time.Now(),
+	}
+}
+
+func buildRuleSetOutput(ruleSet *entity.RuleSet) *model.RuleSetOutput {
+	return &model.RuleSetOutput{
+		ID:        ruleSet.ObjectID.Hex(),
+		Name:      ruleSet.Name,
+		Rules:     ruleSet.Rules,
+		CreatedAt: ruleSet.CreatedAt,
+	}
+}
+
+func buildRuleSetOutputs(count int64, ruleSets []*entity.RuleSet) *model.RuleSetsOutput {
+	data := make([]*model.RuleSetOutput, len(ruleSets))
+	for i, ruleSet := range ruleSets {
+		data[i] = buildRuleSetOutput(ruleSet)
+	}
+
+	return &model.RuleSetsOutput{
+		Count:    count,
+		RuleSets: data,
+	}
+}
@@ -3,34 +3,109 @@ package domain
 import (
 	"context"
 	"errors"
+	"io"
 
+	"github.com/ptrvsrg/crack-hash/commonlib/bus/amqp/deadletter"
 	"github.com/ptrvsrg/crack-hash/manager/pkg/message"
 	"github.com/ptrvsrg/crack-hash/manager/pkg/model"
 )
 
 var (
 	ErrTooManyTasks          = errors.New("too many tasks")
+	ErrUnsupportedAlgorithm  = errors.New("unsupported hash algorithm")
+	ErrInvalidHash           = errors.New("invalid hash")
+	ErrTooManyHashes         = errors.New("too many hashes")
+	ErrInvalidSalt           = errors.New("invalid salt")
+	ErrInvalidAttackMode     = errors.New("invalid attack mode")
 	ErrTaskNotFound          = errors.New("task not found")
 	ErrSubtaskNotFound       = errors.New("subtask not found")
+	ErrSubtaskSkipped        = errors.New("subtask is skipped")
+	ErrSubtaskLeaseExpired   = errors.New("subtask lease expired")
+	ErrStaleAttempt          = errors.New("result of previous attempt")
 	ErrInvalidRequestID      = errors.New("invalid request ID")
 	ErrTaskFinishedByTimeout = errors.New("task finished by timeout")
+	ErrTaskFinished          = errors.New("task is already finished")
+	ErrTaskCancelled         = errors.New("task is cancelled")
+	ErrUnsupportedVersion    = errors.New("unsupported message version")
+	ErrInvalidWordlistID     = errors.New("invalid wordlist ID")
+	ErrInvalidWordlistName   = errors.New("invalid wordlist name")
+	ErrWordlistNotFound      = errors.New("wordlist not found")
+	ErrWordlistTooLarge      = errors.New("wordlist is too large")
+	ErrEmptyWordlist         = errors.New("wordlist is empty")
+	ErrWordlistInUse         = errors.New("wordlist is used by active tasks")
+	ErrInvalidRuleSetID      = errors.New("invalid rule set ID")
+	ErrInvalidRuleSet        = errors.New("invalid rule set")
+	ErrRuleSetNotFound       = errors.New("rule set not found")
+	ErrRuleSetExists         = errors.New("rule set already exists")
+	ErrInvalidMask           = errors.New("invalid mask")
+	ErrInvalidAlphabet       = errors.New("invalid alphabet")
+	ErrInvalidLength         = errors.New("invalid word length")
+	ErrKeyspaceTooLarge      = errors.New("keyspace is too large")
+	ErrInvalidTimeout        = errors.New("invalid timeout")
+	ErrInvalidPriority       = errors.New("invalid priority")
+	ErrInvalidDeadLetterID   = errors.New("invalid dead letter ID")
+	ErrDeadLetterNotFound    = errors.New("dead letter not found")
 )
 
 type HashCrackTask interface {
 	CreateTask(ctx context.Context, input *model.HashCrackTaskInput) (*model.HashCrackTaskIDOutput, error)
+	CreateBatchTask(ctx context.Context, input *model.HashCrackBatchTaskInput) (*model.HashCrackTaskIDOutput, error)
 	GetTaskMetadatas(ctx context.Context, limit, offset int) (*model.HashCrackTaskMetadatasOutput, error)
 	GetTaskStatus(ctx context.Context, id string) (*model.HashCrackTaskStatusOutput, error)
+	GetBatchTaskStatus(ctx context.Context, id string) (*model.HashCrackBatchTaskStatusOutput, error)
+	CancelTask(ctx context.Context, id string) error
+	ExtendTask(
+		ctx context.Context, id string, input *model.HashCrackTaskExtendInput,
+	) (*model.HashCrackTaskDeadlineOutput, error)
 	SaveResultSubtask(ctx context.Context, input *message.HashCrackTaskResult) error
 	ExecutePendingSubtasks(ctx context.Context) error
+	RedeliverExpiredSubtasks(ctx context.Context) error
+	SplitSlowSubtasks(ctx context.Context) error
+	// RelayOutboxMessages sends messages of subtasks, which are saved to the outbox and are not sent yet
+	RelayOutboxMessages(ctx context.Context) error
 	FinishTimeoutTasks(ctx context.Context) error
 	DeleteExpiredTasks(ctx context.Context) error
 }
 
+type Wordlist interface {
+	UploadWordlist(ctx context.Context, name string, content io.Reader) (*model.WordlistOutput, error)
+	GetWordlists(ctx context.Context, limit, offset int) (*model.WordlistsOutput, error)
+	GetWordlist(ctx context.Context, id string) (*model.WordlistOutput, error)
+	OpenWordlistContent(ctx context.Context, id string, offset, limit int) (io.ReadCloser, error)
+	DeleteWordlist(ctx context.Context, id string) error
+}
+
+type RuleSet interface {
+	CreateRuleSet(ctx context.Context, input *model.RuleSetInput) (*model.RuleSetOutput, error)
+	GetRuleSets(ctx context.Context, limit, offset int) (*model.RuleSetsOutput, error)
+	GetRuleSet(ctx context.Context, id string) (*model.RuleSetOutput, error)
+	DeleteRuleSet(ctx context.Context, id string) error
+}
+
+type Worker interface {
+	SaveHeartbeat(ctx context.Context, input *message.WorkerHeartbeat) error
+	GetWorkers(ctx context.Context, limit, offset int) (*model.WorkersOutput, error)
+}
+
+// DeadLetter keeps messages, which consumers of the manager and workers failed to handle, until they are replayed
+// to the failed consumer or deleted
+type DeadLetter interface {
+	SaveDeadLetter(ctx context.Context, input *deadletter.Message) error
+	GetDeadLetters(ctx context.Context, limit, offset int) (*model.DeadLettersOutput, error)
+	GetDeadLetter(ctx context.Context, id string) (*model.DeadLetterOutput, error)
+	ReplayDeadLetter(ctx context.Context, id string) error
+	DeleteDeadLetter(ctx context.Context, id string) error
+}
+
 type Health interface {
 	Health(ctx context.Context) error
 }
 
 type Services struct {
 	HashCrackTask HashCrackTask
+	Wordlist      Wordlist
+	RuleSet       RuleSet
+	Worker        Worker
+	DeadLetter    DeadLetter
 	Health        Health
 }
@@ -0,0 +1,236 @@
+package wordlist
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/rs/zerolog"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/ptrvsrg/crack-hash/manager/config"
+	"github.com/ptrvsrg/crack-hash/manager/internal/persistence/entity"
+	"github.com/ptrvsrg/crack-hash/manager/internal/persistence/repository"
+	"github.com/ptrvsrg/crack-hash/manager/internal/service/domain"
+	"github.com/ptrvsrg/crack-hash/manager/pkg/model"
+)
+
+type svc struct {
+	logger   zerolog.Logger
+	cfg      config.WordlistConfig
+	repo     repository.Wordlist
+	taskRepo repository.HashCrackTask
+}
+
+func NewService(
+	logger zerolog.Logger, cfg config.WordlistConfig, repo repository.Wordlist, taskRepo repository.HashCrackTask,
+) domain.Wordlist {
+	return &svc{
+		logger: logger.With().
+			Str("type", "domain").
+			Str("service", "wordlist").
+			Logger(),
+		cfg:      cfg,
+		repo:     repo,
+		taskRepo: taskRepo,
+	}
+}
+
+func (s *svc) UploadWordlist(ctx context.Context, name string, content io.Reader) (*model.WordlistOutput, error) {
+	s.logger.Info().Str("name", name).Msg("upload wordlist")
+
+	// Validate name
+	name = strings.TrimSpace(name)
+	if name == "" {
+		s.logger.Error().Msg("wordlist name is empty")
+		return nil, domain.ErrInvalidWordlistName
+	}
+
+	// Upload content, reading one byte over the limit to detect too large wordlists
+	wordlist := buildWordlistEntity(name)
+	counter := newLineCounter(io.LimitReader(content, s.cfg.MaxSize+1))
+
+	if err := s.repo.UploadContent(ctx, wordlist.ObjectID, name, counter); err != nil {
+		s.logger.Error().Err(err).Stack().Msg("failed to upload wordlist content")
+		return nil, fmt.Errorf("failed to upload wordlist content: %w", err)
+	}
+
+	// Validate content
+	var err error
+	switch {
+	case counter.Size() > s.cfg.MaxSize:
+		err = fmt.Errorf("%w: limit is %d bytes", domain.ErrWordlistTooLarge, s.cfg.MaxSize)
+	case counter.Lines() == 0:
+		err = domain.ErrEmptyWordlist
+	}
+
+	if err != nil {
+		s.logger.Error().Err(err).Msg("failed to validate wordlist content")
+		s.deleteContent(ctx, wordlist.ObjectID)
+		return nil, err
+	}
+
+	// Save wordlist
+	wordlist.LineCount = counter.Lines()
+	wordlist.Size = counter.Size()
+
+	if err := s.repo.Create(ctx, wordlist); err != nil {
+		s.logger.Error().Err(err).Stack().Msg("failed to create wordlist")
+		s.deleteContent(ctx, wordlist.ObjectID)
+		return nil, fmt.Errorf("failed to create wordlist: %w", err)
+	}
+
+	return buildWordlistOutput(wordlist), nil
+}
+
+func (s *svc) GetWordlists(ctx context.Context, limit, offset int) (*model.WordlistsOutput, error) {
+	s.logger.Info().Int("limit", limit).Int("offset", offset).Msg("get wordlists")
+
+	// Get wordlists and count
+	var (
+		wordlists []*entity.Wordlist
+		count     int64
+	)
+	group, ctx := errgroup.WithContext(ctx)
+
+	group.Go(
+		func() error {
+			var err error
+			wordlists, err = s.repo.GetAll(ctx, limit, offset)
+			if err != nil {
+				return fmt.Errorf("failed to get wordlists: %w", err)
+			}
+			return nil
+		},
+	)
+
+	group.Go(
+		func() error {
+			var err error
+			count, err = s.repo.CountAll(ctx)
+			if err != nil {
+				return fmt.Errorf("failed to count wordlists: %w", err)
+			}
+			return nil
+		},
+	)
+
+	if err := group.Wait(); err != nil {
+		s.logger.Error().Err(err).Stack().Msg("failed to get wordlists and count")
+		return nil, fmt.Errorf("failed to get wordlists and count: %w", err)
+	}
+
+	// Convert wordlists
+	return buildWordlistOutputs(count, wordlists), nil
+}
+
+func (s *svc) GetWordlist(ctx context.Context, id string) (*model.WordlistOutput, error) {
+	s.logger.Info().Str("id", id).Msg("get wordlist")
+
+	wordlist, err := s.getWordlist(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return buildWordlistOutput(wordlist), nil
+}
+
+func (s *svc) OpenWordlistContent(ctx context.Context, id string, offset, limit int) (io.ReadCloser, error) {
+	s.logger.Info().
+		Str("id", id).
+		Int("offset", offset).
+		Int("limit", limit).
+		Msg("open wordlist content")
+
+	// Get wordlist
+	wordlist, err := s.getWordlist(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// Open content
+	content, err := s.repo.OpenContent(ctx, wordlist.ObjectID)
+	if err != nil {
+		s.logger.Error().Err(err).Stack().Msg("failed to open wordlist content")
+
+		if errors.Is(err, repository.ErrWordlistNotFound) {
+			return nil, domain.ErrWordlistNotFound
+		}
+		return nil, fmt.Errorf("failed to open wordlist content: %w", err)
+	}
+
+	return newLineRangeReader(content, offset, limit), nil
+}
+
+func (s *svc) DeleteWordlist(ctx context.Context, id string) error {
+	s.logger.Info().Str("id", id).Msg("delete wordlist")
+
+	// Validate ID
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		s.logger.Error().Err(err).Stack().Msg("failed to validate ID")
+		return domain.ErrInvalidWordlistID
+	}
+
+	// Delete wordlist, unless active tasks still read it
+	_, err = s.taskRepo.WithTransaction(
+		ctx, func(ctx context.Context) (any, error) {
+			count, err := s.taskRepo.CountAllByWordlistAndStatuses(ctx, objID, activeTaskStatuses)
+			if err != nil {
+				return nil, fmt.Errorf("failed to count tasks: %w", err)
+			}
+
+			if count > 0 {
+				return nil, fmt.Errorf("%w: %d tasks", domain.ErrWordlistInUse, count)
+			}
+
+			return nil, s.repo.Delete(ctx, objID)
+		},
+	)
+	if err != nil {
+		s.logger.Error().Err(err).Stack().Msg("failed to delete wordlist")
+
+		switch {
+		case errors.Is(err, domain.ErrWordlistInUse):
+			return err
+		case errors.Is(err, repository.ErrWordlistNotFound):
+			return domain.ErrWordlistNotFound
+		}
+		return fmt.Errorf("failed to delete wordlist: %w", err)
+	}
+
+	s.deleteContent(ctx, objID)
+
+	return nil
+}
+
+func (s *svc) getWordlist(ctx context.Context, id string) (*entity.Wordlist, error) {
+	// Validate ID
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		s.logger.Error().Err(err).Stack().Msg("failed to validate ID")
+		return nil, domain.ErrInvalidWordlistID
+	}
+
+	// Get wordlist
+	wordlist, err := s.repo.Get(ctx, objID)
+	if err != nil {
+		s.logger.Error().Err(err).Stack().Msg("failed to get wordlist")
+
+		if errors.Is(err, repository.ErrWordlistNotFound) {
+			return nil, domain.ErrWordlistNotFound
+		}
+		return nil, fmt.Errorf("failed to get wordlist: %w", err)
+	}
+
+	return wordlist, nil
+}
+
+func (s *svc) deleteContent(ctx context.Context, id primitive.ObjectID) {
+	if err := s.repo.DeleteContent(ctx, id); err != nil {
+		s.logger.Warn().Err(err).Str("id", id.Hex()).Msg("failed to delete wordlist content")
+	}
+}
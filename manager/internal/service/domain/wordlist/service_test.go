@@ -0,0 +1,254 @@
+package wordlist_test
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/rs/zerolog/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/ptrvsrg/crack-hash/commonlib/logging"
+	"github.com/ptrvsrg/crack-hash/manager/config"
+	"github.com/ptrvsrg/crack-hash/manager/internal/persistence/entity"
+	"github.com/ptrvsrg/crack-hash/manager/internal/persistence/repository"
+	repomock "github.com/ptrvsrg/crack-hash/manager/internal/persistence/repository/mock"
+	"github.com/ptrvsrg/crack-hash/manager/internal/service/domain"
+	"github.com/ptrvsrg/crack-hash/manager/internal/service/domain/wordlist"
+)
+
+func init() {
+	logging.Setup(true)
+}
+
+var (
+	mockRepo     *repomock.WordlistMock
+	mockTaskRepo *repomock.HashCrackTaskMock
+	service      domain.Wordlist
+
+	ctx = context.Background()
+)
+
+func TestMain(m *testing.M) {
+	mockRepo = new(repomock.WordlistMock)
+	mockTaskRepo = new(repomock.HashCrackTaskMock)
+	service = wordlist.NewService(log.Logger, config.WordlistConfig{MaxSize: 16}, mockRepo, mockTaskRepo)
+
+	m.Run()
+}
+
+func Test_UploadWordlist(t *testing.T) {
+	t.Run(
+		"Success", func(t *testing.T) {
+			// Arrange
+			mockRepo.On("UploadContent", ctx, mock.Anything, "words", mock.Anything).Run(
+				func(args mock.Arguments) {
+					_, err := io.Copy(io.Discard, args.Get(3).(io.Reader))
+					assert.NoError(t, err)
+				},
+			).Return(nil).Once()
+			mockRepo.On("Create", ctx, mock.Anything).Run(
+				func(args mock.Arguments) {
+					wl, ok := args.Get(1).(*entity.Wordlist)
+					assert.True(t, ok)
+					assert.Equal(t, 3, wl.LineCount)
+					assert.Equal(t, int64(11), wl.Size)
+				},
+			).Return(nil).Once()
+
+			// Act
+			output, err := service.UploadWordlist(ctx, " words ", strings.NewReader("abc\nqwe\nxyz"))
+
+			// Assert
+			require.NoError(t, err)
+			require.Equal(t, "words", output.Name)
+			require.Equal(t, 3, output.LineCount)
+			mockRepo.AssertExpectations(t)
+		},
+	)
+
+	t.Run(
+		"Empty name", func(t *testing.T) {
+			// Act
+			output, err := service.UploadWordlist(ctx, " ", strings.NewReader("abc\n"))
+
+			// Assert
+			require.ErrorIs(t, err, domain.ErrInvalidWordlistName)
+			require.Nil(t, output)
+		},
+	)
+
+	t.Run(
+		"Invalid content", func(t *testing.T) {
+			cases := []struct {
+				Name        string
+				Content     string
+				ExpectedErr error
+			}{
+				{"Too large", "0123456789\n0123456789\n", domain.ErrWordlistTooLarge},
+				{"Empty", "", domain.ErrEmptyWordlist},
+			}
+
+			for _, c := range cases {
+				t.Run(
+					c.Name, func(t *testing.T) {
+						// Arrange
+						mockRepo.On("UploadContent", ctx, mock.Anything, "words", mock.Anything).Run(
+							func(args mock.Arguments) {
+								_, err := io.Copy(io.Discard, args.Get(3).(io.Reader))
+								assert.NoError(t, err)
+							},
+						).Return(nil).Once()
+						mockRepo.On("DeleteContent", ctx, mock.Anything).Return(nil).Once()
+
+						// Act
+						output, err := service.UploadWordlist(ctx, "words", strings.NewReader(c.Content))
+
+						// Assert
+						require.ErrorIs(t, err, c.ExpectedErr)
+						require.Nil(t, output)
+						mockRepo.AssertExpectations(t)
+					},
+				)
+			}
+		},
+	)
+}
+
+func Test_OpenWordlistContent(t *testing.T) {
+	t.Run(
+		"Success", func(t *testing.T) {
+			cases := []struct {
+				Name     string
+				Offset   int
+				Limit    int
+				Expected string
+			}{
+				{"All lines", 0, 0, "a\nb\nc\nd\n"},
+				{"Range", 1, 2, "b\nc\n"},
+				{"Tail", 2, 0, "c\nd\n"},
+				{"Out of range", 10, 2, ""},
+			}
+
+			for _, c := range cases {
+				t.Run(
+					c.Name, func(t *testing.T) {
+						// Arrange
+						id := primitive.NewObjectID()
+
+						mockRepo.On("Get", ctx, id).Return(&entity.Wordlist{ObjectID: id}, nil).Once()
+						mockRepo.On("OpenContent", ctx, id).
+							Return(io.NopCloser(strings.NewReader("a\nb\r\nc\nd")), nil).Once()
+
+						// Act
+						content, err := service.OpenWordlistContent(ctx, id.Hex(), c.Offset, c.Limit)
+						require.NoError(t, err)
+
+						data, err := io.ReadAll(content)
+
+						// Assert
+						require.NoError(t, err)
+						require.Equal(t, c.Expected, string(data))
+						require.NoError(t, content.Close())
+					},
+				)
+			}
+		},
+	)
+
+	t.Run(
+		"Invalid ID", func(t *testing.T) {
+			// Act
+			content, err := service.OpenWordlistContent(ctx, "invalid", 0, 0)
+
+			// Assert
+			require.ErrorIs(t, err, domain.ErrInvalidWordlistID)
+			require.Nil(t, content)
+		},
+	)
+
+	t.Run(
+		"Not found", func(t *testing.T) {
+			// Arrange
+			id := primitive.NewObjectID()
+
+			mockRepo.On("Get", ctx, id).Return(nil, repository.ErrWordlistNotFound).Once()
+
+			// Act
+			content, err := service.OpenWordlistContent(ctx, id.Hex(), 0, 0)
+
+			// Assert
+			require.ErrorIs(t, err, domain.ErrWordlistNotFound)
+			require.Nil(t, content)
+		},
+	)
+}
+
+func Test_DeleteWordlist(t *testing.T) {
+	activeStatuses := []entity.HashCrackTaskStatus{
+		entity.HashCrackTaskStatusPending, entity.HashCrackTaskStatusInProgress,
+	}
+
+	t.Run(
+		"Success", func(t *testing.T) {
+			// Arrange
+			id := primitive.NewObjectID()
+
+			mockTaskRepo.EXPECT().WithTransaction(ctx, mock.Anything).RunAndReturn(runInTransaction).Once()
+			mockTaskRepo.EXPECT().CountAllByWordlistAndStatuses(ctx, id, activeStatuses).Return(0, nil).Once()
+			mockRepo.On("Delete", ctx, id).Return(nil).Once()
+			mockRepo.On("DeleteContent", ctx, id).Return(nil).Once()
+
+			// Act
+			err := service.DeleteWordlist(ctx, id.Hex())
+
+			// Assert
+			require.NoError(t, err)
+			mockRepo.AssertExpectations(t)
+			mockTaskRepo.AssertExpectations(t)
+		},
+	)
+
+	t.Run(
+		"Used by active tasks", func(t *testing.T) {
+			// Arrange
+			id := primitive.NewObjectID()
+
+			mockTaskRepo.EXPECT().WithTransaction(ctx, mock.Anything).RunAndReturn(runInTransaction).Once()
+			mockTaskRepo.EXPECT().CountAllByWordlistAndStatuses(ctx, id, activeStatuses).Return(2, nil).Once()
+
+			// Act
+			err := service.DeleteWordlist(ctx, id.Hex())
+
+			// Assert
+			require.ErrorIs(t, err, domain.ErrWordlistInUse)
+			mockRepo.AssertNotCalled(t, "Delete", ctx, id)
+			mockRepo.AssertNotCalled(t, "DeleteContent", ctx, id)
+		},
+	)
+
+	t.Run(
+		"Not found", func(t *testing.T) {
+			// Arrange
+			id := primitive.NewObjectID()
+
+			mockTaskRepo.EXPECT().WithTransaction(ctx, mock.Anything).RunAndReturn(runInTransaction).Once()
+			mockTaskRepo.EXPECT().CountAllByWordlistAndStatuses(ctx, id, activeStatuses).Return(0, nil).Once()
+			mockRepo.On("Delete", ctx, id).Return(repository.ErrWordlistNotFound).Once()
+
+			// Act
+			err := service.DeleteWordlist(ctx, id.Hex())
+
+			// Assert
+			require.ErrorIs(t, err, domain.ErrWordlistNotFound)
+		},
+	)
+}
+
+func runInTransaction(ctx context.Context, fn func(ctx context.Context) (any, error)) (any, error) {
+	return fn(ctx)
+}
@@ -0,0 +1,145 @@
+package wordlist
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/ptrvsrg/crack-hash/manager/internal/persistence/entity"
+	"github.com/ptrvsrg/crack-hash/manager/pkg/model"
+)
+
+// maxLineSize is the maximum length of a wordlist line
+const maxLineSize = 1024 * 1024
+
+// activeTaskStatuses are the statuses of tasks, which read their wordlists
+var activeTaskStatuses = []entity.HashCrackTaskStatus{
+	entity.HashCrackTaskStatusPending,
+	entity.HashCrackTaskStatusInProgress,
+}
+
+// lineCounter counts bytes and lines passing through it. A trailing line without a line feed is counted too,
+// so the count matches the number of lines produced by bufio.ScanLines.
+type lineCounter struct {
+	r        io.Reader
+	size     int64
+	newLines int
+	lastByte byte
+}
+
+func newLineCounter(r io.Reader) *lineCounter {
+	return &lineCounter{r: r}
+}
+
+func (c *lineCounter) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	if n > 0 {
+		c.size += int64(n)
+		c.newLines += bytes.Count(p[:n], []byte{'\n'})
+		c.lastByte = p[n-1]
+	}
+
+	return n, err
+}
+
+func (c *lineCounter) Size() int64 {
+	return c.size
+}
+
+func (c *lineCounter) Lines() int {
+	if c.size > 0 && c.lastByte != '\n' {
+		return c.newLines + 1
+	}
+
+	return c.newLines
+}
+
+// lineRangeReader reads a range of lines of the underlying reader, every line is terminated by a line feed.
+// The zero limit means all lines after the offset.
+type lineRangeReader struct {
+	closer  io.Closer
+	scanner *bufio.Scanner
+	offset  int
+	limit   int
+	read    int
+	line    []byte
+	buf     []byte
+}
+
+func newLineRangeReader(rc io.ReadCloser, offset, limit int) *lineRangeReader {
+	scanner := bufio.NewScanner(rc)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxLineSize)
+
+	return &lineRangeReader{
+		closer:  rc,
+		scanner: scanner,
+		offset:  offset,
+		limit:   limit,
+	}
+}
+
+func (r *lineRangeReader) Read(p []byte) (int, error) {
+	for len(r.buf) == 0 {
+		if r.limit > 0 && r.read >= r.limit {
+			return 0, io.EOF
+		}
+
+		if !r.scanner.Scan() {
+			if err := r.scanner.Err(); err != nil {
+				return 0, err
+			}
+			return 0, io.EOF
+		}
+
+		if r.offset > 0 {
+			r.offset--
+			continue
+		}
+
+		r.read++
+		r.line = append(append(r.line[:0], r.scanner.Bytes()...), '\n')
+		r.buf = r.line
+	}
+
+	n := copy(p, r.buf)
+	r.buf = r.buf[n:]
+
+	return n, nil
+}
+
+func (r *lineRangeReader) Close() error {
+	return r.closer.Close()
+}
+
+func buildWordlistEntity(name string) *entity.Wordlist {
+	return &entity.Wordlist{
+		ObjectID:  primitive.NewObjectID(),
+		Name:      name,
+		CreatedAt: time.Now(),
+	}
+}
+
+func buildWordlistOutput(wordlist *entity.Wordlist) *model.WordlistOutput {
+	return &model.WordlistOutput{
+		ID:        wordlist.ObjectID.Hex(),
+		Name:      wordlist.Name,
+		LineCount: wordlist.LineCount,
+		Size:      wordlist.Size,
+		CreatedAt: wordlist.CreatedAt,
+	}
+}
+
+func buildWordlistOutputs(count int64, wordlists []*entity.Wordlist) *model.WordlistsOutput {
+	data := make([]*model.WordlistOutput, len(wordlists))
+	for i, wordlist := range wordlists {
+		data[i] = buildWordlistOutput(wordlist)
+	}
+
+	return &model.WordlistsOutput{
+		Count:     count,
+		Wordlists: data,
+	}
+}
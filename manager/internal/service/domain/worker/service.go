@@ -0,0 +1,115 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/rs/zerolog"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/ptrvsrg/crack-hash/manager/config"
+	"github.com/ptrvsrg/crack-hash/manager/internal/persistence/entity"
+	"github.com/ptrvsrg/crack-hash/manager/internal/persistence/repository"
+	"github.com/ptrvsrg/crack-hash/manager/internal/service/domain"
+	"github.com/ptrvsrg/crack-hash/manager/pkg/message"
+	"github.com/ptrvsrg/crack-hash/manager/pkg/model"
+)
+
+type svc struct {
+	logger zerolog.Logger
+	cfg    config.WorkerConfig
+	repo   repository.Worker
+}
+
+func NewService(logger zerolog.Logger, cfg config.WorkerConfig, repo repository.Worker) domain.Worker {
+	return &svc{
+		logger: logger.With().
+			Str("type", "domain").
+			Str("service", "worker").
+			Logger(),
+		cfg:  cfg,
+		repo: repo,
+	}
+}
+
+func (s *svc) SaveHeartbeat(ctx context.Context, input *message.WorkerHeartbeat) error {
+	s.logger.Debug().Str("worker-id", input.WorkerID).Str("event", input.Event).Msg("save heartbeat")
+
+	if input.Version != message.Version {
+		s.logger.Warn().
+			Str("worker-id", input.WorkerID).
+			Int("version", input.Version).
+			Msg("unsupported message version, heartbeat is ignored")
+		return domain.ErrUnsupportedVersion
+	}
+
+	// Get worker, a heartbeat of an unknown worker registers it
+	worker, err := s.repo.Get(ctx, input.WorkerID)
+	if err != nil && !errors.Is(err, repository.ErrWorkerNotFound) {
+		s.logger.Error().Err(err).Stack().Msg("failed to get worker")
+		return fmt.Errorf("failed to get worker: %w", err)
+	}
+
+	now := time.Now()
+	if worker == nil || input.Event == message.WorkerEventRegistered {
+		s.logger.Info().
+			Str("worker-id", input.WorkerID).
+			Str("worker-version", input.WorkerVersion).
+			Msg("worker registered")
+
+		worker = &entity.Worker{ID: input.WorkerID, RegisteredAt: now}
+	}
+
+	// Save worker
+	updateWorkerEntity(worker, input, now)
+
+	if err := s.repo.Save(ctx, worker); err != nil {
+		s.logger.Error().Err(err).Stack().Msg("failed to save worker")
+		return fmt.Errorf("failed to save worker: %w", err)
+	}
+
+	return nil
+}
+
+func (s *svc) GetWorkers(ctx context.Context, limit, offset int) (*model.WorkersOutput, error) {
+	s.logger.Info().Int("limit", limit).Int("offset", offset).Msg("get workers")
+
+	// Get workers and count
+	var (
+		workers []*entity.Worker
+		count   int64
+	)
+	group, ctx := errgroup.WithContext(ctx)
+
+	group.Go(
+		func() error {
+			var err error
+			workers, err = s.repo.GetAll(ctx, limit, offset)
+			if err != nil {
+				return fmt.Errorf("failed to get workers: %w", err)
+			}
+			return nil
+		},
+	)
+
+	group.Go(
+		func() error {
+			var err error
+			count, err = s.repo.CountAll(ctx)
+			if err != nil {
+				return fmt.Errorf("failed to count workers: %w", err)
+			}
+			return nil
+		},
+	)
+
+	if err := group.Wait(); err != nil {
+		s.logger.Error().Err(err).Stack().Msg("failed to get workers and count")
+		return nil, fmt.Errorf("failed to get workers and count: %w", err)
+	}
+
+	// Convert workers
+	return buildWorkerOutputs(count, workers, time.Now().Add(-s.cfg.HeartbeatTimeout)), nil
+}
@@ -0,0 +1,208 @@
+package worker_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ptrvsrg/crack-hash/commonlib/logging"
+	"github.com/ptrvsrg/crack-hash/manager/config"
+	"github.com/ptrvsrg/crack-hash/manager/internal/persistence/entity"
+	"github.com/ptrvsrg/crack-hash/manager/internal/persistence/repository"
+	repomock "github.com/ptrvsrg/crack-hash/manager/internal/persistence/repository/mock"
+	"github.com/ptrvsrg/crack-hash/manager/internal/service/domain"
+	"github.com/ptrvsrg/crack-hash/manager/internal/service/domain/worker"
+	"github.com/ptrvsrg/crack-hash/manager/pkg/message"
+	"github.com/ptrvsrg/crack-hash/manager/pkg/model"
+)
+
+func init() {
+	logging.Setup(true)
+}
+
+var (
+	mockRepo *repomock.WorkerMock
+	service  domain.Worker
+
+	ctx = context.Background()
+)
+
+func TestMain(m *testing.M) {
+	mockRepo = new(repomock.WorkerMock)
+	service = worker.NewService(log.Logger, config.WorkerConfig{HeartbeatTimeout: 30 * time.Second}, mockRepo)
+
+	m.Run()
+}
+
+func Test_SaveHeartbeat(t *testing.T) {
+	newInput := func(event string) *message.WorkerHeartbeat {
+		return &message.WorkerHeartbeat{
+			Version:       message.Version,
+			WorkerID:      "worker-1",
+			Event:         event,
+			WorkerVersion: "1.0.0",
+			CPUCount:      8,
+			Algorithms:    []string{"MD5", "SHA1"},
+			Speed:         1500.0,
+		}
+	}
+
+	t.Run(
+		"Register", func(t *testing.T) {
+			// Arrange
+			input := newInput(message.WorkerEventRegistered)
+			registeredAt := time.Now().Add(-time.Hour)
+
+			mockRepo.On("Get", ctx, "worker-1").
+				Return(&entity.Worker{ID: "worker-1", RegisteredAt: registeredAt}, nil).Once()
+			mockRepo.On("Save", ctx, mock.Anything).Run(
+				func(args mock.Arguments) {
+					w, ok := args.Get(1).(*entity.Worker)
+					assert.True(t, ok)
+					assert.Equal(t, "worker-1", w.ID)
+					assert.Equal(t, "1.0.0", w.Version)
+					assert.Equal(t, 8, w.CPUCount)
+					assert.Equal(t, []string{"MD5", "SHA1"}, w.Algorithms)
+					assert.Equal(t, 1500.0, w.Speed)
+					assert.True(t, w.RegisteredAt.After(registeredAt)) // A restarted worker is registered again
+					assert.Equal(t, w.RegisteredAt, w.LastHeartbeatAt)
+				},
+			).Return(nil).Once()
+
+			// Act
+			err := service.SaveHeartbeat(ctx, input)
+
+			// Assert
+			require.NoError(t, err)
+			mockRepo.AssertExpectations(t)
+		},
+	)
+
+	t.Run(
+		"Heartbeat", func(t *testing.T) {
+			// Arrange
+			input := newInput(message.WorkerEventHeartbeat)
+			registeredAt := time.Now().Add(-time.Hour)
+
+			mockRepo.On("Get", ctx, "worker-1").
+				Return(&entity.Worker{ID: "worker-1", RegisteredAt: registeredAt}, nil).Once()
+			mockRepo.On("Save", ctx, mock.Anything).Run(
+				func(args mock.Arguments) {
+					w, ok := args.Get(1).(*entity.Worker)
+					assert.True(t, ok)
+					assert.Equal(t, registeredAt, w.RegisteredAt)
+					assert.True(t, w.LastHeartbeatAt.After(registeredAt))
+				},
+			).Return(nil).Once()
+
+			// Act
+			err := service.SaveHeartbeat(ctx, input)
+
+			// Assert
+			require.NoError(t, err)
+			mockRepo.AssertExpectations(t)
+		},
+	)
+
+	t.Run(
+		"Heartbeat of unknown worker", func(t *testing.T) {
+			// Arrange
+			input := newInput(message.WorkerEventHeartbeat)
+
+			mockRepo.On("Get", ctx, "worker-1").Return(nil, repository.ErrWorkerNotFound).Once()
+			mockRepo.On("Save", ctx, mock.Anything).Run(
+				func(args mock.Arguments) {
+					w, ok := args.Get(1).(*entity.Worker)
+					assert.True(t, ok)
+					assert.Equal(t, "worker-1", w.ID)
+					assert.False(t, w.RegisteredAt.IsZero())
+				},
+			).Return(nil).Once()
+
+			// Act
+			err := service.SaveHeartbeat(ctx, input)
+
+			// Assert
+			require.NoError(t, err)
+			mockRepo.AssertExpectations(t)
+		},
+	)
+
+	t.Run(
+		"Unsupported message version", func(t *testing.T) {
+			// Arrange
+			input := newInput(message.WorkerEventHeartbeat)
+			input.Version = 0
+
+			// Act
+			err := service.SaveHeartbeat(ctx, input)
+
+			// Assert
+			require.ErrorIs(t, err, domain.ErrUnsupportedVersion)
+			mockRepo.AssertExpectations(t)
+		},
+	)
+
+	t.Run(
+		"Repository error", func(t *testing.T) {
+			// Arrange
+			input := newInput(message.WorkerEventHeartbeat)
+
+			mockRepo.On("Get", ctx, "worker-1").Return(nil, errors.New("error")).Once()
+
+			// Act
+			err := service.SaveHeartbeat(ctx, input)
+
+			// Assert
+			require.Error(t, err)
+			mockRepo.AssertExpectations(t)
+		},
+	)
+}
+
+func Test_GetWorkers(t *testing.T) {
+	t.Run(
+		"Success", func(t *testing.T) {
+			// Arrange
+			workers := []*entity.Worker{
+				{ID: "worker-1", LastHeartbeatAt: time.Now().Add(-10 * time.Second)},
+				{ID: "worker-2", LastHeartbeatAt: time.Now().Add(-time.Minute)},
+			}
+
+			mockRepo.On("GetAll", mock.Anything, 10, 0).Return(workers, nil).Once()
+			mockRepo.On("CountAll", mock.Anything).Return(int64(2), nil).Once()
+
+			// Act
+			output, err := service.GetWorkers(ctx, 10, 0)
+
+			// Assert
+			require.NoError(t, err)
+			require.Equal(t, int64(2), output.Count)
+			require.Len(t, output.Workers, 2)
+			assert.Equal(t, model.WorkerStatusOnline, output.Workers[0].Status)
+			assert.Equal(t, model.WorkerStatusOffline, output.Workers[1].Status)
+			mockRepo.AssertExpectations(t)
+		},
+	)
+
+	t.Run(
+		"Repository error", func(t *testing.T) {
+			// Arrange
+			mockRepo.On("GetAll", mock.Anything, 10, 0).Return(nil, errors.New("error")).Once()
+			mockRepo.On("CountAll", mock.Anything).Return(int64(0), nil).Maybe()
+
+			// Act
+			output, err := service.GetWorkers(ctx, 10, 0)
+
+			// Assert
+			require.Error(t, err)
+			require.Nil(t, output)
+		},
+	)
+}
@@ -0,0 +1,51 @@
+package worker
+
+import (
+	"time"
+
+	"github.com/ptrvsrg/crack-hash/manager/internal/persistence/entity"
+	"github.com/ptrvsrg/crack-hash/manager/pkg/message"
+	"github.com/ptrvsrg/crack-hash/manager/pkg/model"
+)
+
+func updateWorkerEntity(worker *entity.Worker, input *message.WorkerHeartbeat, now time.Time) {
+	worker.Version = input.WorkerVersion
+	worker.CPUCount = input.CPUCount
+	worker.Algorithms = input.Algorithms
+	if worker.Algorithms == nil {
+		worker.Algorithms = make([]string, 0)
+	}
+	worker.Speed = input.Speed
+	worker.LastHeartbeatAt = now
+}
+
+// buildWorkerOutput converts the worker, it is online if its last heartbeat is not before the time
+func buildWorkerOutput(worker *entity.Worker, onlineSince time.Time) *model.WorkerOutput {
+	status := model.WorkerStatusOnline
+	if worker.LastHeartbeatAt.Before(onlineSince) {
+		status = model.WorkerStatusOffline
+	}
+
+	return &model.WorkerOutput{
+		ID:              worker.ID,
+		Version:         worker.Version,
+		Status:          status,
+		CPUCount:        worker.CPUCount,
+		Algorithms:      worker.Algorithms,
+		Speed:           worker.Speed,
+		RegisteredAt:    worker.RegisteredAt,
+		LastHeartbeatAt: worker.LastHeartbeatAt,
+	}
+}
+
+func buildWorkerOutputs(count int64, workers []*entity.Worker, onlineSince time.Time) *model.WorkersOutput {
+	data := make([]*model.WorkerOutput, len(workers))
+	for i, worker := range workers {
+		data[i] = buildWorkerOutput(worker, onlineSince)
+	}
+
+	return &model.WorkersOutput{
+		Count:   count,
+		Workers: data,
+	}
+}
@@ -0,0 +1,92 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mock
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// TaskQueueMock is an autogenerated mock type for the TaskQueue type
+type TaskQueueMock struct {
+	mock.Mock
+}
+
+type TaskQueueMock_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *TaskQueueMock) EXPECT() *TaskQueueMock_Expecter {
+	return &TaskQueueMock_Expecter{mock: &_m.Mock}
+}
+
+// IdleCapacity provides a mock function with given fields: ctx
+func (_m *TaskQueueMock) IdleCapacity(ctx context.Context) (int, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IdleCapacity")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (int, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) int); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TaskQueueMock_IdleCapacity_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IdleCapacity'
+type TaskQueueMock_IdleCapacity_Call struct {
+	*mock.Call
+}
+
+// IdleCapacity is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *TaskQueueMock_Expecter) IdleCapacity(ctx interface{}) *TaskQueueMock_IdleCapacity_Call {
+	return &TaskQueueMock_IdleCapacity_Call{Call: _e.mock.On("IdleCapacity", ctx)}
+}
+
+func (_c *TaskQueueMock_IdleCapacity_Call) Run(run func(ctx context.Context)) *TaskQueueMock_IdleCapacity_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *TaskQueueMock_IdleCapacity_Call) Return(_a0 int, _a1 error) *TaskQueueMock_IdleCapacity_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *TaskQueueMock_IdleCapacity_Call) RunAndReturn(run func(context.Context) (int, error)) *TaskQueueMock_IdleCapacity_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewTaskQueueMock creates a new instance of TaskQueueMock. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewTaskQueueMock(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *TaskQueueMock {
+	mock := &TaskQueueMock{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -5,6 +5,7 @@ package mock
 import (
 	context "context"
 
+	infrastructure "github.com/ptrvsrg/crack-hash/manager/internal/service/infrastructure"
 	mock "github.com/stretchr/testify/mock"
 )
 
@@ -21,27 +22,27 @@ func (_m *TaskSplitMock) EXPECT() *TaskSplitMock_Expecter {
 	return &TaskSplitMock_Expecter{mock: &_m.Mock}
 }
 
-// Split provides a mock function with given fields: ctx, wordMaxLength, alphabetLength
-func (_m *TaskSplitMock) Split(ctx context.Context, wordMaxLength int, alphabetLength int) (int, error) {
-	ret := _m.Called(ctx, wordMaxLength, alphabetLength)
+// Split provides a mock function with given fields: ctx, algorithm, wordMinLength, wordMaxLength, alphabetLength
+func (_m *TaskSplitMock) Split(ctx context.Context, algorithm string, wordMinLength int, wordMaxLength int, alphabetLength int) (infrastructure.KeyPartition, error) {
+	ret := _m.Called(ctx, algorithm, wordMinLength, wordMaxLength, alphabetLength)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Split")
 	}
 
-	var r0 int
+	var r0 infrastructure.KeyPartition
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, int, int) (int, error)); ok {
-		return rf(ctx, wordMaxLength, alphabetLength)
+	if rf, ok := ret.Get(0).(func(context.Context, string, int, int, int) (infrastructure.KeyPartition, error)); ok {
+		return rf(ctx, algorithm, wordMinLength, wordMaxLength, alphabetLength)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, int, int) int); ok {
-		r0 = rf(ctx, wordMaxLength, alphabetLength)
+	if rf, ok := ret.Get(0).(func(context.Context, string, int, int, int) infrastructure.KeyPartition); ok {
+		r0 = rf(ctx, algorithm, wordMinLength, wordMaxLength, alphabetLength)
 	} else {
-		r0 = ret.Get(0).(int)
+		r0 = ret.Get(0).(infrastructure.KeyPartition)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, int, int) error); ok {
-		r1 = rf(ctx, wordMaxLength, alphabetLength)
+	if rf, ok := ret.Get(1).(func(context.Context, string, int, int, int) error); ok {
+		r1 = rf(ctx, algorithm, wordMinLength, wordMaxLength, alphabetLength)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -56,25 +57,143 @@ type TaskSplitMock_Split_Call struct {
 
 // Split is a helper method to define mock.On call
 //   - ctx context.Context
+//   - algorithm string
+//   - wordMinLength int
 //   - wordMaxLength int
 //   - alphabetLength int
-func (_e *TaskSplitMock_Expecter) Split(ctx interface{}, wordMaxLength interface{}, alphabetLength interface{}) *TaskSplitMock_Split_Call {
-	return &TaskSplitMock_Split_Call{Call: _e.mock.On("Split", ctx, wordMaxLength, alphabetLength)}
+func (_e *TaskSplitMock_Expecter) Split(ctx interface{}, algorithm interface{}, wordMinLength interface{}, wordMaxLength interface{}, alphabetLength interface{}) *TaskSplitMock_Split_Call {
+	return &TaskSplitMock_Split_Call{Call: _e.mock.On("Split", ctx, algorithm, wordMinLength, wordMaxLength, alphabetLength)}
 }
 
-func (_c *TaskSplitMock_Split_Call) Run(run func(ctx context.Context, wordMaxLength int, alphabetLength int)) *TaskSplitMock_Split_Call {
+func (_c *TaskSplitMock_Split_Call) Run(run func(ctx context.Context, algorithm string, wordMinLength int, wordMaxLength int, alphabetLength int)) *TaskSplitMock_Split_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(int), args[2].(int))
+		run(args[0].(context.Context), args[1].(string), args[2].(int), args[3].(int), args[4].(int))
 	})
 	return _c
 }
 
-func (_c *TaskSplitMock_Split_Call) Return(_a0 int, _a1 error) *TaskSplitMock_Split_Call {
+func (_c *TaskSplitMock_Split_Call) Return(_a0 infrastructure.KeyPartition, _a1 error) *TaskSplitMock_Split_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *TaskSplitMock_Split_Call) RunAndReturn(run func(context.Context, int, int) (int, error)) *TaskSplitMock_Split_Call {
+func (_c *TaskSplitMock_Split_Call) RunAndReturn(run func(context.Context, string, int, int, int) (infrastructure.KeyPartition, error)) *TaskSplitMock_Split_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SplitKeyspace provides a mock function with given fields: ctx, algorithm, size
+func (_m *TaskSplitMock) SplitKeyspace(ctx context.Context, algorithm string, size int) (infrastructure.KeyPartition, error) {
+	ret := _m.Called(ctx, algorithm, size)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SplitKeyspace")
+	}
+
+	var r0 infrastructure.KeyPartition
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int) (infrastructure.KeyPartition, error)); ok {
+		return rf(ctx, algorithm, size)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, int) infrastructure.KeyPartition); ok {
+		r0 = rf(ctx, algorithm, size)
+	} else {
+		r0 = ret.Get(0).(infrastructure.KeyPartition)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, int) error); ok {
+		r1 = rf(ctx, algorithm, size)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TaskSplitMock_SplitKeyspace_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SplitKeyspace'
+type TaskSplitMock_SplitKeyspace_Call struct {
+	*mock.Call
+}
+
+// SplitKeyspace is a helper method to define mock.On call
+//   - ctx context.Context
+//   - algorithm string
+//   - size int
+func (_e *TaskSplitMock_Expecter) SplitKeyspace(ctx interface{}, algorithm interface{}, size interface{}) *TaskSplitMock_SplitKeyspace_Call {
+	return &TaskSplitMock_SplitKeyspace_Call{Call: _e.mock.On("SplitKeyspace", ctx, algorithm, size)}
+}
+
+func (_c *TaskSplitMock_SplitKeyspace_Call) Run(run func(ctx context.Context, algorithm string, size int)) *TaskSplitMock_SplitKeyspace_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(int))
+	})
+	return _c
+}
+
+func (_c *TaskSplitMock_SplitKeyspace_Call) Return(_a0 infrastructure.KeyPartition, _a1 error) *TaskSplitMock_SplitKeyspace_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *TaskSplitMock_SplitKeyspace_Call) RunAndReturn(run func(context.Context, string, int) (infrastructure.KeyPartition, error)) *TaskSplitMock_SplitKeyspace_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SplitMask provides a mock function with given fields: ctx, algorithm, charsetLengths
+func (_m *TaskSplitMock) SplitMask(ctx context.Context, algorithm string, charsetLengths []int) (infrastructure.KeyPartition, error) {
+	ret := _m.Called(ctx, algorithm, charsetLengths)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SplitMask")
+	}
+
+	var r0 infrastructure.KeyPartition
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, []int) (infrastructure.KeyPartition, error)); ok {
+		return rf(ctx, algorithm, charsetLengths)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, []int) infrastructure.KeyPartition); ok {
+		r0 = rf(ctx, algorithm, charsetLengths)
+	} else {
+		r0 = ret.Get(0).(infrastructure.KeyPartition)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, []int) error); ok {
+		r1 = rf(ctx, algorithm, charsetLengths)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TaskSplitMock_SplitMask_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SplitMask'
+type TaskSplitMock_SplitMask_Call struct {
+	*mock.Call
+}
+
+// SplitMask is a helper method to define mock.On call
+//   - ctx context.Context
+//   - algorithm string
+//   - charsetLengths []int
+func (_e *TaskSplitMock_Expecter) SplitMask(ctx interface{}, algorithm interface{}, charsetLengths interface{}) *TaskSplitMock_SplitMask_Call {
+	return &TaskSplitMock_SplitMask_Call{Call: _e.mock.On("SplitMask", ctx, algorithm, charsetLengths)}
+}
+
+func (_c *TaskSplitMock_SplitMask_Call) Run(run func(ctx context.Context, algorithm string, charsetLengths []int)) *TaskSplitMock_SplitMask_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].([]int))
+	})
+	return _c
+}
+
+func (_c *TaskSplitMock_SplitMask_Call) Return(_a0 infrastructure.KeyPartition, _a1 error) *TaskSplitMock_SplitMask_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *TaskSplitMock_SplitMask_Call) RunAndReturn(run func(context.Context, string, []int) (infrastructure.KeyPartition, error)) *TaskSplitMock_SplitMask_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -23,17 +23,17 @@ func (_m *TaskWithSubtasksMock) EXPECT() *TaskWithSubtasksMock_Expecter {
 	return &TaskWithSubtasksMock_Expecter{mock: &_m.Mock}
 }
 
-// CreateTaskWithSubtasks provides a mock function with given fields: ctx, task
-func (_m *TaskWithSubtasksMock) CreateTaskWithSubtasks(ctx context.Context, task *entity.HashCrackTaskWithSubtasks) error {
-	ret := _m.Called(ctx, task)
+// CreateTaskWithSubtasks provides a mock function with given fields: ctx, task, activeLimit
+func (_m *TaskWithSubtasksMock) CreateTaskWithSubtasks(ctx context.Context, task *entity.HashCrackTaskWithSubtasks, activeLimit int) error {
+	ret := _m.Called(ctx, task, activeLimit)
 
 	if len(ret) == 0 {
 		panic("no return value specified for CreateTaskWithSubtasks")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, *entity.HashCrackTaskWithSubtasks) error); ok {
-		r0 = rf(ctx, task)
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.HashCrackTaskWithSubtasks, int) error); ok {
+		r0 = rf(ctx, task, activeLimit)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -49,13 +49,14 @@ type TaskWithSubtasksMock_CreateTaskWithSubtasks_Call struct {
 // CreateTaskWithSubtasks is a helper method to define mock.On call
 //   - ctx context.Context
 //   - task *entity.HashCrackTaskWithSubtasks
-func (_e *TaskWithSubtasksMock_Expecter) CreateTaskWithSubtasks(ctx interface{}, task interface{}) *TaskWithSubtasksMock_CreateTaskWithSubtasks_Call {
-	return &TaskWithSubtasksMock_CreateTaskWithSubtasks_Call{Call: _e.mock.On("CreateTaskWithSubtasks", ctx, task)}
+//   - activeLimit int
+func (_e *TaskWithSubtasksMock_Expecter) CreateTaskWithSubtasks(ctx interface{}, task interface{}, activeLimit interface{}) *TaskWithSubtasksMock_CreateTaskWithSubtasks_Call {
+	return &TaskWithSubtasksMock_CreateTaskWithSubtasks_Call{Call: _e.mock.On("CreateTaskWithSubtasks", ctx, task, activeLimit)}
 }
 
-func (_c *TaskWithSubtasksMock_CreateTaskWithSubtasks_Call) Run(run func(ctx context.Context, task *entity.HashCrackTaskWithSubtasks)) *TaskWithSubtasksMock_CreateTaskWithSubtasks_Call {
+func (_c *TaskWithSubtasksMock_CreateTaskWithSubtasks_Call) Run(run func(ctx context.Context, task *entity.HashCrackTaskWithSubtasks, activeLimit int)) *TaskWithSubtasksMock_CreateTaskWithSubtasks_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(*entity.HashCrackTaskWithSubtasks))
+		run(args[0].(context.Context), args[1].(*entity.HashCrackTaskWithSubtasks), args[2].(int))
 	})
 	return _c
 }
@@ -65,7 +66,7 @@ func (_c *TaskWithSubtasksMock_CreateTaskWithSubtasks_Call) Return(_a0 error) *T
 	return _c
 }
 
-func (_c *TaskWithSubtasksMock_CreateTaskWithSubtasks_Call) RunAndReturn(run func(context.Context, *entity.HashCrackTaskWithSubtasks) error) *TaskWithSubtasksMock_CreateTaskWithSubtasks_Call {
+func (_c *TaskWithSubtasksMock_CreateTaskWithSubtasks_Call) RunAndReturn(run func(context.Context, *entity.HashCrackTaskWithSubtasks, int) error) *TaskWithSubtasksMock_CreateTaskWithSubtasks_Call {
 	_c.Call.Return(run)
 	return _c
 }
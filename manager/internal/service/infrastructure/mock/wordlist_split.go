@@ -0,0 +1,98 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mock
+
+import (
+	context "context"
+
+	infrastructure "github.com/ptrvsrg/crack-hash/manager/internal/service/infrastructure"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// WordlistSplitMock is an autogenerated mock type for the WordlistSplit type
+type WordlistSplitMock struct {
+	mock.Mock
+}
+
+type WordlistSplitMock_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *WordlistSplitMock) EXPECT() *WordlistSplitMock_Expecter {
+	return &WordlistSplitMock_Expecter{mock: &_m.Mock}
+}
+
+// SplitLines provides a mock function with given fields: ctx, algorithm, lineCount, ruleCount
+func (_m *WordlistSplitMock) SplitLines(ctx context.Context, algorithm string, lineCount int, ruleCount int) ([]infrastructure.LineRange, error) {
+	ret := _m.Called(ctx, algorithm, lineCount, ruleCount)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SplitLines")
+	}
+
+	var r0 []infrastructure.LineRange
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int, int) ([]infrastructure.LineRange, error)); ok {
+		return rf(ctx, algorithm, lineCount, ruleCount)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, int, int) []infrastructure.LineRange); ok {
+		r0 = rf(ctx, algorithm, lineCount, ruleCount)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]infrastructure.LineRange)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, int, int) error); ok {
+		r1 = rf(ctx, algorithm, lineCount, ruleCount)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// WordlistSplitMock_SplitLines_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SplitLines'
+type WordlistSplitMock_SplitLines_Call struct {
+	*mock.Call
+}
+
+// SplitLines is a helper method to define mock.On call
+//   - ctx context.Context
+//   - algorithm string
+//   - lineCount int
+//   - ruleCount int
+func (_e *WordlistSplitMock_Expecter) SplitLines(ctx interface{}, algorithm interface{}, lineCount interface{}, ruleCount interface{}) *WordlistSplitMock_SplitLines_Call {
+	return &WordlistSplitMock_SplitLines_Call{Call: _e.mock.On("SplitLines", ctx, algorithm, lineCount, ruleCount)}
+}
+
+func (_c *WordlistSplitMock_SplitLines_Call) Run(run func(ctx context.Context, algorithm string, lineCount int, ruleCount int)) *WordlistSplitMock_SplitLines_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(int), args[3].(int))
+	})
+	return _c
+}
+
+func (_c *WordlistSplitMock_SplitLines_Call) Return(_a0 []infrastructure.LineRange, _a1 error) *WordlistSplitMock_SplitLines_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *WordlistSplitMock_SplitLines_Call) RunAndReturn(run func(context.Context, string, int, int) ([]infrastructure.LineRange, error)) *WordlistSplitMock_SplitLines_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewWordlistSplitMock creates a new instance of WordlistSplitMock. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewWordlistSplitMock(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *WordlistSplitMock {
+	mock := &WordlistSplitMock{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -8,20 +8,70 @@ import (
 
 var (
 	ErrInvalidAlphabetLength = errors.New("invalid alphabet length")
+	ErrInvalidWordMinLength  = errors.New("invalid word min length")
 	ErrInvalidWordMaxLength  = errors.New("invalid word max length")
+	ErrInvalidKeyspaceSize   = errors.New("invalid keyspace size")
+	ErrInvalidLineCount      = errors.New("invalid line count")
+	ErrInvalidRuleCount      = errors.New("invalid rule count")
+	ErrInvalidMaskLength     = errors.New("invalid mask length")
+	ErrInvalidCharsetLength  = errors.New("invalid charset length")
+	ErrKeyspaceTooLarge      = errors.New("keyspace is too large")
+	ErrTooManyActiveTasks    = errors.New("too many active tasks")
 )
 
+// LineRange is a range of wordlist lines
+type LineRange struct {
+	Offset int
+	Count  int
+}
+
+// KeyPartition is a partition of the keyspace of a brute force or mask task into consecutive chunks,
+// the last chunk may be shorter
+type KeyPartition struct {
+	Size      int
+	ChunkSize int
+}
+
+// PartCount returns the number of chunks of the partition
+func (p KeyPartition) PartCount() int {
+	if p.ChunkSize <= 0 {
+		return 0
+	}
+
+	return (p.Size + p.ChunkSize - 1) / p.ChunkSize
+}
+
+// TaskSplit splits the keyspaces of tasks, the hash algorithm of the task may size the chunks
 type TaskSplit interface {
-	Split(ctx context.Context, wordMaxLength, alphabetLength int) (int, error)
+	Split(ctx context.Context, algorithm string, wordMinLength, wordMaxLength, alphabetLength int) (KeyPartition, error)
+	SplitMask(ctx context.Context, algorithm string, charsetLengths []int) (KeyPartition, error)
+	// SplitKeyspace splits the keyspace of the given number of candidates
+	SplitKeyspace(ctx context.Context, algorithm string, size int) (KeyPartition, error)
+}
+
+// WordlistSplit splits the wordlists of dictionary tasks into ranges of lines
+type WordlistSplit interface {
+	SplitLines(ctx context.Context, algorithm string, lineCount, ruleCount int) ([]LineRange, error)
 }
 
 type TaskWithSubtasks interface {
-	CreateTaskWithSubtasks(ctx context.Context, task *entity.HashCrackTaskWithSubtasks) error
+	// CreateTaskWithSubtasks creates the task with its subtasks, unless the limit of pending and in progress tasks
+	// is reached
+	CreateTaskWithSubtasks(ctx context.Context, task *entity.HashCrackTaskWithSubtasks, activeLimit int) error
 	UpdateTaskWithSubtasks(ctx context.Context, task *entity.HashCrackTaskWithSubtasks) error
 	DeleteTasksWithSubtasks(ctx context.Context, tasks []*entity.HashCrackTaskWithSubtasks) error
 }
 
+// TaskQueue reports the state of the queue of started subtasks
+type TaskQueue interface {
+	// IdleCapacity returns the number of subtasks, which are started by workers at once without waiting in
+	// the queue. It is the number of consumers of the queue not busy with running subtasks, if no subtask waits in it
+	IdleCapacity(ctx context.Context) (int, error)
+}
+
 type Services struct {
 	TaskSplit        TaskSplit
+	WordlistSplit    WordlistSplit
 	TaskWithSubtasks TaskWithSubtasks
+	TaskQueue        TaskQueue
 }
@@ -0,0 +1,30 @@
+package taskqueue
+
+import (
+	"context"
+
+	"github.com/rs/zerolog"
+
+	"github.com/ptrvsrg/crack-hash/manager/internal/service/infrastructure"
+)
+
+type directSvc struct {
+	logger zerolog.Logger
+}
+
+// NewDirectService creates the task queue of the http transport, which sends subtasks to workers directly.
+// The idle capacity of workers is unknown without a broker, so slow subtasks are not split
+func NewDirectService(logger zerolog.Logger) infrastructure.TaskQueue {
+	return &directSvc{
+		logger: logger.With().
+			Str("type", "infrastructure").
+			Str("service", "direct-task-queue").
+			Logger(),
+	}
+}
+
+func (s *directSvc) IdleCapacity(_ context.Context) (int, error) {
+	s.logger.Debug().Msg("idle capacity is unknown for direct task queue")
+
+	return 0, nil
+}
@@ -0,0 +1,63 @@
+package taskqueue
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/rs/zerolog"
+
+	"github.com/ptrvsrg/crack-hash/commonlib/bus/amqp"
+	"github.com/ptrvsrg/crack-hash/manager/internal/persistence/entity"
+	"github.com/ptrvsrg/crack-hash/manager/internal/persistence/repository"
+	"github.com/ptrvsrg/crack-hash/manager/internal/service/infrastructure"
+)
+
+type svc struct {
+	logger      zerolog.Logger
+	ch          *amqp.Channel
+	queue       string
+	subtaskRepo repository.HashCrackSubtask
+}
+
+func NewService(
+	logger zerolog.Logger, ch *amqp.Channel, queue string, subtaskRepo repository.HashCrackSubtask,
+) infrastructure.TaskQueue {
+	return &svc{
+		logger: logger.With().
+			Str("type", "infrastructure").
+			Str("service", "task-queue").
+			Logger(),
+		ch:          ch,
+		queue:       queue,
+		subtaskRepo: subtaskRepo,
+	}
+}
+
+func (s *svc) IdleCapacity(ctx context.Context) (int, error) {
+	s.logger.Debug().Str("queue", s.queue).Msg("inspect task queue")
+
+	queue, err := s.ch.QueueInspect(s.queue)
+	if err != nil {
+		s.logger.Error().Err(err).Stack().Msg("failed to inspect task queue")
+		return 0, fmt.Errorf("failed to inspect task queue: %w", err)
+	}
+
+	s.logger.Debug().
+		Int("messages", queue.Messages).
+		Int("consumers", queue.Consumers).
+		Msg("task queue inspected")
+
+	if queue.Messages > 0 {
+		return 0, nil
+	}
+
+	// Subtasks prefetched by consumers are not counted in the queue messages, so the consumers are busy with
+	// the running subtasks
+	running, err := s.subtaskRepo.CountAllByStatus(ctx, entity.HashCrackSubtaskStatusInProgress)
+	if err != nil {
+		s.logger.Error().Err(err).Stack().Msg("failed to count running subtasks")
+		return 0, fmt.Errorf("failed to count running subtasks: %w", err)
+	}
+
+	return max(0, queue.Consumers-int(running)), nil
+}
@@ -0,0 +1,116 @@
+package adaptive
+
+import (
+	"context"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"github.com/ptrvsrg/crack-hash/manager/config"
+	"github.com/ptrvsrg/crack-hash/manager/internal/persistence/repository"
+	"github.com/ptrvsrg/crack-hash/manager/internal/service/infrastructure"
+	"github.com/ptrvsrg/crack-hash/manager/internal/service/infrastructure/tasksplit/chunkbased"
+)
+
+type svc struct {
+	cfg         config.TaskSplitConfig
+	subtaskRepo repository.HashCrackSubtask
+	fixedSplit  infrastructure.TaskSplit
+	logger      zerolog.Logger
+}
+
+// NewService creates the split service sizing chunks by the speed of workers, so a subtask takes about
+// the target duration. The fixed chunk size is used until the speed is measured
+func NewService(
+	logger zerolog.Logger, cfg config.TaskSplitConfig, subtaskRepo repository.HashCrackSubtask,
+) infrastructure.TaskSplit {
+	return &svc{
+		cfg:         cfg,
+		subtaskRepo: subtaskRepo,
+		fixedSplit:  chunkbased.NewService(logger, cfg.ChunkSize),
+		logger: logger.With().
+			Str("type", "infrastructure").
+			Str("infra-service", "task-split").
+			Str("strategy", "adaptive").
+			Logger(),
+	}
+}
+
+func (s *svc) Split(
+	ctx context.Context, algorithm string, wordMinLength, wordMaxLength, alphabetLength int,
+) (infrastructure.KeyPartition, error) {
+	partition, err := s.fixedSplit.Split(ctx, algorithm, wordMinLength, wordMaxLength, alphabetLength)
+	if err != nil {
+		return partition, err
+	}
+
+	partition.ChunkSize = s.chunkSize(ctx, algorithm, partition.Size)
+
+	s.logger.Info().
+		Int("chunkSize", partition.ChunkSize).
+		Int("numSubtasks", partition.PartCount()).
+		Msg("task split adapted")
+
+	return partition, nil
+}
+
+func (s *svc) SplitMask(
+	ctx context.Context, algorithm string, charsetLengths []int,
+) (infrastructure.KeyPartition, error) {
+	partition, err := s.fixedSplit.SplitMask(ctx, algorithm, charsetLengths)
+	if err != nil {
+		return partition, err
+	}
+
+	partition.ChunkSize = s.chunkSize(ctx, algorithm, partition.Size)
+
+	s.logger.Info().
+		Int("chunkSize", partition.ChunkSize).
+		Int("numSubtasks", partition.PartCount()).
+		Msg("mask split adapted")
+
+	return partition, nil
+}
+
+func (s *svc) SplitKeyspace(ctx context.Context, algorithm string, size int) (infrastructure.KeyPartition, error) {
+	partition, err := s.fixedSplit.SplitKeyspace(ctx, algorithm, size)
+	if err != nil {
+		return partition, err
+	}
+
+	partition.ChunkSize = s.chunkSize(ctx, algorithm, partition.Size)
+
+	s.logger.Info().
+		Int("chunkSize", partition.ChunkSize).
+		Int("numSubtasks", partition.PartCount()).
+		Msg("keyspace split adapted")
+
+	return partition, nil
+}
+
+// chunkSize returns the number of candidates of the hash algorithm checked by a worker during the target duration,
+// limited to split the keyspace of the given size in the minimum number of parts
+func (s *svc) chunkSize(ctx context.Context, algorithm string, size int) int {
+	chunkSize := s.cfg.ChunkSize
+
+	speed, err := s.subtaskRepo.GetAverageSpeed(ctx, algorithm, time.Now().Add(-s.cfg.Adaptive.Window))
+	switch {
+	case err != nil:
+		s.logger.Warn().Err(err).Msg("failed to get average speed, use fixed chunk size")
+	case speed > 0:
+		// The chunk is not larger than the keyspace, so the conversion does not overflow
+		chunkSize = int(min(speed*s.cfg.Adaptive.TargetDuration.Seconds(), float64(max(1, size))))
+	default:
+		s.logger.Debug().Msg("speed is not measured, use fixed chunk size")
+	}
+
+	s.logger.Debug().
+		Float64("speed", speed).
+		Int("chunkSize", chunkSize).
+		Msg("chunk size by speed")
+
+	// Keep small tasks parallel, but not split in tiny parts
+	chunkSize = min(chunkSize, (size+s.cfg.Adaptive.MinParts-1)/s.cfg.Adaptive.MinParts)
+
+	return max(chunkSize, s.cfg.Adaptive.MinChunkSize)
+}
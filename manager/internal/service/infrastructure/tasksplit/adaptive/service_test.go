@@ -0,0 +1,180 @@
+package adaptive_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ptrvsrg/crack-hash/commonlib/logging"
+	"github.com/ptrvsrg/crack-hash/manager/config"
+	repomock "github.com/ptrvsrg/crack-hash/manager/internal/persistence/repository/mock"
+	"github.com/ptrvsrg/crack-hash/manager/internal/service/infrastructure"
+	"github.com/ptrvsrg/crack-hash/manager/internal/service/infrastructure/tasksplit/adaptive"
+)
+
+var (
+	cfg = config.TaskSplitConfig{
+		Strategy:  "adaptive",
+		ChunkSize: 1000,
+		MaxParts:  100,
+		Adaptive: config.TaskAdaptiveSplitConfig{
+			TargetDuration: 10 * time.Second,
+			Window:         time.Hour,
+			MinChunkSize:   10,
+			MinParts:       4,
+		},
+	}
+
+	ctx       = context.Background()
+	algorithm = "MD5"
+)
+
+func init() {
+	logging.Setup(true)
+}
+
+func newServiceWithSpeed(speed float64, err error) infrastructure.TaskSplit {
+	subtaskRepo := new(repomock.HashCrackSubtaskMock)
+	subtaskRepo.EXPECT().GetAverageSpeed(ctx, algorithm, mock.Anything).Return(speed, err).Maybe()
+
+	return adaptive.NewService(log.Logger, cfg, subtaskRepo)
+}
+
+func TestSplit(t *testing.T) {
+	t.Run(
+		"Speed measured", func(t *testing.T) {
+			// Arrange
+			svc := newServiceWithSpeed(2, nil)
+
+			// Act
+			partition, err := svc.Split(ctx, algorithm, 1, 3, 5)
+
+			// Assert
+			require.NoError(t, err)
+			assert.Equal(t, infrastructure.KeyPartition{Size: 155, ChunkSize: 20}, partition) // 2 words/s * 10s
+		},
+	)
+
+	t.Run(
+		"Speed not measured", func(t *testing.T) {
+			// Arrange
+			svc := newServiceWithSpeed(0, nil)
+
+			// Act
+			partition, err := svc.Split(ctx, algorithm, 1, 3, 5)
+
+			// Assert
+			require.NoError(t, err)
+			assert.Equal(t, 39, partition.ChunkSize) // Fixed chunk is limited to split 155 words in 4 parts
+			assert.Equal(t, 4, partition.PartCount())
+		},
+	)
+
+	t.Run(
+		"Speed error", func(t *testing.T) {
+			// Arrange
+			svc := newServiceWithSpeed(0, errors.New("error"))
+
+			// Act
+			partition, err := svc.Split(ctx, algorithm, 1, 3, 5)
+
+			// Assert
+			require.NoError(t, err)
+			assert.Equal(t, 39, partition.ChunkSize)
+		},
+	)
+
+	t.Run(
+		"Small task", func(t *testing.T) {
+			// Arrange
+			svc := newServiceWithSpeed(1_000_000, nil)
+
+			// Act
+			partition, err := svc.Split(ctx, algorithm, 1, 1, 5)
+
+			// Assert
+			require.NoError(t, err)
+			assert.Equal(t, infrastructure.KeyPartition{Size: 5, ChunkSize: 10}, partition)
+			assert.Equal(t, 1, partition.PartCount())
+		},
+	)
+
+	t.Run(
+		"Invalid input", func(t *testing.T) {
+			// Arrange
+			svc := newServiceWithSpeed(2, nil)
+
+			// Act
+			partition, err := svc.Split(ctx, algorithm, 0, 3, 5)
+
+			// Assert
+			require.ErrorIs(t, err, infrastructure.ErrInvalidWordMinLength)
+			assert.Equal(t, 0, partition.PartCount())
+		},
+	)
+}
+
+func TestSplitKeyspace(t *testing.T) {
+	t.Run(
+		"Success", func(t *testing.T) {
+			// Arrange
+			svc := newServiceWithSpeed(5, nil)
+
+			// Act
+			partition, err := svc.SplitKeyspace(ctx, algorithm, 200)
+
+			// Assert
+			require.NoError(t, err)
+			assert.Equal(t, infrastructure.KeyPartition{Size: 200, ChunkSize: 50}, partition) // 5 candidates/s * 10s
+		},
+	)
+
+	t.Run(
+		"Invalid size", func(t *testing.T) {
+			// Arrange
+			svc := newServiceWithSpeed(5, nil)
+
+			// Act
+			partition, err := svc.SplitKeyspace(ctx, algorithm, 0)
+
+			// Assert
+			require.ErrorIs(t, err, infrastructure.ErrInvalidKeyspaceSize)
+			assert.Equal(t, 0, partition.PartCount())
+		},
+	)
+}
+
+func TestSplitMask(t *testing.T) {
+	t.Run(
+		"Success", func(t *testing.T) {
+			// Arrange
+			svc := newServiceWithSpeed(3, nil)
+
+			// Act
+			partition, err := svc.SplitMask(ctx, algorithm, []int{26, 10})
+
+			// Assert
+			require.NoError(t, err)
+			assert.Equal(t, infrastructure.KeyPartition{Size: 260, ChunkSize: 30}, partition)
+		},
+	)
+
+	t.Run(
+		"Empty mask", func(t *testing.T) {
+			// Arrange
+			svc := newServiceWithSpeed(3, nil)
+
+			// Act
+			_, err := svc.SplitMask(ctx, algorithm, nil)
+
+			// Assert
+			require.ErrorIs(t, err, infrastructure.ErrInvalidMaskLength)
+		},
+	)
+}
@@ -2,8 +2,8 @@ package chunkbased
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"math"
 
 	"github.com/rs/zerolog"
 
@@ -26,34 +26,109 @@ func NewService(logger zerolog.Logger, chunkSize int) infrastructure.TaskSplit {
 	}
 }
 
-func (s *svc) Split(_ context.Context, wordMaxLength, alphabetLength int) (int, error) {
+func (s *svc) Split(
+	_ context.Context, _ string, wordMinLength, wordMaxLength, alphabetLength int,
+) (infrastructure.KeyPartition, error) {
 	s.logger.Info().
+		Int("wordMinLength", wordMinLength).
 		Int("wordMaxLength", wordMaxLength).
 		Int("alphabetLength", alphabetLength).
 		Msg("split task")
 
 	// Validate input
-	if wordMaxLength <= -1 {
-		return 0, infrastructure.ErrInvalidWordMaxLength
+	if wordMinLength <= 0 {
+		return infrastructure.KeyPartition{}, infrastructure.ErrInvalidWordMinLength
+	}
+
+	if wordMaxLength < wordMinLength {
+		return infrastructure.KeyPartition{}, infrastructure.ErrInvalidWordMaxLength
 	}
 
 	if alphabetLength <= -1 {
-		return 0, infrastructure.ErrInvalidAlphabetLength
+		return infrastructure.KeyPartition{}, infrastructure.ErrInvalidAlphabetLength
 	}
 
-	// Calculate word count
+	// Calculate word count, the words shorter than the min length are skipped
 	wordCount, err := helper.SumOfGeomSeries(alphabetLength, alphabetLength, wordMaxLength)
 	if err != nil {
 		s.logger.Error().Err(err).Stack().Msg("failed to calculate number of subtasks")
-		return 0, fmt.Errorf("failed to calculate number of subtasks: %w", err)
+
+		if errors.Is(err, helper.ErrIntLimits) {
+			return infrastructure.KeyPartition{}, fmt.Errorf("%w: %w", infrastructure.ErrKeyspaceTooLarge, err)
+		}
+		return infrastructure.KeyPartition{}, fmt.Errorf("failed to calculate number of subtasks: %w", err)
+	}
+
+	skippedCount, err := helper.SumOfGeomSeries(alphabetLength, alphabetLength, wordMinLength-1)
+	if err != nil {
+		s.logger.Error().Err(err).Stack().Msg("failed to calculate number of subtasks")
+		return infrastructure.KeyPartition{}, fmt.Errorf("failed to calculate number of subtasks: %w", err)
+	}
+	wordCount -= skippedCount
+
+	// Calculate number of subtasks
+	partition := infrastructure.KeyPartition{Size: wordCount, ChunkSize: s.chunkSize}
+
+	s.logger.Info().
+		Int("numSubtasks", partition.PartCount()).
+		Msg("number of subtasks calculated")
+
+	return partition, nil
+}
+
+func (s *svc) SplitMask(_ context.Context, _ string, charsetLengths []int) (infrastructure.KeyPartition, error) {
+	s.logger.Info().
+		Ints("charsetLengths", charsetLengths).
+		Msg("split mask")
+
+	// Validate input
+	if len(charsetLengths) == 0 {
+		return infrastructure.KeyPartition{}, infrastructure.ErrInvalidMaskLength
+	}
+
+	for _, length := range charsetLengths {
+		if length <= 0 {
+			return infrastructure.KeyPartition{}, infrastructure.ErrInvalidCharsetLength
+		}
+	}
+
+	// Calculate word count, every position of the mask multiplies it by the length of its charset
+	wordCount, err := helper.Product(charsetLengths...)
+	if err != nil {
+		s.logger.Error().Err(err).Stack().Msg("failed to calculate number of subtasks")
+
+		if errors.Is(err, helper.ErrIntLimits) {
+			return infrastructure.KeyPartition{}, fmt.Errorf("%w: %w", infrastructure.ErrKeyspaceTooLarge, err)
+		}
+		return infrastructure.KeyPartition{}, fmt.Errorf("failed to calculate number of subtasks: %w", err)
+	}
+
+	// Calculate number of subtasks
+	partition := infrastructure.KeyPartition{Size: wordCount, ChunkSize: s.chunkSize}
+
+	s.logger.Info().
+		Int("numSubtasks", partition.PartCount()).
+		Msg("number of subtasks calculated")
+
+	return partition, nil
+}
+
+func (s *svc) SplitKeyspace(_ context.Context, _ string, size int) (infrastructure.KeyPartition, error) {
+	s.logger.Info().
+		Int("size", size).
+		Msg("split keyspace")
+
+	// Validate input
+	if size <= 0 {
+		return infrastructure.KeyPartition{}, infrastructure.ErrInvalidKeyspaceSize
 	}
 
 	// Calculate number of subtasks
-	numSubtasks := int(math.Ceil(float64(wordCount) / float64(s.chunkSize)))
+	partition := infrastructure.KeyPartition{Size: size, ChunkSize: s.chunkSize}
 
 	s.logger.Info().
-		Int("numSubtasks", numSubtasks).
+		Int("numSubtasks", partition.PartCount()).
 		Msg("number of subtasks calculated")
 
-	return numSubtasks, nil
+	return partition, nil
 }
@@ -17,7 +17,8 @@ import (
 var (
 	svc infrastructure.TaskSplit
 
-	ctx = context.Background()
+	ctx       = context.Background()
+	algorithm = "MD5"
 )
 
 func init() {
@@ -38,11 +39,36 @@ func TestSplit(t *testing.T) {
 			alphabetLength := 5
 
 			// Act
-			numSubtasks, err := svc.Split(ctx, wordMaxLength, alphabetLength)
+			partition, err := svc.Split(ctx, algorithm, 1, wordMaxLength, alphabetLength)
 
 			// Assert
 			require.NoError(t, err)
-			assert.Equal(t, 1, numSubtasks) // Ожидаемое количество подзадач
+			assert.Equal(t, 1, partition.PartCount()) // Ожидаемое количество подзадач
+		},
+	)
+
+	t.Run(
+		"Min length", func(t *testing.T) {
+			// Arrange
+			svc := chunkbased.NewService(log.Logger, 10)
+
+			// Act
+			partition, err := svc.Split(ctx, algorithm, 2, 3, 5)
+
+			// Assert
+			require.NoError(t, err)
+			assert.Equal(t, infrastructure.KeyPartition{Size: 150, ChunkSize: 10}, partition) // 5^2 + 5^3 = 150 words
+		},
+	)
+
+	t.Run(
+		"Keyspace too large", func(t *testing.T) {
+			// Act
+			partition, err := svc.Split(ctx, algorithm, 1, 20, 95)
+
+			// Assert
+			require.ErrorIs(t, err, infrastructure.ErrKeyspaceTooLarge)
+			assert.Equal(t, 0, partition.PartCount())
 		},
 	)
 
@@ -53,11 +79,11 @@ func TestSplit(t *testing.T) {
 			alphabetLength := 26
 
 			// Act
-			numSubtasks, err := svc.Split(ctx, wordMaxLength, alphabetLength)
+			partition, err := svc.Split(ctx, algorithm, 1, wordMaxLength, alphabetLength)
 
 			// Assert
 			require.NoError(t, err)
-			assert.Greater(t, numSubtasks, 1) // Количество подзадач должно быть больше 1
+			assert.Greater(t, partition.PartCount(), 1) // Количество подзадач должно быть больше 1
 		},
 	)
 
@@ -70,12 +96,24 @@ func TestSplit(t *testing.T) {
 					alphabetLength := 5
 
 					// Act
-					numSubtasks, err := svc.Split(ctx, wordMaxLength, alphabetLength)
+					partition, err := svc.Split(ctx, algorithm, 1, wordMaxLength, alphabetLength)
 
 					// Assert
 					require.Error(t, err)
 					assert.ErrorIs(t, err, infrastructure.ErrInvalidWordMaxLength)
-					assert.Equal(t, 0, numSubtasks)
+					assert.Equal(t, 0, partition.PartCount())
+				},
+			)
+
+			t.Run(
+				"Invalid wordMinLength", func(t *testing.T) {
+					// Act
+					partition, err := svc.Split(ctx, algorithm, 0, 3, 5)
+
+					// Assert
+					require.Error(t, err)
+					assert.ErrorIs(t, err, infrastructure.ErrInvalidWordMinLength)
+					assert.Equal(t, 0, partition.PartCount())
 				},
 			)
 
@@ -86,14 +124,101 @@ func TestSplit(t *testing.T) {
 					alphabetLength := -1
 
 					// Act
-					numSubtasks, err := svc.Split(ctx, wordMaxLength, alphabetLength)
+					partition, err := svc.Split(ctx, algorithm, 1, wordMaxLength, alphabetLength)
 
 					// Assert
 					require.Error(t, err)
 					assert.ErrorIs(t, err, infrastructure.ErrInvalidAlphabetLength)
-					assert.Equal(t, 0, numSubtasks)
+					assert.Equal(t, 0, partition.PartCount())
 				},
 			)
 		},
 	)
 }
+
+func TestSplitKeyspace(t *testing.T) {
+	t.Run(
+		"Success", func(t *testing.T) {
+			// Arrange
+			svc := chunkbased.NewService(log.Logger, 4)
+
+			// Act
+			partition, err := svc.SplitKeyspace(ctx, algorithm, 10)
+
+			// Assert
+			require.NoError(t, err)
+			assert.Equal(t, infrastructure.KeyPartition{Size: 10, ChunkSize: 4}, partition)
+			assert.Equal(t, 3, partition.PartCount())
+		},
+	)
+
+	t.Run(
+		"Invalid size", func(t *testing.T) {
+			// Act
+			partition, err := svc.SplitKeyspace(ctx, algorithm, 0)
+
+			// Assert
+			require.ErrorIs(t, err, infrastructure.ErrInvalidKeyspaceSize)
+			assert.Equal(t, 0, partition.PartCount())
+		},
+	)
+}
+
+func TestSplitMask(t *testing.T) {
+	t.Run(
+		"Success", func(t *testing.T) {
+			// Arrange
+			svc := chunkbased.NewService(log.Logger, 100)
+
+			// Act
+			partition, err := svc.SplitMask(ctx, algorithm, []int{26, 26, 10})
+
+			// Assert
+			require.NoError(t, err)
+			assert.Equal(t, 68, partition.PartCount()) // 26 * 26 * 10 = 6760 words
+		},
+	)
+
+	t.Run(
+		"Keyspace too large", func(t *testing.T) {
+			// Arrange
+			charsetLengths := make([]int, 12)
+			for i := range charsetLengths {
+				charsetLengths[i] = 95
+			}
+
+			// Act
+			partition, err := svc.SplitMask(ctx, algorithm, charsetLengths)
+
+			// Assert
+			require.ErrorIs(t, err, infrastructure.ErrKeyspaceTooLarge)
+			assert.Equal(t, 0, partition.PartCount())
+		},
+	)
+
+	t.Run(
+		"Invalid input", func(t *testing.T) {
+			cases := []struct {
+				Name           string
+				CharsetLengths []int
+				ExpectedErr    error
+			}{
+				{"Empty mask", nil, infrastructure.ErrInvalidMaskLength},
+				{"Empty charset", []int{26, 0}, infrastructure.ErrInvalidCharsetLength},
+			}
+
+			for _, c := range cases {
+				t.Run(
+					c.Name, func(t *testing.T) {
+						// Act
+						partition, err := svc.SplitMask(ctx, algorithm, c.CharsetLengths)
+
+						// Assert
+						require.ErrorIs(t, err, c.ExpectedErr)
+						assert.Equal(t, 0, partition.PartCount())
+					},
+				)
+			}
+		},
+	)
+}
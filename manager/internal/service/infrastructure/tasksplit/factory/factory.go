@@ -4,21 +4,35 @@ import (
 	"github.com/rs/zerolog"
 
 	"github.com/ptrvsrg/crack-hash/manager/config"
+	"github.com/ptrvsrg/crack-hash/manager/internal/persistence/repository"
 	"github.com/ptrvsrg/crack-hash/manager/internal/service/infrastructure"
+	"github.com/ptrvsrg/crack-hash/manager/internal/service/infrastructure/tasksplit/adaptive"
 	"github.com/ptrvsrg/crack-hash/manager/internal/service/infrastructure/tasksplit/chunkbased"
+	"github.com/ptrvsrg/crack-hash/manager/internal/service/infrastructure/tasksplit/wordlist"
 )
 
 type Strategy string
 
 const (
 	StrategyChunkBased Strategy = "chunk-based"
+	StrategyAdaptive   Strategy = "adaptive"
 )
 
-func NewService(logger zerolog.Logger, cfg config.TaskSplitConfig) infrastructure.TaskSplit {
+func NewService(
+	logger zerolog.Logger, cfg config.TaskSplitConfig, subtaskRepo repository.HashCrackSubtask,
+) infrastructure.TaskSplit {
 	switch Strategy(cfg.Strategy) {
+	case StrategyAdaptive:
+		return adaptive.NewService(logger, cfg, subtaskRepo)
 	case StrategyChunkBased:
 		fallthrough
 	default:
 		return chunkbased.NewService(logger, cfg.ChunkSize)
 	}
 }
+
+// NewWordlistService creates the split service of dictionary tasks, the candidates of wordlist lines are sized
+// by the key split strategy
+func NewWordlistService(logger zerolog.Logger, keySplit infrastructure.TaskSplit) infrastructure.WordlistSplit {
+	return wordlist.NewService(logger, keySplit)
+}
@@ -0,0 +1,83 @@
+package wordlist
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/rs/zerolog"
+
+	"github.com/ptrvsrg/crack-hash/manager/internal/helper"
+	"github.com/ptrvsrg/crack-hash/manager/internal/service/infrastructure"
+)
+
+type svc struct {
+	keySplit infrastructure.TaskSplit
+	logger   zerolog.Logger
+}
+
+// NewService creates the split service of wordlists. Every line yields a candidate per rule, so the wordlist
+// is a keyspace of lines multiplied by rules, which is sized by the key split strategy and split by lines
+func NewService(logger zerolog.Logger, keySplit infrastructure.TaskSplit) infrastructure.WordlistSplit {
+	return &svc{
+		keySplit: keySplit,
+		logger: logger.With().
+			Str("type", "infrastructure").
+			Str("infra-service", "task-split").
+			Str("strategy", "wordlist").
+			Logger(),
+	}
+}
+
+func (s *svc) SplitLines(
+	ctx context.Context, algorithm string, lineCount, ruleCount int,
+) ([]infrastructure.LineRange, error) {
+	s.logger.Info().
+		Int("lineCount", lineCount).
+		Int("ruleCount", ruleCount).
+		Msg("split wordlist")
+
+	// Validate input
+	if lineCount <= 0 {
+		return nil, infrastructure.ErrInvalidLineCount
+	}
+	if ruleCount <= 0 {
+		return nil, infrastructure.ErrInvalidRuleCount
+	}
+
+	// Calculate candidate count
+	candidateCount, err := helper.Product(lineCount, ruleCount)
+	if err != nil {
+		s.logger.Error().Err(err).Stack().Msg("failed to calculate number of subtasks")
+
+		if errors.Is(err, helper.ErrIntLimits) {
+			return nil, fmt.Errorf("%w: %w", infrastructure.ErrKeyspaceTooLarge, err)
+		}
+		return nil, fmt.Errorf("failed to calculate number of subtasks: %w", err)
+	}
+
+	partition, err := s.keySplit.SplitKeyspace(ctx, algorithm, candidateCount)
+	if err != nil {
+		return nil, fmt.Errorf("failed to split keyspace: %w", err)
+	}
+
+	// A chunk holds fewer lines to keep the keyspace of subtasks
+	chunkLines := max(1, partition.ChunkSize/ruleCount)
+
+	// Calculate line ranges
+	ranges := make([]infrastructure.LineRange, 0, (lineCount+chunkLines-1)/chunkLines)
+	for offset := 0; offset < lineCount; offset += chunkLines {
+		ranges = append(
+			ranges, infrastructure.LineRange{
+				Offset: offset,
+				Count:  min(chunkLines, lineCount-offset),
+			},
+		)
+	}
+
+	s.logger.Info().
+		Int("numSubtasks", len(ranges)).
+		Msg("number of subtasks calculated")
+
+	return ranges, nil
+}
@@ -0,0 +1,140 @@
+package wordlist_test
+
+import (
+	"context"
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/rs/zerolog/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ptrvsrg/crack-hash/commonlib/logging"
+	"github.com/ptrvsrg/crack-hash/manager/internal/service/infrastructure"
+	infrasvcmock "github.com/ptrvsrg/crack-hash/manager/internal/service/infrastructure/mock"
+	"github.com/ptrvsrg/crack-hash/manager/internal/service/infrastructure/tasksplit/chunkbased"
+	"github.com/ptrvsrg/crack-hash/manager/internal/service/infrastructure/tasksplit/wordlist"
+)
+
+var (
+	ctx       = context.Background()
+	algorithm = "MD5"
+)
+
+func init() {
+	logging.Setup(true)
+}
+
+func TestSplitLines(t *testing.T) {
+	t.Run(
+		"Success", func(t *testing.T) {
+			// Arrange
+			svc := wordlist.NewService(log.Logger, chunkbased.NewService(log.Logger, 4))
+
+			// Act
+			ranges, err := svc.SplitLines(ctx, algorithm, 10, 1)
+
+			// Assert
+			require.NoError(t, err)
+			assert.Equal(
+				t, []infrastructure.LineRange{
+					{Offset: 0, Count: 4},
+					{Offset: 4, Count: 4},
+					{Offset: 8, Count: 2},
+				}, ranges,
+			)
+		},
+	)
+
+	t.Run(
+		"Success - with rules", func(t *testing.T) {
+			// Arrange
+			svc := wordlist.NewService(log.Logger, chunkbased.NewService(log.Logger, 4))
+
+			// Act
+			ranges, err := svc.SplitLines(ctx, algorithm, 5, 2)
+
+			// Assert
+			require.NoError(t, err)
+			assert.Equal(
+				t, []infrastructure.LineRange{
+					{Offset: 0, Count: 2},
+					{Offset: 2, Count: 2},
+					{Offset: 4, Count: 1},
+				}, ranges,
+			)
+		},
+	)
+
+	t.Run(
+		"Success - sized by key split", func(t *testing.T) {
+			// Arrange
+			keySplit := new(infrasvcmock.TaskSplitMock)
+			svc := wordlist.NewService(log.Logger, keySplit)
+
+			keySplit.EXPECT().SplitKeyspace(ctx, algorithm, 200).
+				Return(infrastructure.KeyPartition{Size: 200, ChunkSize: 50}, nil).Once()
+
+			// Act
+			ranges, err := svc.SplitLines(ctx, algorithm, 100, 2)
+
+			// Assert
+			require.NoError(t, err)
+			assert.Equal(
+				t, []infrastructure.LineRange{
+					{Offset: 0, Count: 25},
+					{Offset: 25, Count: 25},
+					{Offset: 50, Count: 25},
+					{Offset: 75, Count: 25},
+				}, ranges,
+			)
+			keySplit.AssertExpectations(t)
+		},
+	)
+
+	t.Run(
+		"Key split error", func(t *testing.T) {
+			// Arrange
+			keySplit := new(infrasvcmock.TaskSplitMock)
+			svc := wordlist.NewService(log.Logger, keySplit)
+			expectedErr := errors.New("split error")
+
+			keySplit.EXPECT().SplitKeyspace(ctx, algorithm, 10).Return(infrastructure.KeyPartition{}, expectedErr).Once()
+
+			// Act
+			ranges, err := svc.SplitLines(ctx, algorithm, 10, 1)
+
+			// Assert
+			require.ErrorIs(t, err, expectedErr)
+			assert.Nil(t, ranges)
+		},
+	)
+
+	testCases := []struct {
+		name        string
+		lineCount   int
+		ruleCount   int
+		expectedErr error
+	}{
+		{"Invalid line count", 0, 1, infrastructure.ErrInvalidLineCount},
+		{"Invalid rule count", 10, 0, infrastructure.ErrInvalidRuleCount},
+		{"Keyspace too large", math.MaxInt, 2, infrastructure.ErrKeyspaceTooLarge},
+	}
+
+	for _, tc := range testCases {
+		t.Run(
+			tc.name, func(t *testing.T) {
+				// Arrange
+				svc := wordlist.NewService(log.Logger, chunkbased.NewService(log.Logger, 4))
+
+				// Act
+				ranges, err := svc.SplitLines(ctx, algorithm, tc.lineCount, tc.ruleCount)
+
+				// Assert
+				require.ErrorIs(t, err, tc.expectedErr)
+				assert.Nil(t, ranges)
+			},
+		)
+	}
+}
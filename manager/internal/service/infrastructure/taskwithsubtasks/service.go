@@ -29,10 +29,33 @@ func NewService(
 	}
 }
 
-func (s *svc) CreateTaskWithSubtasks(ctx context.Context, task *entity.HashCrackTaskWithSubtasks) error {
-	s.logger.Debug().Str("id", task.ObjectID.Hex()).Msg("create task with subtasks")
+func (s *svc) CreateTaskWithSubtasks(
+	ctx context.Context, task *entity.HashCrackTaskWithSubtasks, activeLimit int,
+) error {
+	s.logger.Debug().Str("id", task.ObjectID.Hex()).Int("active-limit", activeLimit).Msg("create task with subtasks")
 
 	_, err := s.taskRepo.WithTransaction(ctx, func(ctx context.Context) (any, error) {
+		// Check limit of active tasks, the lock keeps concurrent creations from exceeding it
+		if err := s.taskRepo.LockCreation(ctx); err != nil {
+			s.logger.Error().Err(err).Stack().Msg("failed to lock creation of tasks")
+			return nil, fmt.Errorf("failed to lock creation of tasks: %w", err)
+		}
+
+		activeCount, err := s.taskRepo.CountAllByStatuses(
+			ctx, []entity.HashCrackTaskStatus{entity.HashCrackTaskStatusPending, entity.HashCrackTaskStatusInProgress},
+		)
+		if err != nil {
+			s.logger.Error().Err(err).Stack().Msg("failed to count active tasks")
+			return nil, fmt.Errorf("failed to count active tasks: %w", err)
+		}
+
+		if activeCount >= int64(activeLimit) {
+			s.logger.Warn().Int64("active-count", activeCount).Msg("too many active tasks")
+			return nil, fmt.Errorf(
+				"%w: %d active tasks, limit is %d", infrastructure.ErrTooManyActiveTasks, activeCount, activeLimit,
+			)
+		}
+
 		if err := s.taskRepo.Create(ctx, task.ToHashCrackTask()); err != nil {
 			s.logger.Error().Err(err).Stack().Msg("failed to create task")
 			return nil, fmt.Errorf("failed to create task: %w", err)
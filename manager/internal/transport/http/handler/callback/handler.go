@@ -0,0 +1,111 @@
+package callback
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog"
+
+	"github.com/ptrvsrg/crack-hash/commonlib/http/handler"
+	"github.com/ptrvsrg/crack-hash/commonlib/http/helper"
+	"github.com/ptrvsrg/crack-hash/manager/internal/service/domain"
+	"github.com/ptrvsrg/crack-hash/manager/pkg/message"
+	_ "github.com/ptrvsrg/crack-hash/manager/pkg/model"
+)
+
+type hdlr struct {
+	logger    zerolog.Logger
+	taskSvc   domain.HashCrackTask
+	workerSvc domain.Worker
+}
+
+// NewHandler creates the handler of callbacks of workers, which replaces the AMQP consumers
+// for the http transport
+func NewHandler(logger zerolog.Logger, taskSvc domain.HashCrackTask, workerSvc domain.Worker) handler.Handler {
+	return &hdlr{
+		logger:    logger.With().Str("handler", "callback").Logger(),
+		taskSvc:   taskSvc,
+		workerSvc: workerSvc,
+	}
+}
+
+func (h *hdlr) RegisterRoutes(r *gin.Engine) {
+	h.logger.Debug().Msg("register routes")
+
+	inAPI := r.Group("/internal/v1")
+	{
+		inAPI.POST("/tasks/results", h.handleSaveTaskResult)
+		inAPI.POST("/workers/heartbeats", h.handleSaveWorkerHeartbeat)
+	}
+}
+
+// handleSaveTaskResult godoc
+//
+//	@Id				SaveTaskResult
+//	@Summary	    Save result of subtask
+//	@Description	Callback of a worker for saving the progress or the result of a subtask in the http transport
+//	@Tags			Callback API
+//	@Accept			application/json
+//	@Produce		application/json
+//	@Param			input	body	message.HashCrackTaskResult	true	"Result of subtask"
+//	@Success		204
+//	@Failure		400 {object} model.ErrorOutput
+//	@Failure		500 {object} model.ErrorOutput
+//	@Router			/internal/v1/tasks/results [post]
+func (h *hdlr) handleSaveTaskResult(ctx *gin.Context) {
+	h.logger.Debug().Msg("handle save task result")
+
+	input := &message.HashCrackTaskResult{}
+	if err := ctx.ShouldBindJSON(input); err != nil {
+		_ = helper.ErrorWithStatus(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	// Results of cancelled, finished and unknown subtasks are dropped like in the AMQP consumer,
+	// so the worker does not retry them
+	err := h.taskSvc.SaveResultSubtask(ctx, input)
+	if err != nil && !errors.Is(err, domain.ErrTaskNotFound) && !errors.Is(err, domain.ErrInvalidRequestID) &&
+		!errors.Is(err, domain.ErrTaskCancelled) && !errors.Is(err, domain.ErrSubtaskSkipped) &&
+		!errors.Is(err, domain.ErrTaskFinishedByTimeout) && !errors.Is(err, domain.ErrStaleAttempt) {
+		_ = helper.ErrorWithStatus(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// handleSaveWorkerHeartbeat godoc
+//
+//	@Id				SaveWorkerHeartbeat
+//	@Summary	    Save heartbeat of worker
+//	@Description	Callback of a worker for registration and heartbeats in the http transport
+//	@Tags			Callback API
+//	@Accept			application/json
+//	@Produce		application/json
+//	@Param			input	body	message.WorkerHeartbeat	true	"Heartbeat of worker"
+//	@Success		204
+//	@Failure		400 {object} model.ErrorOutput
+//	@Failure		500 {object} model.ErrorOutput
+//	@Router			/internal/v1/workers/heartbeats [post]
+func (h *hdlr) handleSaveWorkerHeartbeat(ctx *gin.Context) {
+	h.logger.Debug().Msg("handle save worker heartbeat")
+
+	input := &message.WorkerHeartbeat{}
+	if err := ctx.ShouldBindJSON(input); err != nil {
+		_ = helper.ErrorWithStatus(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	if err := h.workerSvc.SaveHeartbeat(ctx, input); err != nil {
+		if errors.Is(err, domain.ErrUnsupportedVersion) {
+			_ = helper.ErrorWithStatus(ctx, http.StatusBadRequest, err)
+			return
+		}
+
+		_ = helper.ErrorWithStatus(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
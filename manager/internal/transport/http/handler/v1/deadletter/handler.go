@@ -0,0 +1,151 @@
+package deadletter
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog"
+
+	"github.com/ptrvsrg/crack-hash/commonlib/http/handler"
+	"github.com/ptrvsrg/crack-hash/commonlib/http/helper"
+	"github.com/ptrvsrg/crack-hash/manager/internal/service/domain"
+	"github.com/ptrvsrg/crack-hash/manager/pkg/model"
+)
+
+type hdlr struct {
+	logger zerolog.Logger
+	svc    domain.DeadLetter
+}
+
+func NewHandler(logger zerolog.Logger, svc domain.DeadLetter) handler.Handler {
+	return &hdlr{
+		logger: logger.With().Str("handler", "dead-letter").Logger(),
+		svc:    svc,
+	}
+}
+
+func (h *hdlr) RegisterRoutes(r *gin.Engine) {
+	h.logger.Debug().Msg("register routes")
+
+	adminAPI := r.Group("/v1/admin/dead-letters")
+	{
+		adminAPI.GET("", h.handleGetDeadLetters)
+		adminAPI.GET("/:id", h.handleGetDeadLetter)
+		adminAPI.POST("/:id/replay", h.handleReplayDeadLetter)
+		adminAPI.DELETE("/:id", h.handleDeleteDeadLetter)
+	}
+}
+
+// handleGetDeadLetters godoc
+//
+//	@Id				GetDeadLetters
+//	@Summary	    Get dead letters
+//	@Description	Request for getting messages, which consumers of the manager and workers failed to handle
+//	@Tags			Dead Letter API
+//	@Produce		application/json
+//	@Param			limit	query	int	false	"Limit"
+//	@Param			offset	query	int	false	"Offset"
+//	@Success		200 {object} model.DeadLettersOutput
+//	@Failure		400 {object} model.ErrorOutput
+//	@Failure		500 {object} model.ErrorOutput
+//	@Router			/v1/admin/dead-letters [get]
+func (h *hdlr) handleGetDeadLetters(c *gin.Context) {
+	h.logger.Debug().Msg("handle get dead letters")
+
+	input := &model.DeadLettersInput{}
+	if err := c.ShouldBindQuery(input); err != nil {
+		_ = helper.ErrorWithStatus(c, http.StatusBadRequest, err)
+		return
+	}
+
+	output, err := h.svc.GetDeadLetters(c, input.Limit, input.Offset)
+	if err != nil {
+		_ = helper.ErrorWithStatus(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(200, output)
+}
+
+// handleGetDeadLetter godoc
+//
+//	@Id				GetDeadLetter
+//	@Summary	    Get dead letter
+//	@Description	Request for getting dead letter
+//	@Tags			Dead Letter API
+//	@Produce		application/json
+//	@Param			id	path	string	true	"Dead letter ID"
+//	@Success		200 {object} model.DeadLetterOutput
+//	@Failure		400 {object} model.ErrorOutput
+//	@Failure		404 {object} model.ErrorOutput
+//	@Failure		500 {object} model.ErrorOutput
+//	@Router			/v1/admin/dead-letters/{id} [get]
+func (h *hdlr) handleGetDeadLetter(c *gin.Context) {
+	h.logger.Debug().Msg("handle get dead letter")
+
+	output, err := h.svc.GetDeadLetter(c, c.Param("id"))
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(200, output)
+}
+
+// handleReplayDeadLetter godoc
+//
+//	@Id				ReplayDeadLetter
+//	@Summary	    Replay dead letter
+//	@Description	Request for publishing dead letter to the queue of the failed consumer again, the dead letter is deleted
+//	@Tags			Dead Letter API
+//	@Param			id	path	string	true	"Dead letter ID"
+//	@Success		204
+//	@Failure		400 {object} model.ErrorOutput
+//	@Failure		404 {object} model.ErrorOutput
+//	@Failure		500 {object} model.ErrorOutput
+//	@Router			/v1/admin/dead-letters/{id}/replay [post]
+func (h *hdlr) handleReplayDeadLetter(c *gin.Context) {
+	h.logger.Debug().Msg("handle replay dead letter")
+
+	if err := h.svc.ReplayDeadLetter(c, c.Param("id")); err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// handleDeleteDeadLetter godoc
+//
+//	@Id				DeleteDeadLetter
+//	@Summary	    Delete dead letter
+//	@Description	Request for deleting dead letter without replay
+//	@Tags			Dead Letter API
+//	@Param			id	path	string	true	"Dead letter ID"
+//	@Success		204
+//	@Failure		400 {object} model.ErrorOutput
+//	@Failure		404 {object} model.ErrorOutput
+//	@Failure		500 {object} model.ErrorOutput
+//	@Router			/v1/admin/dead-letters/{id} [delete]
+func (h *hdlr) handleDeleteDeadLetter(c *gin.Context) {
+	h.logger.Debug().Msg("handle delete dead letter")
+
+	if err := h.svc.DeleteDeadLetter(c, c.Param("id")); err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (h *hdlr) handleError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, domain.ErrInvalidDeadLetterID):
+		_ = helper.ErrorWithStatus(c, http.StatusBadRequest, err)
+	case errors.Is(err, domain.ErrDeadLetterNotFound):
+		_ = helper.ErrorWithStatus(c, http.StatusNotFound, err)
+	default:
+		_ = helper.ErrorWithStatus(c, http.StatusInternalServerError, err)
+	}
+}
@@ -2,7 +2,10 @@ package hashcrack
 
 import (
 	"errors"
+	"math"
 	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/rs/zerolog"
@@ -18,14 +21,17 @@ var (
 )
 
 type hdlr struct {
-	logger zerolog.Logger
-	svc    domain.HashCrackTask
+	logger     zerolog.Logger
+	svc        domain.HashCrackTask
+	retryAfter string
 }
 
-func NewHandler(logger zerolog.Logger, svc domain.HashCrackTask) handler.Handler {
+// NewHandler creates the handler of hash crack tasks, retryAfter is sent to clients, if there are too many tasks
+func NewHandler(logger zerolog.Logger, svc domain.HashCrackTask, retryAfter time.Duration) handler.Handler {
 	return &hdlr{
-		logger: logger.With().Str("handler", "hash-crack").Logger(),
-		svc:    svc,
+		logger:     logger.With().Str("handler", "hash-crack").Logger(),
+		svc:        svc,
+		retryAfter: strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))),
 	}
 }
 
@@ -35,8 +41,12 @@ func (h *hdlr) RegisterRoutes(r *gin.Engine) {
 	exAPI := r.Group("/v1/hash/crack")
 	{
 		exAPI.POST("", h.handleCreateTask)
+		exAPI.POST("/batch", h.handleCreateBatchTask)
 		exAPI.GET("/metadatas", h.handleGetTaskMetadatas)
 		exAPI.GET("/status", h.handleGetTaskStatus)
+		exAPI.GET("/batch/status", h.handleGetBatchTaskStatus)
+		exAPI.DELETE("/:id", h.handleCancelTask)
+		exAPI.POST("/:id/extend", h.handleExtendTask)
 	}
 }
 
@@ -51,6 +61,9 @@ func (h *hdlr) RegisterRoutes(r *gin.Engine) {
 //	@Param			input	body	model.HashCrackTaskInput	true	"Hash crack task input"
 //	@Success		202 {object} model.HashCrackTaskIDOutput
 //	@Failure		400 {object} model.ErrorOutput
+//	@Failure		404 {object} model.ErrorOutput
+//	@Failure		429 {object} model.ErrorOutput
+//	@Header			429 {string} Retry-After "Seconds to wait before creating a task again"
 //	@Failure		500 {object} model.ErrorOutput
 //	@Router			/v1/hash/crack [post]
 func (h *hdlr) handleCreateTask(ctx *gin.Context) {
@@ -65,7 +78,68 @@ func (h *hdlr) handleCreateTask(ctx *gin.Context) {
 	output, err := h.svc.CreateTask(ctx, input)
 	if err != nil {
 		switch {
+		case errors.Is(err, domain.ErrUnsupportedAlgorithm), errors.Is(err, domain.ErrInvalidHash),
+			errors.Is(err, domain.ErrInvalidSalt), errors.Is(err, domain.ErrInvalidAttackMode),
+			errors.Is(err, domain.ErrInvalidWordlistID), errors.Is(err, domain.ErrInvalidRuleSetID),
+			errors.Is(err, domain.ErrInvalidMask),
+			errors.Is(err, domain.ErrInvalidAlphabet), errors.Is(err, domain.ErrInvalidLength),
+			errors.Is(err, domain.ErrKeyspaceTooLarge), errors.Is(err, domain.ErrInvalidTimeout),
+			errors.Is(err, domain.ErrInvalidPriority):
+			_ = helper.ErrorWithStatus(ctx, http.StatusBadRequest, err)
+		case errors.Is(err, domain.ErrWordlistNotFound), errors.Is(err, domain.ErrRuleSetNotFound):
+			_ = helper.ErrorWithStatus(ctx, http.StatusNotFound, err)
+		case errors.Is(err, domain.ErrTooManyTasks):
+			ctx.Header("Retry-After", h.retryAfter)
+			_ = helper.ErrorWithStatus(ctx, http.StatusTooManyRequests, err)
+		default:
+			_ = helper.ErrorWithStatus(ctx, http.StatusInternalServerError, err)
+		}
+		return
+	}
+
+	ctx.JSON(202, output)
+}
+
+// handleCreateBatchTask godoc
+//
+//	@Id				HashCrackBatch
+//	@Summary	    Create new batch hash crack task
+//	@Description	Request for create new hash crack task for a list of hashes
+//	@Tags			Hash Crack API
+//	@Accept			application/json
+//	@Produce		application/json
+//	@Param			input	body	model.HashCrackBatchTaskInput	true	"Batch hash crack task input"
+//	@Success		202 {object} model.HashCrackTaskIDOutput
+//	@Failure		400 {object} model.ErrorOutput
+//	@Failure		404 {object} model.ErrorOutput
+//	@Failure		429 {object} model.ErrorOutput
+//	@Header			429 {string} Retry-After "Seconds to wait before creating a task again"
+//	@Failure		500 {object} model.ErrorOutput
+//	@Router			/v1/hash/crack/batch [post]
+func (h *hdlr) handleCreateBatchTask(ctx *gin.Context) {
+	h.logger.Debug().Msg("handle create batch task")
+
+	input := &model.HashCrackBatchTaskInput{}
+	if err := ctx.ShouldBindJSON(input); err != nil {
+		_ = helper.ErrorWithStatus(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	output, err := h.svc.CreateBatchTask(ctx, input)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrUnsupportedAlgorithm), errors.Is(err, domain.ErrInvalidHash),
+			errors.Is(err, domain.ErrInvalidSalt), errors.Is(err, domain.ErrTooManyHashes),
+			errors.Is(err, domain.ErrInvalidAttackMode), errors.Is(err, domain.ErrInvalidWordlistID),
+			errors.Is(err, domain.ErrInvalidRuleSetID), errors.Is(err, domain.ErrInvalidMask),
+			errors.Is(err, domain.ErrInvalidAlphabet), errors.Is(err, domain.ErrInvalidLength),
+			errors.Is(err, domain.ErrKeyspaceTooLarge), errors.Is(err, domain.ErrInvalidTimeout),
+			errors.Is(err, domain.ErrInvalidPriority):
+			_ = helper.ErrorWithStatus(ctx, http.StatusBadRequest, err)
+		case errors.Is(err, domain.ErrWordlistNotFound), errors.Is(err, domain.ErrRuleSetNotFound):
+			_ = helper.ErrorWithStatus(ctx, http.StatusNotFound, err)
 		case errors.Is(err, domain.ErrTooManyTasks):
+			ctx.Header("Retry-After", h.retryAfter)
 			_ = helper.ErrorWithStatus(ctx, http.StatusTooManyRequests, err)
 		default:
 			_ = helper.ErrorWithStatus(ctx, http.StatusInternalServerError, err)
@@ -145,3 +219,120 @@ func (h *hdlr) handleGetTaskStatus(c *gin.Context) {
 
 	c.JSON(200, output)
 }
+
+// handleGetBatchTaskStatus godoc
+//
+//	@Id				CheckHashCrackBatchStatus
+//	@Summary	    Get per-hash status of hash crack task
+//	@Description	Request for getting status of every target hash of hash crack task
+//	@Tags			Hash Crack API
+//	@Produce		application/json
+//	@Param			requestID	query	string	true	"Hash crack task ID"
+//	@Success		200 {object} model.HashCrackBatchTaskStatusOutput
+//	@Failure		400 {object} model.ErrorOutput
+//	@Failure		404 {object} model.ErrorOutput
+//	@Failure		500 {object} model.ErrorOutput
+//	@Router			/v1/hash/crack/batch/status [get]
+func (h *hdlr) handleGetBatchTaskStatus(c *gin.Context) {
+	h.logger.Debug().Msg("handle get batch task status")
+
+	id, ok := c.GetQuery("requestID")
+	if !ok {
+		_ = helper.ErrorWithStatus(c, http.StatusBadRequest, ErrRequestIDNotFound)
+		return
+	}
+
+	output, err := h.svc.GetBatchTaskStatus(c, id)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrInvalidRequestID):
+			_ = helper.ErrorWithStatus(c, http.StatusBadRequest, err)
+		case errors.Is(err, domain.ErrTaskNotFound):
+			_ = helper.ErrorWithStatus(c, http.StatusNotFound, err)
+		default:
+			_ = helper.ErrorWithStatus(c, http.StatusInternalServerError, err)
+		}
+
+		return
+	}
+
+	c.JSON(200, output)
+}
+
+// handleCancelTask godoc
+//
+//	@Id				CancelHashCrack
+//	@Summary	    Cancel hash crack task
+//	@Description	Request for cancelling hash crack task, workers stop its subtasks and their results are discarded
+//	@Tags			Hash Crack API
+//	@Param			id	path	string	true	"Hash crack task ID"
+//	@Success		204
+//	@Failure		400 {object} model.ErrorOutput
+//	@Failure		404 {object} model.ErrorOutput
+//	@Failure		409 {object} model.ErrorOutput
+//	@Failure		500 {object} model.ErrorOutput
+//	@Router			/v1/hash/crack/{id} [delete]
+func (h *hdlr) handleCancelTask(c *gin.Context) {
+	h.logger.Debug().Msg("handle cancel task")
+
+	if err := h.svc.CancelTask(c, c.Param("id")); err != nil {
+		switch {
+		case errors.Is(err, domain.ErrInvalidRequestID):
+			_ = helper.ErrorWithStatus(c, http.StatusBadRequest, err)
+		case errors.Is(err, domain.ErrTaskNotFound):
+			_ = helper.ErrorWithStatus(c, http.StatusNotFound, err)
+		case errors.Is(err, domain.ErrTaskFinished):
+			_ = helper.ErrorWithStatus(c, http.StatusConflict, err)
+		default:
+			_ = helper.ErrorWithStatus(c, http.StatusInternalServerError, err)
+		}
+
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// handleExtendTask godoc
+//
+//	@Id				ExtendHashCrack
+//	@Summary	    Extend hash crack task
+//	@Description	Request for extending the deadline of hash crack task, the task is finished with error after it
+//	@Tags			Hash Crack API
+//	@Accept			application/json
+//	@Produce		application/json
+//	@Param			id		path	string							true	"Hash crack task ID"
+//	@Param			input	body	model.HashCrackTaskExtendInput	true	"Hash crack task extend input"
+//	@Success		200 {object} model.HashCrackTaskDeadlineOutput
+//	@Failure		400 {object} model.ErrorOutput
+//	@Failure		404 {object} model.ErrorOutput
+//	@Failure		409 {object} model.ErrorOutput
+//	@Failure		500 {object} model.ErrorOutput
+//	@Router			/v1/hash/crack/{id}/extend [post]
+func (h *hdlr) handleExtendTask(c *gin.Context) {
+	h.logger.Debug().Msg("handle extend task")
+
+	input := &model.HashCrackTaskExtendInput{}
+	if err := c.ShouldBindJSON(input); err != nil {
+		_ = helper.ErrorWithStatus(c, http.StatusBadRequest, err)
+		return
+	}
+
+	output, err := h.svc.ExtendTask(c, c.Param("id"), input)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrInvalidRequestID), errors.Is(err, domain.ErrInvalidTimeout):
+			_ = helper.ErrorWithStatus(c, http.StatusBadRequest, err)
+		case errors.Is(err, domain.ErrTaskNotFound):
+			_ = helper.ErrorWithStatus(c, http.StatusNotFound, err)
+		case errors.Is(err, domain.ErrTaskFinished):
+			_ = helper.ErrorWithStatus(c, http.StatusConflict, err)
+		default:
+			_ = helper.ErrorWithStatus(c, http.StatusInternalServerError, err)
+		}
+
+		return
+	}
+
+	c.JSON(http.StatusOK, output)
+}
@@ -0,0 +1,167 @@
+package ruleset
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog"
+
+	"github.com/ptrvsrg/crack-hash/commonlib/http/handler"
+	"github.com/ptrvsrg/crack-hash/commonlib/http/helper"
+	"github.com/ptrvsrg/crack-hash/manager/internal/service/domain"
+	"github.com/ptrvsrg/crack-hash/manager/pkg/model"
+)
+
+type hdlr struct {
+	logger zerolog.Logger
+	svc    domain.RuleSet
+}
+
+func NewHandler(logger zerolog.Logger, svc domain.RuleSet) handler.Handler {
+	return &hdlr{
+		logger: logger.With().Str("handler", "rule-set").Logger(),
+		svc:    svc,
+	}
+}
+
+func (h *hdlr) RegisterRoutes(r *gin.Engine) {
+	h.logger.Debug().Msg("register routes")
+
+	exAPI := r.Group("/v1/rulesets")
+	{
+		exAPI.POST("", h.handleCreateRuleSet)
+		exAPI.GET("", h.handleGetRuleSets)
+		exAPI.GET("/:id", h.handleGetRuleSet)
+		exAPI.DELETE("/:id", h.handleDeleteRuleSet)
+	}
+}
+
+// handleCreateRuleSet godoc
+//
+//	@Id				CreateRuleSet
+//	@Summary	    Create rule set
+//	@Description	Request for creating a named set of word mangling rules in the hashcat rule syntax
+//	@Tags			Rule Set API
+//	@Accept			application/json
+//	@Produce		application/json
+//	@Param			input	body	model.RuleSetInput	true	"Rule set input"
+//	@Success		201 {object} model.RuleSetOutput
+//	@Failure		400 {object} model.ErrorOutput
+//	@Failure		409 {object} model.ErrorOutput
+//	@Failure		500 {object} model.ErrorOutput
+//	@Router			/v1/rulesets [post]
+func (h *hdlr) handleCreateRuleSet(c *gin.Context) {
+	h.logger.Debug().Msg("handle create rule set")
+
+	input := &model.RuleSetInput{}
+	if err := c.ShouldBindJSON(input); err != nil {
+		_ = helper.ErrorWithStatus(c, http.StatusBadRequest, err)
+		return
+	}
+
+	output, err := h.svc.CreateRuleSet(c, input)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrInvalidRuleSet):
+			_ = helper.ErrorWithStatus(c, http.StatusBadRequest, err)
+		case errors.Is(err, domain.ErrRuleSetExists):
+			_ = helper.ErrorWithStatus(c, http.StatusConflict, err)
+		default:
+			_ = helper.ErrorWithStatus(c, http.StatusInternalServerError, err)
+		}
+		return
+	}
+
+	c.JSON(201, output)
+}
+
+// handleGetRuleSets godoc
+//
+//	@Id				GetRuleSets
+//	@Summary	    Get rule sets
+//	@Description	Request for getting rule sets
+//	@Tags			Rule Set API
+//	@Produce		application/json
+//	@Param			limit	query	int	false	"Limit"
+//	@Param			offset	query	int	false	"Offset"
+//	@Success		200 {object} model.RuleSetsOutput
+//	@Failure		400 {object} model.ErrorOutput
+//	@Failure		500 {object} model.ErrorOutput
+//	@Router			/v1/rulesets [get]
+func (h *hdlr) handleGetRuleSets(c *gin.Context) {
+	h.logger.Debug().Msg("handle get rule sets")
+
+	input := &model.RuleSetsInput{}
+	if err := c.ShouldBindQuery(input); err != nil {
+		_ = helper.ErrorWithStatus(c, http.StatusBadRequest, err)
+		return
+	}
+
+	output, err := h.svc.GetRuleSets(c, input.Limit, input.Offset)
+	if err != nil {
+		_ = helper.ErrorWithStatus(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(200, output)
+}
+
+// handleGetRuleSet godoc
+//
+//	@Id				GetRuleSet
+//	@Summary	    Get rule set
+//	@Description	Request for getting rule set
+//	@Tags			Rule Set API
+//	@Produce		application/json
+//	@Param			id	path	string	true	"Rule set ID"
+//	@Success		200 {object} model.RuleSetOutput
+//	@Failure		400 {object} model.ErrorOutput
+//	@Failure		404 {object} model.ErrorOutput
+//	@Failure		500 {object} model.ErrorOutput
+//	@Router			/v1/rulesets/{id} [get]
+func (h *hdlr) handleGetRuleSet(c *gin.Context) {
+	h.logger.Debug().Msg("handle get rule set")
+
+	output, err := h.svc.GetRuleSet(c, c.Param("id"))
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(200, output)
+}
+
+// handleDeleteRuleSet godoc
+//
+//	@Id				DeleteRuleSet
+//	@Summary	    Delete rule set
+//	@Description	Request for deleting rule set, tasks already created with it keep their rules
+//	@Tags			Rule Set API
+//	@Param			id	path	string	true	"Rule set ID"
+//	@Success		204
+//	@Failure		400 {object} model.ErrorOutput
+//	@Failure		404 {object} model.ErrorOutput
+//	@Failure		500 {object} model.ErrorOutput
+//	@Router			/v1/rulesets/{id} [delete]
+func (h *hdlr) handleDeleteRuleSet(c *gin.Context) {
+	h.logger.Debug().Msg("handle delete rule set")
+
+	if err := h.svc.DeleteRuleSet(c, c.Param("id")); err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (h *hdlr) handleError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, domain.ErrInvalidRuleSetID):
+		_ = helper.ErrorWithStatus(c, http.StatusBadRequest, err)
+	case errors.Is(err, domain.ErrRuleSetNotFound):
+		_ = helper.ErrorWithStatus(c, http.StatusNotFound, err)
+	default:
+		_ = helper.ErrorWithStatus(c, http.StatusInternalServerError, err)
+	}
+}
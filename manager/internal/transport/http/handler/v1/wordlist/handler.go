@@ -0,0 +1,232 @@
+package wordlist
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog"
+
+	"github.com/ptrvsrg/crack-hash/commonlib/http/handler"
+	"github.com/ptrvsrg/crack-hash/commonlib/http/helper"
+	"github.com/ptrvsrg/crack-hash/manager/internal/service/domain"
+	"github.com/ptrvsrg/crack-hash/manager/pkg/model"
+)
+
+var (
+	ErrFileNotFound = errors.New("file not found")
+)
+
+type hdlr struct {
+	logger zerolog.Logger
+	svc    domain.Wordlist
+}
+
+func NewHandler(logger zerolog.Logger, svc domain.Wordlist) handler.Handler {
+	return &hdlr{
+		logger: logger.With().Str("handler", "wordlist").Logger(),
+		svc:    svc,
+	}
+}
+
+func (h *hdlr) RegisterRoutes(r *gin.Engine) {
+	h.logger.Debug().Msg("register routes")
+
+	exAPI := r.Group("/v1/wordlists")
+	{
+		exAPI.POST("", h.handleUploadWordlist)
+		exAPI.GET("", h.handleGetWordlists)
+		exAPI.GET("/:id", h.handleGetWordlist)
+		exAPI.GET("/:id/content", h.handleGetWordlistContent)
+		exAPI.DELETE("/:id", h.handleDeleteWordlist)
+	}
+}
+
+// handleUploadWordlist godoc
+//
+//	@Id				UploadWordlist
+//	@Summary	    Upload wordlist
+//	@Description	Request for uploading a wordlist for dictionary attacks, one word per line
+//	@Tags			Wordlist API
+//	@Accept			multipart/form-data
+//	@Produce		application/json
+//	@Param			file	formData	file	true	"Wordlist file"
+//	@Param			name	formData	string	false	"Wordlist name, defaults to the file name"
+//	@Success		201 {object} model.WordlistOutput
+//	@Failure		400 {object} model.ErrorOutput
+//	@Failure		413 {object} model.ErrorOutput
+//	@Failure		500 {object} model.ErrorOutput
+//	@Router			/v1/wordlists [post]
+func (h *hdlr) handleUploadWordlist(c *gin.Context) {
+	h.logger.Debug().Msg("handle upload wordlist")
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		_ = helper.ErrorWithStatus(c, http.StatusBadRequest, fmt.Errorf("%w: %w", ErrFileNotFound, err))
+		return
+	}
+
+	name := c.PostForm("name")
+	if name == "" {
+		name = fileHeader.Filename
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		_ = helper.ErrorWithStatus(c, http.StatusInternalServerError, err)
+		return
+	}
+	defer func(file io.Closer) {
+		if err := file.Close(); err != nil {
+			h.logger.Error().Err(err).Msg("failed to close file")
+		}
+	}(file)
+
+	output, err := h.svc.UploadWordlist(c, name, file)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrInvalidWordlistName), errors.Is(err, domain.ErrEmptyWordlist):
+			_ = helper.ErrorWithStatus(c, http.StatusBadRequest, err)
+		case errors.Is(err, domain.ErrWordlistTooLarge):
+			_ = helper.ErrorWithStatus(c, http.StatusRequestEntityTooLarge, err)
+		default:
+			_ = helper.ErrorWithStatus(c, http.StatusInternalServerError, err)
+		}
+		return
+	}
+
+	c.JSON(201, output)
+}
+
+// handleGetWordlists godoc
+//
+//	@Id				GetWordlists
+//	@Summary	    Get wordlists
+//	@Description	Request for getting uploaded wordlists
+//	@Tags			Wordlist API
+//	@Produce		application/json
+//	@Param			limit	query	int	false	"Limit"
+//	@Param			offset	query	int	false	"Offset"
+//	@Success		200 {object} model.WordlistsOutput
+//	@Failure		400 {object} model.ErrorOutput
+//	@Failure		500 {object} model.ErrorOutput
+//	@Router			/v1/wordlists [get]
+func (h *hdlr) handleGetWordlists(c *gin.Context) {
+	h.logger.Debug().Msg("handle get wordlists")
+
+	input := &model.WordlistsInput{}
+	if err := c.ShouldBindQuery(input); err != nil {
+		_ = helper.ErrorWithStatus(c, http.StatusBadRequest, err)
+		return
+	}
+
+	output, err := h.svc.GetWordlists(c, input.Limit, input.Offset)
+	if err != nil {
+		_ = helper.ErrorWithStatus(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(200, output)
+}
+
+// handleGetWordlist godoc
+//
+//	@Id				GetWordlist
+//	@Summary	    Get wordlist
+//	@Description	Request for getting wordlist metadata
+//	@Tags			Wordlist API
+//	@Produce		application/json
+//	@Param			id	path	string	true	"Wordlist ID"
+//	@Success		200 {object} model.WordlistOutput
+//	@Failure		400 {object} model.ErrorOutput
+//	@Failure		404 {object} model.ErrorOutput
+//	@Failure		500 {object} model.ErrorOutput
+//	@Router			/v1/wordlists/{id} [get]
+func (h *hdlr) handleGetWordlist(c *gin.Context) {
+	h.logger.Debug().Msg("handle get wordlist")
+
+	output, err := h.svc.GetWordlist(c, c.Param("id"))
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(200, output)
+}
+
+// handleGetWordlistContent godoc
+//
+//	@Id				GetWordlistContent
+//	@Summary	    Get wordlist content
+//	@Description	Request for streaming a range of wordlist lines
+//	@Tags			Wordlist API
+//	@Produce		text/plain
+//	@Param			id		path	string	true	"Wordlist ID"
+//	@Param			offset	query	int		false	"Number of lines to skip"
+//	@Param			limit	query	int		false	"Maximum number of lines, all lines if zero"
+//	@Success		200 {string} string
+//	@Failure		400 {object} model.ErrorOutput
+//	@Failure		404 {object} model.ErrorOutput
+//	@Failure		500 {object} model.ErrorOutput
+//	@Router			/v1/wordlists/{id}/content [get]
+func (h *hdlr) handleGetWordlistContent(c *gin.Context) {
+	h.logger.Debug().Msg("handle get wordlist content")
+
+	input := &model.WordlistContentInput{}
+	if err := c.ShouldBindQuery(input); err != nil {
+		_ = helper.ErrorWithStatus(c, http.StatusBadRequest, err)
+		return
+	}
+
+	content, err := h.svc.OpenWordlistContent(c, c.Param("id"), input.Offset, input.Limit)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+	defer func(content io.Closer) {
+		if err := content.Close(); err != nil {
+			h.logger.Error().Err(err).Msg("failed to close wordlist content")
+		}
+	}(content)
+
+	c.DataFromReader(200, -1, "text/plain; charset=utf-8", content, nil)
+}
+
+// handleDeleteWordlist godoc
+//
+//	@Id				DeleteWordlist
+//	@Summary	    Delete wordlist
+//	@Description	Request for deleting wordlist
+//	@Tags			Wordlist API
+//	@Param			id	path	string	true	"Wordlist ID"
+//	@Success		204
+//	@Failure		400 {object} model.ErrorOutput
+//	@Failure		404 {object} model.ErrorOutput
+//	@Failure		409 {object} model.ErrorOutput
+//	@Failure		500 {object} model.ErrorOutput
+//	@Router			/v1/wordlists/{id} [delete]
+func (h *hdlr) handleDeleteWordlist(c *gin.Context) {
+	h.logger.Debug().Msg("handle delete wordlist")
+
+	if err := h.svc.DeleteWordlist(c, c.Param("id")); err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (h *hdlr) handleError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, domain.ErrInvalidWordlistID):
+		_ = helper.ErrorWithStatus(c, http.StatusBadRequest, err)
+	case errors.Is(err, domain.ErrWordlistNotFound):
+		_ = helper.ErrorWithStatus(c, http.StatusNotFound, err)
+	case errors.Is(err, domain.ErrWordlistInUse):
+		_ = helper.ErrorWithStatus(c, http.StatusConflict, err)
+	default:
+		_ = helper.ErrorWithStatus(c, http.StatusInternalServerError, err)
+	}
+}
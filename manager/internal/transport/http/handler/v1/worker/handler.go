@@ -0,0 +1,65 @@
+package worker
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog"
+
+	"github.com/ptrvsrg/crack-hash/commonlib/http/handler"
+	"github.com/ptrvsrg/crack-hash/commonlib/http/helper"
+	"github.com/ptrvsrg/crack-hash/manager/internal/service/domain"
+	"github.com/ptrvsrg/crack-hash/manager/pkg/model"
+)
+
+type hdlr struct {
+	logger zerolog.Logger
+	svc    domain.Worker
+}
+
+func NewHandler(logger zerolog.Logger, svc domain.Worker) handler.Handler {
+	return &hdlr{
+		logger: logger.With().Str("handler", "worker").Logger(),
+		svc:    svc,
+	}
+}
+
+func (h *hdlr) RegisterRoutes(r *gin.Engine) {
+	h.logger.Debug().Msg("register routes")
+
+	exAPI := r.Group("/v1/workers")
+	{
+		exAPI.GET("", h.handleGetWorkers)
+	}
+}
+
+// handleGetWorkers godoc
+//
+//	@Id				GetWorkers
+//	@Summary	    Get workers
+//	@Description	Request for getting registered workers with their capabilities and online status
+//	@Tags			Worker API
+//	@Produce		application/json
+//	@Param			limit	query	int	false	"Limit"
+//	@Param			offset	query	int	false	"Offset"
+//	@Success		200 {object} model.WorkersOutput
+//	@Failure		400 {object} model.ErrorOutput
+//	@Failure		500 {object} model.ErrorOutput
+//	@Router			/v1/workers [get]
+func (h *hdlr) handleGetWorkers(c *gin.Context) {
+	h.logger.Debug().Msg("handle get workers")
+
+	input := &model.WorkersInput{}
+	if err := c.ShouldBindQuery(input); err != nil {
+		_ = helper.ErrorWithStatus(c, http.StatusBadRequest, err)
+		return
+	}
+
+	output, err := h.svc.GetWorkers(c, input.Limit, input.Offset)
+	if err != nil {
+		_ = helper.ErrorWithStatus(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(200, output)
+}
@@ -42,6 +42,16 @@ var (
 //	@produce					json
 //	@tag.name					Hash Crack API
 //	@tag.description			API for cracking hashes and checking results
+//	@tag.name					Wordlist API
+//	@tag.description			API for managing wordlists of dictionary attacks
+//	@tag.name					Rule Set API
+//	@tag.description			API for managing word mangling rules of dictionary attacks
+//	@tag.name					Worker API
+//	@tag.description			API for getting the registry of workers
+//	@tag.name					Dead Letter API
+//	@tag.description			API for inspecting and replaying messages, which consumers failed to handle
+//	@tag.name					Callback API
+//	@tag.description			API for callbacks of workers in the http transport
 //	@tag.name					Health API
 //	@tag.description			API for health checks
 //	@tag.name					Swagger API
@@ -0,0 +1,323 @@
+package mangling
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Global error variables
+var (
+	ErrInvalidRule     = errors.New("invalid rule")
+	ErrUnknownFunction = errors.New("unknown rule function")
+	ErrMissingArgument = errors.New("missing rule function argument")
+	ErrInvalidPosition = errors.New("invalid rule function position")
+)
+
+// Argument kinds of the rule functions
+const (
+	argChar byte = 'c' // Any character
+	argPos  byte = 'n' // Position 0-9, A-Z
+)
+
+// functions maps every supported function of the hashcat/John rule subset to the kinds of its arguments
+var functions = map[byte]string{
+	':':  "",   // Do nothing
+	'l':  "",   // Lowercase all letters
+	'u':  "",   // Uppercase all letters
+	'c':  "",   // Capitalize the first letter, lowercase the rest
+	'C':  "",   // Lowercase the first letter, uppercase the rest
+	't':  "",   // Toggle the case of all letters
+	'T':  "n",  // Toggle the case of the letter at position N
+	'r':  "",   // Reverse the word
+	'd':  "",   // Duplicate the word
+	'p':  "n",  // Append the word to itself N times
+	'f':  "",   // Append the reversed word
+	'{':  "",   // Rotate the word left
+	'}':  "",   // Rotate the word right
+	'$':  "c",  // Append the character X
+	'^':  "c",  // Prepend the character X
+	'[':  "",   // Delete the first character
+	']':  "",   // Delete the last character
+	'D':  "n",  // Delete the character at position N
+	'x':  "nn", // Extract M characters starting at position N
+	'O':  "nn", // Omit M characters starting at position N
+	'i':  "nc", // Insert the character X at position N
+	'o':  "nc", // Overwrite the character at position N with X
+	'\'': "n",  // Truncate the word at position N
+	's':  "cc", // Replace all characters X with Y
+	'@':  "c",  // Purge all characters X
+	'z':  "n",  // Duplicate the first character N times
+	'Z':  "n",  // Duplicate the last character N times
+	'q':  "",   // Duplicate every character
+	'k':  "",   // Swap the first two characters
+	'K':  "",   // Swap the last two characters
+	'*':  "nn", // Swap the characters at positions N and M
+}
+
+type (
+	// Rule is a parsed chain of functions applied to a word from left to right.
+	Rule struct {
+		source string
+		steps  []step
+	}
+
+	step struct {
+		fn   byte
+		args []byte
+	}
+)
+
+// Parse parses a rule written in the hashcat/John rule syntax. Spaces between functions are ignored.
+func Parse(source string) (*Rule, error) {
+	rule := &Rule{source: source}
+
+	for i := 0; i < len(source); {
+		fn := source[i]
+		i++
+
+		if fn == ' ' || fn == '\t' {
+			continue
+		}
+
+		kinds, ok := functions[fn]
+		if !ok {
+			return nil, fmt.Errorf("%w: %w %q at %d", ErrInvalidRule, ErrUnknownFunction, fn, i-1)
+		}
+
+		if i+len(kinds) > len(source) {
+			return nil, fmt.Errorf("%w: %w of %q", ErrInvalidRule, ErrMissingArgument, fn)
+		}
+
+		args := make([]byte, len(kinds))
+		for j := range kinds {
+			arg := source[i+j]
+			if kinds[j] == argPos {
+				pos, ok := position(arg)
+				if !ok {
+					return nil, fmt.Errorf("%w: %w %q of %q", ErrInvalidRule, ErrInvalidPosition, arg, fn)
+				}
+				arg = byte(pos)
+			}
+			args[j] = arg
+		}
+		i += len(kinds)
+
+		rule.steps = append(rule.steps, step{fn: fn, args: args})
+	}
+
+	return rule, nil
+}
+
+// ParseAll parses all rules, skipping empty lines and comments starting with '#'.
+func ParseAll(sources []string) ([]*Rule, error) {
+	rules := make([]*Rule, 0, len(sources))
+	for _, source := range sources {
+		if strings.TrimSpace(source) == "" || strings.HasPrefix(source, "#") {
+			continue
+		}
+
+		rule, err := Parse(source)
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, rule)
+	}
+
+	return rules, nil
+}
+
+// String returns the source of the rule.
+func (r *Rule) String() string {
+	return r.source
+}
+
+// Apply applies the rule to the word. The word is rejected if the rule turns it into an empty one.
+func (r *Rule) Apply(word string) (string, bool) {
+	w := []byte(word)
+	for _, s := range r.steps {
+		w = s.apply(w)
+	}
+
+	return string(w), len(w) > 0
+}
+
+func (s step) apply(w []byte) []byte {
+	switch s.fn {
+	case ':':
+		return w
+	case 'l':
+		return bytes.ToLower(w)
+	case 'u':
+		return bytes.ToUpper(w)
+	case 'c':
+		w = bytes.ToLower(w)
+		if len(w) > 0 {
+			w[0] = upper(w[0])
+		}
+		return w
+	case 'C':
+		w = bytes.ToUpper(w)
+		if len(w) > 0 {
+			w[0] = lower(w[0])
+		}
+		return w
+	case 't':
+		for i := range w {
+			w[i] = toggle(w[i])
+		}
+		return w
+	case 'T':
+		if n := int(s.args[0]); n < len(w) {
+			w[n] = toggle(w[n])
+		}
+		return w
+	case 'r':
+		for i, j := 0, len(w)-1; i < j; i, j = i+1, j-1 {
+			w[i], w[j] = w[j], w[i]
+		}
+		return w
+	case 'd':
+		return append(w, w...)
+	case 'p':
+		return bytes.Repeat(w, int(s.args[0])+1)
+	case 'f':
+		reflected := make([]byte, len(w))
+		for i := range w {
+			reflected[len(w)-1-i] = w[i]
+		}
+		return append(w, reflected...)
+	case '{':
+		if len(w) > 1 {
+			w = append(w[1:], w[0])
+		}
+		return w
+	case '}':
+		if len(w) > 1 {
+			w = append([]byte{w[len(w)-1]}, w[:len(w)-1]...)
+		}
+		return w
+	case '$':
+		return append(w, s.args[0])
+	case '^':
+		return append([]byte{s.args[0]}, w...)
+	case '[':
+		if len(w) > 0 {
+			w = w[1:]
+		}
+		return w
+	case ']':
+		if len(w) > 0 {
+			w = w[:len(w)-1]
+		}
+		return w
+	case 'D':
+		if n := int(s.args[0]); n < len(w) {
+			w = append(w[:n], w[n+1:]...)
+		}
+		return w
+	case 'x':
+		n, m := int(s.args[0]), int(s.args[1])
+		if n+m <= len(w) {
+			w = w[n : n+m]
+		}
+		return w
+	case 'O':
+		n, m := int(s.args[0]), int(s.args[1])
+		if n+m <= len(w) {
+			w = append(w[:n], w[n+m:]...)
+		}
+		return w
+	case 'i':
+		if n := int(s.args[0]); n <= len(w) {
+			w = append(w[:n], append([]byte{s.args[1]}, w[n:]...)...)
+		}
+		return w
+	case 'o':
+		if n := int(s.args[0]); n < len(w) {
+			w[n] = s.args[1]
+		}
+		return w
+	case '\'':
+		if n := int(s.args[0]); n < len(w) {
+			w = w[:n]
+		}
+		return w
+	case 's':
+		return bytes.ReplaceAll(w, s.args[:1], s.args[1:])
+	case '@':
+		return bytes.ReplaceAll(w, s.args[:1], nil)
+	case 'z':
+		if len(w) > 0 {
+			w = append(bytes.Repeat(w[:1], int(s.args[0])), w...)
+		}
+		return w
+	case 'Z':
+		if len(w) > 0 {
+			w = append(w, bytes.Repeat(w[len(w)-1:], int(s.args[0]))...)
+		}
+		return w
+	case 'q':
+		doubled := make([]byte, 0, 2*len(w))
+		for _, c := range w {
+			doubled = append(doubled, c, c)
+		}
+		return doubled
+	case 'k':
+		if len(w) > 1 {
+			w[0], w[1] = w[1], w[0]
+		}
+		return w
+	case 'K':
+		if n := len(w); n > 1 {
+			w[n-2], w[n-1] = w[n-1], w[n-2]
+		}
+		return w
+	case '*':
+		n, m := int(s.args[0]), int(s.args[1])
+		if n < len(w) && m < len(w) {
+			w[n], w[m] = w[m], w[n]
+		}
+		return w
+	default:
+		return w
+	}
+}
+
+// position decodes the position argument: 0-9 for 0 to 9 and A-Z for 10 to 35
+func position(c byte) (int, bool) {
+	switch {
+	case c >= '0' && c <= '9':
+		return int(c - '0'), true
+	case c >= 'A' && c <= 'Z':
+		return int(c-'A') + 10, true
+	default:
+		return 0, false
+	}
+}
+
+func toggle(c byte) byte {
+	switch {
+	case c >= 'a' && c <= 'z':
+		return upper(c)
+	case c >= 'A' && c <= 'Z':
+		return lower(c)
+	default:
+		return c
+	}
+}
+
+func upper(c byte) byte {
+	if c >= 'a' && c <= 'z' {
+		return c - 'a' + 'A'
+	}
+	return c
+}
+
+func lower(c byte) byte {
+	if c >= 'A' && c <= 'Z' {
+		return c - 'A' + 'a'
+	}
+	return c
+}
@@ -0,0 +1,127 @@
+package mangling_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ptrvsrg/crack-hash/commonlib/logging"
+	"github.com/ptrvsrg/crack-hash/manager/pkg/mangling"
+)
+
+func init() {
+	logging.Setup(true)
+}
+
+func TestRule_Apply(t *testing.T) {
+	testCases := []struct {
+		rule     string
+		word     string
+		expected string
+	}{
+		{":", "p@ssW0rd", "p@ssW0rd"},
+		{"l", "p@ssW0rd", "p@ssw0rd"},
+		{"u", "p@ssW0rd", "P@SSW0RD"},
+		{"c", "p@ssW0rd", "P@ssw0rd"},
+		{"C", "p@ssW0rd", "p@SSW0RD"},
+		{"t", "p@ssW0rd", "P@SSw0RD"},
+		{"T3", "p@ssW0rd", "p@sSW0rd"},
+		{"r", "p@ssW0rd", "dr0Wss@p"},
+		{"d", "p@ssW0rd", "p@ssW0rdp@ssW0rd"},
+		{"p2", "ab", "ababab"},
+		{"f", "p@ssW0rd", "p@ssW0rddr0Wss@p"},
+		{"{", "p@ssW0rd", "@ssW0rdp"},
+		{"}", "p@ssW0rd", "dp@ssW0r"},
+		{"$1", "p@ssW0rd", "p@ssW0rd1"},
+		{"^1", "p@ssW0rd", "1p@ssW0rd"},
+		{"[", "p@ssW0rd", "@ssW0rd"},
+		{"]", "p@ssW0rd", "p@ssW0r"},
+		{"D3", "p@ssW0rd", "p@sW0rd"},
+		{"x04", "p@ssW0rd", "p@ss"},
+		{"O12", "p@ssW0rd", "psW0rd"},
+		{"i4!", "p@ssW0rd", "p@ss!W0rd"},
+		{"o3$", "p@ssW0rd", "p@s$W0rd"},
+		{"'6", "p@ssW0rd", "p@ssW0"},
+		{"ss$", "p@ssW0rd", "p@$$W0rd"},
+		{"@s", "p@ssW0rd", "p@W0rd"},
+		{"z2", "p@ssW0rd", "ppp@ssW0rd"},
+		{"Z2", "p@ssW0rd", "p@ssW0rddd"},
+		{"q", "abc", "aabbcc"},
+		{"k", "p@ssW0rd", "@pssW0rd"},
+		{"K", "p@ssW0rd", "p@ssW0dr"},
+		{"*07", "p@ssW0rd", "d@ssW0rp"},
+		{"c $1 $!", "password", "Password1!"},
+		{"sa@ so0 se3", "password", "p@ssw0rd"},
+		{"TZ", "short", "short"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(
+			tc.rule, func(t *testing.T) {
+				// Arrange
+				rule, err := mangling.Parse(tc.rule)
+				require.NoError(t, err)
+
+				// Act
+				word, ok := rule.Apply(tc.word)
+
+				// Assert
+				require.True(t, ok)
+				require.Equal(t, tc.expected, word)
+			},
+		)
+	}
+
+	t.Run(
+		"Rejects empty word", func(t *testing.T) {
+			// Arrange
+			rule, err := mangling.Parse("]]]")
+			require.NoError(t, err)
+
+			// Act
+			_, ok := rule.Apply("ab")
+
+			// Assert
+			require.False(t, ok)
+		},
+	)
+}
+
+func TestParse(t *testing.T) {
+	testCases := []struct {
+		name        string
+		rule        string
+		expectedErr error
+	}{
+		{"Unknown function", "c!", mangling.ErrUnknownFunction},
+		{"Missing argument", "c $", mangling.ErrMissingArgument},
+		{"Missing second argument", "s1", mangling.ErrMissingArgument},
+		{"Invalid position", "Tz", mangling.ErrInvalidPosition},
+	}
+
+	for _, tc := range testCases {
+		t.Run(
+			tc.name, func(t *testing.T) {
+				// Act
+				rule, err := mangling.Parse(tc.rule)
+
+				// Assert
+				require.ErrorIs(t, err, mangling.ErrInvalidRule)
+				require.ErrorIs(t, err, tc.expectedErr)
+				require.Nil(t, rule)
+			},
+		)
+	}
+
+	t.Run(
+		"Skips comments and empty lines", func(t *testing.T) {
+			// Act
+			rules, err := mangling.ParseAll([]string{"# comment", "", "c", "  ", "u $1"})
+
+			// Assert
+			require.NoError(t, err)
+			require.Len(t, rules, 2)
+			require.Equal(t, "u $1", rules[1].String())
+		},
+	)
+}
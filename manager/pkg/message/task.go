@@ -1,27 +1,119 @@
 package message
 
+const (
+	HashAlgorithmMD5    = "MD5"
+	HashAlgorithmSHA1   = "SHA1"
+	HashAlgorithmSHA256 = "SHA256"
+	HashAlgorithmSHA512 = "SHA512"
+	HashAlgorithmNTLM   = "NTLM"
+)
+
+const (
+	AttackModeBruteForce = "BRUTE_FORCE"
+	AttackModeDictionary = "DICTIONARY"
+	AttackModeMask       = "MASK"
+)
+
+const (
+	SaltPositionPrefix = "PREFIX"
+	SaltPositionSuffix = "SUFFIX"
+	SaltPositionHMAC   = "HMAC"
+)
+
+// MaxTaskPriority is the maximum priority of a task, the queue of started tasks is declared with it
+const MaxTaskPriority = 10
+
+// Version is the version of the format of the messages exchanged by the manager and workers, it is increased
+// on incompatible changes. Messages of other versions are rejected, messages without version have version 0
+const Version = 1
+
 type HashCrackTaskStarted struct {
-	RequestID  string   `json:"requestID" xml:"RequestId" validate:"required"`
-	PartNumber int      `json:"partNumber" xml:"PartNumber"`
-	PartCount  int      `json:"partCount" xml:"PartCount"`
-	Hash       string   `json:"hash" xml:"Hash" validate:"required"`
-	MaxLength  int      `json:"maxLength" xml:"MaxLength" validate:"min=0,max=6"`
-	Alphabet   Alphabet `json:"alphabet" xml:"Alphabet" validate:"required"`
+	Version    int       `json:"version" xml:"Version"`
+	RequestID  string    `json:"requestID" xml:"RequestId" validate:"required"`
+	PartNumber int       `json:"partNumber" xml:"PartNumber"`
+	PartCount  int       `json:"partCount" xml:"PartCount"`
+	Algorithm  string    `json:"algorithm,omitempty" xml:"Algorithm" validate:"omitempty,oneof=MD5 SHA1 SHA256 SHA512 NTLM"`
+	Hash       string    `json:"hash,omitempty" xml:"Hash" validate:"required_without=Hashes"`
+	Hashes     []string  `json:"hashes,omitempty" xml:"Hashes" validate:"required_without=Hash,omitempty,dive,required"`
+	Salt       *Salt     `json:"salt,omitempty" xml:"Salt"`
+	Mode       string    `json:"mode,omitempty" xml:"Mode" validate:"omitempty,oneof=BRUTE_FORCE DICTIONARY MASK"`
+	MinLength  int       `json:"minLength,omitempty" xml:"MinLength" validate:"min=0"`
+	MaxLength  int       `json:"maxLength" xml:"MaxLength" validate:"min=0,gtefield=MinLength"`
+	Alphabet   Alphabet  `json:"alphabet" xml:"Alphabet" validate:"required_without_all=Wordlist Mask"`
+	Wordlist   *Wordlist `json:"wordlist,omitempty" xml:"Wordlist" validate:"required_if=Mode DICTIONARY"`
+	Rules      []string  `json:"rules,omitempty" xml:"Rules" validate:"omitempty,dive,required"`
+	Mask       *Mask     `json:"mask,omitempty" xml:"Mask" validate:"required_if=Mode MASK"`
+	Priority   uint8     `json:"priority,omitempty" xml:"Priority" validate:"max=10"`
+	// StartIndex and EndIndex are the range of candidate indexes of a brute force or mask part,
+	// the end is exclusive. A dictionary part is the range of the wordlist lines
+	StartIndex int `json:"startIndex" xml:"StartIndex" validate:"min=0"`
+	EndIndex   int `json:"endIndex" xml:"EndIndex" validate:"required_without=Wordlist,omitempty,gtfield=StartIndex"`
+	// ResumeOffset is the number of leading candidates of the part checked by the previous attempts,
+	// the worker continues from it. Candidates of a dictionary part are counted in wordlist lines
+	ResumeOffset int `json:"resumeOffset,omitempty" xml:"ResumeOffset" validate:"min=0"`
+	// Attempt is the number of the sending of the part, the worker reports it in the results
+	Attempt int `json:"attempt,omitempty" xml:"Attempt" validate:"min=0"`
+}
+
+// Mask is a mask expanded to the charsets of its positions
+type Mask struct {
+	Charsets []string `json:"charsets" xml:"Charsets" validate:"required,min=1,dive,required"`
+}
+
+// Wordlist is a range of lines of an uploaded wordlist
+type Wordlist struct {
+	ID     string `json:"id" xml:"Id" validate:"required"`
+	Offset int    `json:"offset" xml:"Offset" validate:"min=0"`
+	Count  int    `json:"count" xml:"Count" validate:"min=0"`
+}
+
+type Salt struct {
+	Value    string `json:"value" xml:"Value"`
+	Position string `json:"position" xml:"Position" validate:"required,oneof=PREFIX SUFFIX HMAC"`
 }
 
 type Alphabet struct {
 	Symbols []string `json:"symbols" xml:"Symbols" validate:"required,min=1,dive,required"`
 }
 
+// HashCrackTaskCancelled is broadcast to all workers to stop the subtasks of a cancelled or early finished task
+type HashCrackTaskCancelled struct {
+	RequestID string `json:"requestID" xml:"RequestId" validate:"required"`
+}
+
+// HashCrackTaskShrunk is broadcast to all workers to lower the end of the range of a running subtask, the rest
+// of the range is split off to another subtask. The end is a candidate index or a wordlist line like the range
+type HashCrackTaskShrunk struct {
+	Version    int    `json:"version" xml:"Version"`
+	RequestID  string `json:"requestID" xml:"RequestId" validate:"required"`
+	PartNumber int    `json:"partNumber" xml:"PartNumber"`
+	EndIndex   int    `json:"endIndex" xml:"EndIndex" validate:"min=0"`
+}
+
 type HashCrackTaskResult struct {
+	Version    int     `json:"version" xml:"Version"`
 	RequestID  string  `json:"requestID" xml:"RequestId" validate:"required"`
 	PartNumber int     `json:"partNumber" xml:"PartNumber"`
 	Status     string  `json:"status" xml:"Status" validate:"required,oneof=IN_PROGRESS SUCCESS ERROR"`
 	Answer     *Answer `json:"answer" xml:"Answer"`
 	Error      *string `json:"error" xml:"Error"`
+	// Checkpoint is the number of leading candidates of the part, which are checked. It is counted like
+	// the resume offset of the started task
+	Checkpoint int `json:"checkpoint,omitempty" xml:"Checkpoint" validate:"min=0"`
+	// Speed is the number of candidates checked by the worker per second
+	Speed float64 `json:"speed,omitempty" xml:"Speed" validate:"min=0"`
+	// Attempt is the attempt of the started part, the results of the previous attempts are rejected.
+	// The results without it are sent by workers, which don't report attempts
+	Attempt int `json:"attempt,omitempty" xml:"Attempt" validate:"min=0"`
 }
 
 type Answer struct {
-	Words   []string `json:"words" xml:"Words" validate:"required,min=1,dive,required"`
-	Percent float64  `json:"percent" xml:"Percent" validate:"required,min=0,max=100"`
+	Words   []string    `json:"words" xml:"Words" validate:"required,min=1,dive,required"`
+	Found   []FoundHash `json:"found,omitempty" xml:"Found" validate:"omitempty,dive"`
+	Percent float64     `json:"percent" xml:"Percent" validate:"required,min=0,max=100"`
+}
+
+type FoundHash struct {
+	Hash string `json:"hash" xml:"Hash" validate:"required"`
+	Word string `json:"word" xml:"Word" validate:"required"`
 }
@@ -0,0 +1,20 @@
+package message
+
+const (
+	WorkerEventRegistered = "REGISTERED"
+	WorkerEventHeartbeat  = "HEARTBEAT"
+)
+
+// WorkerHeartbeat is sent by a worker on startup with the REGISTERED event and then periodically with
+// the HEARTBEAT event. Every message carries the capabilities of the worker, so the manager restores
+// the worker in its registry from any of them
+type WorkerHeartbeat struct {
+	Version       int      `json:"version" xml:"Version"`
+	WorkerID      string   `json:"workerID" xml:"WorkerId" validate:"required"`
+	Event         string   `json:"event" xml:"Event" validate:"required,oneof=REGISTERED HEARTBEAT"`
+	WorkerVersion string   `json:"workerVersion" xml:"WorkerVersion"`
+	CPUCount      int      `json:"cpuCount" xml:"CpuCount" validate:"min=0"`
+	Algorithms    []string `json:"algorithms" xml:"Algorithms" validate:"omitempty,dive,required"`
+	// Speed is the number of candidates hashed by the worker per second in the benchmark on startup
+	Speed float64 `json:"speed" xml:"Speed" validate:"min=0"`
+}
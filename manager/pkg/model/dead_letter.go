@@ -0,0 +1,26 @@
+package model
+
+import "time"
+
+type DeadLetterOutput struct {
+	ID string `json:"id" validate:"required"`
+	// Queue is the queue of the failed consumer, it is empty for a consumer of a fanout exchange
+	Queue string `json:"queue,omitempty"`
+	// Exchange is the fanout exchange of the failed consumer
+	Exchange       string    `json:"exchange,omitempty"`
+	Error          string    `json:"error"`
+	Retries        int       `json:"retries" validate:"min=0"`
+	ContentType    string    `json:"contentType"`
+	Body           string    `json:"body"`
+	DeadLetteredAt time.Time `json:"deadLetteredAt" validate:"required"`
+}
+
+type DeadLettersInput struct {
+	Limit  int `form:"limit,default=10" validate:"required,min=0"`
+	Offset int `form:"offset,default=0" validate:"required,min=0"`
+}
+
+type DeadLettersOutput struct {
+	Count       int64               `json:"count" validate:"required,min=0"`
+	DeadLetters []*DeadLetterOutput `json:"deadLetters" validate:"required,min=0,dive"`
+}
@@ -0,0 +1,25 @@
+package model
+
+import "time"
+
+type RuleSetInput struct {
+	Name  string   `json:"name" validate:"required"`
+	Rules []string `json:"rules" validate:"required,min=1,dive,required"`
+}
+
+type RuleSetOutput struct {
+	ID        string    `json:"id" validate:"required"`
+	Name      string    `json:"name" validate:"required"`
+	Rules     []string  `json:"rules" validate:"required,min=1,dive,required"`
+	CreatedAt time.Time `json:"createdAt" validate:"required"`
+}
+
+type RuleSetsInput struct {
+	Limit  int `form:"limit,default=10" validate:"required,min=0"`
+	Offset int `form:"offset,default=0" validate:"required,min=0"`
+}
+
+type RuleSetsOutput struct {
+	Count    int64            `json:"count" validate:"required,min=0"`
+	RuleSets []*RuleSetOutput `json:"ruleSets" validate:"required,min=0,dive"`
+}
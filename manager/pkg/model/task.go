@@ -3,8 +3,48 @@ package model
 import "time"
 
 type HashCrackTaskInput struct {
-	Hash      string `json:"hash" validate:"required"`
-	MaxLength int    `json:"maxLength" validate:"required,min=1,max=6"`
+	Algorithm  string         `json:"algorithm,omitempty" validate:"omitempty,oneof=MD5 SHA1 SHA256 SHA512 NTLM" default:"MD5"`
+	Hash       string         `json:"hash" validate:"required,hexadecimal"`
+	Salt       *HashCrackSalt `json:"salt,omitempty" validate:"omitempty"`
+	Mode       string         `json:"mode,omitempty" validate:"omitempty,oneof=BRUTE_FORCE DICTIONARY MASK" default:"BRUTE_FORCE"`
+	Alphabet   string         `json:"alphabet,omitempty" validate:"omitempty,printascii"`
+	MinLength  int            `json:"minLength,omitempty" validate:"omitempty,min=1,ltefield=MaxLength" default:"1"`
+	MaxLength  int            `json:"maxLength,omitempty" validate:"required_if=Mode BRUTE_FORCE,omitempty,min=1"`
+	WordlistID string         `json:"wordlistId,omitempty" validate:"required_if=Mode DICTIONARY"`
+	RuleSetID  string         `json:"ruleSetId,omitempty" validate:"omitempty"`
+	Mask       string         `json:"mask,omitempty" validate:"required_if=Mode MASK"`
+	Charsets   []string       `json:"charsets,omitempty" validate:"omitempty,max=4,dive,required"`
+	// StopOnFirstMatch finishes the task as soon as a word of the hash is found
+	StopOnFirstMatch bool `json:"stopOnFirstMatch,omitempty"`
+	// Priority of the task from 0 to 10, subtasks of tasks with a higher priority are executed first
+	Priority int `json:"priority,omitempty" validate:"omitempty,min=0,max=10"`
+	// Timeout of the task as a duration, e.g. 30m, the configured timeout is used if empty
+	Timeout string `json:"timeout,omitempty" validate:"omitempty"`
+}
+
+type HashCrackBatchTaskInput struct {
+	Algorithm  string         `json:"algorithm,omitempty" validate:"omitempty,oneof=MD5 SHA1 SHA256 SHA512 NTLM" default:"MD5"`
+	Hashes     []string       `json:"hashes" validate:"required,min=1,dive,required,hexadecimal"`
+	Salt       *HashCrackSalt `json:"salt,omitempty" validate:"omitempty"`
+	Mode       string         `json:"mode,omitempty" validate:"omitempty,oneof=BRUTE_FORCE DICTIONARY MASK" default:"BRUTE_FORCE"`
+	Alphabet   string         `json:"alphabet,omitempty" validate:"omitempty,printascii"`
+	MinLength  int            `json:"minLength,omitempty" validate:"omitempty,min=1,ltefield=MaxLength" default:"1"`
+	MaxLength  int            `json:"maxLength,omitempty" validate:"required_if=Mode BRUTE_FORCE,omitempty,min=1"`
+	WordlistID string         `json:"wordlistId,omitempty" validate:"required_if=Mode DICTIONARY"`
+	RuleSetID  string         `json:"ruleSetId,omitempty" validate:"omitempty"`
+	Mask       string         `json:"mask,omitempty" validate:"required_if=Mode MASK"`
+	Charsets   []string       `json:"charsets,omitempty" validate:"omitempty,max=4,dive,required"`
+	// StopOnFirstMatch finishes the task as soon as a word of every hash is found
+	StopOnFirstMatch bool `json:"stopOnFirstMatch,omitempty"`
+	// Priority of the task from 0 to 10, subtasks of tasks with a higher priority are executed first
+	Priority int `json:"priority,omitempty" validate:"omitempty,min=0,max=10"`
+	// Timeout of the task as a duration, e.g. 30m, the configured timeout is used if empty
+	Timeout string `json:"timeout,omitempty" validate:"omitempty"`
+}
+
+type HashCrackSalt struct {
+	Value    string `json:"value" validate:"required"`
+	Position string `json:"position" validate:"required,oneof=PREFIX SUFFIX HMAC"`
 }
 
 type HashCrackTaskIDOutput struct {
@@ -12,10 +52,32 @@ type HashCrackTaskIDOutput struct {
 }
 
 type HashCrackTaskStatusOutput struct {
-	Status   string                         `json:"status" validate:"required,oneof=PENDING IN_PROGRESS READY PARTIAL_READY ERROR UNKNOWN"`
-	Data     []string                       `json:"data" validate:"required,min=0,dive,required"`
-	Percent  float64                        `json:"percent" validate:"required,min=0,max=100"`
-	Subtasks []HashCrackSubtaskStatusOutput `json:"subtasks" validate:"required,min=0,dive"`
+	Algorithm string                         `json:"algorithm" validate:"required,oneof=MD5 SHA1 SHA256 SHA512 NTLM"`
+	Hash      string                         `json:"hash,omitempty" validate:"omitempty"`
+	Salt      *HashCrackSalt                 `json:"salt,omitempty" validate:"omitempty"`
+	Mode      string                         `json:"mode" validate:"required,oneof=BRUTE_FORCE DICTIONARY MASK"`
+	Status    string                         `json:"status" validate:"required,oneof=PENDING IN_PROGRESS READY PARTIAL_READY ERROR CANCELLED UNKNOWN"`
+	Data      []string                       `json:"data" validate:"required,min=0,dive,required"`
+	Percent   float64                        `json:"percent" validate:"required,min=0,max=100"`
+	Subtasks  []HashCrackSubtaskStatusOutput `json:"subtasks" validate:"required,min=0,dive"`
+}
+
+type HashCrackBatchTaskStatusOutput struct {
+	Algorithm string                         `json:"algorithm" validate:"required,oneof=MD5 SHA1 SHA256 SHA512 NTLM"`
+	Salt      *HashCrackSalt                 `json:"salt,omitempty" validate:"omitempty"`
+	Mode      string                         `json:"mode" validate:"required,oneof=BRUTE_FORCE DICTIONARY MASK"`
+	Status    string                         `json:"status" validate:"required,oneof=PENDING IN_PROGRESS READY PARTIAL_READY ERROR CANCELLED UNKNOWN"`
+	Total     int                            `json:"total" validate:"required,min=1"`
+	Cracked   int                            `json:"cracked" validate:"required,min=0"`
+	Hashes    []HashCrackHashStatusOutput    `json:"hashes" validate:"required,min=1,dive"`
+	Percent   float64                        `json:"percent" validate:"required,min=0,max=100"`
+	Subtasks  []HashCrackSubtaskStatusOutput `json:"subtasks" validate:"required,min=0,dive"`
+}
+
+type HashCrackHashStatusOutput struct {
+	Hash    string   `json:"hash" validate:"required"`
+	Cracked bool     `json:"cracked"`
+	Words   []string `json:"words" validate:"required,min=0,dive,required"`
 }
 
 type HashCrackTaskMetadataInput struct {
@@ -24,10 +86,34 @@ type HashCrackTaskMetadataInput struct {
 }
 
 type HashCrackTaskMetadataOutput struct {
-	RequestID string    `json:"requestId" validate:"required"`
-	Hash      string    `json:"hash" validate:"required"`
-	MaxLength int       `json:"maxLength" validate:"required,min=1,max=6"`
-	CreatedAt time.Time `json:"createdAt" validate:"required"`
+	RequestID        string         `json:"requestId" validate:"required"`
+	Type             string         `json:"type" validate:"required,oneof=SINGLE BATCH"`
+	Algorithm        string         `json:"algorithm" validate:"required,oneof=MD5 SHA1 SHA256 SHA512 NTLM"`
+	Hash             string         `json:"hash,omitempty" validate:"required_if=Type SINGLE"`
+	HashCount        int            `json:"hashCount" validate:"required,min=1"`
+	Salt             *HashCrackSalt `json:"salt,omitempty" validate:"omitempty"`
+	Mode             string         `json:"mode" validate:"required,oneof=BRUTE_FORCE DICTIONARY MASK"`
+	Alphabet         string         `json:"alphabet,omitempty" validate:"omitempty,printascii"`
+	MinLength        int            `json:"minLength,omitempty" validate:"omitempty,min=1,ltefield=MaxLength"`
+	MaxLength        int            `json:"maxLength,omitempty" validate:"omitempty,min=1"`
+	WordlistID       string         `json:"wordlistId,omitempty" validate:"required_if=Mode DICTIONARY"`
+	RuleSetID        string         `json:"ruleSetId,omitempty" validate:"omitempty"`
+	RuleCount        int            `json:"ruleCount,omitempty" validate:"omitempty,min=1"`
+	Mask             string         `json:"mask,omitempty" validate:"required_if=Mode MASK"`
+	Charsets         []string       `json:"charsets,omitempty" validate:"omitempty,max=4,dive,required"`
+	StopOnFirstMatch bool           `json:"stopOnFirstMatch,omitempty"`
+	Priority         int            `json:"priority,omitempty" validate:"omitempty,min=0,max=10"`
+	Deadline         *time.Time     `json:"deadline,omitempty" validate:"omitempty"`
+	CreatedAt        time.Time      `json:"createdAt" validate:"required"`
+}
+
+type HashCrackTaskExtendInput struct {
+	// Timeout is the duration, e.g. 30m, the deadline of the task is extended by
+	Timeout string `json:"timeout" validate:"required"`
+}
+
+type HashCrackTaskDeadlineOutput struct {
+	Deadline time.Time `json:"deadline" validate:"required"`
 }
 
 type HashCrackTaskMetadatasOutput struct {
@@ -36,7 +122,7 @@ type HashCrackTaskMetadatasOutput struct {
 }
 
 type HashCrackSubtaskStatusOutput struct {
-	Status  string   `json:"status" validate:"required,oneof=PENDING IN_PROGRESS SUCCESS ERROR UNKNOWN"`
+	Status  string   `json:"status" validate:"required,oneof=PENDING IN_PROGRESS SUCCESS ERROR CANCELLED SKIPPED UNKNOWN"`
 	Data    []string `json:"data" validate:"required,min=0,dive,required"`
 	Percent float64  `json:"percent" validate:"required,min=0,max=100"`
 }
@@ -0,0 +1,26 @@
+package model
+
+import "time"
+
+type WordlistOutput struct {
+	ID        string    `json:"id" validate:"required"`
+	Name      string    `json:"name" validate:"required"`
+	LineCount int       `json:"lineCount" validate:"required,min=0"`
+	Size      int64     `json:"size" validate:"required,min=0"`
+	CreatedAt time.Time `json:"createdAt" validate:"required"`
+}
+
+type WordlistsInput struct {
+	Limit  int `form:"limit,default=10" validate:"required,min=0"`
+	Offset int `form:"offset,default=0" validate:"required,min=0"`
+}
+
+type WordlistsOutput struct {
+	Count     int64             `json:"count" validate:"required,min=0"`
+	Wordlists []*WordlistOutput `json:"wordlists" validate:"required,min=0,dive"`
+}
+
+type WordlistContentInput struct {
+	Offset int `form:"offset,default=0" validate:"required,min=0"`
+	Limit  int `form:"limit,default=0" validate:"required,min=0"`
+}
@@ -0,0 +1,30 @@
+package model
+
+import "time"
+
+const (
+	WorkerStatusOnline  = "ONLINE"
+	WorkerStatusOffline = "OFFLINE"
+)
+
+type WorkerOutput struct {
+	ID         string   `json:"id" validate:"required"`
+	Version    string   `json:"version"`
+	Status     string   `json:"status" validate:"required,oneof=ONLINE OFFLINE"`
+	CPUCount   int      `json:"cpuCount" validate:"min=0"`
+	Algorithms []string `json:"algorithms" validate:"dive,required"`
+	// Speed is the number of candidates hashed by the worker per second in the benchmark
+	Speed           float64   `json:"speed" validate:"min=0"`
+	RegisteredAt    time.Time `json:"registeredAt" validate:"required"`
+	LastHeartbeatAt time.Time `json:"lastHeartbeatAt" validate:"required"`
+}
+
+type WorkersInput struct {
+	Limit  int `form:"limit,default=10" validate:"required,min=0"`
+	Offset int `form:"offset,default=0" validate:"required,min=0"`
+}
+
+type WorkersOutput struct {
+	Count   int64           `json:"count" validate:"required,min=0"`
+	Workers []*WorkerOutput `json:"workers" validate:"required,min=0,dive"`
+}
@@ -66,6 +66,10 @@ func runServer(ctx context.Context, _ *cli.Command) error {
 	wg, consumerCancel := startAMQPConsumer(ctx, c)
 	defer stopAMQPConsumer(ctx, wg, consumerCancel)
 
+	// Start heartbeats
+	heartbeatDone, heartbeatCancel := startHeartbeat(ctx, c)
+	defer stopHeartbeat(ctx, heartbeatDone, heartbeatCancel)
+
 	// Wait for signal
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM, syscall.SIGQUIT)
@@ -121,3 +125,40 @@ func stopAMQPConsumer(_ context.Context, wg *sync.WaitGroup, cancel context.Canc
 	cancel()
 	wg.Wait()
 }
+
+func startHeartbeat(ctx context.Context, c *di.Container) (<-chan struct{}, context.CancelFunc) {
+	done := make(chan struct{})
+	heartbeatCtx, heartbeatCancel := context.WithCancel(ctx)
+
+	go func() {
+		defer close(done)
+
+		if err := c.DomainSVCs.Registration.Register(heartbeatCtx); err != nil {
+			log.Error().Err(err).Stack().Msg("failed to register worker")
+		}
+
+		ticker := time.NewTicker(c.Config.Heartbeat.Period)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-heartbeatCtx.Done():
+				return
+			case <-ticker.C:
+				if err := c.DomainSVCs.Registration.Heartbeat(heartbeatCtx); err != nil {
+					log.Error().Err(err).Stack().Msg("failed to send heartbeat")
+				}
+			}
+		}
+	}()
+
+	log.Info().Msg("heartbeats started")
+
+	return done, heartbeatCancel
+}
+
+func stopHeartbeat(_ context.Context, done <-chan struct{}, cancel context.CancelFunc) {
+	log.Info().Msg("stopping heartbeats")
+	cancel()
+	<-done
+}
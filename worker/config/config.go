@@ -9,15 +9,22 @@ import (
 const (
 	EnvDev  Env = "dev"
 	EnvProd Env = "prod"
+
+	TransportAMQP Transport = "amqp"
+	TransportHTTP Transport = "http"
 )
 
 type (
-	Env string
+	Env       string
+	Transport string
 
 	Config struct {
-		Server ServerConfig
-		AMQP   AMQPConfig
-		Task   TaskConfig
+		Server    ServerConfig
+		Transport TransportConfig
+		AMQP      AMQPConfig `validate:"-"`
+		Manager   ManagerConfig
+		Task      TaskConfig
+		Heartbeat HeartbeatConfig
 	}
 
 	ServerConfig struct {
@@ -34,25 +41,59 @@ type (
 		MaxAge           time.Duration `default:"24h"`
 	}
 
+	// TransportConfig selects the transport between the manager and workers, it must match the transport
+	// of the manager. The AMQP config is validated only for the amqp transport
+	TransportConfig struct {
+		Type Transport `default:"amqp" validate:"oneof=amqp http"`
+		HTTP HTTPTransportConfig
+	}
+
+	// HTTPTransportConfig configures the http transport. Subtasks posted by the manager wait in the local queue
+	// of the queue size and are executed by the number of runners at once like with the AMQP prefetch
+	HTTPTransportConfig struct {
+		QueueSize   int `default:"100" validate:"min=1"`
+		Concurrency int `default:"10" validate:"min=1"`
+	}
+
+	// AMQPConfig configures the AMQP transport. Handlers of consumers in flight are waited for the drain
+	// timeout on shutdown, then they are cancelled and their messages are requeued
 	AMQPConfig struct {
-		URIs       []string `validate:"required,min=1,dive,required"`
-		Username   string   `validate:"required"`
-		Password   string   `validate:"required"`
-		Prefetch   int      `default:"10" validate:"min=1"`
-		Consumers  AMQPConsumersConfig
-		Publishers AMQPPublishersConfig
+		URIs         []string      `validate:"required,min=1,dive,required"`
+		Username     string        `validate:"required"`
+		Password     string        `validate:"required"`
+		Prefetch     int           `default:"10" validate:"min=1"`
+		DrainTimeout time.Duration `default:"10s"`
+		Consumers    AMQPConsumersConfig
+		Publishers   AMQPPublishersConfig
+		DeadLetter   AMQPDeadLetterConfig
 	}
 
 	AMQPConsumersConfig struct {
-		TaskStarted AMQPConsumerConfig
+		TaskStarted   AMQPConsumerConfig
+		TaskCancelled AMQPExchangeConsumerConfig
+		TaskShrunk    AMQPExchangeConsumerConfig
 	}
 
+	// AMQPConsumerConfig configures a consumer of the queue. A failed message is redelivered max retries
+	// times, the queue must dead-letter rejected messages to the delay queue if max retries is positive.
+	// Subtasks are executed by max in flight handlers at once, they have no timeout if it is 0
 	AMQPConsumerConfig struct {
-		Queue string `validate:"required"`
+		Queue       string        `validate:"required"`
+		MaxRetries  int           `default:"3" validate:"min=0"`
+		MaxInFlight int           `default:"10" validate:"min=0"`
+		Timeout     time.Duration `default:"0s"`
+	}
+
+	// AMQPExchangeConsumerConfig configures a consumer getting all messages of a fanout exchange
+	AMQPExchangeConsumerConfig struct {
+		Exchange    string        `validate:"required"`
+		MaxInFlight int           `default:"10" validate:"min=0"`
+		Timeout     time.Duration `default:"10s"`
 	}
 
 	AMQPPublishersConfig struct {
-		TaskResult AMQPPublisherConfig
+		TaskResult      AMQPPublisherConfig
+		WorkerHeartbeat AMQPPublisherConfig
 	}
 
 	AMQPPublisherConfig struct {
@@ -60,13 +101,43 @@ type (
 		RoutingKey string `validate:"required"`
 	}
 
+	// AMQPDeadLetterConfig configures the exchange getting messages, which consumers failed to handle
+	AMQPDeadLetterConfig struct {
+		Exchange   string `validate:"required"`
+		RoutingKey string `validate:"required"`
+	}
+
+	// ManagerConfig configures the client of managers. Requests are sent to active managers by the strategy
+	// of the load balancer, weights are set in the order of URIs for the weighted strategy. A manager
+	// is ejected after max failures in a row and probed after the eject timeout
+	ManagerConfig struct {
+		URIs          []string      `validate:"required,min=1,dive,required"`
+		Strategy      string        `default:"round-robin" validate:"oneof=round-robin least-outstanding weighted"`
+		Weights       []int         `validate:"omitempty,dive,min=1"`
+		Retries       int           `default:"3" validate:"min=0"`
+		MinRetryWait  time.Duration `default:"100ms"`
+		MaxRetryWait  time.Duration `default:"2s"`
+		HealthTimeout time.Duration `default:"5s"`
+		HealthDelay   time.Duration `default:"10s"`
+		MaxFailures   int           `default:"5" validate:"min=0"`
+		EjectTimeout  time.Duration `default:"30s" validate:"required"`
+	}
+
 	TaskConfig struct {
 		Split          TaskSplitConfig
 		ProgressPeriod time.Duration `default:"5s" validate:"required"`
 	}
 
 	TaskSplitConfig struct {
-		Strategy  string `default:"chunk-based" validate:"oneof=chunk-based"`
-		ChunkSize int    `default:"10000000" validate:"min=1"`
+		Strategy    string `default:"chunk-based" validate:"oneof=chunk-based"`
+		Parallelism int    `default:"0" validate:"min=0"`
+	}
+
+	// HeartbeatConfig configures the announcement of the worker to the manager. The worker ID defaults
+	// to the hostname, the speed is measured by a brute force during the benchmark duration on startup
+	HeartbeatConfig struct {
+		WorkerID          string
+		Period            time.Duration `default:"10s" validate:"required"`
+		BenchmarkDuration time.Duration `default:"1s" validate:"required"`
 	}
 )
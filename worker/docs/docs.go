@@ -67,6 +67,138 @@ const docTemplate = `{
                 }
             }
         },
+        "/internal/v1/tasks": {
+            "post": {
+                "description": "Request of the manager for starting a subtask in the http transport, the subtask is queued",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Hash Crack Task API"
+                ],
+                "summary": "Start subtask",
+                "operationId": "StartTask",
+                "parameters": [
+                    {
+                        "description": "Started subtask",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/message.HashCrackTaskStarted"
+                        }
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorOutput"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorOutput"
+                        }
+                    }
+                }
+            }
+        },
+        "/internal/v1/tasks/cancel": {
+            "post": {
+                "description": "Request of the manager for cancelling subtasks of a task in the http transport",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Hash Crack Task API"
+                ],
+                "summary": "Cancel task",
+                "operationId": "CancelTask",
+                "parameters": [
+                    {
+                        "description": "Cancelled task",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/message.HashCrackTaskCancelled"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorOutput"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorOutput"
+                        }
+                    }
+                }
+            }
+        },
+        "/internal/v1/tasks/shrink": {
+            "post": {
+                "description": "Request of the manager for shrinking the range of a subtask in the http transport",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Hash Crack Task API"
+                ],
+                "summary": "Shrink subtask",
+                "operationId": "ShrinkTask",
+                "parameters": [
+                    {
+                        "description": "Shrunk subtask",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/message.HashCrackTaskShrunk"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorOutput"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorOutput"
+                        }
+                    }
+                }
+            }
+        },
         "/swagger/api-docs.json": {
             "get": {
                 "description": "Request for getting swagger specification in JSON",
@@ -137,6 +269,203 @@ const docTemplate = `{
                     "type": "string"
                 }
             }
+        },
+        "message.Alphabet": {
+            "type": "object",
+            "required": [
+                "symbols"
+            ],
+            "properties": {
+                "symbols": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "message.HashCrackTaskCancelled": {
+            "type": "object",
+            "required": [
+                "requestID"
+            ],
+            "properties": {
+                "requestID": {
+                    "type": "string"
+                }
+            }
+        },
+        "message.HashCrackTaskShrunk": {
+            "type": "object",
+            "required": [
+                "requestID"
+            ],
+            "properties": {
+                "endIndex": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "partNumber": {
+                    "type": "integer"
+                },
+                "requestID": {
+                    "type": "string"
+                },
+                "version": {
+                    "type": "integer"
+                }
+            }
+        },
+        "message.HashCrackTaskStarted": {
+            "type": "object",
+            "required": [
+                "hashes",
+                "requestID",
+                "rules"
+            ],
+            "properties": {
+                "algorithm": {
+                    "type": "string",
+                    "enum": [
+                        "MD5",
+                        "SHA1",
+                        "SHA256",
+                        "SHA512",
+                        "NTLM"
+                    ]
+                },
+                "alphabet": {
+                    "$ref": "#/definitions/message.Alphabet"
+                },
+                "attempt": {
+                    "description": "Attempt is the number of the sending of the part, the worker reports it in the results",
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "endIndex": {
+                    "type": "integer"
+                },
+                "hash": {
+                    "type": "string"
+                },
+                "hashes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "mask": {
+                    "$ref": "#/definitions/message.Mask"
+                },
+                "maxLength": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "minLength": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "mode": {
+                    "type": "string",
+                    "enum": [
+                        "BRUTE_FORCE",
+                        "DICTIONARY",
+                        "MASK"
+                    ]
+                },
+                "partCount": {
+                    "type": "integer"
+                },
+                "partNumber": {
+                    "type": "integer"
+                },
+                "priority": {
+                    "type": "integer",
+                    "maximum": 10
+                },
+                "requestID": {
+                    "type": "string"
+                },
+                "resumeOffset": {
+                    "description": "ResumeOffset is the number of leading candidates of the part checked by the previous attempts,\nthe worker continues from it. Candidates of a dictionary part are counted in wordlist lines",
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "rules": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "salt": {
+                    "$ref": "#/definitions/message.Salt"
+                },
+                "startIndex": {
+                    "description": "StartIndex and EndIndex are the range of candidate indexes of a brute force or mask part,\nthe end is exclusive. A dictionary part is the range of the wordlist lines",
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "version": {
+                    "type": "integer"
+                },
+                "wordlist": {
+                    "$ref": "#/definitions/message.Wordlist"
+                }
+            }
+        },
+        "message.Mask": {
+            "type": "object",
+            "required": [
+                "charsets"
+            ],
+            "properties": {
+                "charsets": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "message.Salt": {
+            "type": "object",
+            "required": [
+                "position"
+            ],
+            "properties": {
+                "position": {
+                    "type": "string",
+                    "enum": [
+                        "PREFIX",
+                        "SUFFIX",
+                        "HMAC"
+                    ]
+                },
+                "value": {
+                    "type": "string"
+                }
+            }
+        },
+        "message.Wordlist": {
+            "type": "object",
+            "required": [
+                "id"
+            ],
+            "properties": {
+                "count": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "id": {
+                    "type": "string"
+                },
+                "offset": {
+                    "type": "integer",
+                    "minimum": 0
+                }
+            }
         }
     },
     "tags": [
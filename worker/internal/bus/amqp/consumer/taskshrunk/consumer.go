@@ -0,0 +1,44 @@
+package taskshrunk
+
+import (
+	"context"
+	"fmt"
+
+	amqp1 "github.com/rabbitmq/amqp091-go"
+
+	"github.com/ptrvsrg/crack-hash/commonlib/bus/amqp"
+	"github.com/ptrvsrg/crack-hash/commonlib/bus/amqp/consumer"
+	"github.com/ptrvsrg/crack-hash/manager/pkg/message"
+	"github.com/ptrvsrg/crack-hash/worker/config"
+	"github.com/ptrvsrg/crack-hash/worker/internal/service/domain"
+)
+
+func NewConsumer(ch *amqp.Channel, cfg config.AMQPConfig, svc domain.HashCrackTask) consumer.Consumer {
+	consumerCfg := cfg.Consumers.TaskShrunk
+
+	return consumer.New(
+		ch, handle(svc),
+		consumer.Config{
+			Exchange:             consumerCfg.Exchange,
+			Consumer:             "",
+			AutoAck:              false,
+			NoLocal:              false,
+			NoWait:               false,
+			DeadLetterExchange:   cfg.DeadLetter.Exchange,
+			DeadLetterRoutingKey: cfg.DeadLetter.RoutingKey,
+			MaxInFlight:          consumerCfg.MaxInFlight,
+			Timeout:              consumerCfg.Timeout,
+			DrainTimeout:         cfg.DrainTimeout,
+		},
+	)
+}
+
+func handle(svc domain.HashCrackTask) consumer.Handler[message.HashCrackTaskShrunk] {
+	return func(ctx context.Context, msg message.HashCrackTaskShrunk, _ amqp1.Delivery) error {
+		if err := svc.ShrinkTask(ctx, &msg); err != nil {
+			return fmt.Errorf("failed to shrink task: %w", err)
+		}
+
+		return nil
+	}
+}
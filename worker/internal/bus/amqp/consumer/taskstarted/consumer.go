@@ -2,6 +2,7 @@ package taskstarted
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	amqp1 "github.com/rabbitmq/amqp091-go"
@@ -13,34 +14,38 @@ import (
 	"github.com/ptrvsrg/crack-hash/worker/internal/service/domain"
 )
 
-func NewConsumer(ch *amqp.Channel, cfg config.AMQPConsumerConfig, svc domain.HashCrackTask) consumer.Consumer {
+func NewConsumer(ch *amqp.Channel, cfg config.AMQPConfig, svc domain.HashCrackTask) consumer.Consumer {
+	consumerCfg := cfg.Consumers.TaskStarted
+
 	return consumer.New(
 		ch, handle(svc),
 		consumer.Config{
-			Queue:     cfg.Queue,
-			Consumer:  "",
-			AutoAck:   false,
-			Exclusive: false,
-			NoLocal:   false,
-			NoWait:    false,
+			Queue:                consumerCfg.Queue,
+			Consumer:             "",
+			AutoAck:              false,
+			Exclusive:            false,
+			NoLocal:              false,
+			NoWait:               false,
+			MaxRetries:           consumerCfg.MaxRetries,
+			DeadLetterExchange:   cfg.DeadLetter.Exchange,
+			DeadLetterRoutingKey: cfg.DeadLetter.RoutingKey,
+			MaxInFlight:          consumerCfg.MaxInFlight,
+			Timeout:              consumerCfg.Timeout,
+			DrainTimeout:         cfg.DrainTimeout,
 		},
 	)
 }
 
 func handle(svc domain.HashCrackTask) consumer.Handler[message.HashCrackTaskStarted] {
-	return func(ctx context.Context, msg message.HashCrackTaskStarted, delivery amqp1.Delivery) error {
-		if err := svc.ExecuteTask(ctx, &msg); err != nil {
-			if err := delivery.Reject(true); err != nil {
-				return fmt.Errorf("failed to reject message: %w", err)
-			}
-
+	return func(ctx context.Context, msg message.HashCrackTaskStarted, _ amqp1.Delivery) error {
+		err := svc.ExecuteTask(ctx, &msg)
+		switch {
+		case err == nil:
+			return nil
+		case errors.Is(err, domain.ErrInvalidTask) || errors.Is(err, domain.ErrUnsupportedVersion):
+			return consumer.Permanent(fmt.Errorf("failed to execute task: %w", err))
+		default:
 			return fmt.Errorf("failed to execute task: %w", err)
 		}
-
-		if err := delivery.Ack(false); err != nil {
-			return fmt.Errorf("failed to ack message: %w", err)
-		}
-
-		return nil
 	}
 }
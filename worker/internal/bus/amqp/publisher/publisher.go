@@ -6,5 +6,6 @@ import (
 )
 
 type Publishers struct {
-	TaskResult publisher.Publisher[message.HashCrackTaskResult]
+	TaskResult      publisher.Publisher[message.HashCrackTaskResult]
+	WorkerHeartbeat publisher.Publisher[message.WorkerHeartbeat]
 }
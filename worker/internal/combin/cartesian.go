@@ -2,57 +2,90 @@ package combin
 
 import (
 	"errors"
+	"math"
 	"sync"
 )
 
 // Global error variables
 var (
 	ErrEmptyAlphabet        = errors.New("alphabet must not be empty")
-	ErrInvalidMaxLength     = errors.New("maxLength must be positive")
+	ErrInvalidMinLength     = errors.New("minLength must be positive")
+	ErrInvalidMaxLength     = errors.New("maxLength must not be less than minLength")
 	ErrInvalidStartIndex    = errors.New("startIndex must be non-negative")
+	ErrInvalidEndIndex      = errors.New("endIndex must not be less than startIndex")
 	ErrStartIndexOutOfRange = errors.New("startIndex exceeds the total number of combinations")
 )
 
 // AlphabetIterator iterates over all combinations of strings from the given alphabet
-// with lengths ranging from 1 to maxLength.
+// with lengths ranging from minLength to maxLength.
 type AlphabetIterator struct {
-	alphabet   string       // Alphabet from which combinations are generated
-	maxLength  int          // Maximum length of combinations
-	current    []rune       // Current combination
-	length     int          // Current length of the combination
-	done       bool         // Flag indicating if iteration is complete
-	startIndex int          // Starting index for generation
-	rw         sync.RWMutex // Mutex for thread-safe access
+	alphabet  string       // Alphabet from which combinations are generated
+	maxLength int          // Maximum length of combinations
+	indexes   []int        // Indexes of the current characters in the alphabet
+	current   []byte       // Current combination
+	length    int          // Current length of the combination
+	remaining int          // Number of combinations left before the end index
+	started   bool         // Flag indicating if the first combination is returned
+	done      bool         // Flag indicating if iteration is complete
+	rw        sync.RWMutex // Mutex for thread-safe access
 }
 
 // NewAlphabetIterator creates a new iterator for generating combinations of strings
-// from the given alphabet with lengths ranging from 1 to maxLength.
-// The `startIndex` parameter specifies the index of the first combination to generate.
-func NewAlphabetIterator(alphabet string, maxLength int, startIndex int) (*AlphabetIterator, error) {
+// from the given alphabet with lengths ranging from minLength to maxLength.
+// The `startIndex` parameter specifies the index of the first combination to generate,
+// the iterator seeks to it directly without generating the previous combinations.
+func NewAlphabetIterator(alphabet string, minLength, maxLength int, startIndex int) (*AlphabetIterator, error) {
+	return NewBoundedAlphabetIterator(alphabet, minLength, maxLength, startIndex, math.MaxInt)
+}
+
+// NewBoundedAlphabetIterator creates a new iterator like NewAlphabetIterator, which stops
+// before the combination with the `endIndex` index.
+func NewBoundedAlphabetIterator(
+	alphabet string, minLength, maxLength int, startIndex, endIndex int,
+) (*AlphabetIterator, error) {
 	if len(alphabet) == 0 {
 		return nil, ErrEmptyAlphabet
 	}
-	if maxLength <= 0 {
+	if minLength <= 0 {
+		return nil, ErrInvalidMinLength
+	}
+	if maxLength < minLength {
 		return nil, ErrInvalidMaxLength
 	}
 	if startIndex < 0 {
 		return nil, ErrInvalidStartIndex
 	}
+	if endIndex < startIndex {
+		return nil, ErrInvalidEndIndex
+	}
 
 	it := &AlphabetIterator{
-		alphabet:   alphabet,
-		maxLength:  maxLength,
-		current:    make([]rune, maxLength),
-		length:     1,
-		done:       false,
-		startIndex: startIndex,
+		alphabet:  alphabet,
+		maxLength: maxLength,
+		indexes:   make([]int, maxLength),
+		current:   make([]byte, maxLength),
+		remaining: endIndex - startIndex,
 	}
 
-	// Fast-forward the iterator to the starting index
-	for i := 0; i < startIndex; i++ {
-		if !it.Next() {
-			return nil, ErrStartIndexOutOfRange
+	// Find the length of the starting combination, skipping all combinations of shorter lengths
+	index := startIndex
+	for it.length = minLength; it.length <= maxLength; it.length++ {
+		count := power(len(alphabet), it.length)
+		if index < count {
+			break
 		}
+		index -= count
+	}
+
+	if it.length > maxLength {
+		return nil, ErrStartIndexOutOfRange
+	}
+
+	// Seek to the starting combination by converting its index to the alphabet-based numeral system
+	for i := it.length - 1; i >= 0; i-- {
+		it.indexes[i] = index % len(alphabet)
+		it.current[i] = alphabet[it.indexes[i]]
+		index /= len(alphabet)
 	}
 
 	return it, nil
@@ -64,35 +97,37 @@ func (it *AlphabetIterator) Next() bool {
 	it.rw.Lock()
 	defer it.rw.Unlock()
 
-	if it.done {
+	if it.done || it.remaining == 0 {
+		it.done = true
 		return false
 	}
+	it.remaining--
 
-	// If the current combination is not initialized, start with the first character
-	if it.current[0] == 0 {
-		it.current[0] = rune(it.alphabet[0])
+	// The first combination is the one at the starting index
+	if !it.started {
+		it.started = true
 		return true
 	}
 
 	// Iterate through the characters in the current combination
 	for i := it.length - 1; i >= 0; i-- {
 		// Find the next character in the alphabet
-		nextIndex := indexOf(it.alphabet, byte(it.current[i]))
-		if nextIndex+1 < len(it.alphabet) {
-			it.current[i] = rune(it.alphabet[nextIndex+1])
+		if it.indexes[i]+1 < len(it.alphabet) {
+			it.indexes[i]++
+			it.current[i] = it.alphabet[it.indexes[i]]
 			return true
-		} else {
-			// If the end of the alphabet is reached, reset the character and move to the previous one
-			it.current[i] = rune(it.alphabet[0])
 		}
+
+		// If the end of the alphabet is reached, reset the character and move to the previous one
+		it.indexes[i] = 0
+		it.current[i] = it.alphabet[0]
 	}
 
 	// If all combinations of the current length are exhausted, increase the length
 	if it.length < it.maxLength {
 		it.length++
-		for i := 0; i < it.length; i++ {
-			it.current[i] = rune(it.alphabet[0])
-		}
+		it.indexes[it.length-1] = 0
+		it.current[it.length-1] = it.alphabet[0]
 		return true
 	}
 
@@ -109,12 +144,15 @@ func (it *AlphabetIterator) Current() string {
 	return string(it.current[:it.length])
 }
 
-// Helper function to find the index of a character in a string
-func indexOf(s string, c byte) int {
-	for i := 0; i < len(s); i++ {
-		if s[i] == c {
-			return i
+// power returns base^exp, saturating at the maximum int value
+func power(base, exp int) int {
+	result := 1
+	for i := 0; i < exp; i++ {
+		if result > math.MaxInt/base {
+			return math.MaxInt
 		}
+		result *= base
 	}
-	return -1
+
+	return result
 }
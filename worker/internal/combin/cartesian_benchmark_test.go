@@ -10,7 +10,46 @@ func BenchmarkAlphabetIterator_Next(b *testing.B) {
 	alphabet := "abcdefghijklmnopqrstuvwxyz0123456789"
 	maxLength := 100
 
-	iterator, err := NewAlphabetIterator(alphabet, maxLength, 0)
+	iterator, err := NewAlphabetIterator(alphabet, 1, maxLength, 0)
+	require.NoError(b, err)
+
+	for i := 0; i < b.N; i++ {
+		iterator.Next()
+	}
+}
+
+func BenchmarkNewAlphabetIterator_Seek(b *testing.B) {
+	alphabet := "abcdefghijklmnopqrstuvwxyz0123456789"
+	maxLength := 12
+	startIndex := 1 << 60
+
+	for i := 0; i < b.N; i++ {
+		_, err := NewAlphabetIterator(alphabet, 1, maxLength, startIndex)
+		require.NoError(b, err)
+	}
+}
+
+func BenchmarkNewBoundedAlphabetIterator_Part(b *testing.B) {
+	alphabet := "abcdefghijklmnopqrstuvwxyz0123456789"
+	maxLength := 8
+	chunkSize := 10_000_000
+
+	for i := 0; i < b.N; i++ {
+		partNumber := i % 280_000
+		_, err := NewBoundedAlphabetIterator(
+			alphabet, 1, maxLength, partNumber*chunkSize, (partNumber+1)*chunkSize,
+		)
+		require.NoError(b, err)
+	}
+}
+
+func BenchmarkMaskIterator_Next(b *testing.B) {
+	charsets := make([]string, 100)
+	for i := range charsets {
+		charsets[i] = "abcdefghijklmnopqrstuvwxyz0123456789"
+	}
+
+	iterator, err := NewMaskIterator(charsets, 0)
 	require.NoError(b, err)
 
 	for i := 0; i < b.N; i++ {
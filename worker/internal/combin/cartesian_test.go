@@ -21,7 +21,7 @@ func TestAlphabetIterator_Next(t *testing.T) {
 
 	t.Run(
 		"Without start index", func(t *testing.T) {
-			iterator, err := combin.NewAlphabetIterator(alphabet, maxLength, 0)
+			iterator, err := combin.NewAlphabetIterator(alphabet, 1, maxLength, 0)
 			require.NoError(t, err)
 
 			results := make([]string, 0)
@@ -40,7 +40,7 @@ func TestAlphabetIterator_Next(t *testing.T) {
 		"With start index", func(t *testing.T) {
 			startIndex := 10
 
-			iterator, err := combin.NewAlphabetIterator(alphabet, maxLength, startIndex)
+			iterator, err := combin.NewAlphabetIterator(alphabet, 1, maxLength, startIndex)
 			require.NoError(t, err)
 
 			results := make([]string, 0)
@@ -56,6 +56,133 @@ func TestAlphabetIterator_Next(t *testing.T) {
 			)
 		},
 	)
+
+	t.Run(
+		"Seek matches iteration", func(t *testing.T) {
+			all, err := combin.NewAlphabetIterator(alphabet, 1, maxLength, 0)
+			require.NoError(t, err)
+
+			expected := make([]string, 0)
+			for all.Next() {
+				expected = append(expected, all.Current())
+			}
+
+			for startIndex := range expected {
+				iterator, err := combin.NewAlphabetIterator(alphabet, 1, maxLength, startIndex)
+				require.NoError(t, err)
+
+				require.True(t, iterator.Next())
+				require.Equal(t, expected[startIndex], iterator.Current())
+			}
+		},
+	)
+
+	t.Run(
+		"With min length", func(t *testing.T) {
+			iterator, err := combin.NewAlphabetIterator(alphabet, 2, maxLength, 0)
+			require.NoError(t, err)
+
+			results := make([]string, 0)
+			for iterator.Next() {
+				results = append(results, iterator.Current())
+			}
+
+			require.Len(t, results, 9+27)
+			require.Equal(t, "aa", results[0])
+			require.Equal(t, "aaa", results[9])
+			require.Equal(t, "ccc", results[len(results)-1])
+		},
+	)
+}
+
+func TestNewBoundedAlphabetIterator(t *testing.T) {
+	t.Run(
+		"Stops before end index", func(t *testing.T) {
+			iterator, err := combin.NewBoundedAlphabetIterator("abc", 1, 3, 2, 5)
+			require.NoError(t, err)
+
+			results := make([]string, 0)
+			for iterator.Next() {
+				results = append(results, iterator.Current())
+			}
+
+			require.Equal(t, []string{"c", "aa", "ab"}, results)
+		},
+	)
+
+	t.Run(
+		"End index beyond the last combination", func(t *testing.T) {
+			iterator, err := combin.NewBoundedAlphabetIterator("abc", 1, 2, 10, 100)
+			require.NoError(t, err)
+
+			results := make([]string, 0)
+			for iterator.Next() {
+				results = append(results, iterator.Current())
+			}
+
+			require.Equal(t, []string{"cb", "cc"}, results)
+		},
+	)
+
+	t.Run(
+		"Empty range", func(t *testing.T) {
+			iterator, err := combin.NewBoundedAlphabetIterator("abc", 1, 2, 3, 3)
+			require.NoError(t, err)
+
+			require.False(t, iterator.Next())
+		},
+	)
+
+	t.Run(
+		"Invalid end index", func(t *testing.T) {
+			iterator, err := combin.NewBoundedAlphabetIterator("abc", 1, 2, 3, 2)
+
+			require.ErrorIs(t, err, combin.ErrInvalidEndIndex)
+			require.Nil(t, iterator)
+		},
+	)
+}
+
+func TestNewAlphabetIterator(t *testing.T) {
+	testCases := []struct {
+		name        string
+		alphabet    string
+		minLength   int
+		maxLength   int
+		startIndex  int
+		expectedErr error
+	}{
+		{"Empty alphabet", "", 1, 3, 0, combin.ErrEmptyAlphabet},
+		{"Invalid min length", "abc", 0, 3, 0, combin.ErrInvalidMinLength},
+		{"Max length less than min length", "abc", 3, 2, 0, combin.ErrInvalidMaxLength},
+		{"Negative start index", "abc", 1, 3, -1, combin.ErrInvalidStartIndex},
+		{"Start index out of range", "abc", 2, 2, 9, combin.ErrStartIndexOutOfRange},
+	}
+
+	for _, tc := range testCases {
+		t.Run(
+			tc.name, func(t *testing.T) {
+				// Act
+				iterator, err := combin.NewAlphabetIterator(tc.alphabet, tc.minLength, tc.maxLength, tc.startIndex)
+
+				// Assert
+				require.ErrorIs(t, err, tc.expectedErr)
+				require.Nil(t, iterator)
+			},
+		)
+	}
+
+	t.Run(
+		"Seeks without iterating", func(t *testing.T) {
+			// Act
+			iterator, err := combin.NewAlphabetIterator("abcdefghijklmnopqrstuvwxyz0123456789", 12, 12, 1<<60)
+
+			// Assert
+			require.NoError(t, err)
+			require.True(t, iterator.Next())
+			require.Len(t, iterator.Current(), 12)
+		},
+	)
 }
 
 func sumOfGeomSeries(a, r float64, n int) float64 {
@@ -0,0 +1,120 @@
+package combin
+
+import (
+	"errors"
+	"math"
+	"sync"
+)
+
+// Global error variables
+var (
+	ErrEmptyMask    = errors.New("mask must not be empty")
+	ErrEmptyCharset = errors.New("charset of a mask position must not be empty")
+)
+
+// MaskIterator iterates over all words matching a mask, every position of the mask has its own charset.
+// The last position changes fastest, so the index of a word is its number in the mixed radix system
+// with the charset sizes as the bases.
+type MaskIterator struct {
+	charsets  [][]rune     // Charsets of the mask positions
+	indexes   []int        // Indexes of the current characters in the charsets
+	current   []rune       // Current word
+	remaining int          // Number of words left before the end index
+	started   bool         // Flag indicating if the first word is returned
+	done      bool         // Flag indicating if iteration is complete
+	rw        sync.RWMutex // Mutex for thread-safe access
+}
+
+// NewMaskIterator creates a new iterator for generating words matching the mask given by the charsets of its
+// positions. The `startIndex` parameter specifies the index of the first word to generate, the iterator seeks
+// to it directly without generating the previous words.
+func NewMaskIterator(charsets []string, startIndex int) (*MaskIterator, error) {
+	return NewBoundedMaskIterator(charsets, startIndex, math.MaxInt)
+}
+
+// NewBoundedMaskIterator creates a new iterator like NewMaskIterator, which stops
+// before the word with the `endIndex` index.
+func NewBoundedMaskIterator(charsets []string, startIndex, endIndex int) (*MaskIterator, error) {
+	if len(charsets) == 0 {
+		return nil, ErrEmptyMask
+	}
+	if startIndex < 0 {
+		return nil, ErrInvalidStartIndex
+	}
+	if endIndex < startIndex {
+		return nil, ErrInvalidEndIndex
+	}
+
+	it := &MaskIterator{
+		charsets:  make([][]rune, len(charsets)),
+		indexes:   make([]int, len(charsets)),
+		current:   make([]rune, len(charsets)),
+		remaining: endIndex - startIndex,
+	}
+
+	for i, charset := range charsets {
+		if len(charset) == 0 {
+			return nil, ErrEmptyCharset
+		}
+		it.charsets[i] = []rune(charset)
+	}
+
+	// Seek to the starting index by converting it to the mixed radix system
+	index := startIndex
+	for i := len(it.charsets) - 1; i >= 0; i-- {
+		base := len(it.charsets[i])
+		it.indexes[i] = index % base
+		it.current[i] = it.charsets[i][it.indexes[i]]
+		index /= base
+	}
+
+	if index > 0 {
+		return nil, ErrStartIndexOutOfRange
+	}
+
+	return it, nil
+}
+
+// Next moves to the next word. It returns true if there is a next word,
+// and false if the iteration is complete.
+func (it *MaskIterator) Next() bool {
+	it.rw.Lock()
+	defer it.rw.Unlock()
+
+	if it.done || it.remaining == 0 {
+		it.done = true
+		return false
+	}
+	it.remaining--
+
+	// The first word is the one at the starting index
+	if !it.started {
+		it.started = true
+		return true
+	}
+
+	// Increment the current word starting from the last position
+	for i := len(it.charsets) - 1; i >= 0; i-- {
+		if it.indexes[i]+1 < len(it.charsets[i]) {
+			it.indexes[i]++
+			it.current[i] = it.charsets[i][it.indexes[i]]
+			return true
+		}
+
+		// If the end of the charset is reached, reset the character and move to the previous position
+		it.indexes[i] = 0
+		it.current[i] = it.charsets[i][0]
+	}
+
+	// If all positions overflowed, mark the iteration as complete
+	it.done = true
+	return false
+}
+
+// Current returns the current word as a string.
+func (it *MaskIterator) Current() string {
+	it.rw.RLock()
+	defer it.rw.RUnlock()
+
+	return string(it.current)
+}
@@ -0,0 +1,118 @@
+package combin_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ptrvsrg/crack-hash/worker/internal/combin"
+)
+
+func TestMaskIterator_Next(t *testing.T) {
+	charsets := []string{"ab", "012", "xy"}
+
+	t.Run(
+		"Without start index", func(t *testing.T) {
+			iterator, err := combin.NewMaskIterator(charsets, 0)
+			require.NoError(t, err)
+
+			results := make([]string, 0)
+			for iterator.Next() {
+				results = append(results, iterator.Current())
+			}
+
+			require.Len(t, results, 2*3*2)
+			require.Equal(t, "a0x", results[0])
+			require.Equal(t, "a0y", results[1])
+			require.Equal(t, "a1x", results[2])
+			require.Equal(t, "b2y", results[len(results)-1])
+		},
+	)
+
+	t.Run(
+		"With start index", func(t *testing.T) {
+			all, err := combin.NewMaskIterator(charsets, 0)
+			require.NoError(t, err)
+
+			expected := make([]string, 0)
+			for all.Next() {
+				expected = append(expected, all.Current())
+			}
+
+			for startIndex := range expected {
+				iterator, err := combin.NewMaskIterator(charsets, startIndex)
+				require.NoError(t, err)
+
+				results := make([]string, 0)
+				for iterator.Next() {
+					results = append(results, iterator.Current())
+				}
+
+				require.Equal(t, expected[startIndex:], results)
+			}
+		},
+	)
+
+	t.Run(
+		"Unicode charsets", func(t *testing.T) {
+			iterator, err := combin.NewMaskIterator([]string{"äö", "ß"}, 1)
+			require.NoError(t, err)
+
+			require.True(t, iterator.Next())
+			require.Equal(t, "öß", iterator.Current())
+			require.False(t, iterator.Next())
+		},
+	)
+}
+
+func TestNewBoundedMaskIterator(t *testing.T) {
+	t.Run(
+		"Stops before end index", func(t *testing.T) {
+			iterator, err := combin.NewBoundedMaskIterator([]string{"ab", "012"}, 2, 5)
+			require.NoError(t, err)
+
+			results := make([]string, 0)
+			for iterator.Next() {
+				results = append(results, iterator.Current())
+			}
+
+			require.Equal(t, []string{"a2", "b0", "b1"}, results)
+		},
+	)
+
+	t.Run(
+		"Invalid end index", func(t *testing.T) {
+			iterator, err := combin.NewBoundedMaskIterator([]string{"ab"}, 1, 0)
+
+			require.ErrorIs(t, err, combin.ErrInvalidEndIndex)
+			require.Nil(t, iterator)
+		},
+	)
+}
+
+func TestNewMaskIterator(t *testing.T) {
+	testCases := []struct {
+		name        string
+		charsets    []string
+		startIndex  int
+		expectedErr error
+	}{
+		{"Empty mask", nil, 0, combin.ErrEmptyMask},
+		{"Empty charset", []string{"ab", ""}, 0, combin.ErrEmptyCharset},
+		{"Negative start index", []string{"ab"}, -1, combin.ErrInvalidStartIndex},
+		{"Start index out of range", []string{"ab", "cd"}, 4, combin.ErrStartIndexOutOfRange},
+	}
+
+	for _, tc := range testCases {
+		t.Run(
+			tc.name, func(t *testing.T) {
+				// Act
+				iterator, err := combin.NewMaskIterator(tc.charsets, tc.startIndex)
+
+				// Assert
+				require.ErrorIs(t, err, tc.expectedErr)
+				require.Nil(t, iterator)
+			},
+		)
+	}
+}
@@ -7,27 +7,38 @@ import (
 
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
+	"resty.dev/v3"
 
 	"github.com/ptrvsrg/crack-hash/commonlib/bus/amqp"
 	consumer2 "github.com/ptrvsrg/crack-hash/commonlib/bus/amqp/consumer"
 	publisher2 "github.com/ptrvsrg/crack-hash/commonlib/bus/amqp/publisher"
+	commonconfig "github.com/ptrvsrg/crack-hash/commonlib/config"
+	"github.com/ptrvsrg/crack-hash/commonlib/http/client"
+	"github.com/ptrvsrg/crack-hash/commonlib/http/client/loadbalancer"
 	"github.com/ptrvsrg/crack-hash/commonlib/http/handler"
+	httppublisher "github.com/ptrvsrg/crack-hash/commonlib/http/publisher"
 	"github.com/ptrvsrg/crack-hash/manager/pkg/message"
 	"github.com/ptrvsrg/crack-hash/worker/config"
+	"github.com/ptrvsrg/crack-hash/worker/internal/bus/amqp/consumer/taskcancelled"
+	"github.com/ptrvsrg/crack-hash/worker/internal/bus/amqp/consumer/taskshrunk"
 	"github.com/ptrvsrg/crack-hash/worker/internal/bus/amqp/consumer/taskstarted"
 	"github.com/ptrvsrg/crack-hash/worker/internal/bus/amqp/publisher"
 	"github.com/ptrvsrg/crack-hash/worker/internal/service/domain"
 	"github.com/ptrvsrg/crack-hash/worker/internal/service/domain/hashcracktask"
 	"github.com/ptrvsrg/crack-hash/worker/internal/service/domain/health"
+	"github.com/ptrvsrg/crack-hash/worker/internal/service/domain/registration"
 	"github.com/ptrvsrg/crack-hash/worker/internal/service/infrastructure"
 	"github.com/ptrvsrg/crack-hash/worker/internal/service/infrastructure/bruteforce/factory"
+	"github.com/ptrvsrg/crack-hash/worker/internal/service/infrastructure/wordlist"
+	"github.com/ptrvsrg/crack-hash/worker/internal/transport/http/handler/dispatch"
 	healthhdlr "github.com/ptrvsrg/crack-hash/worker/internal/transport/http/handler/health"
 	swaggerhdlr "github.com/ptrvsrg/crack-hash/worker/internal/transport/http/handler/swagger"
 )
 
 type Providers struct {
-	AMQPConn    *amqp.Connection
-	AMQPChannel *amqp.Channel
+	AMQPConn      *amqp.Connection
+	AMQPChannel   *amqp.Channel
+	ManagerClient *resty.Client
 }
 
 type Container struct {
@@ -37,6 +48,7 @@ type Container struct {
 	Publishers publisher.Publishers
 	InfraSVCs  infrastructure.Services
 	DomainSVCs domain.Services
+	Dispatcher dispatch.Dispatcher
 	Handlers   []handler.Handler
 	Consumers  []consumer2.Consumer
 }
@@ -61,14 +73,23 @@ func (c *Container) Close(_ context.Context) error {
 
 	errs := make([]error, 0)
 
-	c.Logger.Info().Msg("closing AMQP channel")
-	if err := c.Providers.AMQPChannel.Close(); err != nil {
+	c.Logger.Info().Msg("closing manager HTTP client")
+	if err := c.Providers.ManagerClient.Close(); err != nil {
 		errs = append(errs, err)
 	}
 
-	c.Logger.Info().Msg("closing AMQP connection")
-	if err := c.Providers.AMQPConn.Close(); err != nil {
-		errs = append(errs, err)
+	if c.Providers.AMQPChannel != nil {
+		c.Logger.Info().Msg("closing AMQP channel")
+		if err := c.Providers.AMQPChannel.Close(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if c.Providers.AMQPConn != nil {
+		c.Logger.Info().Msg("closing AMQP connection")
+		if err := c.Providers.AMQPConn.Close(); err != nil {
+			errs = append(errs, err)
+		}
 	}
 
 	if len(errs) > 0 {
@@ -79,6 +100,35 @@ func (c *Container) Close(_ context.Context) error {
 }
 
 func (c *Container) setupProviders(ctx context.Context) {
+	if c.Config.Transport.Type == config.TransportAMQP {
+		c.setupAMQPProviders(ctx)
+	}
+
+	c.Logger.Info().Msg("setup manager HTTP client")
+	managerClient, err := client.New(
+		client.WithRetries(c.Config.Manager.Retries, c.Config.Manager.MinRetryWait, c.Config.Manager.MaxRetryWait),
+		client.WithLoadBalancer(
+			c.Config.Manager.URIs,
+			loadbalancer.WithStrategy(loadbalancer.Strategy(c.Config.Manager.Strategy)),
+			loadbalancer.WithWeights(c.Config.Manager.Weights...),
+			loadbalancer.WithCircuitBreaker(c.Config.Manager.MaxFailures, c.Config.Manager.EjectTimeout),
+			loadbalancer.WithHealthChecks(
+				"/health/liveness", c.Config.Manager.HealthTimeout, c.Config.Manager.HealthDelay, c.Config.Manager.Retries,
+			),
+		),
+	)
+	if err != nil {
+		c.Logger.Fatal().Err(err).Msg("failed to setup manager HTTP client")
+	}
+
+	c.Providers.ManagerClient = managerClient
+}
+
+func (c *Container) setupAMQPProviders(ctx context.Context) {
+	if err := commonconfig.Validate(c.Config.AMQP); err != nil {
+		c.Logger.Fatal().Err(err).Msg("invalid AMQP config")
+	}
+
 	c.Logger.Info().Msg("setup AMQP connection")
 	var (
 		amqpConn *amqp.Connection
@@ -110,20 +160,31 @@ func (c *Container) setupProviders(ctx context.Context) {
 	}
 
 	c.Logger.Info().Msg("setup AMQP channel")
-	amqpCh, err := amqpConn.Channel(ctx)
+	amqpCh, err := amqpConn.Channel(ctx, amqp.WithConfirms())
 	if err != nil {
 		c.Logger.Fatal().Err(err).Msg("failed to setup AMQP channel")
 	}
 
-	c.Providers = Providers{
-		AMQPConn:    amqpConn,
-		AMQPChannel: amqpCh,
-	}
+	c.Providers.AMQPConn = amqpConn
+	c.Providers.AMQPChannel = amqpCh
 }
 
 func (c *Container) setupPublishers(_ context.Context) {
 	c.Logger.Info().Msg("setup publishers")
 
+	// Results and heartbeats are posted to the callback API of the manager in the http transport
+	if c.Config.Transport.Type == config.TransportHTTP {
+		c.Publishers = publisher.Publishers{
+			TaskResult: httppublisher.New[message.HashCrackTaskResult](
+				c.Providers.ManagerClient, httppublisher.Config{Path: "/internal/v1/tasks/results"},
+			),
+			WorkerHeartbeat: httppublisher.New[message.WorkerHeartbeat](
+				c.Providers.ManagerClient, httppublisher.Config{Path: "/internal/v1/workers/heartbeats"},
+			),
+		}
+		return
+	}
+
 	c.Publishers = publisher.Publishers{
 		TaskResult: publisher2.New[message.HashCrackTaskResult](
 			c.Providers.AMQPChannel,
@@ -132,14 +193,24 @@ func (c *Container) setupPublishers(_ context.Context) {
 				RoutingKey: c.Config.AMQP.Publishers.TaskResult.RoutingKey,
 			},
 		),
+		WorkerHeartbeat: publisher2.New[message.WorkerHeartbeat](
+			c.Providers.AMQPChannel,
+			publisher2.Config{
+				Exchange:   c.Config.AMQP.Publishers.WorkerHeartbeat.Exchange,
+				RoutingKey: c.Config.AMQP.Publishers.WorkerHeartbeat.RoutingKey,
+			},
+		),
 	}
 }
 
 func (c *Container) setupServices(_ context.Context) {
 	c.Logger.Info().Msg("setup services")
 
+	wordlistSource := wordlist.NewService(c.Logger, c.Providers.ManagerClient)
+
 	c.InfraSVCs = infrastructure.Services{
-		HashBruteForce: factory.NewService(c.Logger, c.Config.Task.Split),
+		WordlistSource: wordlistSource,
+		HashBruteForce: factory.NewService(c.Logger, c.Config.Task.Split, wordlistSource),
 	}
 	c.DomainSVCs = domain.Services{
 		HashCrackTask: hashcracktask.NewService(
@@ -148,6 +219,12 @@ func (c *Container) setupServices(_ context.Context) {
 			c.Publishers.TaskResult,
 			c.InfraSVCs.HashBruteForce,
 		),
+		Registration: registration.NewService(
+			c.Logger,
+			c.Config.Heartbeat,
+			c.Publishers.WorkerHeartbeat,
+			c.InfraSVCs.HashBruteForce,
+		),
 		Health: health.NewService(c.Logger, c.Providers.AMQPConn),
 	}
 }
@@ -159,14 +236,25 @@ func (c *Container) setupHandlers(_ context.Context) {
 		healthhdlr.NewHandler(c.Logger, c.DomainSVCs.Health),
 		swaggerhdlr.NewHandler(c.Logger),
 	}
+
+	if c.Config.Transport.Type == config.TransportHTTP {
+		c.Dispatcher = dispatch.NewHandler(c.Logger, c.Config.Transport.HTTP, c.DomainSVCs.HashCrackTask)
+		c.Handlers = append(c.Handlers, c.Dispatcher)
+	}
 }
 
 func (c *Container) setupConsumers(_ context.Context) {
 	c.Logger.Info().Msg("setup consumers")
 
+	// The dispatcher runs subtasks posted by the manager in the http transport
+	if c.Config.Transport.Type == config.TransportHTTP {
+		c.Consumers = []consumer2.Consumer{c.Dispatcher}
+		return
+	}
+
 	c.Consumers = []consumer2.Consumer{
-		taskstarted.NewConsumer(
-			c.Providers.AMQPChannel, c.Config.AMQP.Consumers.TaskStarted, c.DomainSVCs.HashCrackTask,
-		),
+		taskstarted.NewConsumer(c.Providers.AMQPChannel, c.Config.AMQP, c.DomainSVCs.HashCrackTask),
+		taskcancelled.NewConsumer(c.Providers.AMQPChannel, c.Config.AMQP, c.DomainSVCs.HashCrackTask),
+		taskshrunk.NewConsumer(c.Providers.AMQPChannel, c.Config.AMQP, c.DomainSVCs.HashCrackTask),
 	}
 }
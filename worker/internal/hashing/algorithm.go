@@ -0,0 +1,156 @@
+package hashing
+
+import (
+	"crypto/hmac"
+	"errors"
+	"hash"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Global error variables
+var (
+	ErrUnsupportedAlgorithm = errors.New("unsupported hash algorithm")
+	ErrUnsupportedSalt      = errors.New("unsupported salt position")
+)
+
+// Salt positions
+const (
+	SaltPrefix SaltPosition = "PREFIX" // digest of salt + word
+	SaltSuffix SaltPosition = "SUFFIX" // digest of word + salt
+	SaltHMAC   SaltPosition = "HMAC"   // HMAC of word keyed by salt
+)
+
+type (
+	// SaltPosition describes how the salt is combined with a candidate word.
+	SaltPosition string
+
+	// Salt is a salt or an HMAC key applied to every candidate word.
+	Salt struct {
+		Value    string
+		Position SaltPosition
+	}
+)
+
+// DefaultAlgorithm is used when a task does not specify an algorithm.
+const DefaultAlgorithm = "MD5"
+
+// Algorithm describes how a candidate word is turned into a digest.
+type Algorithm struct {
+	Name   string           // Name of the algorithm as it appears in task messages
+	New    func() hash.Hash // Constructor of the underlying hash function
+	Encode func(string) []byte
+}
+
+var (
+	registry = make(map[string]Algorithm)
+	rw       sync.RWMutex
+)
+
+// Register adds the algorithm to the registry. An algorithm with the same name is replaced.
+func Register(alg Algorithm) {
+	rw.Lock()
+	defer rw.Unlock()
+
+	if alg.Encode == nil {
+		alg.Encode = encodeRaw
+	}
+
+	registry[strings.ToUpper(alg.Name)] = alg
+}
+
+// Get returns the registered algorithm by its name. The empty name resolves to DefaultAlgorithm.
+func Get(name string) (Algorithm, error) {
+	if name == "" {
+		name = DefaultAlgorithm
+	}
+
+	rw.RLock()
+	defer rw.RUnlock()
+
+	alg, ok := registry[strings.ToUpper(name)]
+	if !ok {
+		return Algorithm{}, ErrUnsupportedAlgorithm
+	}
+
+	return alg, nil
+}
+
+// Names returns the sorted names of all registered algorithms.
+func Names() []string {
+	rw.RLock()
+	defer rw.RUnlock()
+
+	names := make([]string, 0, len(registry))
+	for name := range registry {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// NewHasher creates a hasher bound to the algorithm. A hasher is not safe for concurrent use.
+func (a Algorithm) NewHasher() *Hasher {
+	return &Hasher{
+		h:      a.New(),
+		encode: a.Encode,
+	}
+}
+
+// NewSaltedHasher creates a hasher that applies the salt to every word before hashing.
+// The nil salt is equivalent to NewHasher.
+func (a Algorithm) NewSaltedHasher(salt *Salt) (*Hasher, error) {
+	if salt == nil {
+		return a.NewHasher(), nil
+	}
+
+	switch salt.Position {
+	case SaltPrefix:
+		return &Hasher{
+			h: a.New(),
+			encode: func(word string) []byte {
+				return a.Encode(salt.Value + word)
+			},
+		}, nil
+
+	case SaltSuffix:
+		return &Hasher{
+			h: a.New(),
+			encode: func(word string) []byte {
+				return a.Encode(word + salt.Value)
+			},
+		}, nil
+
+	case SaltHMAC:
+		return &Hasher{
+			h:      hmac.New(a.New, []byte(salt.Value)),
+			encode: a.Encode,
+		}, nil
+
+	default:
+		return nil, ErrUnsupportedSalt
+	}
+}
+
+// Hasher computes digests of candidate words, reusing its internal state between calls.
+type Hasher struct {
+	h      hash.Hash
+	encode func(string) []byte
+	sum    []byte
+}
+
+// Sum returns the digest of the word. The returned slice is valid until the next call.
+func (h *Hasher) Sum(word string) []byte {
+	h.h.Reset()
+	_, _ = h.h.Write(h.encode(word))
+	h.sum = h.h.Sum(h.sum[:0])
+
+	return h.sum
+}
+
+// Helper function to encode a word as its raw bytes
+func encodeRaw(word string) []byte {
+	return []byte(word)
+}
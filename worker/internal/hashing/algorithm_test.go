@@ -0,0 +1,121 @@
+package hashing_test
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ptrvsrg/crack-hash/commonlib/logging"
+	"github.com/ptrvsrg/crack-hash/worker/internal/hashing"
+)
+
+func init() {
+	logging.Setup(true)
+}
+
+func TestHasher_Sum(t *testing.T) {
+	testCases := []struct {
+		algorithm string
+		word      string
+		expected  string
+	}{
+		{"MD5", "abc", "900150983cd24fb0d6963f7d28e17f72"},
+		{"SHA1", "abc", "a9993e364706816aba3e25717850c26c9cd0d89d"},
+		{"SHA256", "abc", "ba7816bf8f01cfea414140de5dae2223b00361a396177a9cb410ff61f20015ad"},
+		{
+			"SHA512", "abc",
+			"ddaf35a193617abacc417349ae20413112e6fa4e89a97ea20a9eeee64b55d39a" +
+				"2192992a274fc1a836ba3c23a3feebbd454d4423643ce80e2a9ac94fa54ca49f",
+		},
+		{"NTLM", "password", "8846f7eaee8fb117ad06bdd830b7586c"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(
+			tc.algorithm, func(t *testing.T) {
+				// Arrange
+				alg, err := hashing.Get(tc.algorithm)
+				require.NoError(t, err)
+
+				// Act
+				sum := alg.NewHasher().Sum(tc.word)
+
+				// Assert
+				require.Equal(t, tc.expected, hex.EncodeToString(sum))
+			},
+		)
+	}
+}
+
+func TestHasher_SumSalted(t *testing.T) {
+	testCases := []struct {
+		name     string
+		salt     hashing.Salt
+		expected string
+	}{
+		{
+			"Prefix", hashing.Salt{Value: "salt", Position: hashing.SaltPrefix},
+			"f9c1e496823ebd52340672dc1cb2d87f", // md5("saltpass")
+		},
+		{
+			"Suffix", hashing.Salt{Value: "salt", Position: hashing.SaltSuffix},
+			"83234657c5df8232839ac8c0572e158d", // md5("passsalt")
+		},
+		{
+			"HMAC", hashing.Salt{Value: "key", Position: hashing.SaltHMAC},
+			"1fbc56cf51ee57c9852acb83a3c3d13d", // hmac-md5("key", "pass")
+		},
+	}
+
+	alg, err := hashing.Get("MD5")
+	require.NoError(t, err)
+
+	for _, tc := range testCases {
+		t.Run(
+			tc.name, func(t *testing.T) {
+				// Arrange
+				hasher, err := alg.NewSaltedHasher(&tc.salt)
+				require.NoError(t, err)
+
+				// Act
+				sum := hasher.Sum("pass")
+
+				// Assert
+				require.Equal(t, tc.expected, hex.EncodeToString(sum))
+			},
+		)
+	}
+
+	t.Run(
+		"Unsupported position", func(t *testing.T) {
+			_, err := alg.NewSaltedHasher(&hashing.Salt{Value: "salt", Position: "MIDDLE"})
+			require.ErrorIs(t, err, hashing.ErrUnsupportedSalt)
+		},
+	)
+}
+
+func TestGet(t *testing.T) {
+	t.Run(
+		"Default algorithm", func(t *testing.T) {
+			alg, err := hashing.Get("")
+			require.NoError(t, err)
+			require.Equal(t, hashing.DefaultAlgorithm, alg.Name)
+		},
+	)
+
+	t.Run(
+		"Case insensitive", func(t *testing.T) {
+			alg, err := hashing.Get("sha256")
+			require.NoError(t, err)
+			require.Equal(t, "SHA256", alg.Name)
+		},
+	)
+
+	t.Run(
+		"Unsupported algorithm", func(t *testing.T) {
+			_, err := hashing.Get("CRC32")
+			require.ErrorIs(t, err, hashing.ErrUnsupportedAlgorithm)
+		},
+	)
+}
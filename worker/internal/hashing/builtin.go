@@ -0,0 +1,32 @@
+package hashing
+
+import (
+	"crypto/md5"  // nolint
+	"crypto/sha1" // nolint
+	"crypto/sha256"
+	"crypto/sha512"
+	"unicode/utf16"
+
+	"golang.org/x/crypto/md4" // nolint
+)
+
+func init() {
+	Register(Algorithm{Name: "MD5", New: md5.New})
+	Register(Algorithm{Name: "SHA1", New: sha1.New})
+	Register(Algorithm{Name: "SHA256", New: sha256.New})
+	Register(Algorithm{Name: "SHA512", New: sha512.New})
+	Register(Algorithm{Name: "NTLM", New: md4.New, Encode: encodeUTF16LE})
+}
+
+// Helper function to encode a word as UTF-16LE, as NTLM does before hashing
+func encodeUTF16LE(word string) []byte {
+	units := utf16.Encode([]rune(word))
+
+	buf := make([]byte, 2*len(units))
+	for i, u := range units {
+		buf[2*i] = byte(u)
+		buf[2*i+1] = byte(u >> 8)
+	}
+
+	return buf
+}
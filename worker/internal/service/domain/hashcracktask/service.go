@@ -2,7 +2,9 @@ package hashcracktask
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/rs/zerolog"
@@ -10,15 +12,28 @@ import (
 
 	"github.com/ptrvsrg/crack-hash/commonlib/bus/amqp/publisher"
 	"github.com/ptrvsrg/crack-hash/manager/pkg/message"
+	"github.com/ptrvsrg/crack-hash/worker/internal/hashing"
 	"github.com/ptrvsrg/crack-hash/worker/internal/service/domain"
 	"github.com/ptrvsrg/crack-hash/worker/internal/service/infrastructure"
 )
 
+// cancelledRetention is the time cancelled tasks are remembered to skip their subtasks left in the queue
+const cancelledRetention = 24 * time.Hour
+
 type svc struct {
 	logger         zerolog.Logger
 	progressPeriod time.Duration
 	publisher      publisher.Publisher[message.HashCrackTaskResult]
 	bruteforce     infrastructure.HashBruteForce
+
+	mu        sync.Mutex
+	running   map[string]map[int]*runningSubtask // Running subtasks by task ID and part
+	cancelled map[string]time.Time               // Cancellation times of cancelled tasks by task ID
+}
+
+type runningSubtask struct {
+	cancel context.CancelCauseFunc
+	shrink chan int // New ends of the range of the subtask
 }
 
 func NewService(
@@ -35,6 +50,8 @@ func NewService(
 		progressPeriod: progressPeriod,
 		publisher:      publisher,
 		bruteforce:     bruteforce,
+		running:        make(map[string]map[int]*runningSubtask),
+		cancelled:      make(map[string]time.Time),
 	}
 }
 
@@ -42,30 +59,65 @@ func (s *svc) ExecuteTask(ctx context.Context, input *message.HashCrackTaskStart
 	// Brute force
 	s.logger.Info().
 		Str("id", input.RequestID).
+		Str("algorithm", input.Algorithm).
+		Str("mode", input.Mode).
+		Int("hashCount", len(input.Hashes)).
 		Int("part", input.PartNumber).
-		Msg("brute force md5")
+		Msg("brute force")
+
+	// Fail the subtask of a manager of another version, the ranges may be computed differently
+	if input.Version != message.Version {
+		err := fmt.Errorf("%w: %d, expected %d", domain.ErrUnsupportedVersion, input.Version, message.Version)
+		s.logger.Error().Err(err).Str("id", input.RequestID).Int("part", input.PartNumber).Msg("skip subtask")
+
+		msg := buildErrorResultMessage(input, lo.ToPtr(err.Error()))
+		if err := s.publisher.SendMessage(ctx, msg, publisher.Persistent, false, false); err != nil {
+			s.logger.Error().Err(err).Stack().Msg("failed to send result message")
+			return fmt.Errorf("failed to send result message: %w", err)
+		}
+
+		return nil
+	}
 
-	progressCh, err := s.bruteforce.BruteForceMD5(
-		input.Hash, input.Alphabet.Symbols, input.MaxLength, input.PartNumber, s.progressPeriod,
-	)
+	taskCtx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+
+	running := &runningSubtask{cancel: cancel, shrink: make(chan int, 1)}
+	if !s.register(input.RequestID, input.PartNumber, running) {
+		s.logger.Info().
+			Str("id", input.RequestID).
+			Int("part", input.PartNumber).
+			Msg("task is cancelled, skip subtask")
+		return nil
+	}
+	defer s.unregister(input.RequestID, input.PartNumber)
+
+	progressCh, err := s.bruteforce.BruteForce(taskCtx, buildBruteForceTask(input, running.shrink), s.progressPeriod)
 	if err != nil {
-		s.logger.Error().Err(err).Stack().Msg("failed to brute force md5")
+		s.logger.Error().Err(err).Stack().Msg("failed to brute force")
 
-		msg := buildErrorResultMessage(input.RequestID, input.PartNumber, lo.ToPtr(err.Error()))
+		// The task is rejected before the brute force is started, so it fails again on redelivery
+		msg := buildErrorResultMessage(input, lo.ToPtr(err.Error()))
 		if err := s.publisher.SendMessage(ctx, msg, publisher.Persistent, false, false); err != nil {
 			s.logger.Error().Err(err).Stack().Msg("failed to send result message")
+			return fmt.Errorf("failed to send result message: %w", err)
 		}
 
-		return fmt.Errorf("failed to brute force md5: %w", err)
+		return fmt.Errorf("failed to brute force: %w: %w", domain.ErrInvalidTask, err)
 	}
 
 	for progress := range progressCh {
+		// Discard results of stopped subtask
+		if taskCtx.Err() != nil {
+			continue
+		}
+
 		// Send result
 		var msg *message.HashCrackTaskResult
 		if progress.Status == infrastructure.TaskStatusError {
-			msg = buildErrorResultMessage(input.RequestID, input.PartNumber, progress.Reason)
+			msg = buildErrorResultMessage(input, progress.Reason)
 		} else {
-			msg = buildResultMessage(input.RequestID, input.PartNumber, progress)
+			msg = buildResultMessage(input, progress)
 		}
 
 		if err := s.publisher.SendMessage(ctx, msg, publisher.Persistent, false, false); err != nil {
@@ -74,32 +126,185 @@ func (s *svc) ExecuteTask(ctx context.Context, input *message.HashCrackTaskStart
 		}
 	}
 
+	if errors.Is(context.Cause(taskCtx), domain.ErrTaskCancelled) {
+		s.logger.Info().
+			Str("id", input.RequestID).
+			Int("part", input.PartNumber).
+			Msg("brute force is cancelled")
+		return nil
+	}
+
+	if taskCtx.Err() != nil {
+		return fmt.Errorf("failed to brute force: %w", context.Cause(taskCtx))
+	}
+
+	s.logger.Info().
+		Str("id", input.RequestID).
+		Int("part", input.PartNumber).
+		Msg("end brute force")
+
+	return nil
+}
+
+func (s *svc) CancelTask(_ context.Context, input *message.HashCrackTaskCancelled) error {
+	s.logger.Info().Str("id", input.RequestID).Msg("cancel task")
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Remember cancelled task, forgetting the old ones
+	now := time.Now()
+	for id, cancelledAt := range s.cancelled {
+		if now.Sub(cancelledAt) > cancelledRetention {
+			delete(s.cancelled, id)
+		}
+	}
+	s.cancelled[input.RequestID] = now
+
+	// Stop running subtasks
+	for part, running := range s.running[input.RequestID] {
+		s.logger.Debug().Str("id", input.RequestID).Int("part", part).Msg("stop subtask")
+		running.cancel(domain.ErrTaskCancelled)
+	}
+
+	return nil
+}
+
+func (s *svc) ShrinkTask(_ context.Context, input *message.HashCrackTaskShrunk) error {
+	if input.Version != message.Version {
+		s.logger.Error().
+			Err(domain.ErrUnsupportedVersion).
+			Str("id", input.RequestID).
+			Int("version", input.Version).
+			Msg("skip shrink message")
+		return nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// The message is broadcast to all workers, only the one running the subtask handles it
+	running, ok := s.running[input.RequestID][input.PartNumber]
+	if !ok {
+		return nil
+	}
+
 	s.logger.Info().
 		Str("id", input.RequestID).
 		Int("part", input.PartNumber).
-		Msg("end brute force md5")
+		Int("end", input.EndIndex).
+		Msg("shrink subtask")
+
+	// Replace the end, which is not taken yet
+	select {
+	case <-running.shrink:
+	default:
+	}
+	running.shrink <- input.EndIndex
 
 	return nil
 }
 
-func buildErrorResultMessage(requestID string, partNumber int, error *string) *message.HashCrackTaskResult {
+// register saves the running subtask, it returns false if the task is cancelled
+func (s *svc) register(requestID string, partNumber int, running *runningSubtask) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.cancelled[requestID]; ok {
+		return false
+	}
+
+	if _, ok := s.running[requestID]; !ok {
+		s.running[requestID] = make(map[int]*runningSubtask)
+	}
+	s.running[requestID][partNumber] = running
+
+	return true
+}
+
+// unregister drops the finished subtask
+func (s *svc) unregister(requestID string, partNumber int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.running[requestID], partNumber)
+	if len(s.running[requestID]) == 0 {
+		delete(s.running, requestID)
+	}
+}
+
+func buildBruteForceTask(input *message.HashCrackTaskStarted, shrink <-chan int) *infrastructure.BruteForceTask {
+	task := &infrastructure.BruteForceTask{
+		Algorithm:    input.Algorithm,
+		Hash:         input.Hash,
+		Hashes:       input.Hashes,
+		Alphabet:     input.Alphabet.Symbols,
+		MinLength:    input.MinLength,
+		MaxLength:    input.MaxLength,
+		PartNumber:   input.PartNumber,
+		ResumeOffset: input.ResumeOffset,
+		Shrink:       shrink,
+	}
+
+	if input.Wordlist == nil {
+		task.Range = &infrastructure.KeyRange{
+			Start: input.StartIndex,
+			End:   input.EndIndex,
+		}
+	}
+
+	if input.Salt != nil {
+		task.Salt = &hashing.Salt{
+			Value:    input.Salt.Value,
+			Position: hashing.SaltPosition(input.Salt.Position),
+		}
+	}
+
+	if input.Mode == message.AttackModeDictionary && input.Wordlist != nil {
+		task.Wordlist = &infrastructure.WordlistRange{
+			ID:     input.Wordlist.ID,
+			Offset: input.Wordlist.Offset,
+			Count:  input.Wordlist.Count,
+		}
+		task.Rules = input.Rules
+	}
+
+	if input.Mode == message.AttackModeMask && input.Mask != nil {
+		task.Mask = input.Mask.Charsets
+	}
+
+	return task
+}
+
+func buildErrorResultMessage(input *message.HashCrackTaskStarted, error *string) *message.HashCrackTaskResult {
 	return &message.HashCrackTaskResult{
-		RequestID:  requestID,
-		PartNumber: partNumber,
+		Version:    message.Version,
+		RequestID:  input.RequestID,
+		PartNumber: input.PartNumber,
+		Attempt:    input.Attempt,
 		Error:      error,
 		Status:     string(infrastructure.TaskStatusError),
 	}
 }
 
 func buildResultMessage(
-	requestID string, partNumber int, progress infrastructure.TaskProgress,
+	input *message.HashCrackTaskStarted, progress infrastructure.TaskProgress,
 ) *message.HashCrackTaskResult {
 	return &message.HashCrackTaskResult{
-		RequestID:  requestID,
-		PartNumber: partNumber,
+		Version:    message.Version,
+		RequestID:  input.RequestID,
+		PartNumber: input.PartNumber,
+		Attempt:    input.Attempt,
 		Status:     string(progress.Status),
+		Checkpoint: progress.Checkpoint,
+		Speed:      progress.Speed,
 		Answer: &message.Answer{
-			Words:   progress.Answers,
+			Words: progress.Answers,
+			Found: lo.Map(
+				progress.Found, func(found infrastructure.FoundHash, _ int) message.FoundHash {
+					return message.FoundHash{Hash: found.Hash, Word: found.Word}
+				},
+			),
 			Percent: progress.Percent,
 		},
 	}
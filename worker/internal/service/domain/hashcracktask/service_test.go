@@ -9,12 +9,14 @@ import (
 
 	"github.com/rs/zerolog/log"
 	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
 	mock3 "github.com/stretchr/testify/mock"
 
 	"github.com/ptrvsrg/crack-hash/commonlib/bus/amqp/publisher"
 	"github.com/ptrvsrg/crack-hash/commonlib/bus/amqp/publisher/mock"
 	"github.com/ptrvsrg/crack-hash/commonlib/logging"
 	"github.com/ptrvsrg/crack-hash/manager/pkg/message"
+	"github.com/ptrvsrg/crack-hash/worker/internal/hashing"
 	"github.com/ptrvsrg/crack-hash/worker/internal/service/domain"
 	"github.com/ptrvsrg/crack-hash/worker/internal/service/domain/hashcracktask"
 	"github.com/ptrvsrg/crack-hash/worker/internal/service/infrastructure"
@@ -59,6 +61,7 @@ func Test_ExecuteTask(t *testing.T) {
 					name: "Success",
 					progress: infrastructure.TaskProgress{
 						Answers: []string{"abc"},
+						Found:   []infrastructure.FoundHash{{Hash: "900150983cd24fb0d6963f7d28e17f72", Word: "abc"}},
 						Percent: 100.0,
 						Status:  infrastructure.TaskStatusSuccess,
 					},
@@ -78,6 +81,7 @@ func Test_ExecuteTask(t *testing.T) {
 						Answers: []string{"abc"},
 						Percent: 65.0,
 						Status:  infrastructure.TaskStatusInProgress,
+						Speed:   1500.0,
 					},
 				},
 			}
@@ -88,27 +92,35 @@ func Test_ExecuteTask(t *testing.T) {
 						// Arrange
 						hash := md5.Sum([]byte("abc"))
 						input := &message.HashCrackTaskStarted{
+							Version:    message.Version,
 							RequestID:  "123",
+							Algorithm:  message.HashAlgorithmMD5,
 							Hash:       string(hash[:]),
 							MaxLength:  5,
+							EndIndex:   1000,
 							PartNumber: 0,
 							Alphabet: message.Alphabet{
 								Symbols: []string{"a", "b", "c"},
 							},
 						}
+						expected := &infrastructure.BruteForceTask{
+							Algorithm: message.HashAlgorithmMD5,
+							Hash:      string(hash[:]),
+							Alphabet:  []string{"a", "b", "c"},
+							MaxLength: 5,
+							Range:     &infrastructure.KeyRange{Start: 0, End: 1000},
+						}
 						progressCh := make(chan infrastructure.TaskProgress, 1)
 						progressCh <- tc.progress
 						close(progressCh)
 
-						mockBruteForce.On(
-							"BruteForceMD5", input.Hash, input.Alphabet.Symbols, input.MaxLength, input.PartNumber,
-							time.Second,
-						).Return(progressCh, nil).Once()
+						mockBruteForce.On("BruteForce", mock3.Anything, matchBruteForceTask(expected), time.Second).Return(progressCh, nil).Once()
 						mockPublisher.On("SendMessage", ctx, mock3.Anything, publisher.Persistent, false, false).
 							Run(
 								func(args mock3.Arguments) {
 									msg, ok := args.Get(1).(*message.HashCrackTaskResult)
 									require.True(t, ok)
+									require.Equal(t, message.Version, msg.Version)
 									require.Equal(t, input.RequestID, msg.RequestID)
 									require.Equal(t, input.PartNumber, msg.PartNumber)
 
@@ -122,7 +134,9 @@ func Test_ExecuteTask(t *testing.T) {
 									require.Nil(t, msg.Error)
 									require.NotNil(t, msg.Answer)
 									require.Equal(t, tc.progress.Answers, msg.Answer.Words)
+									require.Len(t, msg.Answer.Found, len(tc.progress.Found))
 									require.Equal(t, tc.progress.Percent, msg.Answer.Percent)
+									require.Equal(t, tc.progress.Speed, msg.Speed)
 									require.Equal(t, string(tc.progress.Status), msg.Status)
 								},
 							).
@@ -140,24 +154,132 @@ func Test_ExecuteTask(t *testing.T) {
 		},
 	)
 
+	t.Run(
+		"Brute force task", func(t *testing.T) {
+			testCases := []struct {
+				name     string
+				input    *message.HashCrackTaskStarted
+				expected *infrastructure.BruteForceTask
+			}{
+				{
+					name: "Salted batch",
+					input: &message.HashCrackTaskStarted{
+						Version:    message.Version,
+						RequestID:  "123",
+						Algorithm:  message.HashAlgorithmSHA256,
+						Hashes:     []string{"aa", "bb"},
+						Salt:       &message.Salt{Value: "pepper", Position: "SUFFIX"},
+						MinLength:  2,
+						MaxLength:  4,
+						PartNumber: 3,
+						Alphabet:   message.Alphabet{Symbols: []string{"x", "y"}},
+						StartIndex: 10,
+						EndIndex:   20,
+					},
+					expected: &infrastructure.BruteForceTask{
+						Algorithm:  message.HashAlgorithmSHA256,
+						Hashes:     []string{"aa", "bb"},
+						Salt:       &hashing.Salt{Value: "pepper", Position: hashing.SaltSuffix},
+						Alphabet:   []string{"x", "y"},
+						MinLength:  2,
+						MaxLength:  4,
+						PartNumber: 3,
+						Range:      &infrastructure.KeyRange{Start: 10, End: 20},
+					},
+				},
+				{
+					name: "Dictionary",
+					input: &message.HashCrackTaskStarted{
+						Version:      message.Version,
+						RequestID:    "123",
+						Algorithm:    message.HashAlgorithmMD5,
+						Hash:         "900150983cd24fb0d6963f7d28e17f72",
+						Mode:         message.AttackModeDictionary,
+						Wordlist:     &message.Wordlist{ID: "wordlist", Offset: 100, Count: 50},
+						Rules:        []string{"u"},
+						ResumeOffset: 5,
+					},
+					expected: &infrastructure.BruteForceTask{
+						Algorithm:    message.HashAlgorithmMD5,
+						Hash:         "900150983cd24fb0d6963f7d28e17f72",
+						Wordlist:     &infrastructure.WordlistRange{ID: "wordlist", Offset: 100, Count: 50},
+						Rules:        []string{"u"},
+						ResumeOffset: 5,
+					},
+				},
+				{
+					name: "Mask",
+					input: &message.HashCrackTaskStarted{
+						Version:    message.Version,
+						RequestID:  "123",
+						Algorithm:  message.HashAlgorithmMD5,
+						Hash:       "900150983cd24fb0d6963f7d28e17f72",
+						Mode:       message.AttackModeMask,
+						Mask:       &message.Mask{Charsets: []string{"ab", "01"}},
+						StartIndex: 0,
+						EndIndex:   4,
+					},
+					expected: &infrastructure.BruteForceTask{
+						Algorithm: message.HashAlgorithmMD5,
+						Hash:      "900150983cd24fb0d6963f7d28e17f72",
+						Mask:      []string{"ab", "01"},
+						Range:     &infrastructure.KeyRange{Start: 0, End: 4},
+					},
+				},
+			}
+
+			for _, tc := range testCases {
+				t.Run(
+					tc.name, func(t *testing.T) {
+						// Arrange
+						publisherMock := new(mock.PublisherMock[message.HashCrackTaskResult])
+						bruteForceMock := new(mock2.HashBruteForceMock)
+						service := hashcracktask.NewService(log.Logger, time.Second, publisherMock, bruteForceMock)
+
+						progressCh := make(chan infrastructure.TaskProgress)
+						close(progressCh)
+
+						bruteForceMock.On("BruteForce", mock3.Anything, matchBruteForceTask(tc.expected), time.Second).
+							Return(progressCh, nil).Once()
+
+						// Act
+						err := service.ExecuteTask(ctx, tc.input)
+
+						// Assert
+						require.NoError(t, err)
+						bruteForceMock.AssertExpectations(t)
+					},
+				)
+			}
+		},
+	)
+
 	t.Run(
 		"BruteForceError", func(t *testing.T) {
 			// Arrange
 			hash := md5.Sum([]byte("abc"))
 			input := &message.HashCrackTaskStarted{
+				Version:    message.Version,
 				RequestID:  "123",
+				Algorithm:  message.HashAlgorithmMD5,
 				Hash:       string(hash[:]),
 				MaxLength:  5,
+				EndIndex:   1000,
 				PartNumber: 0,
 				Alphabet: message.Alphabet{
 					Symbols: []string{"a", "b", "c"},
 				},
 			}
+			expected := &infrastructure.BruteForceTask{
+				Algorithm: message.HashAlgorithmMD5,
+				Hash:      string(hash[:]),
+				Alphabet:  []string{"a", "b", "c"},
+				MaxLength: 5,
+				Range:     &infrastructure.KeyRange{Start: 0, End: 1000},
+			}
 			expectedError := errors.New("brute force failed")
 
-			mockBruteForce.On(
-				"BruteForceMD5", input.Hash, input.Alphabet.Symbols, input.MaxLength, input.PartNumber, time.Second,
-			).Return(nil, expectedError).Once()
+			mockBruteForce.On("BruteForce", mock3.Anything, matchBruteForceTask(expected), time.Second).Return(nil, expectedError).Once()
 			mockPublisher.On("SendMessage", ctx, mock3.Anything, publisher.Persistent, false, false).
 				Run(
 					func(args mock3.Arguments) {
@@ -179,7 +301,242 @@ func Test_ExecuteTask(t *testing.T) {
 			// Assert
 			require.Error(t, err)
 			require.ErrorIs(t, err, expectedError)
+			require.ErrorIs(t, err, domain.ErrInvalidTask)
 			mockBruteForce.AssertExpectations(t)
 		},
 	)
+
+	t.Run(
+		"Unsupported version", func(t *testing.T) {
+			// Arrange
+			publisherMock := new(mock.PublisherMock[message.HashCrackTaskResult])
+			bruteForceMock := new(mock2.HashBruteForceMock)
+			service := hashcracktask.NewService(log.Logger, time.Second, publisherMock, bruteForceMock)
+			input := &message.HashCrackTaskStarted{
+				RequestID:  "123",
+				Algorithm:  message.HashAlgorithmMD5,
+				Hash:       "900150983cd24fb0d6963f7d28e17f72",
+				MaxLength:  5,
+				PartNumber: 3,
+				Attempt:    2,
+				Alphabet: message.Alphabet{
+					Symbols: []string{"a", "b", "c"},
+				},
+			}
+
+			publisherMock.On(
+				"SendMessage", ctx, &message.HashCrackTaskResult{
+					Version:    message.Version,
+					RequestID:  input.RequestID,
+					PartNumber: input.PartNumber,
+					Attempt:    input.Attempt,
+					Status:     string(infrastructure.TaskStatusError),
+					Error:      lo.ToPtr("unsupported message version: 0, expected 1"),
+				}, publisher.Persistent, false, false,
+			).Return(nil).Once()
+
+			// Act
+			err := service.ExecuteTask(ctx, input)
+
+			// Assert
+			require.NoError(t, err)
+			publisherMock.AssertExpectations(t)
+			bruteForceMock.AssertNotCalled(t, "BruteForce", mock3.Anything, mock3.Anything, mock3.Anything)
+		},
+	)
+}
+
+func Test_CancelTask(t *testing.T) {
+	input := &message.HashCrackTaskStarted{
+		Version:    message.Version,
+		RequestID:  "456",
+		Algorithm:  message.HashAlgorithmMD5,
+		Hash:       "900150983cd24fb0d6963f7d28e17f72",
+		MaxLength:  5,
+		EndIndex:   1000,
+		PartNumber: 1,
+		Alphabet: message.Alphabet{
+			Symbols: []string{"a", "b", "c"},
+		},
+	}
+
+	expected := &infrastructure.BruteForceTask{
+		Algorithm:  message.HashAlgorithmMD5,
+		Hash:       "900150983cd24fb0d6963f7d28e17f72",
+		Alphabet:   []string{"a", "b", "c"},
+		MaxLength:  5,
+		PartNumber: 1,
+		Range:      &infrastructure.KeyRange{Start: 0, End: 1000},
+	}
+
+	t.Run(
+		"Stop running subtask", func(t *testing.T) {
+			// Arrange
+			publisherMock := new(mock.PublisherMock[message.HashCrackTaskResult])
+			bruteForceMock := new(mock2.HashBruteForceMock)
+			service := hashcracktask.NewService(log.Logger, time.Second, publisherMock, bruteForceMock)
+
+			started := make(chan struct{})
+			bruteForceMock.EXPECT().BruteForce(mock3.Anything, matchBruteForceTask(expected), time.Second).RunAndReturn(
+				func(
+					ctx context.Context, _ *infrastructure.BruteForceTask, _ time.Duration,
+				) (<-chan infrastructure.TaskProgress, error) {
+					progressCh := make(chan infrastructure.TaskProgress)
+					go func() {
+						defer close(progressCh)
+
+						close(started)
+						<-ctx.Done()
+						progressCh <- infrastructure.TaskProgress{
+							Status: infrastructure.TaskStatusError,
+							Reason: lo.ToPtr(context.Cause(ctx).Error()),
+						}
+					}()
+
+					return progressCh, nil
+				},
+			).Once()
+
+			errCh := make(chan error, 1)
+			go func() {
+				errCh <- service.ExecuteTask(ctx, input)
+			}()
+			<-started
+
+			// Act
+			err := service.CancelTask(ctx, &message.HashCrackTaskCancelled{RequestID: input.RequestID})
+
+			// Assert
+			require.NoError(t, err)
+			require.NoError(t, <-errCh)
+			bruteForceMock.AssertExpectations(t)
+			publisherMock.AssertNotCalled(
+				t, "SendMessage", mock3.Anything, mock3.Anything, mock3.Anything, mock3.Anything, mock3.Anything,
+			)
+		},
+	)
+
+	t.Run(
+		"Skip subtask of cancelled task", func(t *testing.T) {
+			// Arrange
+			publisherMock := new(mock.PublisherMock[message.HashCrackTaskResult])
+			bruteForceMock := new(mock2.HashBruteForceMock)
+			service := hashcracktask.NewService(log.Logger, time.Second, publisherMock, bruteForceMock)
+
+			require.NoError(t, service.CancelTask(ctx, &message.HashCrackTaskCancelled{RequestID: input.RequestID}))
+
+			// Act
+			err := service.ExecuteTask(ctx, input)
+
+			// Assert
+			require.NoError(t, err)
+			bruteForceMock.AssertNotCalled(t, "BruteForce", mock3.Anything, mock3.Anything, mock3.Anything)
+			publisherMock.AssertNotCalled(
+				t, "SendMessage", mock3.Anything, mock3.Anything, mock3.Anything, mock3.Anything, mock3.Anything,
+			)
+		},
+	)
+}
+
+// matchBruteForceTask matches the expected brute force task, the shrink channel is created per subtask
+func matchBruteForceTask(expected *infrastructure.BruteForceTask) interface{} {
+	return mock3.MatchedBy(
+		func(task *infrastructure.BruteForceTask) bool {
+			actual := *task
+			actual.Shrink = nil
+			return assert.ObjectsAreEqual(expected, &actual)
+		},
+	)
+}
+
+func Test_ShrinkTask(t *testing.T) {
+	input := &message.HashCrackTaskStarted{
+		Version:    message.Version,
+		RequestID:  "789",
+		Algorithm:  message.HashAlgorithmMD5,
+		Hash:       "900150983cd24fb0d6963f7d28e17f72",
+		MaxLength:  5,
+		PartNumber: 2,
+		Alphabet: message.Alphabet{
+			Symbols: []string{"a", "b", "c"},
+		},
+		StartIndex: 100,
+		EndIndex:   200,
+	}
+
+	expected := &infrastructure.BruteForceTask{
+		Algorithm:  message.HashAlgorithmMD5,
+		Hash:       "900150983cd24fb0d6963f7d28e17f72",
+		Alphabet:   []string{"a", "b", "c"},
+		MaxLength:  5,
+		PartNumber: 2,
+		Range:      &infrastructure.KeyRange{Start: 100, End: 200},
+	}
+
+	t.Run(
+		"Shrink running subtask", func(t *testing.T) {
+			// Arrange
+			publisherMock := new(mock.PublisherMock[message.HashCrackTaskResult])
+			bruteForceMock := new(mock2.HashBruteForceMock)
+			service := hashcracktask.NewService(log.Logger, time.Second, publisherMock, bruteForceMock)
+
+			shrinkCh := make(chan (<-chan int), 1)
+			bruteForceMock.EXPECT().BruteForce(mock3.Anything, matchBruteForceTask(expected), time.Second).RunAndReturn(
+				func(
+					ctx context.Context, task *infrastructure.BruteForceTask, _ time.Duration,
+				) (<-chan infrastructure.TaskProgress, error) {
+					progressCh := make(chan infrastructure.TaskProgress)
+					go func() {
+						defer close(progressCh)
+
+						shrinkCh <- task.Shrink
+						<-ctx.Done()
+					}()
+
+					return progressCh, nil
+				},
+			).Once()
+
+			taskCtx, cancel := context.WithCancel(ctx)
+			errCh := make(chan error, 1)
+			go func() {
+				errCh <- service.ExecuteTask(taskCtx, input)
+			}()
+			shrink := <-shrinkCh
+
+			// Act
+			err := service.ShrinkTask(
+				ctx, &message.HashCrackTaskShrunk{
+					Version: message.Version, RequestID: input.RequestID, PartNumber: input.PartNumber, EndIndex: 150,
+				},
+			)
+
+			// Assert
+			require.NoError(t, err)
+			require.Equal(t, 150, <-shrink)
+
+			cancel()
+			require.Error(t, <-errCh)
+			bruteForceMock.AssertExpectations(t)
+		},
+	)
+
+	t.Run(
+		"Skip subtask of other worker", func(t *testing.T) {
+			// Arrange
+			publisherMock := new(mock.PublisherMock[message.HashCrackTaskResult])
+			bruteForceMock := new(mock2.HashBruteForceMock)
+			service := hashcracktask.NewService(log.Logger, time.Second, publisherMock, bruteForceMock)
+
+			// Act
+			err := service.ShrinkTask(
+				ctx, &message.HashCrackTaskShrunk{
+					Version: message.Version, RequestID: input.RequestID, PartNumber: input.PartNumber, EndIndex: 150,
+				},
+			)
+
+			// Assert
+			require.NoError(t, err)
+		},
+	)
 }
@@ -32,7 +32,8 @@ func NewService(logger zerolog.Logger, amqpConn *amqp.Connection) domain.Health
 func (s *svc) Health(_ context.Context) error {
 	s.logger.Info().Msg("health check")
 
-	if s.amqpConn.IsReconnect() {
+	// The connection is absent for the http transport
+	if s.amqpConn != nil && s.amqpConn.IsReconnect() {
 		s.logger.Error().Err(errAMQPReconnect).Msg("failed to check amqp connection")
 		return errAMQPReconnect
 	}
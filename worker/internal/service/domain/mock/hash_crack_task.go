@@ -23,6 +23,53 @@ func (_m *HashCrackTaskMock) EXPECT() *HashCrackTaskMock_Expecter {
 	return &HashCrackTaskMock_Expecter{mock: &_m.Mock}
 }
 
+// CancelTask provides a mock function with given fields: ctx, input
+func (_m *HashCrackTaskMock) CancelTask(ctx context.Context, input *message.HashCrackTaskCancelled) error {
+	ret := _m.Called(ctx, input)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CancelTask")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *message.HashCrackTaskCancelled) error); ok {
+		r0 = rf(ctx, input)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// HashCrackTaskMock_CancelTask_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CancelTask'
+type HashCrackTaskMock_CancelTask_Call struct {
+	*mock.Call
+}
+
+// CancelTask is a helper method to define mock.On call
+//   - ctx context.Context
+//   - input *message.HashCrackTaskCancelled
+func (_e *HashCrackTaskMock_Expecter) CancelTask(ctx interface{}, input interface{}) *HashCrackTaskMock_CancelTask_Call {
+	return &HashCrackTaskMock_CancelTask_Call{Call: _e.mock.On("CancelTask", ctx, input)}
+}
+
+func (_c *HashCrackTaskMock_CancelTask_Call) Run(run func(ctx context.Context, input *message.HashCrackTaskCancelled)) *HashCrackTaskMock_CancelTask_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*message.HashCrackTaskCancelled))
+	})
+	return _c
+}
+
+func (_c *HashCrackTaskMock_CancelTask_Call) Return(_a0 error) *HashCrackTaskMock_CancelTask_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *HashCrackTaskMock_CancelTask_Call) RunAndReturn(run func(context.Context, *message.HashCrackTaskCancelled) error) *HashCrackTaskMock_CancelTask_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // ExecuteTask provides a mock function with given fields: ctx, input
 func (_m *HashCrackTaskMock) ExecuteTask(ctx context.Context, input *message.HashCrackTaskStarted) error {
 	ret := _m.Called(ctx, input)
@@ -70,6 +117,53 @@ func (_c *HashCrackTaskMock_ExecuteTask_Call) RunAndReturn(run func(context.Cont
 	return _c
 }
 
+// ShrinkTask provides a mock function with given fields: ctx, input
+func (_m *HashCrackTaskMock) ShrinkTask(ctx context.Context, input *message.HashCrackTaskShrunk) error {
+	ret := _m.Called(ctx, input)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ShrinkTask")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *message.HashCrackTaskShrunk) error); ok {
+		r0 = rf(ctx, input)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// HashCrackTaskMock_ShrinkTask_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ShrinkTask'
+type HashCrackTaskMock_ShrinkTask_Call struct {
+	*mock.Call
+}
+
+// ShrinkTask is a helper method to define mock.On call
+//   - ctx context.Context
+//   - input *message.HashCrackTaskShrunk
+func (_e *HashCrackTaskMock_Expecter) ShrinkTask(ctx interface{}, input interface{}) *HashCrackTaskMock_ShrinkTask_Call {
+	return &HashCrackTaskMock_ShrinkTask_Call{Call: _e.mock.On("ShrinkTask", ctx, input)}
+}
+
+func (_c *HashCrackTaskMock_ShrinkTask_Call) Run(run func(ctx context.Context, input *message.HashCrackTaskShrunk)) *HashCrackTaskMock_ShrinkTask_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*message.HashCrackTaskShrunk))
+	})
+	return _c
+}
+
+func (_c *HashCrackTaskMock_ShrinkTask_Call) Return(_a0 error) *HashCrackTaskMock_ShrinkTask_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *HashCrackTaskMock_ShrinkTask_Call) RunAndReturn(run func(context.Context, *message.HashCrackTaskShrunk) error) *HashCrackTaskMock_ShrinkTask_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewHashCrackTaskMock creates a new instance of HashCrackTaskMock. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewHashCrackTaskMock(t interface {
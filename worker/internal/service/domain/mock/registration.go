@@ -0,0 +1,128 @@
+// Code generated by mockery v2.53.3. DO NOT EDIT.
+
+package mock
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// RegistrationMock is an autogenerated mock type for the Registration type
+type RegistrationMock struct {
+	mock.Mock
+}
+
+type RegistrationMock_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *RegistrationMock) EXPECT() *RegistrationMock_Expecter {
+	return &RegistrationMock_Expecter{mock: &_m.Mock}
+}
+
+// Heartbeat provides a mock function with given fields: ctx
+func (_m *RegistrationMock) Heartbeat(ctx context.Context) error {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Heartbeat")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RegistrationMock_Heartbeat_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Heartbeat'
+type RegistrationMock_Heartbeat_Call struct {
+	*mock.Call
+}
+
+// Heartbeat is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *RegistrationMock_Expecter) Heartbeat(ctx interface{}) *RegistrationMock_Heartbeat_Call {
+	return &RegistrationMock_Heartbeat_Call{Call: _e.mock.On("Heartbeat", ctx)}
+}
+
+func (_c *RegistrationMock_Heartbeat_Call) Run(run func(ctx context.Context)) *RegistrationMock_Heartbeat_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *RegistrationMock_Heartbeat_Call) Return(_a0 error) *RegistrationMock_Heartbeat_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *RegistrationMock_Heartbeat_Call) RunAndReturn(run func(context.Context) error) *RegistrationMock_Heartbeat_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Register provides a mock function with given fields: ctx
+func (_m *RegistrationMock) Register(ctx context.Context) error {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Register")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RegistrationMock_Register_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Register'
+type RegistrationMock_Register_Call struct {
+	*mock.Call
+}
+
+// Register is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *RegistrationMock_Expecter) Register(ctx interface{}) *RegistrationMock_Register_Call {
+	return &RegistrationMock_Register_Call{Call: _e.mock.On("Register", ctx)}
+}
+
+func (_c *RegistrationMock_Register_Call) Run(run func(ctx context.Context)) *RegistrationMock_Register_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *RegistrationMock_Register_Call) Return(_a0 error) *RegistrationMock_Register_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *RegistrationMock_Register_Call) RunAndReturn(run func(context.Context) error) *RegistrationMock_Register_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewRegistrationMock creates a new instance of RegistrationMock. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewRegistrationMock(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *RegistrationMock {
+	mock := &RegistrationMock{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,141 @@
+package registration
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"runtime"
+	"strings"
+	"sync"
+
+	"github.com/rs/zerolog"
+
+	"github.com/ptrvsrg/crack-hash/commonlib/bus/amqp/publisher"
+	"github.com/ptrvsrg/crack-hash/manager/pkg/message"
+	"github.com/ptrvsrg/crack-hash/worker/config"
+	"github.com/ptrvsrg/crack-hash/worker/internal/hashing"
+	"github.com/ptrvsrg/crack-hash/worker/internal/service/domain"
+	"github.com/ptrvsrg/crack-hash/worker/internal/service/infrastructure"
+	"github.com/ptrvsrg/crack-hash/worker/internal/version"
+)
+
+type svc struct {
+	logger     zerolog.Logger
+	cfg        config.HeartbeatConfig
+	workerID   string
+	publisher  publisher.Publisher[message.WorkerHeartbeat]
+	bruteforce infrastructure.HashBruteForce
+
+	mu    sync.Mutex
+	speed float64 // Speed measured by the benchmark
+}
+
+func NewService(
+	logger zerolog.Logger,
+	cfg config.HeartbeatConfig,
+	publisher publisher.Publisher[message.WorkerHeartbeat],
+	bruteforce infrastructure.HashBruteForce,
+) domain.Registration {
+	workerID := cfg.WorkerID
+	if workerID == "" {
+		workerID = defaultWorkerID()
+	}
+
+	return &svc{
+		logger: logger.With().
+			Str("type", "domain").
+			Str("service", "registration").
+			Str("worker-id", workerID).
+			Logger(),
+		cfg:        cfg,
+		workerID:   workerID,
+		publisher:  publisher,
+		bruteforce: bruteforce,
+	}
+}
+
+func (s *svc) Register(ctx context.Context) error {
+	s.logger.Info().Msg("register worker")
+
+	// Measure speed, the worker is registered without speed if the benchmark fails
+	speed, err := s.benchmark(ctx)
+	if err != nil {
+		s.logger.Warn().Err(err).Msg("failed to run benchmark")
+	}
+
+	s.logger.Info().Float64("speed", speed).Msg("benchmark finished")
+
+	s.mu.Lock()
+	s.speed = speed
+	s.mu.Unlock()
+
+	return s.send(ctx, message.WorkerEventRegistered)
+}
+
+func (s *svc) Heartbeat(ctx context.Context) error {
+	s.logger.Debug().Msg("send heartbeat")
+
+	return s.send(ctx, message.WorkerEventHeartbeat)
+}
+
+func (s *svc) send(ctx context.Context, event string) error {
+	s.mu.Lock()
+	speed := s.speed
+	s.mu.Unlock()
+
+	msg := &message.WorkerHeartbeat{
+		Version:       message.Version,
+		WorkerID:      s.workerID,
+		Event:         event,
+		WorkerVersion: version.AppVersion,
+		CPUCount:      runtime.NumCPU(),
+		Algorithms:    hashing.Names(),
+		Speed:         speed,
+	}
+
+	// Heartbeats are outdated soon, so they are not persisted
+	if err := s.publisher.SendMessage(ctx, msg, publisher.Transient, false, false); err != nil {
+		s.logger.Error().Err(err).Stack().Str("event", event).Msg("failed to send heartbeat message")
+		return fmt.Errorf("failed to send heartbeat message: %w", err)
+	}
+
+	return nil
+}
+
+// benchmark brute forces the keyspace, which has no target word, during the benchmark duration and returns
+// the number of candidates checked per second
+func (s *svc) benchmark(ctx context.Context) (float64, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.cfg.BenchmarkDuration)
+	defer cancel()
+
+	task := &infrastructure.BruteForceTask{
+		Algorithm: hashing.DefaultAlgorithm,
+		Hash:      strings.Repeat("0", 32),
+		Alphabet:  strings.Split("abcdefghijklmnopqrstuvwxyz0123456789", ""),
+		MinLength: 8,
+		MaxLength: 8,
+		Range:     &infrastructure.KeyRange{Start: 0, End: 2_821_109_907_456}, // 36^8 candidates
+	}
+
+	progressCh, err := s.bruteforce.BruteForce(ctx, task, s.cfg.BenchmarkDuration)
+	if err != nil {
+		return 0, fmt.Errorf("failed to brute force: %w", err)
+	}
+
+	speed := 0.0
+	for progress := range progressCh {
+		speed = progress.Speed
+	}
+
+	return speed, nil
+}
+
+// defaultWorkerID returns the hostname, which is unique for containers and pods
+func defaultWorkerID() string {
+	hostname, err := os.Hostname()
+	if err != nil || hostname == "" {
+		return fmt.Sprintf("worker-%d", os.Getpid())
+	}
+
+	return hostname
+}
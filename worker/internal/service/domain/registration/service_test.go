@@ -0,0 +1,141 @@
+package registration_test
+
+import (
+	"context"
+	"errors"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/stretchr/testify/assert"
+	mock3 "github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ptrvsrg/crack-hash/commonlib/bus/amqp/publisher"
+	"github.com/ptrvsrg/crack-hash/commonlib/bus/amqp/publisher/mock"
+	"github.com/ptrvsrg/crack-hash/commonlib/logging"
+	"github.com/ptrvsrg/crack-hash/manager/pkg/message"
+	"github.com/ptrvsrg/crack-hash/worker/config"
+	"github.com/ptrvsrg/crack-hash/worker/internal/hashing"
+	"github.com/ptrvsrg/crack-hash/worker/internal/service/domain"
+	"github.com/ptrvsrg/crack-hash/worker/internal/service/domain/registration"
+	"github.com/ptrvsrg/crack-hash/worker/internal/service/infrastructure"
+	mock2 "github.com/ptrvsrg/crack-hash/worker/internal/service/infrastructure/mock"
+)
+
+var (
+	cfg = config.HeartbeatConfig{
+		WorkerID:          "worker-1",
+		Period:            time.Second,
+		BenchmarkDuration: time.Second,
+	}
+
+	ctx = context.Background()
+)
+
+func init() {
+	logging.Setup(true)
+}
+
+func newServiceWithMocks() (
+	domain.Registration, *mock.PublisherMock[message.WorkerHeartbeat], *mock2.HashBruteForceMock,
+) {
+	mockPublisher := new(mock.PublisherMock[message.WorkerHeartbeat])
+	mockBruteForce := new(mock2.HashBruteForceMock)
+
+	return registration.NewService(log.Logger, cfg, mockPublisher, mockBruteForce), mockPublisher, mockBruteForce
+}
+
+func matchHeartbeat(event string, speed float64) any {
+	return mock3.MatchedBy(
+		func(msg *message.WorkerHeartbeat) bool {
+			return assert.ObjectsAreEqual(
+				&message.WorkerHeartbeat{
+					Version:       message.Version,
+					WorkerID:      "worker-1",
+					Event:         event,
+					WorkerVersion: msg.WorkerVersion,
+					CPUCount:      runtime.NumCPU(),
+					Algorithms:    hashing.Names(),
+					Speed:         speed,
+				}, msg,
+			)
+		},
+	)
+}
+
+func Test_Register(t *testing.T) {
+	t.Run(
+		"Success", func(t *testing.T) {
+			// Arrange
+			svc, mockPublisher, mockBruteForce := newServiceWithMocks()
+
+			progressCh := make(chan infrastructure.TaskProgress, 2)
+			progressCh <- infrastructure.TaskProgress{Status: infrastructure.TaskStatusInProgress, Speed: 1000.0}
+			progressCh <- infrastructure.TaskProgress{Status: infrastructure.TaskStatusError, Speed: 1500.0}
+			close(progressCh)
+
+			mockBruteForce.On("BruteForce", mock3.Anything, mock3.Anything, time.Second).Return(progressCh, nil).Once()
+			mockPublisher.On(
+				"SendMessage", ctx, matchHeartbeat(message.WorkerEventRegistered, 1500.0),
+				publisher.Transient, false, false,
+			).Return(nil).Once()
+			mockPublisher.On(
+				"SendMessage", ctx, matchHeartbeat(message.WorkerEventHeartbeat, 1500.0),
+				publisher.Transient, false, false,
+			).Return(nil).Once()
+
+			// Act
+			registerErr := svc.Register(ctx)
+			heartbeatErr := svc.Heartbeat(ctx)
+
+			// Assert
+			require.NoError(t, registerErr)
+			require.NoError(t, heartbeatErr)
+			mockBruteForce.AssertExpectations(t)
+			mockPublisher.AssertExpectations(t)
+		},
+	)
+
+	t.Run(
+		"Benchmark error", func(t *testing.T) {
+			// Arrange
+			svc, mockPublisher, mockBruteForce := newServiceWithMocks()
+
+			mockBruteForce.On("BruteForce", mock3.Anything, mock3.Anything, time.Second).
+				Return(nil, errors.New("error")).Once()
+			mockPublisher.On(
+				"SendMessage", ctx, matchHeartbeat(message.WorkerEventRegistered, 0),
+				publisher.Transient, false, false,
+			).Return(nil).Once()
+
+			// Act
+			err := svc.Register(ctx)
+
+			// Assert
+			require.NoError(t, err)
+			mockBruteForce.AssertExpectations(t)
+			mockPublisher.AssertExpectations(t)
+		},
+	)
+
+	t.Run(
+		"Publish error", func(t *testing.T) {
+			// Arrange
+			svc, mockPublisher, mockBruteForce := newServiceWithMocks()
+
+			mockBruteForce.On("BruteForce", mock3.Anything, mock3.Anything, time.Second).
+				Return(nil, errors.New("error")).Once()
+			mockPublisher.On("SendMessage", ctx, mock3.Anything, publisher.Transient, false, false).
+				Return(errors.New("error")).Once()
+
+			// Act
+			err := svc.Register(ctx)
+
+			// Assert
+			require.Error(t, err)
+			mockPublisher.AssertExpectations(t)
+		},
+	)
+}
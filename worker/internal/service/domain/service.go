@@ -2,12 +2,27 @@ package domain
 
 import (
 	"context"
+	"errors"
 
 	"github.com/ptrvsrg/crack-hash/manager/pkg/message"
 )
 
+var (
+	ErrTaskCancelled      = errors.New("task is cancelled")
+	ErrUnsupportedVersion = errors.New("unsupported message version")
+	ErrInvalidTask        = errors.New("invalid task")
+)
+
 type HashCrackTask interface {
 	ExecuteTask(ctx context.Context, input *message.HashCrackTaskStarted) error
+	CancelTask(ctx context.Context, input *message.HashCrackTaskCancelled) error
+	ShrinkTask(ctx context.Context, input *message.HashCrackTaskShrunk) error
+}
+
+// Registration announces the worker to the manager on startup and then periodically
+type Registration interface {
+	Register(ctx context.Context) error
+	Heartbeat(ctx context.Context) error
 }
 
 type Health interface {
@@ -16,5 +31,6 @@ type Health interface {
 
 type Services struct {
 	HashCrackTask HashCrackTask
+	Registration  Registration
 	Health        Health
 }
@@ -1,9 +1,10 @@
 package chunkbased
 
 import (
-	"crypto/md5" // nolint
-	"encoding/hex"
+	"context"
+	"errors"
 	"fmt"
+	"runtime"
 	"strings"
 	"time"
 
@@ -11,94 +12,90 @@ import (
 
 	"github.com/ptrvsrg/crack-hash/worker/internal/combin"
 	"github.com/ptrvsrg/crack-hash/worker/internal/service/infrastructure"
+	"github.com/ptrvsrg/crack-hash/worker/internal/service/infrastructure/bruteforce/search"
 )
 
+// ErrMissingRange is returned for a brute force or mask task without the range of candidate indexes
+var ErrMissingRange = errors.New("missing range of candidate indexes")
+
 type svc struct {
-	logger    zerolog.Logger
-	chunkSize int
+	logger      zerolog.Logger
+	parallelism int
 }
 
-func NewService(logger zerolog.Logger, chunkSize int) infrastructure.HashBruteForce {
+// NewService creates the brute force service of alphabet and mask tasks checking every chunk in `parallelism`
+// goroutines, a non-positive parallelism means the number of CPUs
+func NewService(logger zerolog.Logger, parallelism int) infrastructure.HashBruteForce {
+	if parallelism <= 0 {
+		parallelism = runtime.NumCPU()
+	}
+
 	return &svc{
 		logger: logger.With().
 			Str("type", "infrastructure").
 			Str("service", "brute-force").
 			Str("strategy", "chunk-based").Logger(),
-		chunkSize: chunkSize,
+		parallelism: parallelism,
 	}
 }
 
-func (s *svc) BruteForceMD5(
-	hash string, alphabet []string, maxLength, partNumber int, progressPeriod time.Duration,
+func (s *svc) BruteForce(
+	ctx context.Context, task *infrastructure.BruteForceTask, progressPeriod time.Duration,
 ) (<-chan infrastructure.TaskProgress, error) {
-
 	s.logger.Info().
-		Str("hash", hash).
-		Int("maxLength", maxLength).
-		Str("alphabet", strings.Join(alphabet, "")).
-		Int("part", partNumber).
-		Int("chunkSize", s.chunkSize).
-		Msg("brute force md5")
-
-	// Create alphabet iterator
-	gen, err := combin.NewAlphabetIterator(
-		strings.Join(alphabet, ""),
-		maxLength,
-		partNumber*s.chunkSize,
-	)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create alphabet iterator: %w", err)
+		Str("algorithm", task.Algorithm).
+		Str("hash", task.Hash).
+		Int("hashCount", len(task.Hashes)).
+		Bool("salted", task.Salt != nil).
+		Int("maskLength", len(task.Mask)).
+		Int("minLength", task.MinLength).
+		Int("maxLength", task.MaxLength).
+		Str("alphabet", strings.Join(task.Alphabet, "")).
+		Int("part", task.PartNumber).
+		Int("parallelism", s.parallelism).
+		Msg("brute force")
+
+	if task.Range == nil {
+		return nil, ErrMissingRange
 	}
 
-	// Initialize progress
-	progress := infrastructure.TaskProgress{
-		Answers: make([]string, 0, 1024),
-		Percent: 0.0,
-		Status:  infrastructure.TaskStatusInProgress,
+	chunk := search.Chunk{
+		Open:    candidatesOpener(task),
+		Base:    task.Range.Start,
+		Size:    max(0, task.Range.End-task.Range.Start),
+		PerUnit: 1,
 	}
-	progressCh := make(chan infrastructure.TaskProgress, 1)
-
-	go func() {
-		// Create ticker
-		ticker := time.NewTicker(progressPeriod)
-		defer ticker.Stop()
-		defer close(progressCh)
-
-		for i := 0; i < s.chunkSize && gen.Next(); i++ {
-			select {
-			case <-ticker.C:
-				progress.Percent = 100 * float64(i+1) / float64(s.chunkSize)
-				s.logger.Debug().
-					Str("hash", hash).
-					Int("maxLength", maxLength).
-					Int("part", partNumber).
-					Msgf("processed by %.2f%%", progress.Percent)
-
-				progressCh <- progress
-
-			default:
-				word := gen.Current()
-				md5Hash := md5.Sum([]byte(word)) // nolint
-				sum := hex.EncodeToString(md5Hash[:])
-
-				if sum == hash {
-					progress.Answers = append(progress.Answers, word)
-				}
 
-			}
-		}
+	return search.Run(ctx, s.logger, task, chunk, s.parallelism, progressPeriod)
+}
 
-		progress.Percent = 100.0
-		progress.Status = infrastructure.TaskStatusSuccess
+// candidatesOpener returns the opener of the mask or alphabet iterators over the range of the task
+func candidatesOpener(task *infrastructure.BruteForceTask) search.Opener {
+	offset := task.Range.Start
 
-		s.logger.Debug().
-			Str("hash", hash).
-			Int("maxLength", maxLength).
-			Int("part", partNumber).
-			Msgf("processed by %.2f%%", progress.Percent)
+	if len(task.Mask) > 0 {
+		return func(start, end int) (search.Candidates, error) {
+			gen, err := combin.NewBoundedMaskIterator(task.Mask, offset+start, offset+end)
+			if err != nil {
+				return nil, fmt.Errorf("failed to create mask iterator: %w", err)
+			}
+
+			return gen, nil
+		}
+	}
 
-		progressCh <- progress
-	}()
+	return func(start, end int) (search.Candidates, error) {
+		gen, err := combin.NewBoundedAlphabetIterator(
+			strings.Join(task.Alphabet, ""),
+			max(1, task.MinLength),
+			task.MaxLength,
+			offset+start,
+			offset+end,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create alphabet iterator: %w", err)
+		}
 
-	return progressCh, nil
+		return gen, nil
+	}
 }
@@ -1,6 +1,9 @@
 package chunkbased_test
 
 import (
+	"context"
+	"fmt"
+	"runtime"
 	"strings"
 	"testing"
 	"time"
@@ -8,6 +11,7 @@ import (
 	"github.com/rs/zerolog/log"
 
 	"github.com/ptrvsrg/crack-hash/commonlib/logging"
+	"github.com/ptrvsrg/crack-hash/worker/internal/service/infrastructure"
 	"github.com/ptrvsrg/crack-hash/worker/internal/service/infrastructure/bruteforce/chunkbased"
 )
 
@@ -16,18 +20,33 @@ func init() {
 }
 
 func Benchmark(b *testing.B) {
-	svc := chunkbased.NewService(log.Logger, 10_000_000)
-	hash := "abcde"
+	hash := "ab56b4d92b40713acc5af89985d4b786"
 	alphabet := "abcdefghijklmnopqrstuvwxyz1234567890"
 	maxLength := 5
 
-	for i := 0; i < b.N; i++ {
-		ch, err := svc.BruteForceMD5(hash, strings.Split(alphabet, ""), maxLength, 0, time.Second)
-		if err != nil {
-			b.Fatal(err)
-		}
+	for _, parallelism := range []int{1, runtime.NumCPU()} {
+		b.Run(
+			fmt.Sprintf("Parallelism %d", parallelism), func(b *testing.B) {
+				svc := chunkbased.NewService(log.Logger, parallelism)
 
-		for range ch {
-		}
+				for i := 0; i < b.N; i++ {
+					task := &infrastructure.BruteForceTask{
+						Algorithm: "MD5",
+						Hash:      hash,
+						Alphabet:  strings.Split(alphabet, ""),
+						MaxLength: maxLength,
+						Range:     &infrastructure.KeyRange{Start: 0, End: 10_000_000},
+					}
+
+					ch, err := svc.BruteForce(context.Background(), task, time.Second)
+					if err != nil {
+						b.Fatal(err)
+					}
+
+					for range ch {
+					}
+				}
+			},
+		)
 	}
 }
@@ -0,0 +1,334 @@
+package chunkbased_test
+
+import (
+	"context"
+	"crypto/md5" // nolint
+	"encoding/hex"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ptrvsrg/crack-hash/worker/internal/hashing"
+	"github.com/ptrvsrg/crack-hash/worker/internal/service/infrastructure"
+	"github.com/ptrvsrg/crack-hash/worker/internal/service/infrastructure/bruteforce/chunkbased"
+)
+
+var ctx = context.Background()
+
+func md5Hex(word string) string {
+	sum := md5.Sum([]byte(word)) // nolint
+	return hex.EncodeToString(sum[:])
+}
+
+func collect(t *testing.T, task *infrastructure.BruteForceTask) infrastructure.TaskProgress {
+	t.Helper()
+
+	svc := chunkbased.NewService(log.Logger, 1)
+
+	ch, err := svc.BruteForce(ctx, task, time.Minute)
+	require.NoError(t, err)
+
+	var last infrastructure.TaskProgress
+	for progress := range ch {
+		last = progress
+	}
+
+	return last
+}
+
+func TestBruteForce(t *testing.T) {
+	t.Run(
+		"Single hash", func(t *testing.T) {
+			// Act
+			progress := collect(
+				t, &infrastructure.BruteForceTask{
+					Algorithm: "MD5",
+					Hash:      md5Hex("cab"),
+					Alphabet:  []string{"a", "b", "c"},
+					MaxLength: 3,
+					Range:     &infrastructure.KeyRange{Start: 0, End: 1000},
+				},
+			)
+
+			// Assert
+			require.Equal(t, infrastructure.TaskStatusSuccess, progress.Status)
+			require.Equal(t, []string{"cab"}, progress.Answers)
+			require.Equal(t, []infrastructure.FoundHash{{Hash: md5Hex("cab"), Word: "cab"}}, progress.Found)
+		},
+	)
+
+	t.Run(
+		"Multiple hashes", func(t *testing.T) {
+			// Act
+			progress := collect(
+				t, &infrastructure.BruteForceTask{
+					Algorithm: "MD5",
+					Hashes:    []string{md5Hex("ab"), md5Hex("zz"), md5Hex("cc")},
+					Alphabet:  []string{"a", "b", "c"},
+					MaxLength: 3,
+					Range:     &infrastructure.KeyRange{Start: 0, End: 1000},
+				},
+			)
+
+			// Assert
+			require.Equal(t, infrastructure.TaskStatusSuccess, progress.Status)
+			require.ElementsMatch(t, []string{"ab", "cc"}, progress.Answers)
+			require.ElementsMatch(
+				t, []infrastructure.FoundHash{
+					{Hash: md5Hex("ab"), Word: "ab"},
+					{Hash: md5Hex("cc"), Word: "cc"},
+				}, progress.Found,
+			)
+		},
+	)
+
+	t.Run(
+		"Salted hash", func(t *testing.T) {
+			// Act
+			progress := collect(
+				t, &infrastructure.BruteForceTask{
+					Algorithm: "MD5",
+					Hash:      md5Hex("saltba"),
+					Salt:      &hashing.Salt{Value: "salt", Position: hashing.SaltPrefix},
+					Alphabet:  []string{"a", "b", "c"},
+					MaxLength: 3,
+					Range:     &infrastructure.KeyRange{Start: 0, End: 1000},
+				},
+			)
+
+			// Assert
+			require.Equal(t, []string{"ba"}, progress.Answers)
+		},
+	)
+
+	t.Run(
+		"Min length", func(t *testing.T) {
+			// Act
+			progress := collect(
+				t, &infrastructure.BruteForceTask{
+					Algorithm: "MD5",
+					Hashes:    []string{md5Hex("b"), md5Hex("ba")},
+					Alphabet:  []string{"a", "b", "c"},
+					MinLength: 2,
+					MaxLength: 3,
+					Range:     &infrastructure.KeyRange{Start: 0, End: 1000},
+				},
+			)
+
+			// Assert
+			require.Equal(t, []string{"ba"}, progress.Answers)
+		},
+	)
+
+	t.Run(
+		"Invalid hash", func(t *testing.T) {
+			// Arrange
+			svc := chunkbased.NewService(log.Logger, 1)
+
+			// Act
+			_, err := svc.BruteForce(
+				ctx, &infrastructure.BruteForceTask{
+					Algorithm: "MD5",
+					Hashes:    []string{md5Hex("a"), "not a hash"},
+					Alphabet:  []string{"a"},
+					MaxLength: 1,
+					Range:     &infrastructure.KeyRange{Start: 0, End: 1000},
+				}, time.Minute,
+			)
+
+			// Assert
+			require.Error(t, err)
+		},
+	)
+
+	t.Run(
+		"Missing range", func(t *testing.T) {
+			// Arrange
+			svc := chunkbased.NewService(log.Logger, 1)
+
+			// Act
+			_, err := svc.BruteForce(
+				ctx, &infrastructure.BruteForceTask{
+					Algorithm: "MD5",
+					Hash:      md5Hex("a"),
+					Alphabet:  []string{"a"},
+					MaxLength: 1,
+				}, time.Minute,
+			)
+
+			// Assert
+			require.ErrorIs(t, err, chunkbased.ErrMissingRange)
+		},
+	)
+
+	t.Run(
+		"Mask", func(t *testing.T) {
+			// Act
+			progress := collect(
+				t, &infrastructure.BruteForceTask{
+					Algorithm: "MD5",
+					Hashes:    []string{md5Hex("Ab1"), md5Hex("ab1")},
+					Mask:      []string{"AB", "ab", "0123456789"},
+					Range:     &infrastructure.KeyRange{Start: 0, End: 1000},
+				},
+			)
+
+			// Assert
+			require.Equal(t, infrastructure.TaskStatusSuccess, progress.Status)
+			require.Equal(t, []string{"Ab1"}, progress.Answers)
+		},
+	)
+
+	t.Run(
+		"Mask part", func(t *testing.T) {
+			// Arrange
+			svc := chunkbased.NewService(log.Logger, 1)
+			task := &infrastructure.BruteForceTask{
+				Algorithm: "MD5",
+				Hashes:    []string{md5Hex("a1"), md5Hex("b2")},
+				Mask:      []string{"ab", "012"},
+				Range:     &infrastructure.KeyRange{Start: 4, End: 8},
+			}
+
+			// Act
+			ch, err := svc.BruteForce(ctx, task, time.Minute)
+			require.NoError(t, err)
+
+			var progress infrastructure.TaskProgress
+			for progress = range ch {
+			}
+
+			// Assert
+			require.Equal(t, infrastructure.TaskStatusSuccess, progress.Status)
+			require.Equal(t, []string{"b2"}, progress.Answers)
+		},
+	)
+
+	t.Run(
+		"Parallel", func(t *testing.T) {
+			// Arrange
+			svc := chunkbased.NewService(log.Logger, 4)
+			task := &infrastructure.BruteForceTask{
+				Algorithm: "MD5",
+				Hashes:    []string{md5Hex("a"), md5Hex("cab"), md5Hex("bbb")},
+				Alphabet:  []string{"a", "b", "c"},
+				MaxLength: 3,
+				Range:     &infrastructure.KeyRange{Start: 0, End: 1000},
+			}
+
+			// Act
+			ch, err := svc.BruteForce(ctx, task, time.Minute)
+			require.NoError(t, err)
+
+			var progress infrastructure.TaskProgress
+			for progress = range ch {
+			}
+
+			// Assert
+			require.Equal(t, infrastructure.TaskStatusSuccess, progress.Status)
+			require.ElementsMatch(t, []string{"a", "cab", "bbb"}, progress.Answers)
+			require.Equal(t, 100.0, progress.Percent)
+		},
+	)
+
+	t.Run(
+		"Resume from offset", func(t *testing.T) {
+			// Act
+			progress := collect(
+				t, &infrastructure.BruteForceTask{
+					Algorithm:    "MD5",
+					Hashes:       []string{md5Hex("a"), md5Hex("cab")},
+					Alphabet:     []string{"a", "b", "c"},
+					MaxLength:    3,
+					Range:        &infrastructure.KeyRange{Start: 0, End: 1000},
+					ResumeOffset: 10,
+				},
+			)
+
+			// Assert
+			require.Equal(t, infrastructure.TaskStatusSuccess, progress.Status)
+			require.Equal(t, []string{"cab"}, progress.Answers)
+			require.Equal(t, 100.0, progress.Percent)
+			require.Equal(t, 1000, progress.Checkpoint)
+		},
+	)
+
+	t.Run(
+		"Key range", func(t *testing.T) {
+			// Act
+			progress := collect(
+				t, &infrastructure.BruteForceTask{
+					Algorithm: "MD5",
+					Hashes:    []string{md5Hex("a"), md5Hex("cab")},
+					Alphabet:  []string{"a", "b", "c"},
+					MaxLength: 3,
+					Range:     &infrastructure.KeyRange{Start: 3, End: 39},
+				},
+			)
+
+			// Assert
+			require.Equal(t, infrastructure.TaskStatusSuccess, progress.Status)
+			require.Equal(t, []string{"cab"}, progress.Answers)
+			require.Equal(t, 36, progress.Checkpoint)
+		},
+	)
+
+	t.Run(
+		"Shrunk", func(t *testing.T) {
+			// Arrange
+			shrink := make(chan int, 1)
+			shrink <- 100
+
+			// Act
+			progress := collect(
+				t, &infrastructure.BruteForceTask{
+					Algorithm: "MD5",
+					Hash:      md5Hex("zzzzz"),
+					Alphabet:  strings.Split("abcdefghijklmnopqrstuvwxyz", ""),
+					MaxLength: 5,
+					Range:     &infrastructure.KeyRange{Start: 0, End: 12356630},
+					Shrink:    shrink,
+				},
+			)
+
+			// Assert
+			require.Equal(t, infrastructure.TaskStatusSuccess, progress.Status)
+			require.Empty(t, progress.Answers)
+			require.Equal(t, 100.0, progress.Percent)
+			require.Equal(t, 100, progress.Checkpoint)
+		},
+	)
+
+	t.Run(
+		"Cancelled", func(t *testing.T) {
+			// Arrange
+			svc := chunkbased.NewService(log.Logger, 2)
+			cancelledCtx, cancel := context.WithCancel(ctx)
+			cancel()
+
+			// Act
+			ch, err := svc.BruteForce(
+				cancelledCtx, &infrastructure.BruteForceTask{
+					Algorithm: "MD5",
+					Hash:      md5Hex("cab"),
+					Alphabet:  []string{"a", "b", "c"},
+					MaxLength: 3,
+					Range:     &infrastructure.KeyRange{Start: 0, End: 1000},
+				}, time.Minute,
+			)
+			require.NoError(t, err)
+
+			var progress infrastructure.TaskProgress
+			for progress = range ch {
+			}
+
+			// Assert
+			require.Equal(t, infrastructure.TaskStatusError, progress.Status)
+			require.NotNil(t, progress.Reason)
+			require.Equal(t, context.Canceled.Error(), *progress.Reason)
+		},
+	)
+}
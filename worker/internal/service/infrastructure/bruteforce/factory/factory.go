@@ -1,11 +1,15 @@
 package factory
 
 import (
+	"context"
+	"time"
+
 	"github.com/rs/zerolog"
 
 	"github.com/ptrvsrg/crack-hash/worker/config"
 	"github.com/ptrvsrg/crack-hash/worker/internal/service/infrastructure"
 	"github.com/ptrvsrg/crack-hash/worker/internal/service/infrastructure/bruteforce/chunkbased"
+	"github.com/ptrvsrg/crack-hash/worker/internal/service/infrastructure/bruteforce/wordlist"
 )
 
 type Strategy string
@@ -14,11 +18,35 @@ const (
 	StrategyChunkBased Strategy = "chunk-based"
 )
 
-func NewService(logger zerolog.Logger, cfg config.TaskSplitConfig) infrastructure.HashBruteForce {
+// router checks dictionary tasks by the wordlist strategy and other tasks by the keyspace strategy
+type router struct {
+	keyspace infrastructure.HashBruteForce
+	wordlist infrastructure.HashBruteForce
+}
+
+func NewService(
+	logger zerolog.Logger, cfg config.TaskSplitConfig, wordlists infrastructure.WordlistSource,
+) infrastructure.HashBruteForce {
+	var keyspace infrastructure.HashBruteForce
 	switch Strategy(cfg.Strategy) {
 	case StrategyChunkBased:
 		fallthrough
 	default:
-		return chunkbased.NewService(logger, cfg.ChunkSize)
+		keyspace = chunkbased.NewService(logger, cfg.Parallelism)
+	}
+
+	return &router{
+		keyspace: keyspace,
+		wordlist: wordlist.NewService(logger, cfg.Parallelism, wordlists),
 	}
 }
+
+func (r *router) BruteForce(
+	ctx context.Context, task *infrastructure.BruteForceTask, progressPeriod time.Duration,
+) (<-chan infrastructure.TaskProgress, error) {
+	if task.Wordlist != nil {
+		return r.wordlist.BruteForce(ctx, task, progressPeriod)
+	}
+
+	return r.keyspace.BruteForce(ctx, task, progressPeriod)
+}
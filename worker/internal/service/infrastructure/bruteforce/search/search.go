@@ -0,0 +1,362 @@
+package search
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/samber/lo"
+
+	"github.com/ptrvsrg/crack-hash/worker/internal/combin"
+	"github.com/ptrvsrg/crack-hash/worker/internal/hashing"
+	"github.com/ptrvsrg/crack-hash/worker/internal/service/infrastructure"
+)
+
+// flushSize is the number of candidates a goroutine checks before publishing its counter
+const flushSize = 4096
+
+type (
+	// Candidates iterates over the words to check. An iterator may implement io.Closer to release its source,
+	// `Err() error` to report a failure of reading its source and `Rejected() bool` to report the current
+	// candidate, which is counted as checked but not hashed, so the counter stays aligned with the units
+	Candidates interface {
+		Next() bool
+		Current() string
+	}
+
+	// Opener opens the iterator over the candidates with indexes from start to end of the chunk
+	Opener func(start, end int) (Candidates, error)
+
+	// Chunk is the part of a task checked by the search. The chunk is measured in units, a unit is
+	// a candidate or a wordlist line yielding a candidate per rule
+	Chunk struct {
+		Open    Opener
+		Base    int // Index of the first unit of the chunk in the task, the shrunk ends are relative to it
+		Size    int // Number of units of the chunk
+		PerUnit int // Number of candidates per unit
+	}
+
+	// chunkRange is a range of the chunk checked by a goroutine
+	chunkRange struct {
+		gen       Candidates
+		start     int
+		processed atomic.Int64
+		done      atomic.Bool
+	}
+
+	// chunkSearch collects the results of the goroutines checking the candidates of a chunk
+	chunkSearch struct {
+		processed atomic.Int64
+		ranges    []*chunkRange
+		end       atomic.Int64 // End of the chunk, which is lowered when the chunk is shrunk
+		perUnit   int
+		initial   int64 // Number of candidates checked before the search is started, e.g. on resume
+		startedAt time.Time
+		mu        sync.Mutex
+		answers   []string
+		found     []infrastructure.FoundHash
+		err       error
+	}
+)
+
+// Run checks the candidates of the chunk against the target hashes of the task in `parallelism` goroutines
+// and reports the progress every period. The candidates checked by the previous attempt are skipped
+func Run(
+	ctx context.Context,
+	logger zerolog.Logger,
+	task *infrastructure.BruteForceTask,
+	chunk Chunk,
+	parallelism int,
+	progressPeriod time.Duration,
+) (<-chan infrastructure.TaskProgress, error) {
+	hash, maxLength, partNumber := task.Hash, task.MaxLength, task.PartNumber
+
+	// Resolve hash algorithm
+	alg, err := hashing.Get(task.Algorithm)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve hash algorithm %q: %w", task.Algorithm, err)
+	}
+
+	targets, err := decodeTargets(task)
+	if err != nil {
+		return nil, err
+	}
+
+	// Create hashers, a hasher reuses its state, so every goroutine needs its own one
+	hashers := make([]*hashing.Hasher, parallelism)
+	for i := range hashers {
+		hashers[i], err = alg.NewSaltedHasher(task.Salt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create hasher: %w", err)
+		}
+	}
+
+	// Create candidates iterators, every goroutine iterates over its own range of the chunk
+	offset := min(max(0, task.ResumeOffset), chunk.Size)
+
+	ranges, err := openRanges(chunk.Open, offset, chunk.Size, parallelism)
+	if err != nil {
+		return nil, err
+	}
+
+	// Start goroutines checking the candidates
+	res := &chunkSearch{
+		ranges:    ranges,
+		perUnit:   chunk.PerUnit,
+		initial:   int64(offset * chunk.PerUnit),
+		startedAt: time.Now(),
+		answers:   make([]string, 0, 1024),
+		found:     make([]infrastructure.FoundHash, 0, 1024),
+	}
+	res.processed.Store(res.initial)
+	res.end.Store(int64(chunk.Size))
+
+	var wg sync.WaitGroup
+	for i, rng := range ranges {
+		wg.Add(1)
+		go func(rng *chunkRange, hasher *hashing.Hasher) {
+			defer wg.Done()
+			res.check(ctx, rng, hasher, targets)
+		}(rng, hashers[i])
+	}
+
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+
+	// Aggregate the progress of the goroutines
+	progressCh := make(chan infrastructure.TaskProgress, 1)
+
+	go func() {
+		// Create ticker
+		ticker := time.NewTicker(progressPeriod)
+		defer ticker.Stop()
+		defer close(progressCh)
+
+		for {
+			select {
+			case end := <-task.Shrink:
+				logger.Info().Int("part", partNumber).Int("end", end).Msg("shrink chunk")
+				res.shrink(end - chunk.Base)
+
+			case <-ticker.C:
+				progress := res.progress()
+				logger.Debug().
+					Str("hash", hash).
+					Int("maxLength", maxLength).
+					Int("part", partNumber).
+					Msgf("processed by %.2f%%", progress.Percent)
+
+				progressCh <- progress
+
+			case <-done:
+				progress := res.progress()
+
+				if ctx.Err() != nil {
+					logger.Info().Err(context.Cause(ctx)).Int("part", partNumber).Msg("brute force is stopped")
+
+					progress.Status = infrastructure.TaskStatusError
+					progress.Reason = lo.ToPtr(context.Cause(ctx).Error())
+					progressCh <- progress
+					return
+				}
+
+				if res.err != nil {
+					logger.Error().Err(res.err).Int("part", partNumber).Msg("failed to check candidates")
+
+					progress.Status = infrastructure.TaskStatusError
+					progress.Reason = lo.ToPtr(res.err.Error())
+					progressCh <- progress
+					return
+				}
+
+				progress.Percent = 100.0
+				progress.Status = infrastructure.TaskStatusSuccess
+
+				logger.Debug().
+					Str("hash", hash).
+					Int("maxLength", maxLength).
+					Int("part", partNumber).
+					Msgf("processed by %.2f%%", progress.Percent)
+
+				progressCh <- progress
+				return
+			}
+		}
+	}()
+
+	return progressCh, nil
+}
+
+// openRanges splits the chunk from the offset to the end into equal ranges and opens the candidates iterator
+// over every range. The ranges beyond the end of the keyspace are dropped, the last chunk of a task is usually
+// incomplete.
+func openRanges(open Opener, offset, end, count int) ([]*chunkRange, error) {
+	size := max(1, (end-offset+count-1)/count)
+
+	ranges := make([]*chunkRange, 0, count)
+	for start := offset; start < end; start += size {
+		gen, err := open(start, min(start+size, end))
+		if err != nil {
+			if start > 0 && errors.Is(err, combin.ErrStartIndexOutOfRange) {
+				break
+			}
+
+			for _, rng := range ranges {
+				closeCandidates(rng.gen)
+			}
+			return nil, err
+		}
+
+		ranges = append(ranges, &chunkRange{gen: gen, start: start})
+	}
+
+	return ranges, nil
+}
+
+// check hashes the candidates and collects the words of the target hashes. The processed counter
+// is published and the context is checked in batches to keep the hot loop free of synchronization.
+func (r *chunkSearch) check(ctx context.Context, rng *chunkRange, hasher *hashing.Hasher, targets map[string]string) {
+	gen := rng.gen
+	defer closeCandidates(gen)
+
+	// The range is beyond the end of the shrunk chunk
+	if r.reached(rng) {
+		rng.done.Store(true)
+		return
+	}
+
+	rejecting, _ := gen.(interface{ Rejected() bool })
+
+	processed := 0
+	for gen.Next() {
+		if rejecting == nil || !rejecting.Rejected() {
+			word := gen.Current()
+
+			if target, ok := targets[string(hasher.Sum(word))]; ok {
+				r.mu.Lock()
+				r.answers = append(r.answers, word)
+				r.found = append(r.found, infrastructure.FoundHash{Hash: target, Word: word})
+				r.mu.Unlock()
+			}
+		}
+
+		processed++
+		if processed == flushSize {
+			r.processed.Add(int64(processed))
+			rng.processed.Add(int64(processed))
+			processed = 0
+
+			if ctx.Err() != nil {
+				return
+			}
+
+			if r.reached(rng) {
+				rng.done.Store(true)
+				return
+			}
+		}
+	}
+	r.processed.Add(int64(processed))
+	rng.processed.Add(int64(processed))
+
+	if errGen, ok := gen.(interface{ Err() error }); ok && errGen.Err() != nil {
+		r.mu.Lock()
+		r.err = errors.Join(r.err, fmt.Errorf("failed to read wordlist: %w", errGen.Err()))
+		r.mu.Unlock()
+		return
+	}
+
+	rng.done.Store(ctx.Err() == nil)
+}
+
+// shrink lowers the end of the chunk, the goroutines stop at the next flush after reaching it
+func (r *chunkSearch) shrink(end int) {
+	for {
+		current := r.end.Load()
+		if int64(end) >= current || r.end.CompareAndSwap(current, int64(max(0, end))) {
+			return
+		}
+	}
+}
+
+// reached reports whether the goroutine has checked its range up to the end of the chunk
+func (r *chunkSearch) reached(rng *chunkRange) bool {
+	return int64(rng.start)+rng.processed.Load()/int64(r.perUnit) >= r.end.Load()
+}
+
+// progress returns the snapshot of the search progress
+func (r *chunkSearch) progress() infrastructure.TaskProgress {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	total := int(r.end.Load()) * r.perUnit
+	processed := r.processed.Load()
+
+	return infrastructure.TaskProgress{
+		Answers:    append([]string(nil), r.answers...),
+		Found:      append([]infrastructure.FoundHash(nil), r.found...),
+		Percent:    min(100.0, 100*float64(processed)/float64(max(1, total))),
+		Status:     infrastructure.TaskStatusInProgress,
+		Checkpoint: r.checkpoint(),
+		Speed:      r.speed(processed),
+	}
+}
+
+// speed returns the number of candidates checked per second since the search is started
+func (r *chunkSearch) speed(processed int64) float64 {
+	elapsed := time.Since(r.startedAt).Seconds()
+	if elapsed <= 0 {
+		return 0
+	}
+
+	return float64(max(0, processed-r.initial)) / elapsed
+}
+
+// checkpoint returns the number of leading candidates of the chunk, which are checked. The ranges are checked
+// concurrently, so it is the position reached in the first unfinished range
+func (r *chunkSearch) checkpoint() int {
+	end := int(r.end.Load())
+	for _, rng := range r.ranges {
+		if !rng.done.Load() {
+			return min(end, rng.start+int(rng.processed.Load())/r.perUnit)
+		}
+	}
+
+	return end
+}
+
+func closeCandidates(gen Candidates) {
+	if closer, ok := gen.(io.Closer); ok {
+		_ = closer.Close()
+	}
+}
+
+// decodeTargets maps the raw digests of the target hashes to their hex representation,
+// so every candidate is hashed once and looked up in the set
+func decodeTargets(task *infrastructure.BruteForceTask) (map[string]string, error) {
+	hashes := task.Hashes
+	if len(hashes) == 0 {
+		hashes = []string{task.Hash}
+	}
+
+	targets := make(map[string]string, len(hashes))
+	for _, hash := range hashes {
+		digest, err := hex.DecodeString(hash)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode hash %q: %w", hash, err)
+		}
+
+		targets[string(digest)] = hash
+	}
+
+	return targets, nil
+}
@@ -0,0 +1,178 @@
+package wordlist
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"runtime"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"github.com/ptrvsrg/crack-hash/manager/pkg/mangling"
+	"github.com/ptrvsrg/crack-hash/worker/internal/service/infrastructure"
+	"github.com/ptrvsrg/crack-hash/worker/internal/service/infrastructure/bruteforce/search"
+)
+
+// ErrMissingWordlist is returned for a task without the range of wordlist lines
+var ErrMissingWordlist = errors.New("missing range of wordlist lines")
+
+// maxLineSize is the maximum length of a wordlist line
+const maxLineSize = 1024 * 1024
+
+type svc struct {
+	logger      zerolog.Logger
+	parallelism int
+	wordlists   infrastructure.WordlistSource
+}
+
+// NewService creates the brute force service of dictionary tasks checking every range of wordlist lines
+// in `parallelism` goroutines, a non-positive parallelism means the number of CPUs
+func NewService(
+	logger zerolog.Logger, parallelism int, wordlists infrastructure.WordlistSource,
+) infrastructure.HashBruteForce {
+	if parallelism <= 0 {
+		parallelism = runtime.NumCPU()
+	}
+
+	return &svc{
+		logger: logger.With().
+			Str("type", "infrastructure").
+			Str("service", "brute-force").
+			Str("strategy", "wordlist").Logger(),
+		parallelism: parallelism,
+		wordlists:   wordlists,
+	}
+}
+
+func (s *svc) BruteForce(
+	ctx context.Context, task *infrastructure.BruteForceTask, progressPeriod time.Duration,
+) (<-chan infrastructure.TaskProgress, error) {
+	s.logger.Info().
+		Str("algorithm", task.Algorithm).
+		Str("hash", task.Hash).
+		Int("hashCount", len(task.Hashes)).
+		Bool("salted", task.Salt != nil).
+		Int("ruleCount", len(task.Rules)).
+		Int("part", task.PartNumber).
+		Int("parallelism", s.parallelism).
+		Msg("brute force")
+
+	if task.Wordlist == nil {
+		return nil, ErrMissingWordlist
+	}
+
+	rules, err := mangling.ParseAll(task.Rules)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse rules: %w", err)
+	}
+
+	// Ranges of the chunk are ranges of the wordlist lines, every line yields a candidate per rule
+	chunk := search.Chunk{
+		Open:    s.candidatesOpener(ctx, task.Wordlist, rules),
+		Base:    task.Wordlist.Offset,
+		Size:    task.Wordlist.Count,
+		PerUnit: max(1, len(rules)),
+	}
+
+	return search.Run(ctx, s.logger, task, chunk, s.parallelism, progressPeriod)
+}
+
+// candidatesOpener returns the opener of the iterators over the lines of the wordlist range, mangled by the rules
+func (s *svc) candidatesOpener(
+	ctx context.Context, wordlist *infrastructure.WordlistRange, rules []*mangling.Rule,
+) search.Opener {
+	return func(start, end int) (search.Candidates, error) {
+		content, err := s.wordlists.Open(ctx, wordlist.ID, wordlist.Offset+start, end-start)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open wordlist: %w", err)
+		}
+
+		lines := bufio.NewScanner(content)
+		lines.Buffer(make([]byte, 0, 64*1024), maxLineSize)
+
+		var gen search.Candidates = &lineIterator{scanner: lines, closer: content}
+		if len(rules) > 0 {
+			gen = &ruleIterator{words: gen, rules: rules}
+		}
+
+		return gen, nil
+	}
+}
+
+// lineIterator iterates over the lines of a wordlist range
+type lineIterator struct {
+	scanner *bufio.Scanner
+	closer  io.Closer
+}
+
+func (it *lineIterator) Next() bool {
+	return it.scanner.Scan()
+}
+
+func (it *lineIterator) Current() string {
+	return it.scanner.Text()
+}
+
+func (it *lineIterator) Err() error {
+	return it.scanner.Err()
+}
+
+func (it *lineIterator) Close() error {
+	return it.closer.Close()
+}
+
+// ruleIterator applies every rule to every base word. A rejected candidate is still yielded, so every
+// base word yields a candidate per rule and the search counts the wordlist lines exactly
+type ruleIterator struct {
+	words    search.Candidates
+	rules    []*mangling.Rule
+	word     string
+	current  string
+	rejected bool
+	next     int
+}
+
+func (it *ruleIterator) Next() bool {
+	if it.next == 0 {
+		if !it.words.Next() {
+			return false
+		}
+		it.word = it.words.Current()
+	}
+
+	rule := it.rules[it.next]
+	it.next = (it.next + 1) % len(it.rules)
+
+	candidate, ok := rule.Apply(it.word)
+	it.current, it.rejected = candidate, !ok
+
+	return true
+}
+
+func (it *ruleIterator) Current() string {
+	return it.current
+}
+
+// Rejected reports whether the current candidate is rejected by its rule and must not be checked
+func (it *ruleIterator) Rejected() bool {
+	return it.rejected
+}
+
+func (it *ruleIterator) Err() error {
+	if errWords, ok := it.words.(interface{ Err() error }); ok {
+		return errWords.Err()
+	}
+
+	return nil
+}
+
+func (it *ruleIterator) Close() error {
+	if closer, ok := it.words.(io.Closer); ok {
+		return closer.Close()
+	}
+
+	return nil
+}
@@ -0,0 +1,249 @@
+package wordlist_test
+
+import (
+	"context"
+	"crypto/md5" // nolint
+	"encoding/hex"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ptrvsrg/crack-hash/manager/pkg/mangling"
+	"github.com/ptrvsrg/crack-hash/worker/internal/service/infrastructure"
+	"github.com/ptrvsrg/crack-hash/worker/internal/service/infrastructure/bruteforce/wordlist"
+	"github.com/ptrvsrg/crack-hash/worker/internal/service/infrastructure/mock"
+)
+
+var (
+	mockWordlists = new(mock.WordlistSourceMock)
+
+	ctx = context.Background()
+)
+
+func md5Hex(word string) string {
+	sum := md5.Sum([]byte(word)) // nolint
+	return hex.EncodeToString(sum[:])
+}
+
+func collect(t *testing.T, task *infrastructure.BruteForceTask) infrastructure.TaskProgress {
+	t.Helper()
+
+	svc := wordlist.NewService(log.Logger, 1, mockWordlists)
+
+	ch, err := svc.BruteForce(ctx, task, time.Minute)
+	require.NoError(t, err)
+
+	var last infrastructure.TaskProgress
+	for progress := range ch {
+		last = progress
+	}
+
+	return last
+}
+
+func TestBruteForce(t *testing.T) {
+	t.Run(
+		"Dictionary", func(t *testing.T) {
+			// Arrange
+			mockWordlists.On("Open", ctx, "wordlist", 10, 3).
+				Return(io.NopCloser(strings.NewReader("password\nqwerty\nletmein\n")), nil).Once()
+
+			// Act
+			progress := collect(
+				t, &infrastructure.BruteForceTask{
+					Algorithm: "MD5",
+					Hashes:    []string{md5Hex("qwerty"), md5Hex("abc")},
+					Wordlist:  &infrastructure.WordlistRange{ID: "wordlist", Offset: 10, Count: 3},
+				},
+			)
+
+			// Assert
+			require.Equal(t, infrastructure.TaskStatusSuccess, progress.Status)
+			require.Equal(t, []string{"qwerty"}, progress.Answers)
+			require.Equal(t, 100.0, progress.Percent)
+			mockWordlists.AssertExpectations(t)
+		},
+	)
+
+	t.Run(
+		"Parallel dictionary", func(t *testing.T) {
+			// Arrange
+			svc := wordlist.NewService(log.Logger, 2, mockWordlists)
+
+			mockWordlists.On("Open", ctx, "wordlist", 10, 2).
+				Return(io.NopCloser(strings.NewReader("password\nqwerty\n")), nil).Once()
+			mockWordlists.On("Open", ctx, "wordlist", 12, 1).
+				Return(io.NopCloser(strings.NewReader("letmein\n")), nil).Once()
+
+			// Act
+			ch, err := svc.BruteForce(
+				ctx, &infrastructure.BruteForceTask{
+					Algorithm: "MD5",
+					Hashes:    []string{md5Hex("password"), md5Hex("letmein")},
+					Wordlist:  &infrastructure.WordlistRange{ID: "wordlist", Offset: 10, Count: 3},
+				}, time.Minute,
+			)
+			require.NoError(t, err)
+
+			var progress infrastructure.TaskProgress
+			for progress = range ch {
+			}
+
+			// Assert
+			require.Equal(t, infrastructure.TaskStatusSuccess, progress.Status)
+			require.ElementsMatch(t, []string{"password", "letmein"}, progress.Answers)
+			mockWordlists.AssertExpectations(t)
+		},
+	)
+
+	t.Run(
+		"Resume dictionary", func(t *testing.T) {
+			// Arrange
+			mockWordlists.On("Open", ctx, "wordlist", 12, 1).
+				Return(io.NopCloser(strings.NewReader("letmein\n")), nil).Once()
+
+			// Act
+			progress := collect(
+				t, &infrastructure.BruteForceTask{
+					Algorithm:    "MD5",
+					Hashes:       []string{md5Hex("password"), md5Hex("letmein")},
+					Wordlist:     &infrastructure.WordlistRange{ID: "wordlist", Offset: 10, Count: 3},
+					ResumeOffset: 2,
+				},
+			)
+
+			// Assert
+			require.Equal(t, infrastructure.TaskStatusSuccess, progress.Status)
+			require.Equal(t, []string{"letmein"}, progress.Answers)
+			require.Equal(t, 3, progress.Checkpoint)
+			mockWordlists.AssertExpectations(t)
+		},
+	)
+
+	t.Run(
+		"Dictionary open error", func(t *testing.T) {
+			// Arrange
+			svc := wordlist.NewService(log.Logger, 1, mockWordlists)
+			expectedErr := errors.New("manager is unavailable")
+
+			mockWordlists.On("Open", ctx, "wordlist", 0, 3).Return(nil, expectedErr).Once()
+
+			// Act
+			_, err := svc.BruteForce(
+				ctx, &infrastructure.BruteForceTask{
+					Algorithm: "MD5",
+					Hash:      md5Hex("a"),
+					Wordlist:  &infrastructure.WordlistRange{ID: "wordlist", Count: 3},
+				}, time.Minute,
+			)
+
+			// Assert
+			require.ErrorIs(t, err, expectedErr)
+		},
+	)
+
+	t.Run(
+		"Dictionary with rules", func(t *testing.T) {
+			// Arrange
+			mockWordlists.On("Open", ctx, "wordlist", 0, 2).
+				Return(io.NopCloser(strings.NewReader("password\nqwerty\n")), nil).Once()
+
+			// Act
+			progress := collect(
+				t, &infrastructure.BruteForceTask{
+					Algorithm: "MD5",
+					Hashes:    []string{md5Hex("Password1!"), md5Hex("QWERTY")},
+					Wordlist:  &infrastructure.WordlistRange{ID: "wordlist", Count: 2},
+					Rules:     []string{":", "c $1 $!", "u", "]]]]]]]]"},
+				},
+			)
+
+			// Assert
+			require.Equal(t, infrastructure.TaskStatusSuccess, progress.Status)
+			require.ElementsMatch(t, []string{"Password1!", "QWERTY"}, progress.Answers)
+			mockWordlists.AssertExpectations(t)
+		},
+	)
+
+	t.Run(
+		"Rejected candidates are counted", func(t *testing.T) {
+			// Arrange
+			ctx, cancel := context.WithCancel(ctx)
+			cancel()
+
+			// Rule "]" rejects every single letter word, the search stops at the first flush covering 2048 lines
+			lines := strings.Repeat("a\n", 3000) + "b\n" + strings.Repeat("a\n", 6999)
+			mockWordlists.On("Open", ctx, "wordlist", 0, 10000).
+				Return(io.NopCloser(strings.NewReader(lines)), nil).Once()
+
+			svc := wordlist.NewService(log.Logger, 1, mockWordlists)
+
+			// Act
+			ch, err := svc.BruteForce(
+				ctx, &infrastructure.BruteForceTask{
+					Algorithm: "MD5",
+					Hash:      md5Hex("b"),
+					Wordlist:  &infrastructure.WordlistRange{ID: "wordlist", Count: 10000},
+					Rules:     []string{":", "]"},
+				}, time.Minute,
+			)
+			require.NoError(t, err)
+
+			var progress infrastructure.TaskProgress
+			for progress = range ch {
+			}
+
+			// Assert
+			require.Equal(t, infrastructure.TaskStatusError, progress.Status)
+			require.Equal(t, 2048, progress.Checkpoint)
+			require.Empty(t, progress.Answers)
+			mockWordlists.AssertExpectations(t)
+		},
+	)
+
+	t.Run(
+		"Invalid rule", func(t *testing.T) {
+			// Arrange
+			svc := wordlist.NewService(log.Logger, 1, mockWordlists)
+
+			// Act
+			_, err := svc.BruteForce(
+				ctx, &infrastructure.BruteForceTask{
+					Algorithm: "MD5",
+					Hash:      md5Hex("a"),
+					Wordlist:  &infrastructure.WordlistRange{ID: "wordlist", Count: 3},
+					Rules:     []string{"c!"},
+				}, time.Minute,
+			)
+
+			// Assert
+			require.ErrorIs(t, err, mangling.ErrInvalidRule)
+		},
+	)
+
+	t.Run(
+		"Missing wordlist", func(t *testing.T) {
+			// Arrange
+			svc := wordlist.NewService(log.Logger, 1, mockWordlists)
+
+			// Act
+			_, err := svc.BruteForce(
+				ctx, &infrastructure.BruteForceTask{
+					Algorithm: "MD5",
+					Hash:      md5Hex("a"),
+					Alphabet:  []string{"a"},
+					MaxLength: 1,
+					Range:     &infrastructure.KeyRange{Start: 0, End: 1},
+				}, time.Minute,
+			)
+
+			// Assert
+			require.ErrorIs(t, err, wordlist.ErrMissingWordlist)
+		},
+	)
+}
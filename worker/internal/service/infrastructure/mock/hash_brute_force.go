@@ -3,6 +3,8 @@
 package mock
 
 import (
+	context "context"
+
 	infrastructure "github.com/ptrvsrg/crack-hash/worker/internal/service/infrastructure"
 	mock "github.com/stretchr/testify/mock"
 
@@ -22,29 +24,29 @@ func (_m *HashBruteForceMock) EXPECT() *HashBruteForceMock_Expecter {
 	return &HashBruteForceMock_Expecter{mock: &_m.Mock}
 }
 
-// BruteForceMD5 provides a mock function with given fields: hash, alphabet, maxLength, partNumber, progressPeriod
-func (_m *HashBruteForceMock) BruteForceMD5(hash string, alphabet []string, maxLength int, partNumber int, progressPeriod time.Duration) (<-chan infrastructure.TaskProgress, error) {
-	ret := _m.Called(hash, alphabet, maxLength, partNumber, progressPeriod)
+// BruteForce provides a mock function with given fields: ctx, task, progressPeriod
+func (_m *HashBruteForceMock) BruteForce(ctx context.Context, task *infrastructure.BruteForceTask, progressPeriod time.Duration) (<-chan infrastructure.TaskProgress, error) {
+	ret := _m.Called(ctx, task, progressPeriod)
 
 	if len(ret) == 0 {
-		panic("no return value specified for BruteForceMD5")
+		panic("no return value specified for BruteForce")
 	}
 
 	var r0 <-chan infrastructure.TaskProgress
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string, []string, int, int, time.Duration) (<-chan infrastructure.TaskProgress, error)); ok {
-		return rf(hash, alphabet, maxLength, partNumber, progressPeriod)
+	if rf, ok := ret.Get(0).(func(context.Context, *infrastructure.BruteForceTask, time.Duration) (<-chan infrastructure.TaskProgress, error)); ok {
+		return rf(ctx, task, progressPeriod)
 	}
-	if rf, ok := ret.Get(0).(func(string, []string, int, int, time.Duration) <-chan infrastructure.TaskProgress); ok {
-		r0 = rf(hash, alphabet, maxLength, partNumber, progressPeriod)
+	if rf, ok := ret.Get(0).(func(context.Context, *infrastructure.BruteForceTask, time.Duration) <-chan infrastructure.TaskProgress); ok {
+		r0 = rf(ctx, task, progressPeriod)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(chan infrastructure.TaskProgress)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(string, []string, int, int, time.Duration) error); ok {
-		r1 = rf(hash, alphabet, maxLength, partNumber, progressPeriod)
+	if rf, ok := ret.Get(1).(func(context.Context, *infrastructure.BruteForceTask, time.Duration) error); ok {
+		r1 = rf(ctx, task, progressPeriod)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -52,34 +54,32 @@ func (_m *HashBruteForceMock) BruteForceMD5(hash string, alphabet []string, maxL
 	return r0, r1
 }
 
-// HashBruteForceMock_BruteForceMD5_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'BruteForceMD5'
-type HashBruteForceMock_BruteForceMD5_Call struct {
+// HashBruteForceMock_BruteForce_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'BruteForce'
+type HashBruteForceMock_BruteForce_Call struct {
 	*mock.Call
 }
 
-// BruteForceMD5 is a helper method to define mock.On call
-//   - hash string
-//   - alphabet []string
-//   - maxLength int
-//   - partNumber int
+// BruteForce is a helper method to define mock.On call
+//   - ctx context.Context
+//   - task *infrastructure.BruteForceTask
 //   - progressPeriod time.Duration
-func (_e *HashBruteForceMock_Expecter) BruteForceMD5(hash interface{}, alphabet interface{}, maxLength interface{}, partNumber interface{}, progressPeriod interface{}) *HashBruteForceMock_BruteForceMD5_Call {
-	return &HashBruteForceMock_BruteForceMD5_Call{Call: _e.mock.On("BruteForceMD5", hash, alphabet, maxLength, partNumber, progressPeriod)}
+func (_e *HashBruteForceMock_Expecter) BruteForce(ctx interface{}, task interface{}, progressPeriod interface{}) *HashBruteForceMock_BruteForce_Call {
+	return &HashBruteForceMock_BruteForce_Call{Call: _e.mock.On("BruteForce", ctx, task, progressPeriod)}
 }
 
-func (_c *HashBruteForceMock_BruteForceMD5_Call) Run(run func(hash string, alphabet []string, maxLength int, partNumber int, progressPeriod time.Duration)) *HashBruteForceMock_BruteForceMD5_Call {
+func (_c *HashBruteForceMock_BruteForce_Call) Run(run func(ctx context.Context, task *infrastructure.BruteForceTask, progressPeriod time.Duration)) *HashBruteForceMock_BruteForce_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string), args[1].([]string), args[2].(int), args[3].(int), args[4].(time.Duration))
+		run(args[0].(context.Context), args[1].(*infrastructure.BruteForceTask), args[2].(time.Duration))
 	})
 	return _c
 }
 
-func (_c *HashBruteForceMock_BruteForceMD5_Call) Return(_a0 <-chan infrastructure.TaskProgress, _a1 error) *HashBruteForceMock_BruteForceMD5_Call {
+func (_c *HashBruteForceMock_BruteForce_Call) Return(_a0 <-chan infrastructure.TaskProgress, _a1 error) *HashBruteForceMock_BruteForce_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *HashBruteForceMock_BruteForceMD5_Call) RunAndReturn(run func(string, []string, int, int, time.Duration) (<-chan infrastructure.TaskProgress, error)) *HashBruteForceMock_BruteForceMD5_Call {
+func (_c *HashBruteForceMock_BruteForce_Call) RunAndReturn(run func(context.Context, *infrastructure.BruteForceTask, time.Duration) (<-chan infrastructure.TaskProgress, error)) *HashBruteForceMock_BruteForce_Call {
 	_c.Call.Return(run)
 	return _c
 }
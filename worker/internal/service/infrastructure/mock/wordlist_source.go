@@ -0,0 +1,99 @@
+// Code generated by mockery v2.53.3. DO NOT EDIT.
+
+package mock
+
+import (
+	context "context"
+
+	io "io"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// WordlistSourceMock is an autogenerated mock type for the WordlistSource type
+type WordlistSourceMock struct {
+	mock.Mock
+}
+
+type WordlistSourceMock_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *WordlistSourceMock) EXPECT() *WordlistSourceMock_Expecter {
+	return &WordlistSourceMock_Expecter{mock: &_m.Mock}
+}
+
+// Open provides a mock function with given fields: ctx, id, offset, count
+func (_m *WordlistSourceMock) Open(ctx context.Context, id string, offset int, count int) (io.ReadCloser, error) {
+	ret := _m.Called(ctx, id, offset, count)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Open")
+	}
+
+	var r0 io.ReadCloser
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int, int) (io.ReadCloser, error)); ok {
+		return rf(ctx, id, offset, count)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, int, int) io.ReadCloser); ok {
+		r0 = rf(ctx, id, offset, count)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(io.ReadCloser)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, int, int) error); ok {
+		r1 = rf(ctx, id, offset, count)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// WordlistSourceMock_Open_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Open'
+type WordlistSourceMock_Open_Call struct {
+	*mock.Call
+}
+
+// Open is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - offset int
+//   - count int
+func (_e *WordlistSourceMock_Expecter) Open(ctx interface{}, id interface{}, offset interface{}, count interface{}) *WordlistSourceMock_Open_Call {
+	return &WordlistSourceMock_Open_Call{Call: _e.mock.On("Open", ctx, id, offset, count)}
+}
+
+func (_c *WordlistSourceMock_Open_Call) Run(run func(ctx context.Context, id string, offset int, count int)) *WordlistSourceMock_Open_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(int), args[3].(int))
+	})
+	return _c
+}
+
+func (_c *WordlistSourceMock_Open_Call) Return(_a0 io.ReadCloser, _a1 error) *WordlistSourceMock_Open_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *WordlistSourceMock_Open_Call) RunAndReturn(run func(context.Context, string, int, int) (io.ReadCloser, error)) *WordlistSourceMock_Open_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewWordlistSourceMock creates a new instance of WordlistSourceMock. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewWordlistSourceMock(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *WordlistSourceMock {
+	mock := &WordlistSourceMock{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
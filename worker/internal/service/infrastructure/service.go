@@ -1,6 +1,12 @@
 package infrastructure
 
-import "time"
+import (
+	"context"
+	"io"
+	"time"
+
+	"github.com/ptrvsrg/crack-hash/worker/internal/hashing"
+)
 
 const (
 	TaskStatusInProgress TaskStatus = "IN_PROGRESS"
@@ -13,18 +19,64 @@ type (
 
 	TaskProgress struct {
 		Answers []string
+		Found   []FoundHash
 		Percent float64
 		Status  TaskStatus
 		Reason  *string
+		// Checkpoint is the number of leading candidates of the part, which are checked,
+		// candidates of a dictionary part are counted in wordlist lines
+		Checkpoint int
+		// Speed is the number of candidates checked per second
+		Speed float64
+	}
+
+	FoundHash struct {
+		Hash string
+		Word string
+	}
+
+	BruteForceTask struct {
+		Algorithm  string
+		Hash       string
+		Hashes     []string
+		Salt       *hashing.Salt
+		Alphabet   []string
+		MinLength  int
+		MaxLength  int
+		PartNumber int
+		Wordlist   *WordlistRange
+		Rules      []string
+		Mask       []string
+		// ResumeOffset is the checkpoint of the previous attempt, the candidates before it are not checked again
+		ResumeOffset int
+		// Range is the range of candidate indexes of a brute force or mask part
+		Range *KeyRange
+		// Shrink receives new ends of the range, the candidates after the end are not checked.
+		// The end is a candidate index or a wordlist line like the range
+		Shrink <-chan int
+	}
+
+	KeyRange struct {
+		Start int
+		End   int
+	}
+
+	WordlistRange struct {
+		ID     string
+		Offset int
+		Count  int
 	}
 )
 
 type HashBruteForce interface {
-	BruteForceMD5(
-		hash string, alphabet []string, maxLength, partNumber int, progressPeriod time.Duration,
-	) (<-chan TaskProgress, error)
+	BruteForce(ctx context.Context, task *BruteForceTask, progressPeriod time.Duration) (<-chan TaskProgress, error)
+}
+
+type WordlistSource interface {
+	Open(ctx context.Context, id string, offset, count int) (io.ReadCloser, error)
 }
 
 type Services struct {
+	WordlistSource WordlistSource
 	HashBruteForce HashBruteForce
 }
@@ -0,0 +1,56 @@
+package wordlist
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/rs/zerolog"
+	"resty.dev/v3"
+
+	"github.com/ptrvsrg/crack-hash/worker/internal/service/infrastructure"
+)
+
+const contentPath = "/v1/wordlists/{id}/content"
+
+type svc struct {
+	logger zerolog.Logger
+	client *resty.Client
+}
+
+func NewService(logger zerolog.Logger, client *resty.Client) infrastructure.WordlistSource {
+	return &svc{
+		logger: logger.With().
+			Str("type", "infrastructure").
+			Str("service", "wordlist-source").
+			Logger(),
+		client: client,
+	}
+}
+
+func (s *svc) Open(ctx context.Context, id string, offset, count int) (io.ReadCloser, error) {
+	s.logger.Debug().
+		Str("id", id).
+		Int("offset", offset).
+		Int("count", count).
+		Msg("open wordlist")
+
+	resp, err := s.client.R().
+		SetContext(ctx).
+		SetPathParam("id", id).
+		SetQueryParam("offset", strconv.Itoa(offset)).
+		SetQueryParam("limit", strconv.Itoa(count)).
+		SetDoNotParseResponse(true).
+		Get(contentPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to request wordlist content: %w", err)
+	}
+
+	if resp.IsError() {
+		_ = resp.Body.Close()
+		return nil, fmt.Errorf("failed to request wordlist content: unexpected status %s", resp.Status())
+	}
+
+	return resp.Body, nil
+}
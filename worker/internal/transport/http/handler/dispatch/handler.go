@@ -0,0 +1,194 @@
+package dispatch
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"runtime/debug"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog"
+
+	"github.com/ptrvsrg/crack-hash/commonlib/bus/amqp/consumer"
+	"github.com/ptrvsrg/crack-hash/commonlib/http/handler"
+	"github.com/ptrvsrg/crack-hash/commonlib/http/helper"
+	"github.com/ptrvsrg/crack-hash/manager/pkg/message"
+	"github.com/ptrvsrg/crack-hash/worker/config"
+	"github.com/ptrvsrg/crack-hash/worker/internal/service/domain"
+	_ "github.com/ptrvsrg/crack-hash/worker/pkg/model"
+)
+
+var (
+	ErrQueueFull = errors.New("task queue is full")
+)
+
+type (
+	// Dispatcher gets messages of the manager over HTTP in the http transport. Started subtasks are put
+	// in the local queue and executed by runners, while the dispatcher is subscribed. Unlike with the broker,
+	// subtasks waiting in the queue are lost on restart, so their tasks are finished only by the task timeout
+	Dispatcher interface {
+		handler.Handler
+		consumer.Consumer
+	}
+
+	hdlr struct {
+		logger zerolog.Logger
+		cfg    config.HTTPTransportConfig
+		svc    domain.HashCrackTask
+		queue  chan *message.HashCrackTaskStarted
+	}
+)
+
+func NewHandler(logger zerolog.Logger, cfg config.HTTPTransportConfig, svc domain.HashCrackTask) Dispatcher {
+	return &hdlr{
+		logger: logger.With().Str("handler", "dispatch").Logger(),
+		cfg:    cfg,
+		svc:    svc,
+		queue:  make(chan *message.HashCrackTaskStarted, cfg.QueueSize),
+	}
+}
+
+func (h *hdlr) RegisterRoutes(r *gin.Engine) {
+	h.logger.Debug().Msg("register routes")
+
+	inAPI := r.Group("/internal/v1/tasks")
+	{
+		inAPI.POST("", h.handleStartTask)
+		inAPI.POST("/cancel", h.handleCancelTask)
+		inAPI.POST("/shrink", h.handleShrinkTask)
+	}
+}
+
+func (h *hdlr) Subscribe(ctx context.Context) {
+	h.logger.Info().Int("concurrency", h.cfg.Concurrency).Msg("runners started")
+
+	wg := sync.WaitGroup{}
+	for range h.cfg.Concurrency {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			h.run(ctx)
+		}()
+	}
+	wg.Wait()
+
+	h.logger.Info().Msg("runners stopped")
+}
+
+func (h *hdlr) run(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+
+		case msg := <-h.queue:
+			h.execute(ctx, msg)
+		}
+	}
+}
+
+func (h *hdlr) execute(ctx context.Context, msg *message.HashCrackTaskStarted) {
+	// catch panic
+	defer func() {
+		if r := recover(); r != nil {
+			h.logger.Error().Msgf("catch panic: %v\n%s", r, string(debug.Stack()))
+		}
+	}()
+
+	if err := h.svc.ExecuteTask(ctx, msg); err != nil {
+		h.logger.Error().Err(err).Str("request-id", msg.RequestID).Msg("failed to execute task")
+	}
+}
+
+// handleStartTask godoc
+//
+//	@Id				StartTask
+//	@Summary	    Start subtask
+//	@Description	Request of the manager for starting a subtask in the http transport, the subtask is queued
+//	@Tags			Hash Crack Task API
+//	@Accept			application/json
+//	@Produce		application/json
+//	@Param			input	body	message.HashCrackTaskStarted	true	"Started subtask"
+//	@Success		202
+//	@Failure		400 {object} model.ErrorOutput
+//	@Failure		429 {object} model.ErrorOutput
+//	@Router			/internal/v1/tasks [post]
+func (h *hdlr) handleStartTask(ctx *gin.Context) {
+	h.logger.Debug().Msg("handle start task")
+
+	input := &message.HashCrackTaskStarted{}
+	if err := ctx.ShouldBindJSON(input); err != nil {
+		_ = helper.ErrorWithStatus(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	// The manager sends the subtask to another worker, if the queue is full. The full queue is backpressure
+	// rather than a failure, so the worker is not ejected by the load balancer of the manager
+	select {
+	case h.queue <- input:
+		ctx.Status(http.StatusAccepted)
+	default:
+		_ = helper.ErrorWithStatus(ctx, http.StatusTooManyRequests, ErrQueueFull)
+	}
+}
+
+// handleCancelTask godoc
+//
+//	@Id				CancelTask
+//	@Summary	    Cancel task
+//	@Description	Request of the manager for cancelling subtasks of a task in the http transport
+//	@Tags			Hash Crack Task API
+//	@Accept			application/json
+//	@Produce		application/json
+//	@Param			input	body	message.HashCrackTaskCancelled	true	"Cancelled task"
+//	@Success		204
+//	@Failure		400 {object} model.ErrorOutput
+//	@Failure		500 {object} model.ErrorOutput
+//	@Router			/internal/v1/tasks/cancel [post]
+func (h *hdlr) handleCancelTask(ctx *gin.Context) {
+	h.logger.Debug().Msg("handle cancel task")
+
+	input := &message.HashCrackTaskCancelled{}
+	if err := ctx.ShouldBindJSON(input); err != nil {
+		_ = helper.ErrorWithStatus(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	if err := h.svc.CancelTask(ctx, input); err != nil {
+		_ = helper.ErrorWithStatus(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// handleShrinkTask godoc
+//
+//	@Id				ShrinkTask
+//	@Summary	    Shrink subtask
+//	@Description	Request of the manager for shrinking the range of a subtask in the http transport
+//	@Tags			Hash Crack Task API
+//	@Accept			application/json
+//	@Produce		application/json
+//	@Param			input	body	message.HashCrackTaskShrunk	true	"Shrunk subtask"
+//	@Success		204
+//	@Failure		400 {object} model.ErrorOutput
+//	@Failure		500 {object} model.ErrorOutput
+//	@Router			/internal/v1/tasks/shrink [post]
+func (h *hdlr) handleShrinkTask(ctx *gin.Context) {
+	h.logger.Debug().Msg("handle shrink task")
+
+	input := &message.HashCrackTaskShrunk{}
+	if err := ctx.ShouldBindJSON(input); err != nil {
+		_ = helper.ErrorWithStatus(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	if err := h.svc.ShrinkTask(ctx, input); err != nil {
+		_ = helper.ErrorWithStatus(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
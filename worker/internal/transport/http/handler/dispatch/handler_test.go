@@ -0,0 +1,87 @@
+package dispatch_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/ptrvsrg/crack-hash/commonlib/logging"
+	"github.com/ptrvsrg/crack-hash/worker/config"
+	domainmock "github.com/ptrvsrg/crack-hash/worker/internal/service/domain/mock"
+	"github.com/ptrvsrg/crack-hash/worker/internal/transport/http/handler/dispatch"
+)
+
+const startedBody = `{"version":1,"requestID":"1","partNumber":0,"partCount":1,"maxLength":1}`
+
+func init() {
+	logging.Setup(true)
+	gin.SetMode(gin.TestMode)
+}
+
+func newRouter(cfg config.HTTPTransportConfig, svc *domainmock.HashCrackTaskMock) *gin.Engine {
+	r := gin.New()
+	dispatch.NewHandler(log.Logger, cfg, svc).RegisterRoutes(r)
+
+	return r
+}
+
+func postTask(r *gin.Engine, body string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/internal/v1/tasks", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	r.ServeHTTP(w, req)
+
+	return w
+}
+
+func TestHandleStartTask(t *testing.T) {
+	t.Run(
+		"Queued", func(t *testing.T) {
+			// Arrange
+			svc := new(domainmock.HashCrackTaskMock)
+			r := newRouter(config.HTTPTransportConfig{QueueSize: 1, Concurrency: 1}, svc)
+
+			// Act
+			w := postTask(r, startedBody)
+
+			// Assert
+			assert.Equal(t, http.StatusAccepted, w.Code)
+			svc.AssertNotCalled(t, "ExecuteTask", mock.Anything, mock.Anything)
+		},
+	)
+
+	t.Run(
+		"Invalid body", func(t *testing.T) {
+			// Arrange
+			svc := new(domainmock.HashCrackTaskMock)
+			r := newRouter(config.HTTPTransportConfig{QueueSize: 1, Concurrency: 1}, svc)
+
+			// Act
+			w := postTask(r, "{")
+
+			// Assert
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+		},
+	)
+
+	t.Run(
+		"Queue is full", func(t *testing.T) {
+			// Arrange
+			svc := new(domainmock.HashCrackTaskMock)
+			r := newRouter(config.HTTPTransportConfig{QueueSize: 1, Concurrency: 1}, svc)
+			assert.Equal(t, http.StatusAccepted, postTask(r, startedBody).Code)
+
+			// Act
+			w := postTask(r, startedBody)
+
+			// Assert
+			assert.Equal(t, http.StatusTooManyRequests, w.Code)
+		},
+	)
+}